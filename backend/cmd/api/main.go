@@ -11,17 +11,25 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/fast-socialfi/backend/internal/config"
 	"github.com/fast-socialfi/backend/internal/database"
-	"github.com/fast-socialfi/backend/internal/handlers"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/ipfs"
 	"github.com/fast-socialfi/backend/internal/middleware"
-	"github.com/fast-socialfi/backend/internal/services"
+	"github.com/fast-socialfi/backend/internal/realtime"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
 	"github.com/fast-socialfi/backend/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 func main() {
@@ -58,26 +66,191 @@ func main() {
 		}
 	}
 
-	// Initialize services
-	userService := services.NewUserService(db)
-	circleService := services.NewCircleService(db)
-	postService := services.NewPostService(db)
-	tradeService := services.NewTradeService(db)
-	web3Service, err := services.NewWeb3Service(cfg.Blockchain)
+	// Initialize Web3 service
+	web3Service, err := web3.NewWeb3Service(
+		cfg.Blockchain.RPCEndpoint,
+		cfg.Blockchain.FactoryAddress,
+		cfg.Blockchain.BondingCurveAddress,
+	)
 	if err != nil {
 		logger.Fatal("Failed to initialize Web3 service", "error", err)
 	}
+	web3Service.SetFeeLimits(cfg.Blockchain)
+
+	// Tokens are revoked across replicas through Redis
+	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.Secret)
+	if redisClient != nil {
+		authMiddleware.SetRevocationList(middleware.NewRedisRevocationList(redisClient))
+	}
+
+	// Repositories and services behind the API handlers
+	userRepo := repository.NewUserRepository(db)
+	circleRepo := repository.NewCircleRepository(db)
+	txRepo := repository.NewTransactionRepository(db)
+	tradeRepo := repository.NewTradeRepository(db)
+	postRepo := repository.NewPostRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	holderRepo := repository.NewHolderRepository(db)
+	relRepo := repository.NewRelationshipRepository(db)
+	authService := service.NewAuthService(
+		userRepo,
+		repository.NewSessionRepository(db),
+		web3Service,
+		authMiddleware,
+		cfg.SIWE,
+		cfg.JWT,
+	)
+	circleService := service.NewCircleService(
+		circleRepo,
+		userRepo,
+		txRepo,
+		repository.NewCircleEventRepository(db),
+		web3Service,
+	)
+	tradingService := service.NewTradingService(circleRepo, userRepo, txRepo, web3Service)
+	if redisClient != nil {
+		// Login nonces and quotes must be redeemable on any replica
+		authService.SetNonceStore(service.NewRedisLoginNonceStore(redisClient))
+		tradingService.SetQuoteStore(service.NewRedisQuoteStore(redisClient))
+	}
+	transactionService := service.NewTransactionService(txRepo, web3Service)
+	holderService := service.NewHolderService(circleRepo, holderRepo)
+	candleService := service.NewCandleService(circleRepo, repository.NewCandleRepository(db))
+	portfolioService := service.NewPortfolioService(
+		circleRepo,
+		userRepo,
+		holderRepo,
+		tradeRepo,
+		repository.NewPortfolioSnapshotRepository(db),
+		web3Service,
+	)
+	tradeHistoryService := service.NewTradeHistoryService(circleRepo, userRepo, tradeRepo)
+	membershipService := service.NewMembershipService(
+		circleRepo,
+		userRepo,
+		repository.NewCircleMemberRepository(db),
+		repository.NewMembershipRuleRepository(db),
+		web3Service,
+	)
+	postService := service.NewPostService(postRepo, userRepo, circleRepo, membershipService, ipfs.NewClient(cfg.IPFS))
+	commentService := service.NewCommentService(
+		commentRepo,
+		postRepo,
+		userRepo,
+		relRepo,
+		membershipService,
+	)
+	voteService := service.NewVoteService(
+		repository.NewVoteRepository(db),
+		postRepo,
+		commentRepo,
+		userRepo,
+		holderRepo,
+		cfg.Vote,
+	)
+	// Tips count at the depth the indexer trusts
+	tipService := service.NewTipService(
+		repository.NewTipRepository(db),
+		postRepo,
+		userRepo,
+		circleRepo,
+		web3Service,
+		cfg.Blockchain.Indexer.ConfirmationDepth,
+	)
+	followService := service.NewFollowService(relRepo, userRepo)
+
+	// Background workers share a context that is cancelled on shutdown
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+
+	// Real-time feed; Redis fans events out to the clients of every replica
+	var broker realtime.Broker = realtime.NewMemoryBroker()
+	if redisClient != nil {
+		broker = realtime.NewRedisBroker(redisClient)
+	}
+	hub := realtime.NewHub(broker, cfg.WebSocket)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		if err := hub.Run(workerCtx); err != nil && err != context.Canceled {
+			logger.Error("WebSocket hub stopped", "error", err)
+		}
+	}()
+
+	if cfg.Blockchain.Indexer.Enabled {
+		eventIndexer := indexer.NewIndexer(web3Service, db, cfg.Blockchain.Indexer)
+		eventIndexer.SetPublisher(hub)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			if cfg.Blockchain.Indexer.BackfillCandles {
+				if err := eventIndexer.BackfillCandles(workerCtx); err != nil {
+					logger.Error("Candle backfill failed", "error", err)
+				}
+			}
+			if err := eventIndexer.Run(workerCtx); err != nil && err != context.Canceled {
+				logger.Error("Event indexer stopped", "error", err)
+			}
+		}()
+	}
+
+	if cfg.Portfolio.SnapshotEnabled {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			if err := portfolioService.RunSnapshots(workerCtx, cfg.Portfolio.SnapshotInterval); err != nil && err != context.Canceled {
+				logger.Error("Portfolio snapshots stopped", "error", err)
+			}
+		}()
+	}
+
+	if cfg.Vote.ReconcileEnabled {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			if err := voteService.RunReconciliation(workerCtx, cfg.Vote.ReconcileInterval); err != nil && err != context.Canceled {
+				logger.Error("Vote reconciliation stopped", "error", err)
+			}
+		}()
+	}
+
+	if cfg.Blockchain.Reconciler.Enabled {
+		reconciler := indexer.NewReconciler(web3Service, db, cfg.Blockchain.Reconciler)
+		reconciler.SetPublisher(hub)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			if err := reconciler.Run(workerCtx); err != nil && err != context.Canceled {
+				logger.Error("Transaction reconciler stopped", "error", err)
+			}
+		}()
+	}
 
 	// Setup Gin router
 	if cfg.App.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	httpLogger, err := zap.NewProduction()
+	if err != nil {
+		logger.Fatal("Failed to initialize request logger", "error", err)
+	}
+	defer httpLogger.Sync()
+
 	router := gin.New()
 	router.Use(gin.Recovery())
-	router.Use(middleware.Logger())
+	router.Use(middleware.Logger(httpLogger))
 	router.Use(middleware.CORS())
-	router.Use(middleware.RateLimiter(cfg.Security.RateLimit))
+	if cfg.Security.RateLimit > 0 {
+		// RateLimit requests are allowed per RateLimitWindow, in bursts of up
+		// to RateLimit
+		rateLimiter := middleware.NewRateLimiter(
+			rate.Every(cfg.Security.RateLimitWindow/time.Duration(cfg.Security.RateLimit)),
+			cfg.Security.RateLimit,
+		)
+		router.Use(rateLimiter.Limit())
+	}
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -87,88 +260,37 @@ func main() {
 		})
 	})
 
-	// API v1 routes
-	v1 := router.Group("/api/v1")
+	// API v1 routes; handlers act for the wallet of a valid token and treat
+	// requests without one as anonymous
+	v1 := router.Group("/api/v1", authMiddleware.OptionalAuth())
 	{
-		// User routes
-		users := v1.Group("/users")
-		{
-			userHandler := handlers.NewUserHandler(userService, web3Service)
-			users.POST("/register", userHandler.Register)
-			users.GET("/:address", userHandler.GetUser)
-			users.PUT("/profile", middleware.Auth(), userHandler.UpdateProfile)
-			users.GET("/:address/circles", userHandler.GetUserCircles)
-			users.POST("/follow", middleware.Auth(), userHandler.FollowUser)
-			users.POST("/unfollow", middleware.Auth(), userHandler.UnfollowUser)
-			users.GET("/:address/followers", userHandler.GetFollowers)
-			users.GET("/:address/following", userHandler.GetFollowing)
-		}
+		handler.NewAuthHandler(authService, authMiddleware).RegisterRoutes(v1)
 
 		// Circle routes
-		circles := v1.Group("/circles")
-		{
-			circleHandler := handlers.NewCircleHandler(circleService, web3Service)
-			circles.POST("", middleware.Auth(), circleHandler.CreateCircle)
-			circles.GET("/:id", circleHandler.GetCircle)
-			circles.GET("/trending", circleHandler.GetTrending)
-			circles.GET("", circleHandler.ListCircles)
-			circles.PUT("/:id", middleware.Auth(), circleHandler.UpdateCircle)
-			circles.POST("/:id/join", middleware.Auth(), circleHandler.JoinCircle)
-			circles.GET("/:id/members", circleHandler.GetMembers)
-			circles.GET("/:id/stats", circleHandler.GetStats)
-		}
-
-		// Post routes
-		posts := v1.Group("/posts")
-		{
-			postHandler := handlers.NewPostHandler(postService, web3Service)
-			posts.POST("", middleware.Auth(), postHandler.CreatePost)
-			posts.GET("/:id", postHandler.GetPost)
-			posts.GET("", postHandler.ListPosts)
-			posts.PUT("/:id", middleware.Auth(), postHandler.UpdatePost)
-			posts.DELETE("/:id", middleware.Auth(), postHandler.DeletePost)
-			posts.POST("/:id/upvote", middleware.Auth(), postHandler.Upvote)
-			posts.POST("/:id/downvote", middleware.Auth(), postHandler.Downvote)
-			posts.POST("/:id/comment", middleware.Auth(), postHandler.AddComment)
-			posts.GET("/:id/comments", postHandler.GetComments)
-			posts.POST("/:id/reward", middleware.Auth(), postHandler.RewardPost)
-		}
+		handler.NewCircleHandler(circleService).RegisterRoutes(v1)
+		handler.NewMembershipHandler(membershipService).RegisterRoutes(v1)
+		handler.NewHolderHandler(holderService).RegisterRoutes(v1)
+		handler.NewCandleHandler(candleService).RegisterRoutes(v1)
 
-		// Trade routes
-		trades := v1.Group("/trades")
-		{
-			tradeHandler := handlers.NewTradeHandler(tradeService, web3Service)
-			trades.POST("/buy", middleware.Auth(), tradeHandler.BuyTokens)
-			trades.POST("/sell", middleware.Auth(), tradeHandler.SellTokens)
-			trades.GET("/history", middleware.Auth(), tradeHandler.GetTradeHistory)
-			trades.GET("/price/:circleId", tradeHandler.GetPrice)
-			trades.GET("/price-impact/:circleId", tradeHandler.GetPriceImpact)
-		}
+		// Trading and transaction routes
+		handler.NewTradingHandler(tradingService).RegisterRoutes(v1)
+		handler.NewTransactionHandler(transactionService).RegisterRoutes(v1)
+		handler.NewTradeHistoryHandler(tradeHistoryService).RegisterRoutes(v1)
 
-		// Analytics routes
-		analytics := v1.Group("/analytics")
-		{
-			analyticsHandler := handlers.NewAnalyticsHandler(db)
-			analytics.GET("/dashboard", analyticsHandler.GetDashboard)
-			analytics.GET("/user/:address", analyticsHandler.GetUserAnalytics)
-			analytics.GET("/circle/:id", analyticsHandler.GetCircleAnalytics)
-		}
+		// User routes
+		handler.NewFollowHandler(followService).RegisterRoutes(v1)
+		handler.NewPortfolioHandler(portfolioService).RegisterRoutes(v1)
 
-		// Notification routes
-		notifications := v1.Group("/notifications")
-		{
-			notificationHandler := handlers.NewNotificationHandler(db)
-			notifications.GET("", middleware.Auth(), notificationHandler.GetNotifications)
-			notifications.PUT("/:id/read", middleware.Auth(), notificationHandler.MarkAsRead)
-			notifications.DELETE("/:id", middleware.Auth(), notificationHandler.DeleteNotification)
-		}
+		// Post, comment, vote and tip routes
+		handler.NewPostHandler(postService).RegisterRoutes(v1)
+		handler.NewCommentHandler(commentService).RegisterRoutes(v1)
+		handler.NewVoteHandler(voteService).RegisterRoutes(v1)
+		handler.NewTipHandler(tipService).RegisterRoutes(v1)
 	}
 
 	// WebSocket endpoint for real-time updates
-	router.GET("/ws", func(c *gin.Context) {
-		// WebSocket handler implementation
-		handlers.HandleWebSocket(c.Writer, c.Request, web3Service)
-	})
+	wsHandler := handler.NewWebSocketHandler(hub, authMiddleware, cfg.Security.AllowedOrigins)
+	router.GET("/ws", wsHandler.Connect)
 
 	// Start server
 	srv := &http.Server{
@@ -193,6 +315,8 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopWorkers()
+	workers.Wait()
 
 	// Graceful shutdown with 5 second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
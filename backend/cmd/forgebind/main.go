@@ -0,0 +1,89 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+// Command forgebind generates Go contract bindings from Foundry build
+// artifacts. abigen only reads solc output, while forge writes one JSON file
+// per contract holding both the ABI and the bytecode.
+//
+//	forgebind -artifacts ../out -pkg contracts -out internal/web3/contracts CircleFactory BondingCurve
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// artifact is the part of a forge build artifact the bindings need
+type artifact struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode struct {
+		Object string `json:"object"`
+	} `json:"bytecode"`
+}
+
+func main() {
+	artifactDir := flag.String("artifacts", "out", "Foundry artifact directory")
+	pkg := flag.String("pkg", "contracts", "Go package name of the bindings")
+	outDir := flag.String("out", ".", "directory the bindings are written to")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: forgebind [flags] Contract...")
+		os.Exit(2)
+	}
+
+	for _, name := range flag.Args() {
+		if err := generate(*artifactDir, *pkg, *outDir, name); err != nil {
+			fmt.Fprintf(os.Stderr, "forgebind: %s: %v\n", name, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generate writes the binding of one contract to <out>/<snake_name>.go
+func generate(artifactDir, pkg, outDir, name string) error {
+	raw, err := os.ReadFile(filepath.Join(artifactDir, name+".sol", name+".json"))
+	if err != nil {
+		return err
+	}
+
+	var a artifact
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return fmt.Errorf("failed to parse artifact: %w", err)
+	}
+
+	code, err := bind.Bind(
+		[]string{name},
+		[]string{string(a.ABI)},
+		[]string{a.Bytecode.Object},
+		nil, pkg, bind.LangGo, nil, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate binding: %w", err)
+	}
+
+	return os.WriteFile(filepath.Join(outDir, snakeCase(name)+".go"), []byte(code), 0o644)
+}
+
+// snakeCase turns CircleFactory into circle_factory
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
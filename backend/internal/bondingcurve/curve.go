@@ -0,0 +1,150 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package bondingcurve
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// CurveType matches the BondingCurve.CurveType enum
+type CurveType uint8
+
+const (
+	Linear CurveType = iota
+	Exponential
+	Sigmoid
+)
+
+// String returns the curve type name
+func (t CurveType) String() string {
+	switch t {
+	case Linear:
+		return "linear"
+	case Exponential:
+		return "exponential"
+	case Sigmoid:
+		return "sigmoid"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
+
+// Params mirrors the BondingCurve.CurveParams stored for each token
+type Params struct {
+	CurveType       CurveType
+	BasePrice       *big.Int
+	Slope           *big.Int
+	GrowthRate      *big.Int
+	MaxPrice        *big.Int
+	InflectionPoint *big.Int
+}
+
+// NewParams maps the createCircle curve arguments the way initializeCurve
+// does: param1 is the slope, param2 the growth rate and param3 both the
+// sigmoid max price and inflection point
+func NewParams(curveType CurveType, basePrice, param1, param2, param3 *big.Int) Params {
+	return Params{
+		CurveType:       curveType,
+		BasePrice:       orZero(basePrice),
+		Slope:           orZero(param1),
+		GrowthRate:      orZero(param2),
+		MaxPrice:        orZero(param3),
+		InflectionPoint: orZero(param3),
+	}
+}
+
+// Price returns the current price per token at the given supply, as
+// BondingCurve.getCurrentPrice does
+func (p Params) Price(supply *big.Int) (*big.Int, error) {
+	switch p.CurveType {
+	case Linear:
+		return LinearPrice(supply, p.BasePrice, p.Slope)
+	case Exponential:
+		return ExponentialPrice(supply, p.BasePrice, p.GrowthRate)
+	case Sigmoid:
+		return SigmoidPrice(supply, p.BasePrice, p.MaxPrice, p.InflectionPoint)
+	}
+	return new(big.Int), nil
+}
+
+// BuyCost returns the cost of buying amount tokens at the given supply, as
+// BondingCurve.calculateBuyCost does. Like the contract it returns zero for
+// the sigmoid curve, which has no cost formula.
+func (p Params) BuyCost(supply, amount *big.Int) (*big.Int, error) {
+	switch p.CurveType {
+	case Linear:
+		return LinearBuyCost(supply, amount, p.BasePrice, p.Slope)
+	case Exponential:
+		return ExponentialBuyCost(supply, amount, p.BasePrice, p.GrowthRate)
+	}
+	return new(big.Int), nil
+}
+
+// SellRefund returns the refund for selling amount tokens at the given
+// supply, as BondingCurve.calculateSellRefund does
+func (p Params) SellRefund(supply, amount *big.Int) (*big.Int, error) {
+	switch p.CurveType {
+	case Linear:
+		return LinearSellRefund(supply, amount, p.BasePrice, p.Slope)
+	case Exponential:
+		return ExponentialSellRefund(supply, amount, p.BasePrice, p.GrowthRate)
+	}
+	return new(big.Int), nil
+}
+
+// BuyPriceImpact returns the average price paid per token and how far it is
+// above the current price in basis points, as BondingCurve.getBuyPriceImpact does
+func (p Params) BuyPriceImpact(supply, amount *big.Int) (avgPrice, impactBps *big.Int, err error) {
+	price, err := p.Price(supply)
+	if err != nil {
+		return nil, nil, err
+	}
+	cost, err := p.BuyCost(supply, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var c calc
+	avgPrice = c.div(cost, amount)
+	impactBps = new(big.Int)
+	if price.Sign() > 0 {
+		impactBps = c.div(c.mul(c.sub(avgPrice, price), big.NewInt(10000)), price)
+	}
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	return avgPrice, impactBps, nil
+}
+
+// SellPriceImpact returns the average refund per token and how far it is
+// below the current price in basis points, as BondingCurve.getSellPriceImpact does
+func (p Params) SellPriceImpact(supply, amount *big.Int) (avgPrice, impactBps *big.Int, err error) {
+	price, err := p.Price(supply)
+	if err != nil {
+		return nil, nil, err
+	}
+	refund, err := p.SellRefund(supply, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var c calc
+	avgPrice = c.div(refund, amount)
+	impactBps = new(big.Int)
+	if price.Sign() > 0 {
+		impactBps = c.div(c.mul(c.sub(price, avgPrice), big.NewInt(10000)), price)
+	}
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	return avgPrice, impactBps, nil
+}
+
+func orZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
@@ -0,0 +1,224 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+// Package bondingcurve reimplements contracts/libraries/BondingCurveMath.sol
+// so prices can be quoted without calling the chain. Every operation uses
+// uint256 semantics: integer division rounds down and anything that would
+// revert on-chain (overflow, underflow, division by zero) returns an error.
+package bondingcurve
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// Precision is the fixed-point scale of slopes, growth rates and Power
+	Precision = big.NewInt(1e18)
+	// Scale is the extra divisor the exponential curve applies to the growth rate
+	Scale = big.NewInt(1e6)
+
+	maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+var (
+	// ErrOverflow mirrors a checked arithmetic overflow revert
+	ErrOverflow = errors.New("arithmetic overflow")
+	// ErrUnderflow mirrors a checked arithmetic underflow revert
+	ErrUnderflow = errors.New("arithmetic underflow")
+	// ErrDivisionByZero mirrors a division by zero revert
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrInsufficientSupply mirrors the "Insufficient supply" require
+	ErrInsufficientSupply = errors.New("insufficient supply")
+	// ErrTooManySteps is returned when an exponential trade would need more
+	// per-token iterations than MaxExponentialSteps
+	ErrTooManySteps = errors.New("too many steps for exponential curve")
+)
+
+// MaxExponentialSteps bounds the per-token loop of the exponential curve. The
+// contract loops once per token unit too, so larger trades run out of gas.
+var MaxExponentialSteps = big.NewInt(100_000)
+
+// LinearPrice returns basePrice + supply * slope / PRECISION
+func LinearPrice(supply, basePrice, slope *big.Int) (*big.Int, error) {
+	var c calc
+	price := c.add(basePrice, c.div(c.mul(supply, slope), Precision))
+	return c.result(price)
+}
+
+// LinearBuyCost returns the cost of buying amount tokens at the given supply
+func LinearBuyCost(supply, amount, basePrice, slope *big.Int) (*big.Int, error) {
+	var c calc
+	baseCost := c.mul(basePrice, amount)
+	area := c.add(c.mul(c.mul(big.NewInt(2), supply), amount), c.mul(amount, amount))
+	slopeCost := c.div(c.mul(slope, area), c.mul(big.NewInt(2), Precision))
+	return c.result(c.add(baseCost, slopeCost))
+}
+
+// LinearSellRefund returns the refund for selling amount tokens at the given supply
+func LinearSellRefund(supply, amount, basePrice, slope *big.Int) (*big.Int, error) {
+	if supply.Cmp(amount) < 0 {
+		return nil, ErrInsufficientSupply
+	}
+
+	var c calc
+	newSupply := c.sub(supply, amount)
+	baseRefund := c.mul(basePrice, amount)
+	area := c.add(c.mul(c.mul(big.NewInt(2), newSupply), amount), c.mul(amount, amount))
+	slopeRefund := c.div(c.mul(slope, area), c.mul(big.NewInt(2), Precision))
+	return c.result(c.add(baseRefund, slopeRefund))
+}
+
+// ExponentialPrice returns basePrice * (1 + growthRate)^supply using the
+// contract's second order Taylor approximation
+func ExponentialPrice(supply, basePrice, growthRate *big.Int) (*big.Int, error) {
+	if supply.Sign() == 0 {
+		return new(big.Int).Set(basePrice), nil
+	}
+
+	var c calc
+	term1 := c.add(Precision, c.div(c.mul(supply, growthRate), Scale))
+	numerator := c.mul(c.mul(c.mul(supply, c.sub(supply, big.NewInt(1))), growthRate), growthRate)
+	denominator := c.mul(c.mul(c.mul(big.NewInt(2), Scale), Scale), Precision)
+	term2 := c.div(numerator, denominator)
+	multiplier := c.add(term1, term2)
+	return c.result(c.div(c.mul(basePrice, multiplier), Precision))
+}
+
+// ExponentialBuyCost sums the price of every token unit bought
+func ExponentialBuyCost(supply, amount, basePrice, growthRate *big.Int) (*big.Int, error) {
+	if amount.Cmp(MaxExponentialSteps) > 0 {
+		return nil, ErrTooManySteps
+	}
+
+	var c calc
+	total := new(big.Int)
+	for i := int64(0); i < amount.Int64(); i++ {
+		price, err := ExponentialPrice(c.add(supply, big.NewInt(i)), basePrice, growthRate)
+		if err != nil {
+			return nil, err
+		}
+		total = c.add(total, price)
+	}
+	return c.result(total)
+}
+
+// ExponentialSellRefund sums the price of every token unit sold
+func ExponentialSellRefund(supply, amount, basePrice, growthRate *big.Int) (*big.Int, error) {
+	if supply.Cmp(amount) < 0 {
+		return nil, ErrInsufficientSupply
+	}
+	if amount.Cmp(MaxExponentialSteps) > 0 {
+		return nil, ErrTooManySteps
+	}
+
+	var c calc
+	total := new(big.Int)
+	for i := int64(1); i <= amount.Int64(); i++ {
+		price, err := ExponentialPrice(c.sub(supply, big.NewInt(i)), basePrice, growthRate)
+		if err != nil {
+			return nil, err
+		}
+		total = c.add(total, price)
+	}
+	return c.result(total)
+}
+
+// SigmoidPrice returns basePrice + (maxPrice - basePrice) * supply / (inflectionPoint + supply)
+func SigmoidPrice(supply, basePrice, maxPrice, inflectionPoint *big.Int) (*big.Int, error) {
+	var c calc
+	priceRange := c.sub(maxPrice, basePrice)
+	numerator := c.mul(c.mul(priceRange, supply), Precision)
+	denominator := c.mul(c.add(inflectionPoint, supply), Precision)
+	return c.result(c.add(basePrice, c.div(numerator, denominator)))
+}
+
+// Sqrt returns the integer square root of x using the Babylonian method
+func Sqrt(x *big.Int) (*big.Int, error) {
+	if x.Sign() == 0 {
+		return new(big.Int), nil
+	}
+
+	var c calc
+	z := c.div(c.add(x, big.NewInt(1)), big.NewInt(2))
+	y := new(big.Int).Set(x)
+	for c.err == nil && z.Cmp(y) < 0 {
+		y = z
+		z = c.div(c.add(c.div(x, z), z), big.NewInt(2))
+	}
+	return c.result(y)
+}
+
+// Power returns base^exponent in PRECISION fixed point by repeated squaring
+func Power(base, exponent *big.Int) (*big.Int, error) {
+	var c calc
+	result := new(big.Int).Set(Precision)
+	b := new(big.Int).Set(base)
+	e := new(big.Int).Set(exponent)
+
+	for c.err == nil && e.Sign() > 0 {
+		if e.Bit(0) == 1 {
+			result = c.div(c.mul(result, b), Precision)
+		}
+		b = c.div(c.mul(b, b), Precision)
+		e.Rsh(e, 1)
+	}
+	return c.result(result)
+}
+
+// calc performs checked uint256 arithmetic, keeping the first error so a
+// formula can be written as a single expression
+type calc struct {
+	err error
+}
+
+func (c *calc) result(v *big.Int) (*big.Int, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return v, nil
+}
+
+func (c *calc) check(v *big.Int) *big.Int {
+	if c.err == nil && v.Cmp(maxUint256) > 0 {
+		c.err = ErrOverflow
+	}
+	return v
+}
+
+func (c *calc) add(a, b *big.Int) *big.Int {
+	if c.err != nil {
+		return new(big.Int)
+	}
+	return c.check(new(big.Int).Add(a, b))
+}
+
+func (c *calc) sub(a, b *big.Int) *big.Int {
+	if c.err != nil {
+		return new(big.Int)
+	}
+	if a.Cmp(b) < 0 {
+		c.err = ErrUnderflow
+		return new(big.Int)
+	}
+	return new(big.Int).Sub(a, b)
+}
+
+func (c *calc) mul(a, b *big.Int) *big.Int {
+	if c.err != nil {
+		return new(big.Int)
+	}
+	return c.check(new(big.Int).Mul(a, b))
+}
+
+func (c *calc) div(a, b *big.Int) *big.Int {
+	if c.err != nil {
+		return new(big.Int)
+	}
+	if b.Sign() == 0 {
+		c.err = ErrDivisionByZero
+		return new(big.Int)
+	}
+	return new(big.Int).Quo(a, b)
+}
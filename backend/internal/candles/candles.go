@@ -0,0 +1,125 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package candles
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/web3"
+)
+
+// Interval is a candle width. Each interval is a whole multiple of the
+// one before it, so it can be rolled up from that interval's candles.
+type Interval struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Intervals lists the stored candle widths, finest first
+var Intervals = []Interval{
+	{Name: "1m", Duration: time.Minute},
+	{Name: "5m", Duration: 5 * time.Minute},
+	{Name: "1h", Duration: time.Hour},
+	{Name: "1d", Duration: 24 * time.Hour},
+}
+
+// Lookup returns the stored interval with the given name
+func Lookup(name string) (Interval, bool) {
+	for _, interval := range Intervals {
+		if interval.Name == name {
+			return interval, true
+		}
+	}
+	return Interval{}, false
+}
+
+// OpenTime returns the start of the UTC bucket containing t
+func OpenTime(t time.Time, d time.Duration) time.Time {
+	return t.UTC().Truncate(d)
+}
+
+// FromTrade turns a single trade into a candle of its own, priced at the
+// trade's post-trade curve price
+func FromTrade(trade *models.Trade) *models.Candle {
+	return &models.Candle{
+		CircleID:    trade.CircleID,
+		OpenTime:    trade.Timestamp.UTC(),
+		Open:        trade.Price,
+		High:        trade.Price,
+		Low:         trade.Price,
+		Close:       trade.Price,
+		TokenVolume: trade.TokenAmount,
+		ETHVolume:   trade.ETHAmount,
+		TradeCount:  1,
+	}
+}
+
+// Aggregate merges candles, which must be sorted oldest first, into
+// buckets of the given interval
+func Aggregate(interval Interval, candles []*models.Candle) ([]*models.Candle, error) {
+	var merged []*models.Candle
+	var current *models.Candle
+	var high, low, tokenVolume, ethVolume *big.Int
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.High = web3.FormatUnits(high, 18)
+		current.Low = web3.FormatUnits(low, 18)
+		current.TokenVolume = web3.FormatUnits(tokenVolume, 18)
+		current.ETHVolume = web3.FormatUnits(ethVolume, 18)
+		merged = append(merged, current)
+	}
+
+	for _, candle := range candles {
+		candleHigh, err := web3.ParseUnits(candle.High, 18)
+		if err != nil {
+			return nil, fmt.Errorf("invalid candle high: %w", err)
+		}
+		candleLow, err := web3.ParseUnits(candle.Low, 18)
+		if err != nil {
+			return nil, fmt.Errorf("invalid candle low: %w", err)
+		}
+		candleTokens, err := web3.ParseUnits(candle.TokenVolume, 18)
+		if err != nil {
+			return nil, fmt.Errorf("invalid candle token volume: %w", err)
+		}
+		candleETH, err := web3.ParseUnits(candle.ETHVolume, 18)
+		if err != nil {
+			return nil, fmt.Errorf("invalid candle ETH volume: %w", err)
+		}
+
+		openTime := OpenTime(candle.OpenTime, interval.Duration)
+		if current == nil || !current.OpenTime.Equal(openTime) {
+			flush()
+			current = &models.Candle{
+				CircleID: candle.CircleID,
+				Interval: interval.Name,
+				OpenTime: openTime,
+				Open:     candle.Open,
+			}
+			high, low = candleHigh, candleLow
+			tokenVolume, ethVolume = new(big.Int), new(big.Int)
+		}
+
+		if candleHigh.Cmp(high) > 0 {
+			high = candleHigh
+		}
+		if candleLow.Cmp(low) < 0 {
+			low = candleLow
+		}
+		tokenVolume.Add(tokenVolume, candleTokens)
+		ethVolume.Add(ethVolume, candleETH)
+		current.Close = candle.Close
+		current.TradeCount += candle.TradeCount
+	}
+	flush()
+
+	return merged, nil
+}
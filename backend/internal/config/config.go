@@ -19,6 +19,10 @@ type Config struct {
 	IPFS       IPFSConfig
 	Security   SecurityConfig
 	JWT        JWTConfig
+	SIWE       SIWEConfig
+	WebSocket  WebSocketConfig
+	Portfolio  PortfolioConfig
+	Vote       VoteConfig
 }
 
 type AppConfig struct {
@@ -54,8 +58,29 @@ type BlockchainConfig struct {
 	ChainID           int64
 	FactoryAddress    string
 	BondingCurveAddress string
-	GasLimit          uint64
-	GasPrice          int64
+	GasLimit          uint64 // ceiling on the gas limit of server-built transactions
+	GasPrice          int64  // ceiling on max fee per gas in wei, 0 for none
+	MaxPriorityFee    int64  // ceiling on the priority fee in wei, 0 for none
+	FeeBumpPercent    int64  // fee increase applied when replacing a stuck transaction
+	Indexer           IndexerConfig
+	Reconciler        ReconcilerConfig
+}
+
+type IndexerConfig struct {
+	Enabled           bool
+	StartBlock        uint64
+	BatchSize         uint64
+	PollInterval      time.Duration
+	ConfirmationDepth uint64
+	BackfillCandles   bool
+}
+
+type ReconcilerConfig struct {
+	Enabled           bool
+	PollInterval      time.Duration
+	DropTimeout       time.Duration
+	BatchSize         int
+	ConfirmationDepth uint64
 }
 
 type IPFSConfig struct {
@@ -71,8 +96,37 @@ type SecurityConfig struct {
 }
 
 type JWTConfig struct {
-	Secret     string
-	Expiration time.Duration
+	Secret            string
+	Expiration        time.Duration
+	AccessExpiration  time.Duration // lifetime of access tokens issued to sessions
+	RefreshExpiration time.Duration // lifetime of a session without a refresh
+}
+
+type SIWEConfig struct {
+	Domain   string        // host sign-in messages must be issued for
+	ChainID  int64         // chain sign-in messages must be issued for
+	NonceTTL time.Duration // how long a login nonce stays redeemable
+}
+
+type WebSocketConfig struct {
+	SendQueueSize    int           // frames buffered per client before it is evicted as too slow
+	PingInterval     time.Duration // how often clients are pinged
+	PongTimeout      time.Duration // how long a client may stay silent, including pongs
+	WriteTimeout     time.Duration // deadline for writing a single frame
+	MaxMessageSize   int64         // largest frame accepted from a client
+	MaxSubscriptions int           // channels a single client may subscribe to
+}
+
+type PortfolioConfig struct {
+	SnapshotEnabled  bool
+	SnapshotInterval time.Duration // how often every holder's portfolio is recorded for charting
+}
+
+type VoteConfig struct {
+	WeightByBalance   bool          // weigh votes on circle posts by the voter's circle token balance
+	MaxWeight         uint          // most a single weighted vote can count for, 0 for no cap
+	ReconcileEnabled  bool
+	ReconcileInterval time.Duration // how often vote counters are recomputed from the ledger
 }
 
 func Load() (*Config, error) {
@@ -109,6 +163,24 @@ func Load() (*Config, error) {
 			BondingCurveAddress: getEnv("BONDING_CURVE_ADDRESS", ""),
 			GasLimit:       uint64(getEnvInt("GAS_LIMIT", 3000000)),
 			GasPrice:       getEnvInt64("GAS_PRICE", 0),
+			MaxPriorityFee: getEnvInt64("GAS_MAX_PRIORITY_FEE", 0),
+			FeeBumpPercent: getEnvInt64("GAS_BUMP_PERCENT", 12),
+			Indexer: IndexerConfig{
+				Enabled:           getEnvBool("INDEXER_ENABLED", true),
+				StartBlock:        uint64(getEnvInt64("INDEXER_START_BLOCK", 0)),
+				BatchSize:         uint64(getEnvInt64("INDEXER_BATCH_SIZE", 2000)),
+				PollInterval:      time.Duration(getEnvInt("INDEXER_POLL_INTERVAL", 12)) * time.Second,
+				ConfirmationDepth: uint64(getEnvInt64("INDEXER_CONFIRMATIONS", 12)),
+				BackfillCandles:   getEnvBool("INDEXER_BACKFILL_CANDLES", false),
+			},
+			Reconciler: ReconcilerConfig{
+				Enabled:      getEnvBool("RECONCILER_ENABLED", true),
+				PollInterval: time.Duration(getEnvInt("RECONCILER_POLL_INTERVAL", 15)) * time.Second,
+				DropTimeout:  time.Duration(getEnvInt("RECONCILER_DROP_TIMEOUT", 30)) * time.Minute,
+				BatchSize:    getEnvInt("RECONCILER_BATCH_SIZE", 100),
+				// Shares the indexer depth so both workers agree on "confirmed"
+				ConfirmationDepth: uint64(getEnvInt64("INDEXER_CONFIRMATIONS", 12)),
+			},
 		},
 		IPFS: IPFSConfig{
 			NodeURL: getEnv("IPFS_NODE_URL", "https://ipfs.infura.io:5001"),
@@ -121,8 +193,34 @@ func Load() (*Config, error) {
 			AllowedOrigins:  []string{getEnv("CORS_ORIGINS", "*")},
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your-secret-key-here"),
-			Expiration: time.Duration(getEnvInt("JWT_EXPIRATION_HOURS", 24)) * time.Hour,
+			Secret:            getEnv("JWT_SECRET", "your-secret-key-here"),
+			Expiration:        time.Duration(getEnvInt("JWT_EXPIRATION_HOURS", 24)) * time.Hour,
+			AccessExpiration:  time.Duration(getEnvInt("JWT_ACCESS_EXPIRATION_MINUTES", 15)) * time.Minute,
+			RefreshExpiration: time.Duration(getEnvInt("JWT_REFRESH_EXPIRATION_HOURS", 720)) * time.Hour,
+		},
+		SIWE: SIWEConfig{
+			Domain: getEnv("SIWE_DOMAIN", "localhost:8080"),
+			// Shares the chain ID so logins are bound to the network trades run on
+			ChainID:  getEnvInt64("CHAIN_ID", 11155111),
+			NonceTTL: time.Duration(getEnvInt("SIWE_NONCE_TTL", 10)) * time.Minute,
+		},
+		WebSocket: WebSocketConfig{
+			SendQueueSize:    getEnvInt("WS_SEND_QUEUE_SIZE", 256),
+			PingInterval:     time.Duration(getEnvInt("WS_PING_INTERVAL", 30)) * time.Second,
+			PongTimeout:      time.Duration(getEnvInt("WS_PONG_TIMEOUT", 60)) * time.Second,
+			WriteTimeout:     time.Duration(getEnvInt("WS_WRITE_TIMEOUT", 10)) * time.Second,
+			MaxMessageSize:   getEnvInt64("WS_MAX_MESSAGE_SIZE", 4096),
+			MaxSubscriptions: getEnvInt("WS_MAX_SUBSCRIPTIONS", 50),
+		},
+		Portfolio: PortfolioConfig{
+			SnapshotEnabled:  getEnvBool("PORTFOLIO_SNAPSHOT_ENABLED", true),
+			SnapshotInterval: time.Duration(getEnvInt("PORTFOLIO_SNAPSHOT_INTERVAL", 60)) * time.Minute,
+		},
+		Vote: VoteConfig{
+			WeightByBalance:   getEnvBool("VOTE_WEIGHT_BY_BALANCE", false),
+			MaxWeight:         uint(getEnvInt("VOTE_MAX_WEIGHT", 10)),
+			ReconcileEnabled:  getEnvBool("VOTE_RECONCILE_ENABLED", true),
+			ReconcileInterval: time.Duration(getEnvInt("VOTE_RECONCILE_INTERVAL", 60)) * time.Minute,
 		},
 	}
 
@@ -0,0 +1,236 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fast-socialfi/backend/internal/middleware"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler handles wallet login and session HTTP requests
+type AuthHandler struct {
+	authSvc *service.AuthService
+	authMW  *middleware.AuthMiddleware
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(authSvc *service.AuthService, authMW *middleware.AuthMiddleware) *AuthHandler {
+	return &AuthHandler{
+		authSvc: authSvc,
+		authMW:  authMW,
+	}
+}
+
+// RegisterRoutes registers auth routes. Session management requires an
+// access token, so those routes carry the auth middleware themselves.
+func (h *AuthHandler) RegisterRoutes(r *gin.RouterGroup) {
+	auth := r.Group("/auth")
+	{
+		auth.GET("/nonce", h.GetNonce)
+		auth.POST("/verify", h.Verify)
+		auth.POST("/refresh", h.Refresh)
+
+		sessions := auth.Group("", h.authMW.Authenticate())
+		sessions.POST("/logout", h.Logout)
+		sessions.POST("/logout-all", h.LogoutAll)
+		sessions.GET("/sessions", h.ListSessions)
+		sessions.DELETE("/sessions/:sessionId", h.RevokeSession)
+	}
+}
+
+// GetNonce godoc
+// @Summary Get a login nonce
+// @Description Issues a single-use nonce for a Sign-In With Ethereum (EIP-4361) message
+// @Tags auth
+// @Produce json
+// @Success 200 {object} service.LoginNonceResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/nonce [get]
+func (h *AuthHandler) GetNonce(c *gin.Context) {
+	nonce, err := h.authSvc.IssueNonce(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to issue nonce",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, nonce)
+}
+
+// Verify godoc
+// @Summary Sign in with Ethereum
+// @Description Verifies a signed EIP-4361 message, creating the user on first login, and opens a session with an access and a refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body service.LoginRequest true "Signed sign-in message"
+// @Success 200 {object} service.LoginResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/verify [post]
+func (h *AuthHandler) Verify(c *gin.Context) {
+	var req service.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.authSvc.Login(c.Request.Context(), &req, clientInfo(c))
+	if err != nil {
+		c.JSON(loginErrorStatus(err), ErrorResponse{
+			Error:   "Login failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Refresh godoc
+// @Summary Refresh an access token
+// @Description Rotates a refresh token and issues a new access token. Reusing a rotated refresh token revokes the session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body service.RefreshRequest true "Refresh token"
+// @Success 200 {object} service.LoginResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req service.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.authSvc.Refresh(c.Request.Context(), &req, clientInfo(c))
+	if err != nil {
+		c.JSON(loginErrorStatus(err), ErrorResponse{
+			Error:   "Failed to refresh token",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Logout godoc
+// @Summary Sign out
+// @Description Ends the session of the access token used for the request
+// @Tags auth
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	err := h.authSvc.Logout(c.Request.Context(), c.GetString("user_address"), c.GetString("session_id"))
+	if err != nil {
+		c.JSON(loginErrorStatus(err), ErrorResponse{
+			Error:   "Failed to sign out",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Signed out",
+	})
+}
+
+// LogoutAll godoc
+// @Summary Sign out everywhere
+// @Description Ends every session of the authenticated wallet, on all devices
+// @Tags auth
+// @Produce json
+// @Success 200 {object} service.LogoutAllResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	resp, err := h.authSvc.LogoutAll(c.Request.Context(), c.GetString("user_address"))
+	if err != nil {
+		c.JSON(loginErrorStatus(err), ErrorResponse{
+			Error:   "Failed to sign out",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListSessions godoc
+// @Summary List sessions
+// @Description Lists the signed-in devices of the authenticated wallet with their user agent, IP address and last use
+// @Tags auth
+// @Produce json
+// @Success 200 {array} service.SessionResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.authSvc.ListSessions(c.Request.Context(), c.GetString("user_address"), c.GetString("session_id"))
+	if err != nil {
+		c.JSON(loginErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get sessions",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Ends one session of the authenticated wallet, e.g. a device whose token was compromised
+// @Tags auth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/sessions/{sessionId} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	err := h.authSvc.RevokeSession(c.Request.Context(), c.GetString("user_address"), c.Param("sessionId"))
+	if err != nil {
+		c.JSON(loginErrorStatus(err), ErrorResponse{
+			Error:   "Failed to revoke session",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Session revoked",
+	})
+}
+
+// clientInfo describes the device a request came from
+func clientInfo(c *gin.Context) service.ClientInfo {
+	return service.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
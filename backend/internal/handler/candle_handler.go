@@ -0,0 +1,82 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// CandleHandler handles circle price chart HTTP requests
+type CandleHandler struct {
+	candleSvc *service.CandleService
+}
+
+// NewCandleHandler creates a new candle handler
+func NewCandleHandler(candleSvc *service.CandleService) *CandleHandler {
+	return &CandleHandler{
+		candleSvc: candleSvc,
+	}
+}
+
+// RegisterRoutes registers candle routes
+func (h *CandleHandler) RegisterRoutes(r *gin.RouterGroup) {
+	circles := r.Group("/circles")
+	{
+		circles.GET("/:id/candles", h.GetCandles)
+	}
+}
+
+// GetCandles godoc
+// @Summary Get a circle's price candles
+// @Description Retrieves OHLCV candles of the circle token, oldest first. Intervals without trades are omitted.
+// @Tags circles
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param interval query string false "Candle interval (1m, 5m, 1h, 1d)" default(1h)
+// @Param from query int false "Range start, unix seconds"
+// @Param to query int false "Range end (exclusive), unix seconds; defaults to now"
+// @Success 200 {object} service.CandlesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/candles [get]
+func (h *CandleHandler) GetCandles(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	query := &service.CandleQuery{Interval: c.DefaultQuery("interval", "1h")}
+	for name, target := range map[string]*int64{"from": &query.From, "to": &query.To} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: name + " must be a unix timestamp in seconds",
+			})
+			return
+		}
+		*target = value
+	}
+
+	resp, err := h.candleSvc.GetCandles(c.Request.Context(), id, query)
+	if err != nil {
+		c.JSON(candleErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get candles",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
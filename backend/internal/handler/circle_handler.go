@@ -5,6 +5,7 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 
@@ -28,27 +29,33 @@ func NewCircleHandler(circleSvc *service.CircleService) *CircleHandler {
 func (h *CircleHandler) RegisterRoutes(r *gin.RouterGroup) {
 	circles := r.Group("/circles")
 	{
-		circles.POST("", h.CreateCircle)
+		circles.POST("/prepare", h.PrepareCreateCircle)
+		circles.POST("", h.SubmitCreateCircle)
 		circles.GET("", h.ListCircles)
 		circles.GET("/:id", h.GetCircle)
 		circles.GET("/search", h.SearchCircles)
 		circles.GET("/trending", h.GetTrendingCircles)
 		circles.PUT("/:id/sync", h.SyncCircleFromBlockchain)
+		circles.GET("/:id/events", h.GetCircleEvents)
+		circles.POST("/:id/deactivate/prepare", h.PrepareDeactivateCircle)
+		circles.POST("/:id/reactivate/prepare", h.PrepareReactivateCircle)
+		circles.POST("/:id/transfer-ownership/prepare", h.PrepareTransferCircleOwnership)
+		circles.POST("/:id/lifecycle", h.SubmitCircleLifecycle)
 	}
 }
 
-// CreateCircle godoc
-// @Summary Create a new circle
-// @Description Creates a new circle with bonding curve
+// PrepareCreateCircle godoc
+// @Summary Prepare a circle creation transaction
+// @Description Builds an unsigned createCircle transaction for the user's wallet to sign
 // @Tags circles
 // @Accept json
 // @Produce json
 // @Param request body service.CreateCircleRequest true "Circle creation request"
-// @Success 201 {object} service.CreateCircleResponse
+// @Success 200 {object} service.PrepareCreateCircleResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/v1/circles [post]
-func (h *CircleHandler) CreateCircle(c *gin.Context) {
+// @Router /api/v1/circles/prepare [post]
+func (h *CircleHandler) PrepareCreateCircle(c *gin.Context) {
 	var req service.CreateCircleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -58,9 +65,54 @@ func (h *CircleHandler) CreateCircle(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.circleSvc.CreateCircle(c.Request.Context(), &req)
+	sender, ok := resolveSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.circleSvc.PrepareCreateCircle(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to prepare circle creation",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SubmitCreateCircle godoc
+// @Summary Create a new circle
+// @Description Broadcasts a wallet-signed createCircle transaction
+// @Tags circles
+// @Accept json
+// @Produce json
+// @Param request body service.SubmitCreateCircleRequest true "Signed circle creation transaction"
+// @Success 201 {object} service.CreateCircleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/circles [post]
+func (h *CircleHandler) SubmitCreateCircle(c *gin.Context) {
+	var req service.SubmitCreateCircleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := resolveSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.circleSvc.SubmitCreateCircle(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(submitErrorStatus(err), ErrorResponse{
 			Error:   "Failed to create circle",
 			Message: err.Error(),
 		})
@@ -235,6 +287,228 @@ func (h *CircleHandler) SyncCircleFromBlockchain(c *gin.Context) {
 	})
 }
 
+// GetCircleEvents godoc
+// @Summary Get circle lifecycle history
+// @Description Retrieves the audit trail of a circle's creation, deactivations, reactivations and ownership transfers
+// @Tags circles
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} models.CircleEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/events [get]
+func (h *CircleHandler) GetCircleEvents(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit > 100 {
+		limit = 100
+	}
+
+	events, err := h.circleSvc.GetCircleEvents(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		c.JSON(circleErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get circle events",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// PrepareDeactivateCircle godoc
+// @Summary Prepare a circle deactivation transaction
+// @Description Builds an unsigned deactivateCircle transaction for the authenticated circle owner
+// @Tags circles
+// @Accept json
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param request body service.CircleLifecycleRequest false "Sender"
+// @Success 200 {object} service.PreparedTxResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/deactivate/prepare [post]
+func (h *CircleHandler) PrepareDeactivateCircle(c *gin.Context) {
+	h.prepareLifecycle(c, h.circleSvc.PrepareDeactivateCircle)
+}
+
+// PrepareReactivateCircle godoc
+// @Summary Prepare a circle reactivation transaction
+// @Description Builds an unsigned reactivateCircle transaction for the authenticated circle owner
+// @Tags circles
+// @Accept json
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param request body service.CircleLifecycleRequest false "Sender"
+// @Success 200 {object} service.PreparedTxResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/reactivate/prepare [post]
+func (h *CircleHandler) PrepareReactivateCircle(c *gin.Context) {
+	h.prepareLifecycle(c, h.circleSvc.PrepareReactivateCircle)
+}
+
+// PrepareTransferCircleOwnership godoc
+// @Summary Prepare a circle ownership transfer transaction
+// @Description Builds an unsigned transferCircleOwnership transaction for the authenticated circle owner
+// @Tags circles
+// @Accept json
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param request body service.TransferCircleOwnershipRequest true "New owner"
+// @Success 200 {object} service.PreparedTxResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/transfer-ownership/prepare [post]
+func (h *CircleHandler) PrepareTransferCircleOwnership(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.TransferCircleOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := requireSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.circleSvc.PrepareTransferCircleOwnership(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(circleErrorStatus(err), ErrorResponse{
+			Error:   "Failed to prepare ownership transfer",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SubmitCircleLifecycle godoc
+// @Summary Submit a circle lifecycle transaction
+// @Description Broadcasts a wallet-signed deactivateCircle, reactivateCircle or transferCircleOwnership transaction
+// @Tags circles
+// @Accept json
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param request body service.SubmitCircleLifecycleRequest true "Signed lifecycle transaction"
+// @Success 202 {object} service.CircleLifecycleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/lifecycle [post]
+func (h *CircleHandler) SubmitCircleLifecycle(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.SubmitCircleLifecycleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := requireSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.circleSvc.SubmitCircleLifecycle(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(circleErrorStatus(err), ErrorResponse{
+			Error:   "Failed to submit circle transaction",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// prepareLifecycle handles the deactivate and reactivate prepare endpoints,
+// which only differ in the service call
+func (h *CircleHandler) prepareLifecycle(c *gin.Context, prepare func(context.Context, uint64, *service.CircleLifecycleRequest) (*service.PreparedTxResponse, error)) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.CircleLifecycleRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	sender, ok := requireSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := prepare(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(circleErrorStatus(err), ErrorResponse{
+			Error:   "Failed to prepare circle transaction",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// circleIDParam parses the :id path parameter
+func circleIDParam(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid circle ID",
+			Message: err.Error(),
+		})
+		return 0, false
+	}
+	return id, true
+}
+
 // Response types
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -0,0 +1,188 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// CommentHandler handles comment HTTP requests
+type CommentHandler struct {
+	commentSvc *service.CommentService
+}
+
+// NewCommentHandler creates a new comment handler
+func NewCommentHandler(commentSvc *service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentSvc: commentSvc,
+	}
+}
+
+// RegisterRoutes registers comment routes
+func (h *CommentHandler) RegisterRoutes(r *gin.RouterGroup) {
+	posts := r.Group("/posts")
+	{
+		posts.POST("/:id/comments", h.AddComment)
+		posts.GET("/:id/comments", h.GetComments)
+	}
+
+	comments := r.Group("/comments")
+	{
+		comments.DELETE("/:id", h.DeleteComment)
+	}
+}
+
+// AddComment godoc
+// @Summary Comment on a post
+// @Description Comments on a post, or replies to one of its comments when parent_comment_id is set. Comments on circle posts need the right to post in the circle.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body service.CreateCommentRequest true "Comment"
+// @Success 201 {object} service.CommentNode
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/posts/{id}/comments [post]
+func (h *CommentHandler) AddComment(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	comment, err := h.commentSvc.AddComment(c.Request.Context(), id, sender, &req)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), ErrorResponse{
+			Error:   "Failed to add comment",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// GetComments godoc
+// @Summary Get a post's comments
+// @Description Loads a post's comment tree to the given depth, hiding comments by users the authenticated wallet blocks or is blocked by. Pass next_cursor, or a comment's more_replies, back as cursor to continue that branch.
+// @Tags posts
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param sort query string false "top or new" default(top)
+// @Param depth query int false "Levels to load" default(3)
+// @Param limit query int false "Comments in the first level" default(20)
+// @Param replies query int false "Replies in each deeper branch" default(5)
+// @Param cursor query string false "Branch cursor"
+// @Success 200 {object} service.CommentThreadResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/posts/{id}/comments [get]
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+
+	query := &service.CommentQuery{
+		Sort:   c.Query("sort"),
+		Cursor: c.Query("cursor"),
+		Viewer: viewerAddress(c),
+	}
+	for name, target := range map[string]*int{"depth": &query.Depth, "limit": &query.Limit, "replies": &query.Replies} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: name + " must be a positive integer",
+			})
+			return
+		}
+		*target = value
+	}
+
+	resp, err := h.commentSvc.GetComments(c.Request.Context(), id, query)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get comments",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Removes a comment's content and author while its replies stay in place. The author and moderators of the post's circle may delete it.
+// @Tags posts
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/comments/{id} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	id, ok := commentIDParam(c)
+	if !ok {
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	if err := h.commentSvc.DeleteComment(c.Request.Context(), id, sender); err != nil {
+		c.JSON(commentErrorStatus(err), ErrorResponse{
+			Error:   "Failed to delete comment",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Comment deleted",
+	})
+}
+
+// commentIDParam parses the comment ID path parameter
+func commentIDParam(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid comment ID",
+			Message: err.Error(),
+		})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,232 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/fast-socialfi/backend/internal/ipfs"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/siwe"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/gin-gonic/gin"
+)
+
+// resolveSender determines the wallet address a transaction is prepared for.
+// When the request is authenticated the JWT address wins and a conflicting
+// from_address is rejected; otherwise the address in the body is used.
+func resolveSender(c *gin.Context, fromAddress string) (string, bool) {
+	if userAddress, exists := c.Get("user_address"); exists {
+		authenticated := userAddress.(string)
+		if fromAddress != "" && !strings.EqualFold(fromAddress, authenticated) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "Forbidden",
+				Message: "from_address does not match the authenticated wallet",
+			})
+			return "", false
+		}
+		return authenticated, true
+	}
+
+	if fromAddress == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: "from_address is required",
+		})
+		return "", false
+	}
+
+	return fromAddress, true
+}
+
+// requireSender is resolveSender for endpoints that act on behalf of an
+// authenticated wallet, such as owner-only circle changes
+func requireSender(c *gin.Context, fromAddress string) (string, bool) {
+	if _, exists := c.Get("user_address"); !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication is required",
+		})
+		return "", false
+	}
+	return resolveSender(c, fromAddress)
+}
+
+// viewerAddress is the authenticated wallet reading a public endpoint, or
+// empty for anonymous readers
+func viewerAddress(c *gin.Context) string {
+	if userAddress, exists := c.Get("user_address"); exists {
+		return userAddress.(string)
+	}
+	return ""
+}
+
+// submitErrorStatus maps errors from signed transaction submission to HTTP status codes
+func submitErrorStatus(err error) int {
+	if errors.Is(err, web3.ErrInvalidTransaction) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// quoteErrorStatus maps errors from quoting a trade and redeeming the quote
+// to HTTP status codes
+func quoteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrQuoteNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrQuoteExpired):
+		return http.StatusGone
+	case errors.Is(err, service.ErrQuoteMismatch),
+		errors.Is(err, service.ErrInvalidQuote):
+		return http.StatusBadRequest
+	}
+	return circleErrorStatus(err)
+}
+
+// circleErrorStatus maps errors from owner-only circle changes to HTTP status codes
+func circleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCircleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNotCircleOwner):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrCircleStateConflict):
+		return http.StatusConflict
+	}
+	return submitErrorStatus(err)
+}
+
+// membershipErrorStatus maps errors from joining, posting in and configuring
+// token-gated circles to HTTP status codes
+func membershipErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrNotCircleMember):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAlreadyCircleMember):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrMembershipRequirement),
+		errors.Is(err, service.ErrPostingRestricted):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidMembershipRule):
+		return http.StatusBadRequest
+	}
+	return circleErrorStatus(err)
+}
+
+// candleErrorStatus maps errors from candle queries to HTTP status codes
+func candleErrorStatus(err error) int {
+	if errors.Is(err, service.ErrInvalidCandleQuery) {
+		return http.StatusBadRequest
+	}
+	return circleErrorStatus(err)
+}
+
+// portfolioErrorStatus maps errors from portfolio queries to HTTP status codes
+func portfolioErrorStatus(err error) int {
+	if errors.Is(err, service.ErrInvalidPortfolioQuery) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// tradeHistoryErrorStatus maps errors from trade history queries and exports
+// to HTTP status codes
+func tradeHistoryErrorStatus(err error) int {
+	if errors.Is(err, service.ErrInvalidTradeQuery) {
+		return http.StatusBadRequest
+	}
+	return circleErrorStatus(err)
+}
+
+// postErrorStatus maps errors from creating, reading and changing posts to
+// HTTP status codes. Posting in a circle one has not joined is forbidden
+// rather than a missing membership.
+func postErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNotPostAuthor),
+		errors.Is(err, service.ErrNotCircleMember):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidPost):
+		return http.StatusBadRequest
+	case errors.Is(err, ipfs.ErrUnavailable),
+		errors.Is(err, ipfs.ErrContentTooLarge):
+		return http.StatusBadGateway
+	}
+	return membershipErrorStatus(err)
+}
+
+// commentErrorStatus maps errors from commenting on posts to HTTP status codes
+func commentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCommentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNotCommentAuthor):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidComment):
+		return http.StatusBadRequest
+	}
+	return postErrorStatus(err)
+}
+
+// voteErrorStatus maps errors from voting on posts and comments to HTTP
+// status codes
+func voteErrorStatus(err error) int {
+	if errors.Is(err, service.ErrInvalidVote) {
+		return http.StatusBadRequest
+	}
+	return commentErrorStatus(err)
+}
+
+// tipErrorStatus maps errors from tipping posts to HTTP status codes. A tip
+// whose transaction is not settled yet conflicts until it is.
+func tipErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidTip),
+		errors.Is(err, service.ErrTipNotPrepared),
+		errors.Is(err, web3.ErrTipMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrTipClaimed),
+		errors.Is(err, web3.ErrTipNotSettled):
+		return http.StatusConflict
+	}
+	return postErrorStatus(err)
+}
+
+// followErrorStatus maps errors from following and blocking users to HTTP
+// status codes
+func followErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidFollow):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrUserBlocked):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// loginErrorStatus maps errors from Sign-In With Ethereum and session
+// management to HTTP status codes
+func loginErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, siwe.ErrInvalidMessage):
+		return http.StatusBadRequest
+	case errors.Is(err, siwe.ErrInvalidSignature),
+		errors.Is(err, service.ErrLoginRejected),
+		errors.Is(err, service.ErrLoginNonce),
+		errors.Is(err, service.ErrInvalidRefreshToken),
+		errors.Is(err, service.ErrRefreshTokenReused):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrSessionNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
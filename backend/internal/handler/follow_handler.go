@@ -0,0 +1,201 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// FollowHandler handles follow graph HTTP requests
+type FollowHandler struct {
+	followSvc *service.FollowService
+}
+
+// NewFollowHandler creates a new follow handler
+func NewFollowHandler(followSvc *service.FollowService) *FollowHandler {
+	return &FollowHandler{
+		followSvc: followSvc,
+	}
+}
+
+// RegisterRoutes registers follow routes
+func (h *FollowHandler) RegisterRoutes(r *gin.RouterGroup) {
+	users := r.Group("/users")
+	{
+		users.POST("/:address/follow", h.Follow)
+		users.DELETE("/:address/follow", h.Unfollow)
+		users.POST("/:address/block", h.Block)
+		users.DELETE("/:address/block", h.Unblock)
+		users.GET("/:address/followers", h.GetFollowers)
+		users.GET("/:address/following", h.GetFollowing)
+		users.GET("/:address/mutuals", h.GetMutuals)
+	}
+}
+
+// Follow godoc
+// @Summary Follow a user
+// @Description Makes the authenticated wallet follow a user and notifies them. Following a user again changes nothing.
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Success 200 {object} service.RelationshipResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{address}/follow [post]
+func (h *FollowHandler) Follow(c *gin.Context) {
+	h.change(c, "Failed to follow user", h.followSvc.Follow)
+}
+
+// Unfollow godoc
+// @Summary Unfollow a user
+// @Description Stops the authenticated wallet following a user
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Success 200 {object} service.RelationshipResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{address}/follow [delete]
+func (h *FollowHandler) Unfollow(c *gin.Context) {
+	h.change(c, "Failed to unfollow user", h.followSvc.Unfollow)
+}
+
+// Block godoc
+// @Summary Block a user
+// @Description Makes the authenticated wallet block a user, severing the follows between them both ways and hiding each from the other's feeds and comment threads
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Success 200 {object} service.RelationshipResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{address}/block [post]
+func (h *FollowHandler) Block(c *gin.Context) {
+	h.change(c, "Failed to block user", h.followSvc.Block)
+}
+
+// Unblock godoc
+// @Summary Unblock a user
+// @Description Lifts the authenticated wallet's block of a user. Severed follows are not restored.
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Success 200 {object} service.RelationshipResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{address}/block [delete]
+func (h *FollowHandler) Unblock(c *gin.Context) {
+	h.change(c, "Failed to unblock user", h.followSvc.Unblock)
+}
+
+// GetFollowers godoc
+// @Summary Get a wallet's followers
+// @Description Retrieves a wallet's followers, most recent first. Pass next_cursor back as cursor for the following page.
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Param cursor query string false "Page cursor"
+// @Param limit query int false "Page size" default(20)
+// @Success 200 {object} service.FollowListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/users/{address}/followers [get]
+func (h *FollowHandler) GetFollowers(c *gin.Context) {
+	h.list(c, "Failed to get followers", h.followSvc.ListFollowers)
+}
+
+// GetFollowing godoc
+// @Summary Get the users a wallet follows
+// @Description Retrieves the users a wallet follows, most recent first. Pass next_cursor back as cursor for the following page.
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Param cursor query string false "Page cursor"
+// @Param limit query int false "Page size" default(20)
+// @Success 200 {object} service.FollowListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/users/{address}/following [get]
+func (h *FollowHandler) GetFollowing(c *gin.Context) {
+	h.list(c, "Failed to get following", h.followSvc.ListFollowing)
+}
+
+// GetMutuals godoc
+// @Summary Get a wallet's mutual follows
+// @Description Retrieves the users a wallet follows who follow it back, most recently followed first. Pass next_cursor back as cursor for the following page.
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Param cursor query string false "Page cursor"
+// @Param limit query int false "Page size" default(20)
+// @Success 200 {object} service.FollowListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/users/{address}/mutuals [get]
+func (h *FollowHandler) GetMutuals(c *gin.Context) {
+	h.list(c, "Failed to get mutuals", h.followSvc.ListMutuals)
+}
+
+// change applies a follow or block change by the authenticated wallet to the
+// user in the path
+func (h *FollowHandler) change(
+	c *gin.Context,
+	failure string,
+	apply func(ctx context.Context, actorAddress, address string) (*service.RelationshipResponse, error),
+) {
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	resp, err := apply(c.Request.Context(), sender, c.Param("address"))
+	if err != nil {
+		c.JSON(followErrorStatus(err), ErrorResponse{
+			Error:   failure,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// list serves a page of a follow list of the wallet in the path
+func (h *FollowHandler) list(
+	c *gin.Context,
+	failure string,
+	fetch func(ctx context.Context, address string, query *service.FollowQuery) (*service.FollowListResponse, error),
+) {
+	query := &service.FollowQuery{Cursor: c.Query("cursor")}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: "limit must be a positive integer",
+			})
+			return
+		}
+		query.Limit = limit
+	}
+
+	resp, err := fetch(c.Request.Context(), c.Param("address"), query)
+	if err != nil {
+		c.JSON(followErrorStatus(err), ErrorResponse{
+			Error:   failure,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
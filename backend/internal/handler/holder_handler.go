@@ -0,0 +1,73 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// HolderHandler handles circle cap table HTTP requests
+type HolderHandler struct {
+	holderSvc *service.HolderService
+}
+
+// NewHolderHandler creates a new holder handler
+func NewHolderHandler(holderSvc *service.HolderService) *HolderHandler {
+	return &HolderHandler{
+		holderSvc: holderSvc,
+	}
+}
+
+// RegisterRoutes registers holder routes
+func (h *HolderHandler) RegisterRoutes(r *gin.RouterGroup) {
+	circles := r.Group("/circles")
+	{
+		circles.GET("/:id/holders", h.GetTopHolders)
+	}
+}
+
+// GetTopHolders godoc
+// @Summary Get a circle's top holders
+// @Description Retrieves a page of the circle token's holders, largest balance first, with supply and concentration metrics
+// @Tags circles
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} service.HoldersResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/holders [get]
+func (h *HolderHandler) GetTopHolders(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	resp, err := h.holderSvc.GetTopHolders(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		c.JSON(circleErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get holders",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,180 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// MembershipHandler handles token-gated circle membership HTTP requests
+type MembershipHandler struct {
+	membershipSvc *service.MembershipService
+}
+
+// NewMembershipHandler creates a new membership handler
+func NewMembershipHandler(membershipSvc *service.MembershipService) *MembershipHandler {
+	return &MembershipHandler{
+		membershipSvc: membershipSvc,
+	}
+}
+
+// RegisterRoutes registers membership routes
+func (h *MembershipHandler) RegisterRoutes(r *gin.RouterGroup) {
+	circles := r.Group("/circles")
+	{
+		circles.POST("/:id/join", h.JoinCircle)
+		circles.GET("/:id/membership", h.GetMembership)
+		circles.GET("/:id/membership-rule", h.GetMembershipRule)
+		circles.PUT("/:id/membership-rule", h.SetMembershipRule)
+	}
+}
+
+// JoinCircle godoc
+// @Summary Join a circle
+// @Description Joins a circle if the authenticated wallet holds the circle's minimum token balance
+// @Tags circles
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Success 201 {object} models.UserCircleRelationship
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/join [post]
+func (h *MembershipHandler) JoinCircle(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	member, err := h.membershipSvc.JoinCircle(c.Request.Context(), id, sender)
+	if err != nil {
+		c.JSON(membershipErrorStatus(err), ErrorResponse{
+			Error:   "Failed to join circle",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// GetMembership godoc
+// @Summary Get the caller's circle membership
+// @Description Refreshes the authenticated wallet's token balance and returns its relationship with the circle
+// @Tags circles
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Success 200 {object} models.UserCircleRelationship
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/membership [get]
+func (h *MembershipHandler) GetMembership(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	member, err := h.membershipSvc.GetMembership(c.Request.Context(), id, sender)
+	if err != nil {
+		c.JSON(membershipErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get membership",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// GetMembershipRule godoc
+// @Summary Get a circle's membership rule
+// @Description Retrieves the minimum token balance and hold duration required to join and post in a circle
+// @Tags circles
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Success 200 {object} models.CircleMembershipRule
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/membership-rule [get]
+func (h *MembershipHandler) GetMembershipRule(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.membershipSvc.GetMembershipRule(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(membershipErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get membership rule",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// SetMembershipRule godoc
+// @Summary Set a circle's membership rule
+// @Description Sets the minimum token balance and hold duration for the authenticated circle owner's circle
+// @Tags circles
+// @Accept json
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param request body service.SetMembershipRuleRequest true "Membership rule"
+// @Success 200 {object} models.CircleMembershipRule
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/membership-rule [put]
+func (h *MembershipHandler) SetMembershipRule(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.SetMembershipRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := requireSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	rule, err := h.membershipSvc.SetMembershipRule(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(membershipErrorStatus(err), ErrorResponse{
+			Error:   "Failed to set membership rule",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
@@ -0,0 +1,99 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// PortfolioHandler handles wallet portfolio HTTP requests
+type PortfolioHandler struct {
+	portfolioSvc *service.PortfolioService
+}
+
+// NewPortfolioHandler creates a new portfolio handler
+func NewPortfolioHandler(portfolioSvc *service.PortfolioService) *PortfolioHandler {
+	return &PortfolioHandler{
+		portfolioSvc: portfolioSvc,
+	}
+}
+
+// RegisterRoutes registers portfolio routes
+func (h *PortfolioHandler) RegisterRoutes(r *gin.RouterGroup) {
+	users := r.Group("/users")
+	{
+		users.GET("/:address/portfolio", h.GetPortfolio)
+		users.GET("/:address/portfolio/history", h.GetHistory)
+	}
+}
+
+// GetPortfolio godoc
+// @Summary Get a wallet's portfolio
+// @Description Lists the circle tokens a wallet holds or has traded, valued at the bonding curve price, with cost basis and realized and unrealized profit and loss in ETH under both FIFO and average cost
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Success 200 {object} service.PortfolioResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{address}/portfolio [get]
+func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
+	resp, err := h.portfolioSvc.GetPortfolio(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(portfolioErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get portfolio",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetHistory godoc
+// @Summary Get a wallet's portfolio history
+// @Description Retrieves periodic snapshots of a wallet's portfolio value and average cost profit and loss, oldest first
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Param from query int false "Range start, unix seconds; defaults to 30 days before to"
+// @Param to query int false "Range end (exclusive), unix seconds; defaults to now"
+// @Success 200 {object} service.PortfolioHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{address}/portfolio/history [get]
+func (h *PortfolioHandler) GetHistory(c *gin.Context) {
+	var from, to int64
+	for name, target := range map[string]*int64{"from": &from, "to": &to} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: name + " must be a unix timestamp in seconds",
+			})
+			return
+		}
+		*target = value
+	}
+
+	resp, err := h.portfolioSvc.GetHistory(c.Request.Context(), c.Param("address"), from, to)
+	if err != nil {
+		c.JSON(portfolioErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get portfolio history",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
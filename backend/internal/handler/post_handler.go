@@ -0,0 +1,257 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// PostHandler handles post HTTP requests
+type PostHandler struct {
+	postSvc *service.PostService
+}
+
+// NewPostHandler creates a new post handler
+func NewPostHandler(postSvc *service.PostService) *PostHandler {
+	return &PostHandler{
+		postSvc: postSvc,
+	}
+}
+
+// RegisterRoutes registers post routes
+func (h *PostHandler) RegisterRoutes(r *gin.RouterGroup) {
+	posts := r.Group("/posts")
+	{
+		posts.POST("", h.CreatePost)
+		posts.GET("", h.ListPosts)
+		posts.GET("/:id", h.GetPost)
+		posts.PUT("/:id", h.UpdatePost)
+		posts.DELETE("/:id", h.DeletePost)
+	}
+}
+
+// CreatePost godoc
+// @Summary Create a post
+// @Description Uploads the post body to IPFS and publishes it to the author's feed, or to a circle the author may post in
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param request body service.CreatePostRequest true "Post"
+// @Success 201 {object} service.PostResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /api/v1/posts [post]
+func (h *PostHandler) CreatePost(c *gin.Context) {
+	var req service.CreatePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	post, err := h.postSvc.CreatePost(c.Request.Context(), sender, &req)
+	if err != nil {
+		c.JSON(postErrorStatus(err), ErrorResponse{
+			Error:   "Failed to create post",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, post)
+}
+
+// GetPost godoc
+// @Summary Get a post
+// @Description Retrieves a post with its content read from the IPFS gateway
+// @Tags posts
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} service.PostResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /api/v1/posts/{id} [get]
+func (h *PostHandler) GetPost(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+
+	post, err := h.postSvc.GetPost(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(postErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get post",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// ListPosts godoc
+// @Summary List posts
+// @Description Retrieves posts newest first with their previews, leaving out authors the authenticated wallet blocks or is blocked by. Pass next_cursor back as cursor for the following page.
+// @Tags posts
+// @Produce json
+// @Param circle_id query int false "Only posts in this circle"
+// @Param author query string false "Only posts by this wallet"
+// @Param cursor query string false "Page cursor"
+// @Param limit query int false "Page size" default(20)
+// @Success 200 {object} service.PostListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/posts [get]
+func (h *PostHandler) ListPosts(c *gin.Context) {
+	query := &service.PostQuery{
+		Author: c.Query("author"),
+		Viewer: viewerAddress(c),
+		Cursor: c.Query("cursor"),
+	}
+	if raw := c.Query("circle_id"); raw != "" {
+		circleID, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid circle ID",
+				Message: err.Error(),
+			})
+			return
+		}
+		query.CircleID = circleID
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: "limit must be a positive integer",
+			})
+			return
+		}
+		query.Limit = limit
+	}
+
+	resp, err := h.postSvc.ListPosts(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(postErrorStatus(err), ErrorResponse{
+			Error:   "Failed to list posts",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdatePost godoc
+// @Summary Edit a post
+// @Description Uploads a new version of the authenticated author's post to IPFS. Omitted fields are kept.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body service.UpdatePostRequest true "Changes"
+// @Success 200 {object} service.PostResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /api/v1/posts/{id} [put]
+func (h *PostHandler) UpdatePost(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.UpdatePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	post, err := h.postSvc.UpdatePost(c.Request.Context(), id, sender, &req)
+	if err != nil {
+		c.JSON(postErrorStatus(err), ErrorResponse{
+			Error:   "Failed to update post",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// DeletePost godoc
+// @Summary Delete a post
+// @Description Hides a post from feeds. The author and moderators of the post's circle may delete it.
+// @Tags posts
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/posts/{id} [delete]
+func (h *PostHandler) DeletePost(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	if err := h.postSvc.DeletePost(c.Request.Context(), id, sender); err != nil {
+		c.JSON(postErrorStatus(err), ErrorResponse{
+			Error:   "Failed to delete post",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Post deleted",
+	})
+}
+
+// postIDParam parses the :id path parameter of post routes
+func postIDParam(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid post ID",
+			Message: err.Error(),
+		})
+		return 0, false
+	}
+	return id, true
+}
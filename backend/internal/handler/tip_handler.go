@@ -0,0 +1,167 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// TipHandler handles post tipping HTTP requests
+type TipHandler struct {
+	tipSvc *service.TipService
+}
+
+// NewTipHandler creates a new tip handler
+func NewTipHandler(tipSvc *service.TipService) *TipHandler {
+	return &TipHandler{
+		tipSvc: tipSvc,
+	}
+}
+
+// RegisterRoutes registers tip routes
+func (h *TipHandler) RegisterRoutes(r *gin.RouterGroup) {
+	posts := r.Group("/posts")
+	{
+		posts.POST("/:id/tips/prepare", h.PrepareTip)
+		posts.POST("/:id/tips", h.SubmitTip)
+	}
+
+	circles := r.Group("/circles")
+	{
+		circles.GET("/:id/tips/leaderboard", h.GetLeaderboard)
+	}
+}
+
+// PrepareTip godoc
+// @Summary Prepare a tip transaction
+// @Description Builds an unsigned transfer of ETH, or of the post's circle token, from the authenticated wallet to the post's author
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body service.PrepareTipRequest true "Tip"
+// @Success 200 {object} service.PreparedTxResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/posts/{id}/tips/prepare [post]
+func (h *TipHandler) PrepareTip(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.PrepareTipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	resp, err := h.tipSvc.PrepareTip(c.Request.Context(), id, sender, &req)
+	if err != nil {
+		c.JSON(tipErrorStatus(err), ErrorResponse{
+			Error:   "Failed to prepare tip",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SubmitTip godoc
+// @Summary Submit a mined tip
+// @Description Verifies a mined tip transaction by its receipt and credits it to the post, notifying the author. The transaction must pay a tip prepared for the post and be sent after it was prepared. Submitting a transaction again returns the tip already recorded.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body service.SubmitTipRequest true "Tip transaction"
+// @Success 200 {object} service.TipResponse
+// @Success 201 {object} service.TipResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/posts/{id}/tips [post]
+func (h *TipHandler) SubmitTip(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.SubmitTipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	resp, err := h.tipSvc.SubmitTip(c.Request.Context(), id, sender, &req)
+	if err != nil {
+		c.JSON(tipErrorStatus(err), ErrorResponse{
+			Error:   "Failed to record tip",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if resp.Recorded {
+		status = http.StatusCreated
+	}
+	c.JSON(status, resp)
+}
+
+// GetLeaderboard godoc
+// @Summary Get a circle's most tipped posts
+// @Description Ranks the circle's posts by the tips they received in ETH or in the circle's token
+// @Tags circles
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param asset query string false "eth or token" default(eth)
+// @Param limit query int false "Limit" default(20)
+// @Success 200 {object} service.TipLeaderboardResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/tips/leaderboard [get]
+func (h *TipHandler) GetLeaderboard(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	resp, err := h.tipSvc.GetLeaderboard(c.Request.Context(), id, c.Query("asset"), limit)
+	if err != nil {
+		c.JSON(tipErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get leaderboard",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
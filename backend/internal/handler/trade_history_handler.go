@@ -0,0 +1,225 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// TradeHistoryHandler handles trade history HTTP requests
+type TradeHistoryHandler struct {
+	historySvc *service.TradeHistoryService
+}
+
+// NewTradeHistoryHandler creates a new trade history handler
+func NewTradeHistoryHandler(historySvc *service.TradeHistoryService) *TradeHistoryHandler {
+	return &TradeHistoryHandler{
+		historySvc: historySvc,
+	}
+}
+
+// RegisterRoutes registers trade history routes
+func (h *TradeHistoryHandler) RegisterRoutes(r *gin.RouterGroup) {
+	users := r.Group("/users")
+	{
+		users.GET("/:address/trades", h.GetUserTrades)
+		users.GET("/:address/trades/export", h.ExportUserTrades)
+	}
+
+	circles := r.Group("/circles")
+	{
+		circles.GET("/:id/trades", h.GetCircleTrades)
+		circles.GET("/:id/trades/export", h.ExportCircleTrades)
+	}
+}
+
+// GetUserTrades godoc
+// @Summary Get a wallet's trade history
+// @Description Retrieves a wallet's indexed trades, newest first. Pass next_cursor back as cursor for the following page.
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Param type query string false "BUY or SELL"
+// @Param from query int false "Range start, unix seconds"
+// @Param to query int false "Range end (exclusive), unix seconds"
+// @Param min_size query string false "Smallest ETH amount to include"
+// @Param cursor query string false "Page cursor"
+// @Param limit query int false "Page size" default(50)
+// @Success 200 {object} service.TradeHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{address}/trades [get]
+func (h *TradeHistoryHandler) GetUserTrades(c *gin.Context) {
+	query, ok := tradeHistoryQuery(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.historySvc.GetUserTrades(c.Request.Context(), c.Param("address"), query)
+	if err != nil {
+		c.JSON(tradeHistoryErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get trades",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCircleTrades godoc
+// @Summary Get a circle's trade history
+// @Description Retrieves a circle's indexed trades, newest first. Pass next_cursor back as cursor for the following page.
+// @Tags circles
+// @Produce json
+// @Param id path int true "Circle ID"
+// @Param type query string false "BUY or SELL"
+// @Param from query int false "Range start, unix seconds"
+// @Param to query int false "Range end (exclusive), unix seconds"
+// @Param min_size query string false "Smallest ETH amount to include"
+// @Param cursor query string false "Page cursor"
+// @Param limit query int false "Page size" default(50)
+// @Success 200 {object} service.TradeHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/trades [get]
+func (h *TradeHistoryHandler) GetCircleTrades(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+	query, ok := tradeHistoryQuery(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.historySvc.GetCircleTrades(c.Request.Context(), id, query)
+	if err != nil {
+		c.JSON(tradeHistoryErrorStatus(err), ErrorResponse{
+			Error:   "Failed to get trades",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ExportUserTrades godoc
+// @Summary Export a wallet's trade history
+// @Description Downloads every matching trade of a wallet, oldest first, for tax reporting
+// @Tags users
+// @Produce text/csv,json
+// @Param address path string true "Wallet address"
+// @Param format query string false "csv or json" default(csv)
+// @Param type query string false "BUY or SELL"
+// @Param from query int false "Range start, unix seconds"
+// @Param to query int false "Range end (exclusive), unix seconds"
+// @Param min_size query string false "Smallest ETH amount to include"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{address}/trades/export [get]
+func (h *TradeHistoryHandler) ExportUserTrades(c *gin.Context) {
+	query, ok := tradeHistoryQuery(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.historySvc.ExportUserTrades(c.Request.Context(), c.Param("address"), c.DefaultQuery("format", "csv"), query)
+	h.writeExport(c, export, err)
+}
+
+// ExportCircleTrades godoc
+// @Summary Export a circle's trade history
+// @Description Downloads every matching trade of a circle, oldest first
+// @Tags circles
+// @Produce text/csv,json
+// @Param id path int true "Circle ID"
+// @Param format query string false "csv or json" default(csv)
+// @Param type query string false "BUY or SELL"
+// @Param from query int false "Range start, unix seconds"
+// @Param to query int false "Range end (exclusive), unix seconds"
+// @Param min_size query string false "Smallest ETH amount to include"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/circles/{id}/trades/export [get]
+func (h *TradeHistoryHandler) ExportCircleTrades(c *gin.Context) {
+	id, ok := circleIDParam(c)
+	if !ok {
+		return
+	}
+	query, ok := tradeHistoryQuery(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.historySvc.ExportCircleTrades(c.Request.Context(), id, c.DefaultQuery("format", "csv"), query)
+	h.writeExport(c, export, err)
+}
+
+// writeExport streams a prepared export as a download. Once streaming has
+// begun the status is sent, so a failure part way can only be logged.
+func (h *TradeHistoryHandler) writeExport(c *gin.Context, export *service.TradeExport, err error) {
+	if err != nil {
+		c.JSON(tradeHistoryErrorStatus(err), ErrorResponse{
+			Error:   "Failed to export trades",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", `attachment; filename="`+export.Filename+`"`)
+	c.Status(http.StatusOK)
+	if err := export.Write(c.Request.Context(), c.Writer); err != nil {
+		logger.Error("Trade export failed", "file", export.Filename, "error", err)
+	}
+}
+
+// tradeHistoryQuery parses the filter and paging parameters shared by the
+// trade history endpoints
+func tradeHistoryQuery(c *gin.Context) (*service.TradeHistoryQuery, bool) {
+	query := &service.TradeHistoryQuery{
+		TradeType: c.Query("type"),
+		MinSize:   c.Query("min_size"),
+		Cursor:    c.Query("cursor"),
+	}
+	for name, target := range map[string]*int64{"from": &query.From, "to": &query.To} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: name + " must be a unix timestamp in seconds",
+			})
+			return nil, false
+		}
+		*target = value
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: "limit must be a positive integer",
+			})
+			return nil, false
+		}
+		query.Limit = limit
+	}
+	return query, true
+}
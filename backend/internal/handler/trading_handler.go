@@ -28,25 +28,65 @@ func NewTradingHandler(tradingSvc *service.TradingService) *TradingHandler {
 func (h *TradingHandler) RegisterRoutes(r *gin.RouterGroup) {
 	trading := r.Group("/trading")
 	{
-		trading.POST("/buy", h.BuyTokens)
-		trading.POST("/sell", h.SellTokens)
+		trading.POST("/quote", h.QuoteTrade)
+		trading.POST("/buy/prepare", h.PrepareBuyTokens)
+		trading.POST("/sell/prepare", h.PrepareSellTokens)
+		trading.POST("/submit", h.SubmitTrade)
 		trading.GET("/balance/:circleId/:address", h.GetTokenBalance)
 		trading.GET("/price/:circleId", h.GetCurrentPrice)
 	}
 }
 
-// BuyTokens godoc
-// @Summary Buy circle tokens
-// @Description Purchases tokens from a circle using bonding curve
+// QuoteTrade godoc
+// @Summary Quote a trade
+// @Description Prices a buy or sell on the bonding curve and returns slippage bounds and a quote ID the prepare endpoints accept
+// @Tags trading
+// @Accept json
+// @Produce json
+// @Param request body service.QuoteRequest true "Quote request"
+// @Success 200 {object} service.TradeQuote
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/trading/quote [post]
+func (h *TradingHandler) QuoteTrade(c *gin.Context) {
+	var req service.QuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	quote, err := h.tradingSvc.Quote(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(quoteErrorStatus(err), ErrorResponse{
+			Error:   "Failed to quote trade",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// PrepareBuyTokens godoc
+// @Summary Prepare a buy transaction
+// @Description Builds an unsigned bonding curve purchase for the user's wallet to sign
 // @Tags trading
 // @Accept json
 // @Produce json
 // @Param request body service.BuyTokensRequest true "Buy tokens request"
-// @Success 200 {object} service.TradeResponse
+// @Success 200 {object} service.PreparedTxResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/v1/trading/buy [post]
-func (h *TradingHandler) BuyTokens(c *gin.Context) {
+// @Router /api/v1/trading/buy/prepare [post]
+func (h *TradingHandler) PrepareBuyTokens(c *gin.Context) {
 	var req service.BuyTokensRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -56,10 +96,16 @@ func (h *TradingHandler) BuyTokens(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.tradingSvc.BuyTokens(c.Request.Context(), &req)
+	sender, ok := resolveSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.tradingSvc.PrepareBuyTokens(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to buy tokens",
+		c.JSON(quoteErrorStatus(err), ErrorResponse{
+			Error:   "Failed to prepare buy transaction",
 			Message: err.Error(),
 		})
 		return
@@ -68,18 +114,21 @@ func (h *TradingHandler) BuyTokens(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
-// SellTokens godoc
-// @Summary Sell circle tokens
-// @Description Sells tokens back to a circle using bonding curve
+// PrepareSellTokens godoc
+// @Summary Prepare a sell transaction
+// @Description Builds an unsigned bonding curve sale for the user's wallet to sign
 // @Tags trading
 // @Accept json
 // @Produce json
 // @Param request body service.SellTokensRequest true "Sell tokens request"
-// @Success 200 {object} service.TradeResponse
+// @Success 200 {object} service.PreparedTxResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/v1/trading/sell [post]
-func (h *TradingHandler) SellTokens(c *gin.Context) {
+// @Router /api/v1/trading/sell/prepare [post]
+func (h *TradingHandler) PrepareSellTokens(c *gin.Context) {
 	var req service.SellTokensRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -89,10 +138,57 @@ func (h *TradingHandler) SellTokens(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.tradingSvc.SellTokens(c.Request.Context(), &req)
+	sender, ok := resolveSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.tradingSvc.PrepareSellTokens(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to sell tokens",
+		c.JSON(quoteErrorStatus(err), ErrorResponse{
+			Error:   "Failed to prepare sell transaction",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SubmitTrade godoc
+// @Summary Submit a signed trade
+// @Description Verifies and broadcasts a wallet-signed buy or sell transaction
+// @Tags trading
+// @Accept json
+// @Produce json
+// @Param request body service.SubmitTradeRequest true "Signed trade transaction"
+// @Success 200 {object} service.TradeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/trading/submit [post]
+func (h *TradingHandler) SubmitTrade(c *gin.Context) {
+	var req service.SubmitTradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := resolveSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.tradingSvc.SubmitTrade(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(circleErrorStatus(err), ErrorResponse{
+			Error:   "Failed to submit trade",
 			Message: err.Error(),
 		})
 		return
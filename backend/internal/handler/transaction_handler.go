@@ -0,0 +1,154 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/gin-gonic/gin"
+)
+
+// TransactionHandler handles speed-up and cancel requests for stuck transactions
+type TransactionHandler struct {
+	txSvc *service.TransactionService
+}
+
+// NewTransactionHandler creates a new transaction handler
+func NewTransactionHandler(txSvc *service.TransactionService) *TransactionHandler {
+	return &TransactionHandler{
+		txSvc: txSvc,
+	}
+}
+
+// RegisterRoutes registers transaction routes
+func (h *TransactionHandler) RegisterRoutes(r *gin.RouterGroup) {
+	transactions := r.Group("/transactions")
+	{
+		transactions.POST("/:hash/speed-up/prepare", h.PrepareSpeedUp)
+		transactions.POST("/:hash/cancel/prepare", h.PrepareCancel)
+		transactions.POST("/:hash/replace", h.SubmitReplacement)
+	}
+}
+
+// PrepareSpeedUp godoc
+// @Summary Prepare a speed-up transaction
+// @Description Builds an unsigned copy of a stuck transaction with the same nonce and bumped fees
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param hash path string true "Stuck transaction hash"
+// @Param request body service.PrepareReplacementRequest false "Sender"
+// @Success 200 {object} service.PreparedTxResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/transactions/{hash}/speed-up/prepare [post]
+func (h *TransactionHandler) PrepareSpeedUp(c *gin.Context) {
+	h.prepareReplacement(c, false)
+}
+
+// PrepareCancel godoc
+// @Summary Prepare a cancel transaction
+// @Description Builds an unsigned empty self-transfer with the stuck transaction's nonce and bumped fees
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param hash path string true "Stuck transaction hash"
+// @Param request body service.PrepareReplacementRequest false "Sender"
+// @Success 200 {object} service.PreparedTxResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/transactions/{hash}/cancel/prepare [post]
+func (h *TransactionHandler) PrepareCancel(c *gin.Context) {
+	h.prepareReplacement(c, true)
+}
+
+func (h *TransactionHandler) prepareReplacement(c *gin.Context, cancel bool) {
+	var req service.PrepareReplacementRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	sender, ok := resolveSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.txSvc.PrepareReplacement(c.Request.Context(), c.Param("hash"), &req, cancel)
+	if err != nil {
+		c.JSON(replacementErrorStatus(err), ErrorResponse{
+			Error:   "Failed to prepare replacement transaction",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SubmitReplacement godoc
+// @Summary Submit a signed replacement
+// @Description Verifies and broadcasts a wallet-signed speed-up or cancel and records the new hash
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param hash path string true "Stuck transaction hash"
+// @Param request body service.SubmitReplacementRequest true "Signed replacement transaction"
+// @Success 200 {object} service.ReplacementResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/transactions/{hash}/replace [post]
+func (h *TransactionHandler) SubmitReplacement(c *gin.Context) {
+	var req service.SubmitReplacementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sender, ok := resolveSender(c, req.FromAddress)
+	if !ok {
+		return
+	}
+	req.FromAddress = sender
+
+	resp, err := h.txSvc.SubmitReplacement(c.Request.Context(), c.Param("hash"), &req)
+	if err != nil {
+		c.JSON(replacementErrorStatus(err), ErrorResponse{
+			Error:   "Failed to submit replacement transaction",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// replacementErrorStatus maps speed-up and cancel errors to HTTP status codes
+func replacementErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTransactionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, web3.ErrNotReplaceable):
+		return http.StatusConflict
+	case errors.Is(err, web3.ErrFeeCapExceeded):
+		return http.StatusUnprocessableEntity
+	}
+	return submitErrorStatus(err)
+}
@@ -0,0 +1,166 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// VoteHandler handles voting HTTP requests
+type VoteHandler struct {
+	voteSvc *service.VoteService
+}
+
+// NewVoteHandler creates a new vote handler
+func NewVoteHandler(voteSvc *service.VoteService) *VoteHandler {
+	return &VoteHandler{
+		voteSvc: voteSvc,
+	}
+}
+
+// RegisterRoutes registers vote routes
+func (h *VoteHandler) RegisterRoutes(r *gin.RouterGroup) {
+	posts := r.Group("/posts")
+	{
+		posts.PUT("/:id/vote", h.VotePost)
+		posts.DELETE("/:id/vote", h.RetractPostVote)
+	}
+
+	comments := r.Group("/comments")
+	{
+		comments.PUT("/:id/vote", h.VoteComment)
+		comments.DELETE("/:id/vote", h.RetractCommentVote)
+	}
+}
+
+// VotePost godoc
+// @Summary Vote on a post
+// @Description Sets the caller's vote on a post to up, down or none. Repeating a vote changes nothing. Votes on circle posts may be weighted by the voter's circle token balance.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body service.VoteRequest true "Vote"
+// @Success 200 {object} service.VoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/posts/{id}/vote [put]
+func (h *VoteHandler) VotePost(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+	direction, ok := voteDirection(c)
+	if !ok {
+		return
+	}
+	h.vote(c, func(sender string) (*service.VoteResponse, error) {
+		return h.voteSvc.VotePost(c.Request.Context(), id, sender, direction)
+	})
+}
+
+// RetractPostVote godoc
+// @Summary Retract a vote on a post
+// @Tags posts
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} service.VoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/posts/{id}/vote [delete]
+func (h *VoteHandler) RetractPostVote(c *gin.Context) {
+	id, ok := postIDParam(c)
+	if !ok {
+		return
+	}
+	h.vote(c, func(sender string) (*service.VoteResponse, error) {
+		return h.voteSvc.VotePost(c.Request.Context(), id, sender, service.VoteNone)
+	})
+}
+
+// VoteComment godoc
+// @Summary Vote on a comment
+// @Description Sets the caller's vote on a comment to up or none. Comments cannot be downvoted.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Param request body service.VoteRequest true "Vote"
+// @Success 200 {object} service.VoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/comments/{id}/vote [put]
+func (h *VoteHandler) VoteComment(c *gin.Context) {
+	id, ok := commentIDParam(c)
+	if !ok {
+		return
+	}
+	direction, ok := voteDirection(c)
+	if !ok {
+		return
+	}
+	h.vote(c, func(sender string) (*service.VoteResponse, error) {
+		return h.voteSvc.VoteComment(c.Request.Context(), id, sender, direction)
+	})
+}
+
+// RetractCommentVote godoc
+// @Summary Retract a vote on a comment
+// @Tags posts
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Success 200 {object} service.VoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/comments/{id}/vote [delete]
+func (h *VoteHandler) RetractCommentVote(c *gin.Context) {
+	id, ok := commentIDParam(c)
+	if !ok {
+		return
+	}
+	h.vote(c, func(sender string) (*service.VoteResponse, error) {
+		return h.voteSvc.VoteComment(c.Request.Context(), id, sender, service.VoteNone)
+	})
+}
+
+// vote casts a vote on behalf of the authenticated wallet and writes the
+// resulting counters
+func (h *VoteHandler) vote(c *gin.Context, cast func(sender string) (*service.VoteResponse, error)) {
+	sender, ok := requireSender(c, "")
+	if !ok {
+		return
+	}
+
+	resp, err := cast(sender)
+	if err != nil {
+		c.JSON(voteErrorStatus(err), ErrorResponse{
+			Error:   "Failed to vote",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// voteDirection binds the direction of a vote request
+func voteDirection(c *gin.Context) (string, bool) {
+	var req service.VoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return "", false
+	}
+	return req.Direction, true
+}
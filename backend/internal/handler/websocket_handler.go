@@ -0,0 +1,96 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/middleware"
+	"github.com/fast-socialfi/backend/internal/realtime"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketHandler upgrades authenticated clients onto the real-time feed
+type WebSocketHandler struct {
+	hub      *realtime.Hub
+	auth     *middleware.AuthMiddleware
+	upgrader websocket.Upgrader
+}
+
+// NewWebSocketHandler creates a new WebSocket handler. Browsers may connect
+// from the given origins; "*" allows any.
+func NewWebSocketHandler(hub *realtime.Hub, auth *middleware.AuthMiddleware, allowedOrigins []string) *WebSocketHandler {
+	return &WebSocketHandler{
+		hub:  hub,
+		auth: auth,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" {
+					return true
+				}
+				for _, allowed := range allowedOrigins {
+					if allowed == "*" || strings.EqualFold(allowed, origin) {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	}
+}
+
+// RegisterRoutes registers the WebSocket route
+func (h *WebSocketHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/ws", h.Connect)
+}
+
+// Connect godoc
+// @Summary Open the real-time feed
+// @Description Upgrades to a WebSocket. Send {"action":"subscribe","channel":"circle:1:trades"} to follow circle:{id}:trades, circle:{id}:price or your own user:{address}:notifications. Browsers, which cannot set headers on a WebSocket, pass the access token as the token query parameter.
+// @Tags realtime
+// @Param token query string false "Access token, if not sent as a Bearer Authorization header"
+// @Success 101 "Switching Protocols"
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/ws [get]
+func (h *WebSocketHandler) Connect(c *gin.Context) {
+	token := c.Query("token")
+	if header := c.GetHeader("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "An access token is required",
+		})
+		return
+	}
+
+	claims, err := h.auth.VerifyToken(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// The upgrader answers failed handshakes itself
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	h.hub.Serve(conn, claims.UserAddress, expiresAt)
+}
@@ -0,0 +1,116 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/candles"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// BackfillCandles rebuilds the candles of every traded circle from the trades
+// table, one day at a time. It is meant to run before the indexer starts, for
+// trades that were indexed before candles existed.
+func (ix *Indexer) BackfillCandles(ctx context.Context) error {
+	trades := repository.NewTradeRepository(ix.db)
+	ids, err := trades.GetCircleIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list traded circles: %w", err)
+	}
+
+	day := candles.Intervals[len(candles.Intervals)-1].Duration
+	for _, id := range ids {
+		first, last, err := trades.GetFirstAndLastByCircle(ctx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load trades of circle %d: %w", id, err)
+		}
+
+		end := candles.OpenTime(last.Timestamp, day).Add(day)
+		for start := candles.OpenTime(first.Timestamp, day); start.Before(end); start = start.Add(day) {
+			err := ix.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				return ix.rebuildCandles(ctx, tx, id, start, start.Add(day-time.Nanosecond))
+			})
+			if err != nil {
+				return err
+			}
+		}
+		logger.Info("Backfilled candles", "circle_id", id, "from", first.Timestamp, "to", last.Timestamp)
+	}
+	return nil
+}
+
+// applyCandles rebuilds the candles covering the given trades
+func (ix *Indexer) applyCandles(ctx context.Context, tx *gorm.DB, trades []*models.Trade) error {
+	type span struct{ from, to time.Time }
+	spans := make(map[uint64]*span)
+	for _, trade := range trades {
+		s, ok := spans[trade.CircleID]
+		if !ok {
+			spans[trade.CircleID] = &span{from: trade.Timestamp, to: trade.Timestamp}
+			continue
+		}
+		if trade.Timestamp.Before(s.from) {
+			s.from = trade.Timestamp
+		}
+		if trade.Timestamp.After(s.to) {
+			s.to = trade.Timestamp
+		}
+	}
+
+	for circleID, s := range spans {
+		if err := ix.rebuildCandles(ctx, tx, circleID, s.from, s.to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildCandles recomputes a circle's candles in every interval for the
+// buckets touching [from, to]: 1m buckets from the trades, and each longer
+// interval from the candles of the interval below it
+func (ix *Indexer) rebuildCandles(ctx context.Context, tx *gorm.DB, circleID uint64, from, to time.Time) error {
+	stored := repository.NewCandleRepository(tx)
+
+	finest := candles.Intervals[0]
+	start := candles.OpenTime(from, finest.Duration)
+	end := candles.OpenTime(to, finest.Duration).Add(finest.Duration)
+	trades, err := repository.NewTradeRepository(tx).GetByCircleAndTimeRange(ctx, circleID, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to load trades of circle %d: %w", circleID, err)
+	}
+	source := make([]*models.Candle, 0, len(trades))
+	for _, trade := range trades {
+		source = append(source, candles.FromTrade(trade))
+	}
+
+	for i, interval := range candles.Intervals {
+		start := candles.OpenTime(from, interval.Duration)
+		end := candles.OpenTime(to, interval.Duration).Add(interval.Duration)
+		if i > 0 {
+			if source, err = stored.GetRange(ctx, circleID, candles.Intervals[i-1].Name, start, end); err != nil {
+				return fmt.Errorf("failed to load %s candles of circle %d: %w", candles.Intervals[i-1].Name, circleID, err)
+			}
+		}
+
+		merged, err := candles.Aggregate(interval, source)
+		if err != nil {
+			return fmt.Errorf("failed to aggregate %s candles of circle %d: %w", interval.Name, circleID, err)
+		}
+		if err := stored.ReplaceRange(ctx, circleID, interval.Name, start, end, merged); err != nil {
+			return fmt.Errorf("failed to save %s candles of circle %d: %w", interval.Name, circleID, err)
+		}
+	}
+	return nil
+}
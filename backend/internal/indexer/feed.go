@@ -0,0 +1,61 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer
+
+import (
+	"context"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/realtime"
+	"github.com/fast-socialfi/backend/pkg/logger"
+)
+
+// publishTrades sends a committed batch's trades to the real-time feed,
+// followed by each traded circle's latest price. Delivery is best effort: a
+// batch replayed after a restart or reorg publishes its trades again.
+func publishTrades(ctx context.Context, publisher realtime.Publisher, trades []*models.Trade) {
+	if publisher == nil {
+		return
+	}
+
+	latest := make(map[uint64]*models.Trade)
+	for _, trade := range trades {
+		channel := realtime.CircleTradesChannel(trade.CircleID)
+		if err := publisher.Publish(ctx, channel, realtime.EventTrade, realtime.NewTradeEvent(trade)); err != nil {
+			logger.Warn("Failed to publish trade", "channel", channel, "tx_hash", trade.TxHash, "error", err)
+		}
+
+		last, ok := latest[trade.CircleID]
+		if !ok || trade.BlockNumber > last.BlockNumber ||
+			(trade.BlockNumber == last.BlockNumber && trade.LogIndex > last.LogIndex) {
+			latest[trade.CircleID] = trade
+		}
+	}
+
+	for circleID, trade := range latest {
+		channel := realtime.CirclePriceChannel(circleID)
+		err := publisher.Publish(ctx, channel, realtime.EventPrice, &realtime.PriceEvent{
+			CircleID:    circleID,
+			Price:       trade.Price,
+			BlockNumber: trade.BlockNumber,
+			Timestamp:   trade.Timestamp,
+		})
+		if err != nil {
+			logger.Warn("Failed to publish price", "channel", channel, "error", err)
+		}
+	}
+}
+
+// publishNotification sends a committed notification to its recipient's feed
+func publishNotification(ctx context.Context, publisher realtime.Publisher, address string, notification *models.Notification) {
+	if publisher == nil || notification == nil {
+		return
+	}
+
+	channel := realtime.UserNotificationsChannel(address)
+	if err := publisher.Publish(ctx, channel, realtime.EventNotification, notification); err != nil {
+		logger.Warn("Failed to publish notification", "channel", channel, "error", err)
+	}
+}
@@ -0,0 +1,246 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// applyTransfers journals a batch of Transfer logs and applies the new ones
+// to the holder table. Trades in the batch must already be applied so mints
+// can be matched to the ETH that paid for them.
+func (ix *Indexer) applyTransfers(ctx context.Context, tx *gorm.DB, transfers []types.Log, headers map[uint64]*types.Header) error {
+	circles := repository.NewCircleRepository(tx)
+	journal := repository.NewTokenTransferRepository(tx)
+
+	circleIDs := make(map[common.Address]uint64)
+	touched := make(map[uint64]bool)
+	for _, lg := range transfers {
+		if lg.Removed {
+			continue
+		}
+
+		circleID, ok := circleIDs[lg.Address]
+		if !ok {
+			circle, err := circles.GetByTokenAddress(ctx, lg.Address.Hex())
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			circleID = circle.ID
+			circleIDs[lg.Address] = circleID
+		}
+
+		ev, err := ix.svc.CircleToken().ParseTransfer(lg)
+		if err != nil {
+			return err
+		}
+		transfer := &models.TokenTransfer{
+			CircleID:    circleID,
+			TxHash:      lg.TxHash.Hex(),
+			LogIndex:    lg.Index,
+			FromAddress: ev.From.Hex(),
+			ToAddress:   ev.To.Hex(),
+			Amount:      web3.FormatUnits(ev.Value, 18),
+			BlockNumber: lg.BlockNumber,
+			BlockHash:   lg.BlockHash.Hex(),
+			Timestamp:   time.Unix(int64(headers[lg.BlockNumber].Time), 0).UTC(),
+		}
+
+		inserted, err := journal.CreateIfNotExists(ctx, transfer)
+		if err != nil {
+			return fmt.Errorf("failed to record transfer %s:%d: %w", lg.TxHash.Hex(), lg.Index, err)
+		}
+		if !inserted {
+			continue
+		}
+		if err := ix.applyHolderTransfer(ctx, tx, transfer); err != nil {
+			return err
+		}
+		touched[circleID] = true
+	}
+
+	for circleID := range touched {
+		if err := ix.updateHolderStats(ctx, tx, circleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewindHolders drops transfers after the given block and rebuilds the
+// holders of each affected circle from the transfers that remain
+func (ix *Indexer) rewindHolders(ctx context.Context, tx *gorm.DB, blockNumber uint64) error {
+	journal := repository.NewTokenTransferRepository(tx)
+	holders := repository.NewHolderRepository(tx)
+
+	ids, err := journal.GetCircleIDsAfterBlock(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to load orphaned transfers: %w", err)
+	}
+	if err := journal.DeleteAfterBlock(ctx, blockNumber); err != nil {
+		return fmt.Errorf("failed to roll back transfers: %w", err)
+	}
+
+	for _, id := range ids {
+		if err := holders.DeleteByCircle(ctx, id); err != nil {
+			return fmt.Errorf("failed to roll back holders of circle %d: %w", id, err)
+		}
+		transfers, err := journal.GetByCircle(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to load transfers of circle %d: %w", id, err)
+		}
+		for _, transfer := range transfers {
+			if err := ix.applyHolderTransfer(ctx, tx, transfer); err != nil {
+				return err
+			}
+		}
+		if err := ix.updateHolderStats(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyHolderTransfer moves a transfer's tokens between the positions of the
+// sender and recipient. Mints and burns only touch one side.
+func (ix *Indexer) applyHolderTransfer(ctx context.Context, tx *gorm.DB, transfer *models.TokenTransfer) error {
+	holders := repository.NewHolderRepository(tx)
+	zero := common.Address{}.Hex()
+
+	amount, err := web3.ParseUnits(transfer.Amount, 18)
+	if err != nil {
+		return fmt.Errorf("invalid transfer amount: %w", err)
+	}
+
+	if transfer.FromAddress != zero {
+		sender, err := holders.Get(ctx, transfer.CircleID, transfer.FromAddress)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Only possible when indexing started after the tokens were minted
+			logger.Warn("Transfer from unknown holder", "holder", transfer.FromAddress, "tx_hash", transfer.TxHash)
+			sender, err = &models.CircleHolder{
+				CircleID:      transfer.CircleID,
+				HolderAddress: transfer.FromAddress,
+				Balance:       transfer.Amount,
+				CostBasis:     "0",
+			}, nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load holder %s: %w", transfer.FromAddress, err)
+		}
+		if err := web3.DebitHolder(sender, amount); err != nil {
+			return fmt.Errorf("failed to debit holder %s: %w", transfer.FromAddress, err)
+		}
+		sender.LastBlock = transfer.BlockNumber
+
+		remaining, err := web3.ParseUnits(sender.Balance, 18)
+		if err != nil {
+			return err
+		}
+		if remaining.Sign() == 0 {
+			err = holders.Delete(ctx, transfer.CircleID, transfer.FromAddress)
+		} else {
+			err = holders.Save(ctx, sender)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update holder %s: %w", transfer.FromAddress, err)
+		}
+	}
+
+	if transfer.ToAddress != zero {
+		recipient, err := holders.Get(ctx, transfer.CircleID, transfer.ToAddress)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			recipient, err = &models.CircleHolder{
+				CircleID:      transfer.CircleID,
+				HolderAddress: transfer.ToAddress,
+				Balance:       "0",
+				CostBasis:     "0",
+			}, nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load holder %s: %w", transfer.ToAddress, err)
+		}
+
+		cost := new(big.Int)
+		if transfer.FromAddress == zero {
+			if cost, err = ix.purchaseCost(ctx, tx, transfer, amount); err != nil {
+				return err
+			}
+		}
+		if err := web3.CreditHolder(recipient, amount, cost, transfer.Timestamp); err != nil {
+			return fmt.Errorf("failed to credit holder %s: %w", transfer.ToAddress, err)
+		}
+		recipient.LastBlock = transfer.BlockNumber
+		if err := holders.Save(ctx, recipient); err != nil {
+			return fmt.Errorf("failed to update holder %s: %w", transfer.ToAddress, err)
+		}
+	}
+
+	return nil
+}
+
+// purchaseCost returns the ETH paid for a mint by the bonding curve buy in the
+// same transaction, or zero for mints that were not bought, such as the
+// founder allocation
+func (ix *Indexer) purchaseCost(ctx context.Context, tx *gorm.DB, transfer *models.TokenTransfer, amount *big.Int) (*big.Int, error) {
+	trades, err := repository.NewTradeRepository(tx).GetByTxHash(ctx, transfer.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trades of %s: %w", transfer.TxHash, err)
+	}
+
+	for _, trade := range trades {
+		if trade.TradeType != "BUY" || trade.CircleID != transfer.CircleID {
+			continue
+		}
+		tokens, err := web3.ParseUnits(trade.TokenAmount, 18)
+		if err != nil || tokens.Cmp(amount) != 0 {
+			continue
+		}
+		return web3.ParseUnits(trade.ETHAmount, 18)
+	}
+	return new(big.Int), nil
+}
+
+// updateHolderStats recomputes a circle's supply, holder count and
+// concentration from its holder table
+func (ix *Indexer) updateHolderStats(ctx context.Context, tx *gorm.DB, circleID uint64) error {
+	raw, err := repository.NewHolderRepository(tx).GetBalances(ctx, circleID)
+	if err != nil {
+		return fmt.Errorf("failed to load holders of circle %d: %w", circleID, err)
+	}
+
+	balances := make([]*big.Int, 0, len(raw))
+	supply := new(big.Int)
+	for _, value := range raw {
+		balance, err := web3.ParseUnits(value, 18)
+		if err != nil {
+			return fmt.Errorf("invalid holder balance in circle %d: %w", circleID, err)
+		}
+		balances = append(balances, balance)
+		supply.Add(supply, balance)
+	}
+
+	top10Share, gini := web3.HolderConcentration(balances)
+	return repository.NewCircleRepository(tx).UpdateHolderStats(ctx, circleID, &models.CircleStats{
+		TotalSupply:     web3.FormatUnits(supply, 18),
+		HolderCount:     len(balances),
+		Top10Share:      top10Share,
+		GiniCoefficient: gini,
+	})
+}
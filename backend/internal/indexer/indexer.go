@@ -0,0 +1,893 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/membership"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/realtime"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// IndexerCursorName identifies the cursor row used by the contract event indexer
+const IndexerCursorName = "circle_events"
+
+// blockHistoryWindow is how many blocks of hashes are kept to locate the
+// common ancestor after a reorg
+const blockHistoryWindow = 256
+
+// errReorgDuringSync aborts a batch whose blocks changed while it was fetched
+var errReorgDuringSync = errors.New("chain reorganized during sync")
+
+// Indexer follows the chain and mirrors CircleFactory and BondingCurve events
+// into the circles, trades, candles and transactions tables, and CircleToken
+// transfers into the holder table and the cached balances of circle members.
+// Rows from blocks that are orphaned by a reorg are rolled back, and nothing
+// is marked confirmed until it is ConfirmationDepth blocks deep.
+type Indexer struct {
+	svc       *web3.Web3Service
+	db        *gorm.DB
+	cfg       config.IndexerConfig
+	topics    []common.Hash
+	publisher realtime.Publisher
+}
+
+// NewIndexer creates a new contract event indexer
+func NewIndexer(svc *web3.Web3Service, db *gorm.DB, cfg config.IndexerConfig) *Indexer {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 2000
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 12 * time.Second
+	}
+
+	return &Indexer{
+		svc: svc,
+		db:  db,
+		cfg: cfg,
+		topics: []common.Hash{
+			svc.Events().CircleCreated,
+			svc.Events().CircleDeactivated,
+			svc.Events().CircleReactivated,
+			svc.Events().CircleOwnershipTransferred,
+			svc.Events().TokensPurchased,
+			svc.Events().TokensSold,
+		},
+	}
+}
+
+// SetPublisher sends the trades and prices of each committed batch to the
+// real-time feed
+func (ix *Indexer) SetPublisher(publisher realtime.Publisher) {
+	ix.publisher = publisher
+}
+
+// Run indexes blocks until the context is cancelled. It catches up in
+// batches first and then polls for new blocks.
+func (ix *Indexer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(ix.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			caughtUp, err := ix.SyncOnce(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if errors.Is(err, errReorgDuringSync) {
+					logger.Warn("Indexer batch discarded", "error", err)
+					continue
+				}
+				logger.Error("Indexer sync failed", "error", err)
+				break
+			}
+			if caughtUp {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncOnce indexes the next batch of blocks and reports whether the indexer
+// has reached the chain head
+func (ix *Indexer) SyncOnce(ctx context.Context) (bool, error) {
+	head, err := ix.svc.Client().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get chain head: %w", err)
+	}
+	latest := head.Number.Uint64()
+
+	cursor, err := ix.loadCursor(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	from := ix.cfg.StartBlock
+	if cursor != nil {
+		reorged, err := ix.checkCursor(ctx, cursor, latest)
+		if err != nil {
+			return false, err
+		}
+		if reorged {
+			if err := ix.rewind(ctx, cursor); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+		from = cursor.BlockNumber + 1
+	}
+	if from > latest {
+		return true, ix.confirm(ctx, ix.db, latest)
+	}
+
+	to := from + ix.cfg.BatchSize - 1
+	if to > latest {
+		to = latest
+	}
+
+	logs, err := ix.svc.Client().FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{ix.svc.FactoryAddress(), ix.svc.BondingCurveAddress()},
+		Topics:    [][]common.Hash{ix.topics},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to filter logs for blocks %d-%d: %w", from, to, err)
+	}
+	transfers, err := ix.filterTransfers(ctx, from, to, logs)
+	if err != nil {
+		return false, err
+	}
+	logs = append(logs, transfers...)
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	headers := make(map[uint64]*types.Header)
+	fetchHeader := func(number uint64) (*types.Header, error) {
+		if header, ok := headers[number]; ok {
+			return header, nil
+		}
+		header, err := ix.headerByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		headers[number] = header
+		return header, nil
+	}
+
+	// The batch must extend the indexed chain, and every log must belong to
+	// the block we read its timestamp from; otherwise the chain moved under us
+	fromHeader, err := fetchHeader(from)
+	if err != nil {
+		return false, err
+	}
+	if cursor != nil && fromHeader.ParentHash.Hex() != cursor.BlockHash {
+		return false, fmt.Errorf("%w: block %d does not extend indexed block %d", errReorgDuringSync, from, cursor.BlockNumber)
+	}
+	for _, lg := range logs {
+		header, err := fetchHeader(lg.BlockNumber)
+		if err != nil {
+			return false, err
+		}
+		if header.Hash() != lg.BlockHash {
+			return false, fmt.Errorf("%w: log %s:%d is from block %s", errReorgDuringSync, lg.TxHash.Hex(), lg.Index, lg.BlockHash.Hex())
+		}
+	}
+	toHeader, err := fetchHeader(to)
+	if err != nil {
+		return false, err
+	}
+
+	// Balances are read from the node before the database transaction opens,
+	// so no RPC round trip holds its locks
+	balances, err := ix.readMemberBalances(ctx, transfers)
+	if err != nil {
+		return false, err
+	}
+
+	// Apply the batch and advance the cursor atomically so a crash never
+	// skips or half-applies a block range
+	var trades []*models.Trade
+	err = ix.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, lg := range logs {
+			if lg.Removed {
+				continue
+			}
+			blockTime := time.Unix(int64(headers[lg.BlockNumber].Time), 0).UTC()
+			if err := ix.applyLog(ctx, tx, lg, blockTime); err != nil {
+				return fmt.Errorf("failed to apply log %s:%d: %w", lg.TxHash.Hex(), lg.Index, err)
+			}
+		}
+		trades, err = repository.NewTradeRepository(tx).GetByBlockRange(ctx, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to load indexed trades: %w", err)
+		}
+		if err := ix.applyCandles(ctx, tx, trades); err != nil {
+			return err
+		}
+		if err := ix.applyTransfers(ctx, tx, transfers, headers); err != nil {
+			return err
+		}
+		if err := ix.saveMemberBalances(ctx, tx, balances); err != nil {
+			return err
+		}
+
+		cursors := repository.NewCursorRepository(tx)
+		for _, header := range headers {
+			err := cursors.SaveBlock(ctx, &models.IndexedBlock{
+				BlockNumber: header.Number.Uint64(),
+				BlockHash:   header.Hash().Hex(),
+				ParentHash:  header.ParentHash.Hex(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record block %d: %w", header.Number.Uint64(), err)
+			}
+		}
+		if to >= blockHistoryWindow {
+			if err := cursors.PruneBlocks(ctx, to-blockHistoryWindow); err != nil {
+				return fmt.Errorf("failed to prune block history: %w", err)
+			}
+		}
+
+		if err := cursors.Save(ctx, &models.IndexerCursor{
+			Name:        IndexerCursorName,
+			BlockNumber: to,
+			BlockHash:   toHeader.Hash().Hex(),
+		}); err != nil {
+			return err
+		}
+
+		return ix.confirm(ctx, tx, latest)
+	})
+	if err != nil {
+		return false, err
+	}
+	publishTrades(ctx, ix.publisher, trades)
+
+	if len(logs) > 0 {
+		logger.Info("Indexed contract events", "from", from, "to", to, "logs", len(logs))
+	}
+
+	return to == latest, nil
+}
+
+// loadCursor returns the last indexed block, or nil if nothing was indexed yet
+func (ix *Indexer) loadCursor(ctx context.Context) (*models.IndexerCursor, error) {
+	cursor, err := repository.NewCursorRepository(ix.db).Get(ctx, IndexerCursorName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load indexer cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// checkCursor reports whether the last indexed block is no longer canonical
+func (ix *Indexer) checkCursor(ctx context.Context, cursor *models.IndexerCursor, latest uint64) (bool, error) {
+	if cursor.BlockNumber > latest {
+		return true, nil
+	}
+	header, err := ix.headerByNumber(ctx, cursor.BlockNumber)
+	if errors.Is(err, ethereum.NotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return header.Hash().Hex() != cursor.BlockHash, nil
+}
+
+// headerByNumber fetches a canonical header, reporting ethereum.NotFound for
+// blocks the node does not have
+func (ix *Indexer) headerByNumber(ctx context.Context, number uint64) (*types.Header, error) {
+	header, err := ix.svc.Client().HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err == nil && header == nil {
+		err = ethereum.NotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header %d: %w", number, err)
+	}
+	return header, nil
+}
+
+// rewind rolls the database back to the newest indexed block that is still
+// canonical. Rows from orphaned blocks are removed or returned to pending so
+// the next sync re-applies whatever the canonical chain contains.
+func (ix *Indexer) rewind(ctx context.Context, cursor *models.IndexerCursor) error {
+	blocks, err := repository.NewCursorRepository(ix.db).GetRecentBlocks(ctx, cursor.BlockNumber, blockHistoryWindow)
+	if err != nil {
+		return fmt.Errorf("failed to load block history: %w", err)
+	}
+
+	var ancestor *types.Header
+	for _, block := range blocks {
+		header, err := ix.headerByNumber(ctx, block.BlockNumber)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if header.Hash().Hex() == block.BlockHash {
+			ancestor = header
+			break
+		}
+	}
+	if ancestor == nil {
+		return fmt.Errorf("reorg at block %d is deeper than the indexed block history", cursor.BlockNumber)
+	}
+	safe := ancestor.Number.Uint64()
+
+	balances, err := ix.readOrphanedMemberBalances(ctx, safe)
+	if err != nil {
+		return err
+	}
+
+	err = ix.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		trades := repository.NewTradeRepository(tx)
+		orphaned, err := trades.GetAfterBlock(ctx, safe)
+		if err != nil {
+			return fmt.Errorf("failed to load orphaned trades: %w", err)
+		}
+		if err := trades.DeleteAfterBlock(ctx, safe); err != nil {
+			return fmt.Errorf("failed to roll back trades: %w", err)
+		}
+		if err := ix.applyCandles(ctx, tx, orphaned); err != nil {
+			return err
+		}
+		if err := repository.NewTransactionRepository(tx).ResetAfterBlock(ctx, safe); err != nil {
+			return fmt.Errorf("failed to roll back transactions: %w", err)
+		}
+		if err := ix.rewindCircles(ctx, tx, safe); err != nil {
+			return err
+		}
+		if err := ix.rewindHolders(ctx, tx, safe); err != nil {
+			return err
+		}
+		if err := ix.saveMemberBalances(ctx, tx, balances); err != nil {
+			return err
+		}
+
+		cursors := repository.NewCursorRepository(tx)
+		if err := cursors.DeleteBlocksAfter(ctx, safe); err != nil {
+			return fmt.Errorf("failed to roll back block history: %w", err)
+		}
+		return cursors.Save(ctx, &models.IndexerCursor{
+			Name:        IndexerCursorName,
+			BlockNumber: safe,
+			BlockHash:   ancestor.Hash().Hex(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Warn("Chain reorganization detected, indexer rewound",
+		"orphaned_block", cursor.BlockNumber,
+		"common_ancestor", safe,
+	)
+	return nil
+}
+
+// rewindCircles drops circle events after the given block and restores each
+// affected circle to the state recorded by its latest remaining event
+func (ix *Indexer) rewindCircles(ctx context.Context, tx *gorm.DB, blockNumber uint64) error {
+	events := repository.NewCircleEventRepository(tx)
+	circles := repository.NewCircleRepository(tx)
+
+	ids, err := events.GetCircleIDsAfterBlock(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to load orphaned circle events: %w", err)
+	}
+	if err := events.DeleteAfterBlock(ctx, blockNumber); err != nil {
+		return fmt.Errorf("failed to roll back circle events: %w", err)
+	}
+
+	for _, id := range ids {
+		circle, err := circles.GetByID(ctx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		latest, err := events.GetLatest(ctx, id)
+		if err != nil {
+			return err
+		}
+		if latest == nil {
+			// The creation itself was orphaned; wait for it to be mined again
+			circle.Status = "pending"
+			circle.Active = true
+		} else {
+			circle.OwnerAddress = latest.OwnerAddress
+			circle.Active = latest.Active
+		}
+
+		if err := circles.Update(ctx, circle); err != nil {
+			return fmt.Errorf("failed to roll back circle %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// readOrphanedMemberBalances re-reads cached balances that were read after
+// the given block, since they may include orphaned transfers
+func (ix *Indexer) readOrphanedMemberBalances(ctx context.Context, blockNumber uint64) ([]memberBalance, error) {
+	members, err := repository.NewCircleMemberRepository(ix.db).GetBalancesAfterBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load orphaned member balances: %w", err)
+	}
+
+	balances := make([]memberBalance, 0, len(members))
+	for _, member := range members {
+		balance, err := ix.readMemberBalance(ctx, &member.Circle, member)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+// confirm promotes rows mined at least ConfirmationDepth blocks below the head
+func (ix *Indexer) confirm(ctx context.Context, tx *gorm.DB, latest uint64) error {
+	if latest < ix.cfg.ConfirmationDepth {
+		return nil
+	}
+	safe := latest - ix.cfg.ConfirmationDepth
+
+	if err := repository.NewTransactionRepository(tx).ConfirmMined(ctx, safe); err != nil {
+		return fmt.Errorf("failed to confirm transactions: %w", err)
+	}
+	if err := repository.NewCircleRepository(tx).ConfirmMined(ctx, safe); err != nil {
+		return fmt.Errorf("failed to confirm circles: %w", err)
+	}
+	return nil
+}
+
+// applyLog dispatches a log to the handler for its event
+func (ix *Indexer) applyLog(ctx context.Context, tx *gorm.DB, lg types.Log, blockTime time.Time) error {
+	events := ix.svc.Events()
+	factory, curve := ix.svc.Factory(), ix.svc.BondingCurve()
+
+	switch lg.Topics[0] {
+	case events.CircleCreated:
+		ev, err := factory.ParseCircleCreated(lg)
+		if err != nil {
+			return err
+		}
+		return ix.applyCircleCreated(ctx, tx, lg, blockTime, ev)
+
+	case events.CircleDeactivated:
+		ev, err := factory.ParseCircleDeactivated(lg)
+		if err != nil {
+			return err
+		}
+		return ix.updateCircle(ctx, tx, lg, blockTime, ev.CircleId, "deactivate_circle", "DEACTIVATED", func(circle *models.Circle) {
+			circle.Active = false
+		})
+
+	case events.CircleReactivated:
+		ev, err := factory.ParseCircleReactivated(lg)
+		if err != nil {
+			return err
+		}
+		return ix.updateCircle(ctx, tx, lg, blockTime, ev.CircleId, "reactivate_circle", "REACTIVATED", func(circle *models.Circle) {
+			circle.Active = true
+		})
+
+	case events.CircleOwnershipTransferred:
+		ev, err := factory.ParseCircleOwnershipTransferred(lg)
+		if err != nil {
+			return err
+		}
+		return ix.updateCircle(ctx, tx, lg, blockTime, ev.CircleId, "transfer_circle_ownership", "OWNERSHIP_TRANSFERRED", func(circle *models.Circle) {
+			circle.OwnerAddress = ev.NewOwner.Hex()
+		})
+
+	case events.TokensPurchased:
+		ev, err := curve.ParseTokensPurchased(lg)
+		if err != nil {
+			return err
+		}
+		return ix.applyTrade(ctx, tx, lg, blockTime, "BUY", ev.Token, ev.Buyer, ev.Amount, ev.Cost, ev.NewPrice)
+
+	case events.TokensSold:
+		ev, err := curve.ParseTokensSold(lg)
+		if err != nil {
+			return err
+		}
+		return ix.applyTrade(ctx, tx, lg, blockTime, "SELL", ev.Token, ev.Seller, ev.Amount, ev.Refund, ev.NewPrice)
+	}
+
+	return nil
+}
+
+// applyCircleCreated confirms a pending circle row or creates one for
+// circles that were created outside the API
+func (ix *Indexer) applyCircleCreated(ctx context.Context, tx *gorm.DB, lg types.Log, blockTime time.Time, ev *contracts.CircleFactoryCircleCreated) error {
+	circles := repository.NewCircleRepository(tx)
+
+	circle, err := circles.GetByTxHash(ctx, lg.TxHash.Hex())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		circle, err = circles.GetByChainID(ctx, ev.CircleId.Uint64())
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		circle, err = &models.Circle{}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	circle.ChainCircleID = ev.CircleId.Uint64()
+	circle.OwnerAddress = ev.Owner.Hex()
+	circle.TokenAddress = ev.TokenAddress.Hex()
+	circle.BondingCurveAddress = ix.svc.BondingCurveAddress().Hex()
+	circle.Name = ev.Name
+	circle.Symbol = ev.Symbol
+	circle.CurveType = ev.CurveType
+	circle.Active = true
+	circle.TxHash = lg.TxHash.Hex()
+	if circle.Status != "confirmed" {
+		circle.Status = "mined"
+	}
+
+	if err := circles.Update(ctx, circle); err != nil {
+		return err
+	}
+	if err := ix.recordCircleEvent(ctx, tx, lg, circle, "CREATED", ev.Owner.Hex()); err != nil {
+		return err
+	}
+
+	return ix.upsertTransaction(ctx, tx, &models.Transaction{
+		CircleID:     circle.ID,
+		TxHash:       lg.TxHash.Hex(),
+		TxType:       "create_circle",
+		FromAddress:  ev.Owner.Hex(),
+		ToAddress:    lg.Address.Hex(),
+		Amount:       "0",
+		Status:       "mined",
+		TokenAddress: circle.TokenAddress,
+		BlockNumber:  lg.BlockNumber,
+		BlockHash:    lg.BlockHash.Hex(),
+		Timestamp:    blockTime,
+	})
+}
+
+// updateCircle applies a lifecycle event to an indexed circle
+func (ix *Indexer) updateCircle(ctx context.Context, tx *gorm.DB, lg types.Log, blockTime time.Time, chainCircleID *big.Int, txType, eventType string, apply func(*models.Circle)) error {
+	circles := repository.NewCircleRepository(tx)
+
+	circle, err := circles.GetByChainID(ctx, chainCircleID.Uint64())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Warn("Skipping event for unknown circle", "circle_id", chainCircleID.String(), "tx_hash", lg.TxHash.Hex())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Only the circle owner may change it, so the owner before the change
+	// is the account that sent the transaction
+	actor := circle.OwnerAddress
+
+	apply(circle)
+	if err := circles.Update(ctx, circle); err != nil {
+		return err
+	}
+	if err := ix.recordCircleEvent(ctx, tx, lg, circle, eventType, actor); err != nil {
+		return err
+	}
+
+	return ix.upsertTransaction(ctx, tx, &models.Transaction{
+		CircleID:     circle.ID,
+		TxHash:       lg.TxHash.Hex(),
+		TxType:       txType,
+		FromAddress:  actor,
+		ToAddress:    lg.Address.Hex(),
+		Amount:       "0",
+		Status:       "mined",
+		TokenAddress: circle.TokenAddress,
+		BlockNumber:  lg.BlockNumber,
+		BlockHash:    lg.BlockHash.Hex(),
+		Timestamp:    blockTime,
+	})
+}
+
+// applyTrade records a bonding curve trade and its transaction
+func (ix *Indexer) applyTrade(ctx context.Context, tx *gorm.DB, lg types.Log, blockTime time.Time, tradeType string, token, trader common.Address, amount, ethAmount, newPrice *big.Int) error {
+	circle, err := repository.NewCircleRepository(tx).GetByTokenAddress(ctx, token.Hex())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Warn("Skipping trade for unknown token", "token", token.Hex(), "tx_hash", lg.TxHash.Hex())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	user, err := repository.NewUserRepository(tx).GetOrCreateByAddress(ctx, trader.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to resolve trader: %w", err)
+	}
+
+	err = repository.NewTradeRepository(tx).CreateIfNotExists(ctx, &models.Trade{
+		TxHash:      lg.TxHash.Hex(),
+		LogIndex:    lg.Index,
+		TraderID:    user.UserID,
+		CircleID:    circle.ID,
+		TradeType:   tradeType,
+		TokenAmount: web3.FormatUnits(amount, 18),
+		ETHAmount:   web3.FormatUnits(ethAmount, 18),
+		Price:       web3.FormatUnits(newPrice, 18),
+		Fee:         "0",
+		BlockNumber: lg.BlockNumber,
+		BlockHash:   lg.BlockHash.Hex(),
+		Timestamp:   blockTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	txType := "buy"
+	if tradeType == "SELL" {
+		txType = "sell"
+	}
+
+	return ix.upsertTransaction(ctx, tx, &models.Transaction{
+		CircleID:     circle.ID,
+		TxHash:       lg.TxHash.Hex(),
+		TxType:       txType,
+		FromAddress:  trader.Hex(),
+		ToAddress:    lg.Address.Hex(),
+		Amount:       amount.String(),
+		Status:       "mined",
+		TokenAddress: circle.TokenAddress,
+		BlockNumber:  lg.BlockNumber,
+		BlockHash:    lg.BlockHash.Hex(),
+		Timestamp:    blockTime,
+	})
+}
+
+// filterTransfers fetches the Transfer logs of circle tokens in a block
+// range, including tokens of circles created within the range
+func (ix *Indexer) filterTransfers(ctx context.Context, from, to uint64, logs []types.Log) ([]types.Log, error) {
+	known, err := repository.NewCircleRepository(ix.db).ListTokenAddresses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list circle tokens: %w", err)
+	}
+
+	tokens := make([]common.Address, 0, len(known))
+	for _, address := range known {
+		tokens = append(tokens, common.HexToAddress(address))
+	}
+	created := ix.svc.Events().CircleCreated
+	for _, lg := range logs {
+		if lg.Topics[0] != created {
+			continue
+		}
+		ev, err := ix.svc.Factory().ParseCircleCreated(lg)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, ev.TokenAddress)
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	transfers, err := ix.svc.Client().FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: tokens,
+		Topics:    [][]common.Hash{{ix.svc.Events().Transfer}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter transfers for blocks %d-%d: %w", from, to, err)
+	}
+	return transfers, nil
+}
+
+// memberBalance is a member's balance as read from the node, held until the
+// database transaction that caches it is open
+type memberBalance struct {
+	circle      *models.Circle
+	member      *models.UserCircleRelationship
+	balance     *big.Int
+	blockNumber uint64
+}
+
+// readMemberBalances re-reads the balances of circle members that sent or
+// received tokens in a batch of Transfer logs. Balances are read at the head
+// rather than replayed from the amounts, so a member whose cached balance is
+// already newer than a transfer is left alone.
+func (ix *Indexer) readMemberBalances(ctx context.Context, transfers []types.Log) ([]memberBalance, error) {
+	// The last block each holder's balance changed in, per token
+	touched := make(map[common.Address]map[string]uint64)
+	for _, lg := range transfers {
+		if lg.Removed {
+			continue
+		}
+		ev, err := ix.svc.CircleToken().ParseTransfer(lg)
+		if err != nil {
+			return nil, err
+		}
+		if touched[lg.Address] == nil {
+			touched[lg.Address] = make(map[string]uint64)
+		}
+		for _, holder := range []common.Address{ev.From, ev.To} {
+			if holder != (common.Address{}) {
+				touched[lg.Address][holder.Hex()] = lg.BlockNumber
+			}
+		}
+	}
+
+	// A circle created in this batch has no members yet, so reading the
+	// committed state is enough
+	circles := repository.NewCircleRepository(ix.db)
+	members := repository.NewCircleMemberRepository(ix.db)
+	var balances []memberBalance
+	for token, holders := range touched {
+		circle, err := circles.GetByTokenAddress(ctx, token.Hex())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		wallets := make([]string, 0, len(holders))
+		for wallet := range holders {
+			wallets = append(wallets, wallet)
+		}
+		rows, err := members.GetByCircleAndWallets(ctx, circle.ID, wallets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load circle members: %w", err)
+		}
+
+		for _, member := range rows {
+			if member.BalanceBlock >= holders[common.HexToAddress(member.User.WalletAddress).Hex()] {
+				continue
+			}
+			balance, err := ix.readMemberBalance(ctx, circle, member)
+			if err != nil {
+				return nil, err
+			}
+			balances = append(balances, balance)
+		}
+	}
+	return balances, nil
+}
+
+// readMemberBalance reads a member's balance at the current head
+func (ix *Indexer) readMemberBalance(ctx context.Context, circle *models.Circle, member *models.UserCircleRelationship) (memberBalance, error) {
+	balance, head, err := ix.svc.CurrentTokenBalance(ctx, common.HexToAddress(circle.TokenAddress), common.HexToAddress(member.User.WalletAddress))
+	if err != nil {
+		return memberBalance{}, fmt.Errorf("failed to refresh balance of %s: %w", member.User.WalletAddress, err)
+	}
+	return memberBalance{
+		circle:      circle,
+		member:      member,
+		balance:     balance,
+		blockNumber: head.Number.Uint64(),
+	}, nil
+}
+
+// saveMemberBalances caches balances read before the transaction and applies
+// each circle's membership rule to them. The relationship is loaded again so
+// a change made since the read, such as a moderator muting the member, is
+// kept.
+func (ix *Indexer) saveMemberBalances(ctx context.Context, tx *gorm.DB, balances []memberBalance) error {
+	rules := repository.NewMembershipRuleRepository(tx)
+	members := repository.NewCircleMemberRepository(tx)
+	now := time.Now().UTC()
+
+	for _, read := range balances {
+		rows, err := members.GetByCircleAndWallets(ctx, read.circle.ID, []string{read.member.User.WalletAddress})
+		if err != nil {
+			return fmt.Errorf("failed to load circle member: %w", err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		member := rows[0]
+
+		rule, err := rules.GetByCircle(ctx, read.circle.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			rule, err = nil, nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load membership rule: %w", err)
+		}
+
+		membership.ApplyBalance(member, rule, read.balance, read.blockNumber, now)
+		if err := members.SaveBalance(ctx, member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordCircleEvent journals the circle state produced by a lifecycle log so
+// it can be restored if a later block is orphaned
+func (ix *Indexer) recordCircleEvent(ctx context.Context, tx *gorm.DB, lg types.Log, circle *models.Circle, eventType, actor string) error {
+	return repository.NewCircleEventRepository(tx).CreateIfNotExists(ctx, &models.CircleEvent{
+		CircleID:     circle.ID,
+		EventType:    eventType,
+		ActorAddress: actor,
+		OwnerAddress: circle.OwnerAddress,
+		Active:       circle.Active,
+		TxHash:       lg.TxHash.Hex(),
+		LogIndex:     lg.Index,
+		BlockNumber:  lg.BlockNumber,
+		BlockHash:    lg.BlockHash.Hex(),
+	})
+}
+
+// upsertTransaction marks a transaction submitted through the API as mined or
+// records one that was sent from elsewhere
+func (ix *Indexer) upsertTransaction(ctx context.Context, tx *gorm.DB, row *models.Transaction) error {
+	txs := repository.NewTransactionRepository(tx)
+
+	existing, err := txs.GetByHash(ctx, row.TxHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return txs.Create(ctx, row)
+	}
+	if err != nil {
+		return err
+	}
+
+	if existing.Status != "confirmed" {
+		existing.Status = row.Status
+	}
+	existing.BlockNumber = row.BlockNumber
+	existing.BlockHash = row.BlockHash
+	if existing.CircleID == 0 {
+		existing.CircleID = row.CircleID
+	}
+	if existing.FromAddress == "" {
+		existing.FromAddress = row.FromAddress
+	}
+	if existing.ToAddress == "" {
+		existing.ToAddress = row.ToAddress
+	}
+	if existing.TokenAddress == "" {
+		existing.TokenAddress = row.TokenAddress
+	}
+	// A buy is recorded on submission without the amount it mints
+	if row.Amount != "0" {
+		existing.Amount = row.Amount
+	}
+	return txs.Update(ctx, existing)
+}
@@ -0,0 +1,334 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/realtime"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// Reconciler settles Transaction rows recorded at submission time by looking
+// up their receipts, so their status does not depend on contract events
+type Reconciler struct {
+	svc       *web3.Web3Service
+	db        *gorm.DB
+	cfg       config.ReconcilerConfig
+	publisher realtime.Publisher
+}
+
+// NewReconciler creates a new pending transaction reconciler
+func NewReconciler(svc *web3.Web3Service, db *gorm.DB, cfg config.ReconcilerConfig) *Reconciler {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 15 * time.Second
+	}
+	if cfg.DropTimeout <= 0 {
+		cfg.DropTimeout = 30 * time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	return &Reconciler{svc: svc, db: db, cfg: cfg}
+}
+
+// SetPublisher sends the notifications raised while settling transactions to
+// the real-time feed
+func (rc *Reconciler) SetPublisher(publisher realtime.Publisher) {
+	rc.publisher = publisher
+}
+
+// Run reconciles transactions until the context is cancelled
+func (rc *Reconciler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(rc.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := rc.ReconcileOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Error("Transaction reconciliation failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileOnce checks every unsettled transaction against the chain once
+func (rc *Reconciler) ReconcileOnce(ctx context.Context) error {
+	head, err := rc.svc.Client().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get chain head: %w", err)
+	}
+
+	txRepo := repository.NewTransactionRepository(rc.db)
+	txs, err := txRepo.GetUnsettled(ctx, rc.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to load pending transactions: %w", err)
+	}
+
+	// Stamp the batch before checking it, so a row that fails to reconcile
+	// still yields its place to the rows behind it
+	now := time.Now().UTC()
+	ids := make([]uint64, 0, len(txs))
+	for _, row := range txs {
+		row.CheckedAt = &now
+		ids = append(ids, row.ID)
+	}
+	if err := txRepo.MarkChecked(ctx, ids, now); err != nil {
+		return fmt.Errorf("failed to mark transactions checked: %w", err)
+	}
+
+	for _, row := range txs {
+		if err := rc.reconcile(ctx, row, head.Number.Uint64()); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Warn("Failed to reconcile transaction", "tx_hash", row.TxHash, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// reconcile updates a single row from its receipt
+func (rc *Reconciler) reconcile(ctx context.Context, row *models.Transaction, latest uint64) error {
+	hash := common.HexToHash(row.TxHash)
+
+	receipt, err := rc.svc.Client().TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		// A speed-up or cancel may have lost to the broadcast it replaced
+		receipt, err = rc.restoreSuperseded(ctx, row)
+		if err != nil {
+			return err
+		}
+		if receipt == nil {
+			return rc.reconcileMissing(ctx, row, hash)
+		}
+		hash = receipt.TxHash
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get receipt: %w", err)
+	}
+
+	gasPrice := receipt.EffectiveGasPrice
+	if gasPrice == nil {
+		// Nodes that predate the field only expose the price on the transaction
+		tx, _, err := rc.svc.Client().TransactionByHash(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("failed to get transaction: %w", err)
+		}
+		gasPrice = tx.GasPrice()
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+
+	firstSeen := !row.Applied
+	row.Applied = true
+	row.BlockNumber = receipt.BlockNumber.Uint64()
+	row.BlockHash = receipt.BlockHash.Hex()
+	row.GasUsed = receipt.GasUsed
+	row.GasPrice = gasPrice.String()
+	row.Fee = web3.FormatUnits(fee, 18)
+
+	// A reverted transaction can still be reorged out in favour of one that
+	// succeeds, so it stays mined until it is as deep as a confirmed one
+	succeeded := receipt.Status == types.ReceiptStatusSuccessful
+	switch {
+	case latest < rc.cfg.ConfirmationDepth || row.BlockNumber > latest-rc.cfg.ConfirmationDepth:
+		row.Status = "mined"
+	case !succeeded:
+		row.Status = "failed"
+	default:
+		row.Status = "confirmed"
+	}
+
+	var notification *models.Notification
+	err = rc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := repository.NewTransactionRepository(tx).Update(ctx, row); err != nil {
+			return err
+		}
+		if row.Status == "failed" || (row.Status == "confirmed" && row.TxType == "cancel") {
+			return rc.failCircle(ctx, tx, row)
+		}
+		if firstSeen && succeeded && (row.TxType == "buy" || row.TxType == "sell") {
+			notification, err = rc.notifyTrade(ctx, tx, row)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+	publishNotification(ctx, rc.publisher, row.FromAddress, notification)
+
+	if firstSeen {
+		logger.Info("Transaction settled", "tx_hash", row.TxHash, "status", row.Status, "block", row.BlockNumber)
+	}
+	return nil
+}
+
+// reconcileMissing handles a transaction that has no receipt. A mined row
+// lost its block to a reorg; a pending row the node no longer knows about is
+// dropped once it has been missing for longer than the drop timeout.
+func (rc *Reconciler) reconcileMissing(ctx context.Context, row *models.Transaction, hash common.Hash) error {
+	txs := repository.NewTransactionRepository(rc.db)
+
+	if row.Status == "mined" {
+		row.Status = "pending"
+		row.BlockNumber = 0
+		row.BlockHash = ""
+		return txs.Update(ctx, row)
+	}
+
+	// The row is still pending while the node holds any broadcast of it
+	hashes := []common.Hash{hash}
+	superseded, err := txs.GetSuperseded(ctx, row.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get replaced transactions: %w", err)
+	}
+	for _, replacement := range superseded {
+		hashes = append(hashes, common.HexToHash(replacement.TxHash))
+	}
+	for _, h := range hashes {
+		_, _, err := rc.svc.Client().TransactionByHash(ctx, h)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return fmt.Errorf("failed to get transaction: %w", err)
+		}
+	}
+
+	submitted := row.Timestamp
+	if submitted.IsZero() {
+		submitted = row.CreatedAt
+	}
+	if time.Since(submitted) < rc.cfg.DropTimeout {
+		return nil
+	}
+
+	row.Status = "dropped"
+	err = rc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := repository.NewTransactionRepository(tx).Update(ctx, row); err != nil {
+			return err
+		}
+		return rc.failCircle(ctx, tx, row)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	logger.Warn("Transaction dropped", "tx_hash", row.TxHash, "submitted_at", submitted)
+	return nil
+}
+
+// restoreSuperseded looks for a receipt of the broadcasts a row's current
+// hash superseded. If one was mined the row is moved back onto it and its
+// receipt returned; otherwise the receipt is nil.
+func (rc *Reconciler) restoreSuperseded(ctx context.Context, row *models.Transaction) (*types.Receipt, error) {
+	txs := repository.NewTransactionRepository(rc.db)
+	superseded, err := txs.GetSuperseded(ctx, row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replaced transactions: %w", err)
+	}
+
+	for _, replacement := range superseded {
+		receipt, err := rc.svc.Client().TransactionReceipt(ctx, common.HexToHash(replacement.TxHash))
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt: %w", err)
+		}
+
+		replacedBy := row.TxHash
+		if err := txs.Restore(ctx, row, replacement); err != nil {
+			return nil, fmt.Errorf("failed to restore replaced transaction: %w", err)
+		}
+		logger.Info("Replaced transaction mined", "tx_hash", row.TxHash, "replaced_by", replacedBy)
+		return receipt, nil
+	}
+	return nil, nil
+}
+
+// failCircle marks a circle as failed when its creation transaction did not
+// make it on chain or was cancelled by the owner
+func (rc *Reconciler) failCircle(ctx context.Context, tx *gorm.DB, row *models.Transaction) error {
+	if row.TxType != "create_circle" && row.TxType != "cancel" {
+		return nil
+	}
+
+	circles := repository.NewCircleRepository(tx)
+	circle, err := circles.GetByTxHash(ctx, row.TxHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if circle.Status != "pending" {
+		return nil
+	}
+
+	circle.Status = "failed"
+	return circles.Update(ctx, circle)
+}
+
+// notifyTrade tells the trader their buy or sell went through
+func (rc *Reconciler) notifyTrade(ctx context.Context, tx *gorm.DB, row *models.Transaction) (*models.Notification, error) {
+	user, err := repository.NewUserRepository(tx).GetOrCreateByAddress(ctx, row.FromAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve trader: %w", err)
+	}
+
+	symbol := "tokens"
+	var circleID *uint64
+	if row.CircleID != 0 {
+		circle, err := repository.NewCircleRepository(tx).GetByID(ctx, row.CircleID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if circle != nil {
+			symbol = circle.Symbol
+			circleID = &circle.ID
+		}
+	}
+
+	action := "Buy"
+	if row.TxType == "sell" {
+		action = "Sell"
+	}
+	content := fmt.Sprintf("%s of %s executed in block %d (tx %s)", action, symbol, row.BlockNumber, row.TxHash)
+
+	notification := &models.Notification{
+		UserID:           user.UserID,
+		NotificationType: "TRADE_EXECUTED",
+		Title:            "Trade executed",
+		Content:          &content,
+		RelatedCircleID:  circleID,
+	}
+	if err := repository.NewNotificationRepository(tx).Create(ctx, notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
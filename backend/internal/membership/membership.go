@@ -0,0 +1,72 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package membership
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/web3"
+)
+
+// MinBalance returns a rule's minimum balance in token base units.
+// Circles without a rule, or with a zero minimum, are not gated.
+func MinBalance(rule *models.CircleMembershipRule) *big.Int {
+	if rule == nil {
+		return new(big.Int)
+	}
+	minBalance, err := web3.ParseUnits(rule.MinBalance, 18)
+	if err != nil {
+		// The DECIMAL(30,18) column only holds amounts ParseUnits accepts
+		return new(big.Int)
+	}
+	return minBalance
+}
+
+// MeetsRule reports whether a member has held at least the rule's
+// minimum balance for at least its hold duration, as of now
+func MeetsRule(member *models.UserCircleRelationship, rule *models.CircleMembershipRule, now time.Time) bool {
+	if member.RelationshipType == "OWNS" {
+		return true
+	}
+	if member.HeldSince == nil {
+		return false
+	}
+	if rule == nil {
+		return true
+	}
+	return now.Sub(*member.HeldSince) >= time.Duration(rule.MinHoldSeconds)*time.Second
+}
+
+// ApplyBalance caches a freshly read balance on a circle relationship
+// and applies the circle's membership rule to it. A member below the minimum,
+// or who has not held it for long enough, is read-only until the rule is met
+// again; members muted by a moderator stay muted either way. Circle owners
+// are not gated.
+func ApplyBalance(member *models.UserCircleRelationship, rule *models.CircleMembershipRule, balance *big.Int, blockNumber uint64, now time.Time) {
+	member.TokenBalance = web3.FormatUnits(balance, 18)
+	member.BalanceBlock = blockNumber
+	if member.RelationshipType == "OWNS" {
+		return
+	}
+
+	if balance.Cmp(MinBalance(rule)) < 0 {
+		member.HeldSince = nil
+	} else if member.HeldSince == nil {
+		held := now
+		member.HeldSince = &held
+	}
+
+	eligible := MeetsRule(member, rule, now)
+	switch {
+	case !eligible && member.CanPost:
+		member.CanPost = false
+		member.GateDemoted = true
+	case eligible && member.GateDemoted:
+		member.CanPost = true
+		member.GateDemoted = false
+	}
+}
@@ -5,6 +5,7 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -17,18 +18,30 @@ import (
 // AuthMiddleware handles JWT authentication
 type AuthMiddleware struct {
 	jwtSecret string
+	revoked   RevocationList
 }
 
 // NewAuthMiddleware creates a new auth middleware
 func NewAuthMiddleware(jwtSecret string) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtSecret: jwtSecret,
+		revoked:   NewMemoryRevocationList(),
 	}
 }
 
+// SetRevocationList replaces the in-process revocation list, e.g. with a
+// RedisRevocationList when several replicas serve the API
+func (m *AuthMiddleware) SetRevocationList(list RevocationList) {
+	m.revoked = list
+}
+
 // Claims represents JWT claims
 type Claims struct {
 	UserAddress string `json:"user_address"`
+	// SessionID ties an access token to the session that can revoke it
+	SessionID string `json:"sid,omitempty"`
+	// Role is the user's platform role, e.g. RoleAdmin
+	Role string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -76,8 +89,26 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		}
 
 		if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+			revoked, err := m.isRevoked(c, claims)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error":   "Internal Server Error",
+					"message": "Failed to check token revocation",
+				})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":   "Unauthorized",
+					"message": "Token has been revoked",
+				})
+				c.Abort()
+				return
+			}
+
 			// Set user address in context
-			c.Set("user_address", claims.UserAddress)
+			setClaims(c, claims)
 			c.Next()
 		} else {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -104,6 +135,78 @@ func (m *AuthMiddleware) GenerateToken(userAddress string, duration time.Duratio
 	return token.SignedString([]byte(m.jwtSecret))
 }
 
+// GenerateSessionToken generates a JWT token that is refused once its
+// session is revoked. role is the user's platform role and may be empty.
+func (m *AuthMiddleware) GenerateSessionToken(userAddress, sessionID, role string, duration time.Duration) (string, error) {
+	claims := Claims{
+		UserAddress: userAddress,
+		SessionID:   sessionID,
+		Role:        role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(m.jwtSecret))
+}
+
+// RevokeSession refuses the session's access tokens from now on. The ttl
+// must cover the lifetime of the tokens already issued.
+func (m *AuthMiddleware) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	return m.revoked.Revoke(ctx, sessionID, ttl)
+}
+
+// VerifyToken validates a token presented outside the Authorization header,
+// such as on a WebSocket upgrade, and checks its session was not revoked
+func (m *AuthMiddleware) VerifyToken(ctx context.Context, tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(m.jwtSecret), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+	if claims.SessionID != "" {
+		revoked, err := m.revoked.IsRevoked(ctx, claims.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		if revoked {
+			return nil, fmt.Errorf("token has been revoked")
+		}
+	}
+	return claims, nil
+}
+
+// isRevoked reports whether the token's session was revoked. Tokens from
+// GenerateToken carry no session and can only expire.
+func (m *AuthMiddleware) isRevoked(c *gin.Context, claims *Claims) (bool, error) {
+	if claims.SessionID == "" {
+		return false, nil
+	}
+	return m.revoked.IsRevoked(c.Request.Context(), claims.SessionID)
+}
+
+// setClaims exposes the token's claims to the handlers
+func setClaims(c *gin.Context, claims *Claims) {
+	c.Set("user_address", claims.UserAddress)
+	if claims.SessionID != "" {
+		c.Set("session_id", claims.SessionID)
+	}
+	if claims.Role != "" {
+		c.Set("user_role", claims.Role)
+	}
+}
+
 // OptionalAuth is middleware for optional authentication
 func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -129,7 +232,11 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 
 		if err == nil {
 			if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-				c.Set("user_address", claims.UserAddress)
+				// A revoked token, or one that cannot be checked, leaves the
+				// request anonymous
+				if revoked, err := m.isRevoked(c, claims); err == nil && !revoked {
+					setClaims(c, claims)
+				}
 			}
 		}
 
@@ -0,0 +1,195 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RoleAdmin is the platform role of users who may moderate and configure
+// every circle
+const RoleAdmin = "ADMIN"
+
+// Permission names something a user may be allowed to do in a circle
+type Permission string
+
+const (
+	// PermissionPost allows publishing content in the circle
+	PermissionPost Permission = "post"
+	// PermissionModerate allows hiding, deleting and pinning content
+	PermissionModerate Permission = "moderate"
+	// PermissionInvite allows inviting new members
+	PermissionInvite Permission = "invite"
+	// PermissionManage allows changing circle settings; only the owner holds it
+	PermissionManage Permission = "manage"
+)
+
+// ErrCircleNotFound is returned by Policy.Check for an unknown circle
+var ErrCircleNotFound = errors.New("circle not found")
+
+// Grants reports whether a circle relationship carries a permission. The
+// owner holds every permission; everyone else holds what the relationship's
+// flags allow. rel is nil for users with no relationship to the circle.
+func Grants(rel *models.UserCircleRelationship, isOwner bool, perm Permission) bool {
+	if isOwner || (rel != nil && rel.RelationshipType == "OWNS") {
+		return true
+	}
+	if rel == nil {
+		return false
+	}
+
+	switch perm {
+	case PermissionPost:
+		return rel.CanPost
+	case PermissionModerate:
+		return rel.CanModerate
+	case PermissionInvite:
+		return rel.CanInvite
+	}
+	return false
+}
+
+// Policy decides what authenticated users may do in circles, from their
+// platform role and their relationship with the circle
+type Policy struct {
+	userRepo   *repository.UserRepository
+	circleRepo *repository.CircleRepository
+	memberRepo *repository.CircleMemberRepository
+}
+
+// NewPolicy creates a new circle policy
+func NewPolicy(userRepo *repository.UserRepository, circleRepo *repository.CircleRepository, memberRepo *repository.CircleMemberRepository) *Policy {
+	return &Policy{
+		userRepo:   userRepo,
+		circleRepo: circleRepo,
+		memberRepo: memberRepo,
+	}
+}
+
+// Check reports whether the user holds a permission in a circle. Platform
+// admins hold every permission; banned users and unknown wallets hold none.
+func (p *Policy) Check(ctx context.Context, userAddress, role string, circleID uint64, perm Permission) (bool, error) {
+	circle, err := p.circleRepo.GetByID(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, ErrCircleNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get circle: %w", err)
+	}
+
+	if role == RoleAdmin {
+		return true, nil
+	}
+
+	user, err := p.userRepo.GetByAddress(ctx, userAddress)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsBanned {
+		return false, nil
+	}
+
+	rel, err := p.memberRepo.GetByUserAndCircle(ctx, user.UserID, circle.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		rel = nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get circle relationship: %w", err)
+	}
+
+	return Grants(rel, strings.EqualFold(circle.OwnerAddress, userAddress), perm), nil
+}
+
+// RequireCirclePermission only lets a request through if the authenticated
+// user holds perm in the circle whose ID is the route parameter param. It
+// must run after Authenticate.
+func (p *Policy) RequireCirclePermission(param string, perm Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userAddress := c.GetString("user_address")
+		if userAddress == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Authentication is required",
+			})
+			c.Abort()
+			return
+		}
+
+		circleID, err := strconv.ParseUint(c.Param(param), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid circle ID",
+				"message": "Circle ID must be a valid number",
+			})
+			c.Abort()
+			return
+		}
+
+		allowed, err := p.Check(c.Request.Context(), userAddress, c.GetString("user_role"), circleID, perm)
+		if errors.Is(err, ErrCircleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "Not Found",
+				"message": "Circle not found",
+			})
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Internal Server Error",
+				"message": "Failed to check permissions",
+			})
+			c.Abort()
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": fmt.Sprintf("Missing %s permission for this circle", perm),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePlatformAdmin only lets platform admins through. It must run after
+// Authenticate.
+func RequirePlatformAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("user_address") == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Authentication is required",
+			})
+			c.Abort()
+			return
+		}
+		if c.GetString("user_role") != RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": "Platform admin role is required",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,81 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/database"
+)
+
+// RevocationList records sessions whose access tokens must be refused before
+// they expire. Entries only need to outlive the longest access token.
+type RevocationList interface {
+	Revoke(ctx context.Context, sessionID string, ttl time.Duration) error
+	IsRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// MemoryRevocationList keeps revocations in process. A logout only takes
+// effect on the replica that handled it.
+type MemoryRevocationList struct {
+	mu      sync.Mutex
+	revoked map[string]time.Time
+}
+
+// NewMemoryRevocationList creates an in-process revocation list
+func NewMemoryRevocationList() *MemoryRevocationList {
+	return &MemoryRevocationList{revoked: make(map[string]time.Time)}
+}
+
+// Revoke adds a session and drops the entries past their TTL
+func (l *MemoryRevocationList) Revoke(ctx context.Context, sessionID string, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for id, until := range l.revoked {
+		if now.After(until) {
+			delete(l.revoked, id)
+		}
+	}
+	l.revoked[sessionID] = now.Add(ttl)
+	return nil
+}
+
+// IsRevoked reports whether a session was revoked
+func (l *MemoryRevocationList) IsRevoked(ctx context.Context, sessionID string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until, ok := l.revoked[sessionID]
+	return ok && time.Now().Before(until), nil
+}
+
+// RedisRevocationList shares revocations through Redis so a logout applies
+// on every API replica
+type RedisRevocationList struct {
+	client *database.RedisClient
+}
+
+// NewRedisRevocationList creates a Redis-backed revocation list
+func NewRedisRevocationList(client *database.RedisClient) *RedisRevocationList {
+	return &RedisRevocationList{client: client}
+}
+
+// Revoke writes a session key that Redis expires after the TTL
+func (l *RedisRevocationList) Revoke(ctx context.Context, sessionID string, ttl time.Duration) error {
+	return l.client.Set(ctx, "revoked:session:"+sessionID, 1, ttl)
+}
+
+// IsRevoked checks for the session key
+func (l *RedisRevocationList) IsRevoked(ctx context.Context, sessionID string) (bool, error) {
+	n, err := l.client.Exists(ctx, "revoked:session:"+sessionID)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
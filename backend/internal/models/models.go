@@ -16,8 +16,8 @@ type User struct {
 	Username            *string   `json:"username" gorm:"uniqueIndex;size:50"`
 	DisplayName         *string   `json:"display_name" gorm:"size:100"`
 	Bio                 *string   `json:"bio" gorm:"type:text"`
-	AvatarIPFSHash      *string   `json:"avatar_ipfs_hash" gorm:"size:66"`
-	CoverIPFSHash       *string   `json:"cover_ipfs_hash" gorm:"size:66"`
+	AvatarIPFSHash      *string   `json:"avatar_ipfs_hash" gorm:"column:avatar_ipfs_hash;size:66"`
+	CoverIPFSHash       *string   `json:"cover_ipfs_hash" gorm:"column:cover_ipfs_hash;size:66"`
 	Email               *string   `json:"email" gorm:"size:255"`
 	TwitterHandle       *string   `json:"twitter_handle" gorm:"size:50"`
 	GithubHandle        *string   `json:"github_handle" gorm:"size:50"`
@@ -34,6 +34,7 @@ type User struct {
 	EmailVerified       bool      `json:"email_verified" gorm:"default:false"`
 	KYCVerified         bool      `json:"kyc_verified" gorm:"default:false"`
 	IsBanned            bool      `json:"is_banned" gorm:"default:false"`
+	PlatformRole        string    `json:"platform_role" gorm:"size:20;not null;default:'USER'"`
 	CreatedAt           time.Time `json:"created_at"`
 	UpdatedAt           time.Time `json:"updated_at"`
 	LastActiveAt        *time.Time `json:"last_active_at"`
@@ -47,9 +48,9 @@ func (User) TableName() string {
 // UserRelationship represents relationships between users
 type UserRelationship struct {
 	RelationshipID   uint64    `json:"relationship_id" gorm:"primaryKey;autoIncrement"`
-	FromUserID       uint64    `json:"from_user_id" gorm:"not null;index:idx_from_type"`
-	RelationshipType string    `json:"relationship_type" gorm:"type:enum('FOLLOWS','BLOCKS','COLLABORATES');not null"`
-	ToUserID         uint64    `json:"to_user_id" gorm:"not null;index:idx_to_type"`
+	FromUserID       uint64    `json:"from_user_id" gorm:"not null;index:idx_from_type;uniqueIndex:uk_user_relationship,priority:1;check:chk_no_self_relationship,from_user_id <> to_user_id"`
+	RelationshipType string    `json:"relationship_type" gorm:"type:enum('FOLLOWS','BLOCKS','COLLABORATES');not null;uniqueIndex:uk_user_relationship,priority:2"`
+	ToUserID         uint64    `json:"to_user_id" gorm:"not null;index:idx_to_type;uniqueIndex:uk_user_relationship,priority:3"`
 	StrengthScore    float64   `json:"strength_score" gorm:"default:1.0"`
 	InteractionCount uint      `json:"interaction_count" gorm:"default:0"`
 	CreatedAt        time.Time `json:"created_at"`
@@ -60,6 +61,13 @@ func (UserRelationship) TableName() string {
 	return "user_relationships"
 }
 
+// User relationship types
+const (
+	RelationshipFollows      = "FOLLOWS"
+	RelationshipBlocks       = "BLOCKS"
+	RelationshipCollaborates = "COLLABORATES"
+)
+
 // Circle represents a social circle
 type Circle struct {
 	ID                  uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -76,6 +84,8 @@ type Circle struct {
 	HolderCount         int       `json:"holder_count" gorm:"default:0"`
 	TransactionCount    int       `json:"transaction_count" gorm:"default:0"`
 	TotalVolume         string    `json:"total_volume" gorm:"type:decimal(30,18);default:0"`
+	Top10Share          float64   `json:"top10_share" gorm:"default:0"`
+	GiniCoefficient     float64   `json:"gini_coefficient" gorm:"default:0"`
 	Active              bool      `json:"active" gorm:"default:true"`
 	Status              string    `json:"status" gorm:"default:'pending'"`
 	TxHash              string    `json:"tx_hash" gorm:"size:66"`
@@ -87,7 +97,10 @@ func (Circle) TableName() string {
 	return "circles"
 }
 
-// UserCircleRelationship represents user membership in circles
+// UserCircleRelationship represents user membership in circles. TokenBalance
+// is a cache read at BalanceBlock; HeldSince is when it last reached the
+// circle's minimum, and GateDemoted marks a member made read-only by the
+// membership rule rather than by a moderator.
 type UserCircleRelationship struct {
 	UCRelationshipID   uint64     `json:"uc_relationship_id" gorm:"primaryKey;autoIncrement"`
 	UserID             uint64     `json:"user_id" gorm:"not null;index"`
@@ -99,6 +112,9 @@ type UserCircleRelationship struct {
 	CanPost            bool       `json:"can_post" gorm:"default:true"`
 	CanModerate        bool       `json:"can_moderate" gorm:"default:false"`
 	CanInvite          bool       `json:"can_invite" gorm:"default:false"`
+	BalanceBlock       uint64     `json:"balance_block" gorm:"default:0"`
+	HeldSince          *time.Time `json:"held_since"`
+	GateDemoted        bool       `json:"gate_demoted" gorm:"default:false"`
 	JoinedAt           time.Time  `json:"joined_at"`
 	LastInteractionAt  *time.Time `json:"last_interaction_at"`
 
@@ -110,12 +126,27 @@ func (UserCircleRelationship) TableName() string {
 	return "user_circle_relationships"
 }
 
+// CircleMembershipRule represents the token holding a circle requires of its
+// members
+type CircleMembershipRule struct {
+	CircleID uint64 `json:"circle_id" gorm:"primaryKey;autoIncrement:false"`
+	// MinBalance is in whole tokens, like the other DECIMAL(30,18) amounts
+	MinBalance     string    `json:"min_balance" gorm:"type:decimal(30,18);default:0"`
+	MinHoldSeconds uint64    `json:"min_hold_seconds" gorm:"default:0"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (CircleMembershipRule) TableName() string {
+	return "circle_membership_rules"
+}
+
 // Post represents a content post
 type Post struct {
 	PostID            uint64     `json:"post_id" gorm:"primaryKey;autoIncrement"`
 	AuthorID          uint64     `json:"author_id" gorm:"not null;index"`
 	CircleID          *uint64    `json:"circle_id" gorm:"index"`
-	ContentIPFSHash   string     `json:"content_ipfs_hash" gorm:"not null;size:66"`
+	ContentIPFSHash   string     `json:"content_ipfs_hash" gorm:"column:content_ipfs_hash;not null;size:66"`
 	ContentType       string     `json:"content_type" gorm:"type:enum('TEXT','IMAGE','VIDEO','LINK','NFT');not null"`
 	Title             *string    `json:"title" gorm:"size:255"`
 	PreviewText       *string    `json:"preview_text" gorm:"type:text"`
@@ -125,6 +156,7 @@ type Post struct {
 	Downvotes         uint       `json:"downvotes" gorm:"default:0"`
 	CommentCount      uint       `json:"comment_count" gorm:"default:0"`
 	RewardAmount      string     `json:"reward_amount" gorm:"type:decimal(30,18);default:0"`
+	TokenRewardAmount string     `json:"token_reward_amount" gorm:"type:decimal(30,18);default:0"`
 	IsNFT             bool       `json:"is_nft" gorm:"default:false"`
 	NFTTokenID        *uint64    `json:"nft_token_id"`
 	NFTContractAddress *string   `json:"nft_contract_address" gorm:"size:42"`
@@ -162,10 +194,70 @@ func (Comment) TableName() string {
 	return "comments"
 }
 
+// Vote target types
+const (
+	VoteTargetPost    = "POST"
+	VoteTargetComment = "COMMENT"
+)
+
+// Vote records one user's standing vote on a post or comment. Value is +1 or
+// -1 and Weight is how much the vote counts toward the target's counters.
+type Vote struct {
+	UserID     uint64    `json:"user_id" gorm:"primaryKey"`
+	TargetType string    `json:"target_type" gorm:"primaryKey;type:enum('POST','COMMENT')"`
+	TargetID   uint64    `json:"target_id" gorm:"primaryKey"`
+	Value      int8      `json:"value" gorm:"not null"`
+	Weight     uint      `json:"weight" gorm:"not null;default:1"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (Vote) TableName() string {
+	return "votes"
+}
+
+// PostTip records a verified on-chain tip to a post's author. TokenAddress is
+// empty for ETH tips and the circle token otherwise.
+type PostTip struct {
+	TipID        uint64    `json:"tip_id" gorm:"primaryKey;autoIncrement"`
+	PostID       uint64    `json:"post_id" gorm:"not null;index"`
+	CircleID     *uint64   `json:"circle_id"`
+	TipperID     uint64    `json:"tipper_id" gorm:"not null;index"`
+	AuthorID     uint64    `json:"author_id" gorm:"not null"`
+	TxHash       string    `json:"tx_hash" gorm:"uniqueIndex;not null;size:66"`
+	TokenAddress string    `json:"token_address" gorm:"size:42"`
+	Amount       string    `json:"amount" gorm:"type:decimal(30,18);not null"`
+	BlockNumber  uint64    `json:"block_number"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (PostTip) TableName() string {
+	return "post_tips"
+}
+
+// PostTipIntent is a tip prepared for a tipper to sign. A mined transfer only
+// counts as a tip if it matches an intent and was sent at or after the nonce
+// the intent was prepared with, so transfers made before cannot be claimed.
+type PostTipIntent struct {
+	IntentID     uint64    `json:"intent_id" gorm:"primaryKey;autoIncrement"`
+	PostID       uint64    `json:"post_id" gorm:"not null;index:idx_tip_intent_post_tipper"`
+	TipperID     uint64    `json:"tipper_id" gorm:"not null;index:idx_tip_intent_post_tipper"`
+	TokenAddress string    `json:"token_address" gorm:"size:42"`
+	Amount       string    `json:"amount" gorm:"type:decimal(30,18);not null"`
+	Nonce        uint64    `json:"nonce" gorm:"not null"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (PostTipIntent) TableName() string {
+	return "post_tip_intents"
+}
+
 // Trade represents a token trade
 type Trade struct {
 	TradeID     uint64    `json:"trade_id" gorm:"primaryKey;autoIncrement"`
-	TxHash      string    `json:"tx_hash" gorm:"uniqueIndex;not null;size:66"`
+	TxHash      string    `json:"tx_hash" gorm:"uniqueIndex:uk_trade_tx_log;not null;size:66"`
+	LogIndex    uint      `json:"log_index" gorm:"uniqueIndex:uk_trade_tx_log;not null;default:0"`
 	TraderID    uint64    `json:"trader_id" gorm:"not null;index:idx_trader_time"`
 	CircleID    uint64    `json:"circle_id" gorm:"not null;index:idx_circle_time"`
 	TradeType   string    `json:"trade_type" gorm:"type:enum('BUY','SELL');not null"`
@@ -173,7 +265,8 @@ type Trade struct {
 	ETHAmount   string    `json:"eth_amount" gorm:"type:decimal(30,18);not null"`
 	Price       string    `json:"price" gorm:"type:decimal(30,18);not null"`
 	Fee         string    `json:"fee" gorm:"type:decimal(30,18);default:0"`
-	BlockNumber uint64    `json:"block_number" gorm:"not null"`
+	BlockNumber uint64    `json:"block_number" gorm:"not null;index"`
+	BlockHash   string    `json:"block_hash" gorm:"size:66"`
 	Timestamp   time.Time `json:"timestamp" gorm:"not null;index"`
 
 	Trader User   `json:"trader,omitempty" gorm:"foreignKey:TraderID"`
@@ -220,31 +313,200 @@ func (DirectMessage) TableName() string {
 	return "direct_messages"
 }
 
-// Transaction represents a blockchain transaction
+// Transaction represents a blockchain transaction. Applied is set once the
+// reconciler has applied a receipt to the row, so a trade is announced only
+// the first time it is seen mined, and CheckedAt is when the reconciler last
+// looked the row up.
 type Transaction struct {
-	ID           uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
-	CircleID     uint64    `json:"circle_id" gorm:"index"`
-	TxHash       string    `json:"tx_hash" gorm:"uniqueIndex;not null;size:66"`
-	TxType       string    `json:"tx_type" gorm:"not null"`
-	FromAddress  string    `json:"from_address" gorm:"size:42;index"`
-	ToAddress    string    `json:"to_address" gorm:"size:42;index"`
-	Amount       string    `json:"amount" gorm:"type:decimal(30,18)"`
-	Status       string    `json:"status" gorm:"default:'pending'"`
-	TokenAddress string    `json:"token_address" gorm:"size:42"`
-	Timestamp    time.Time `json:"timestamp"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	CircleID     uint64     `json:"circle_id" gorm:"index"`
+	TxHash       string     `json:"tx_hash" gorm:"uniqueIndex;not null;size:66"`
+	ReplacedHash string     `json:"replaced_hash,omitempty" gorm:"size:66;index"`
+	TxType       string     `json:"tx_type" gorm:"not null"`
+	FromAddress  string     `json:"from_address" gorm:"size:42;index"`
+	ToAddress    string     `json:"to_address" gorm:"size:42;index"`
+	Amount       string     `json:"amount" gorm:"type:decimal(30,18)"`
+	Status       string     `json:"status" gorm:"default:'pending';index:idx_transactions_unsettled,priority:1"`
+	TokenAddress string     `json:"token_address" gorm:"size:42"`
+	BlockNumber  uint64     `json:"block_number" gorm:"index"`
+	BlockHash    string     `json:"block_hash" gorm:"size:66"`
+	GasUsed      uint64     `json:"gas_used"`
+	GasPrice     string     `json:"gas_price" gorm:"size:78"`
+	Fee          string     `json:"fee" gorm:"type:decimal(30,18);default:0"`
+	Applied      bool       `json:"-" gorm:"default:false"`
+	CheckedAt    *time.Time `json:"-" gorm:"index:idx_transactions_unsettled,priority:2"`
+	Timestamp    time.Time  `json:"timestamp"`
+	CreatedAt    time.Time  `json:"created_at"`
 }
 
 func (Transaction) TableName() string {
 	return "transactions"
 }
 
+// TransactionReplacement is a broadcast of a transaction's nonce that a
+// speed-up or cancel superseded. It keeps what the transaction was when sent
+// under that hash, so the transaction can settle on it if it is the one
+// mined.
+type TransactionReplacement struct {
+	ID            uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	TransactionID uint64    `json:"transaction_id" gorm:"not null;index"`
+	TxHash        string    `json:"tx_hash" gorm:"uniqueIndex;not null;size:66"`
+	TxType        string    `json:"tx_type" gorm:"not null"`
+	ToAddress     string    `json:"to_address" gorm:"size:42"`
+	Amount        string    `json:"amount" gorm:"type:decimal(30,18)"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (TransactionReplacement) TableName() string {
+	return "transaction_replacements"
+}
+
+// IndexerCursor records the last block an event indexer has processed
+type IndexerCursor struct {
+	Name        string    `json:"name" gorm:"primaryKey;size:50"`
+	BlockNumber uint64    `json:"block_number" gorm:"not null"`
+	BlockHash   string    `json:"block_hash" gorm:"size:66"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (IndexerCursor) TableName() string {
+	return "indexer_cursors"
+}
+
+// IndexedBlock records the hash of a block the event indexer has processed,
+// used to find the common ancestor after a chain reorganization
+type IndexedBlock struct {
+	BlockNumber uint64    `json:"block_number" gorm:"primaryKey;autoIncrement:false"`
+	BlockHash   string    `json:"block_hash" gorm:"not null;size:66"`
+	ParentHash  string    `json:"parent_hash" gorm:"not null;size:66"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (IndexedBlock) TableName() string {
+	return "indexed_blocks"
+}
+
+// CircleEvent records an on-chain lifecycle change applied to a circle. The
+// circle's current owner and active flag are those of its latest event.
+type CircleEvent struct {
+	ID           uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	CircleID     uint64    `json:"circle_id" gorm:"not null;index"`
+	EventType    string    `json:"event_type" gorm:"type:enum('CREATED','DEACTIVATED','REACTIVATED','OWNERSHIP_TRANSFERRED');not null"`
+	ActorAddress string    `json:"actor_address" gorm:"size:42"`
+	OwnerAddress string    `json:"owner_address" gorm:"size:42"`
+	Active       bool      `json:"active"`
+	TxHash       string    `json:"tx_hash" gorm:"uniqueIndex:uk_circle_event_log;not null;size:66"`
+	LogIndex     uint      `json:"log_index" gorm:"uniqueIndex:uk_circle_event_log;not null"`
+	BlockNumber  uint64    `json:"block_number" gorm:"not null;index"`
+	BlockHash    string    `json:"block_hash" gorm:"size:66"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (CircleEvent) TableName() string {
+	return "circle_events"
+}
+
+// UserSession is a signed-in device. The refresh token itself is never
+// stored, only the hash of the one most recently issued to the device.
+type UserSession struct {
+	SessionID        string     `json:"session_id" gorm:"primaryKey;size:36"`
+	UserID           uint64     `json:"user_id" gorm:"not null;index"`
+	WalletAddress    string     `json:"wallet_address" gorm:"not null;size:42"`
+	RefreshTokenHash string     `json:"-" gorm:"not null;size:64"`
+	UserAgent        string     `json:"user_agent" gorm:"size:255"`
+	IPAddress        string     `json:"ip_address" gorm:"size:45"`
+	LastSeenAt       time.Time  `json:"last_seen_at"`
+	ExpiresAt        time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt        *time.Time `json:"revoked_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// TokenTransfer records a CircleToken Transfer event, including mints from
+// and burns to the zero address. The holder table is rebuilt from these rows
+// when blocks are orphaned.
+type TokenTransfer struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	CircleID    uint64    `json:"circle_id" gorm:"not null;index"`
+	TxHash      string    `json:"tx_hash" gorm:"uniqueIndex:uk_token_transfer_log;not null;size:66"`
+	LogIndex    uint      `json:"log_index" gorm:"uniqueIndex:uk_token_transfer_log;not null"`
+	FromAddress string    `json:"from_address" gorm:"not null;size:42"`
+	ToAddress   string    `json:"to_address" gorm:"not null;size:42"`
+	Amount      string    `json:"amount" gorm:"type:decimal(30,18);not null"`
+	BlockNumber uint64    `json:"block_number" gorm:"not null;index"`
+	BlockHash   string    `json:"block_hash" gorm:"size:66"`
+	Timestamp   time.Time `json:"timestamp" gorm:"not null"`
+}
+
+func (TokenTransfer) TableName() string {
+	return "token_transfers"
+}
+
+// CircleHolder is a wallet's position in a circle token. CostBasis is the ETH
+// paid for the tokens still held, at average cost; tokens received by
+// transfer rather than bought from the curve carry no cost.
+type CircleHolder struct {
+	CircleID        uint64    `json:"circle_id" gorm:"primaryKey;autoIncrement:false"`
+	HolderAddress   string    `json:"holder_address" gorm:"primaryKey;size:42"`
+	Balance         string    `json:"balance" gorm:"type:decimal(30,18);not null;default:0"`
+	CostBasis       string    `json:"cost_basis" gorm:"type:decimal(30,18);not null;default:0"`
+	FirstAcquiredAt time.Time `json:"first_acquired_at"`
+	LastBlock       uint64    `json:"last_block"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (CircleHolder) TableName() string {
+	return "circle_holders"
+}
+
+// Candle is an OHLCV bar of a circle token's price over one interval. 1m
+// candles are built from trades and each longer interval is rolled up from
+// the one below it; buckets without trades have no row.
+type Candle struct {
+	CircleID    uint64    `json:"circle_id" gorm:"primaryKey;autoIncrement:false"`
+	Interval    string    `json:"interval" gorm:"column:candle_interval;primaryKey;size:3"`
+	OpenTime    time.Time `json:"open_time" gorm:"primaryKey"`
+	Open        string    `json:"open" gorm:"type:decimal(30,18);not null"`
+	High        string    `json:"high" gorm:"type:decimal(30,18);not null"`
+	Low         string    `json:"low" gorm:"type:decimal(30,18);not null"`
+	Close       string    `json:"close" gorm:"type:decimal(30,18);not null"`
+	TokenVolume string    `json:"token_volume" gorm:"type:decimal(30,18);not null;default:0"`
+	ETHVolume   string    `json:"eth_volume" gorm:"type:decimal(30,18);not null;default:0"`
+	TradeCount  int       `json:"trade_count" gorm:"default:0"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (Candle) TableName() string {
+	return "candles"
+}
+
+// PortfolioSnapshot records a wallet's portfolio at a point in time for
+// charting. Cost basis and profit and loss are at average cost.
+type PortfolioSnapshot struct {
+	WalletAddress string    `json:"wallet_address" gorm:"primaryKey;size:42"`
+	SnapshotAt    time.Time `json:"snapshot_at" gorm:"primaryKey"`
+	TotalValue    string    `json:"total_value" gorm:"type:decimal(30,18);not null;default:0"`
+	CostBasis     string    `json:"cost_basis" gorm:"type:decimal(30,18);not null;default:0"`
+	RealizedPnL   string    `json:"realized_pnl" gorm:"column:realized_pnl;type:decimal(30,18);not null;default:0"`
+	UnrealizedPnL string    `json:"unrealized_pnl" gorm:"column:unrealized_pnl;type:decimal(30,18);not null;default:0"`
+	PositionCount int       `json:"position_count" gorm:"default:0"`
+}
+
+func (PortfolioSnapshot) TableName() string {
+	return "portfolio_snapshots"
+}
+
 // CircleStats represents circle statistics
 type CircleStats struct {
 	TotalSupply      string
 	HolderCount      int
 	TransactionCount int
 	TotalVolume      string
+	Top10Share       float64
+	GiniCoefficient  float64
 }
 
 // VolumeStats represents volume statistics
@@ -0,0 +1,153 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package pnl
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/web3"
+)
+
+// Position is a position's cost basis and profit and loss in wei under one
+// cost method
+type Position struct {
+	CostBasis  *big.Int // ETH paid for the tokens still held
+	Realized   *big.Int // proceeds of sells less the cost of the tokens sold
+	Unrealized *big.Int // value of the tokens held less their cost basis
+}
+
+// Total returns realized plus unrealized profit and loss
+func (p *Position) Total() *big.Int {
+	return new(big.Int).Add(p.Realized, p.Unrealized)
+}
+
+// lot is a buy whose tokens have not all been sold yet
+type lot struct {
+	amount *big.Int
+	cost   *big.Int
+}
+
+// takeLots removes amount tokens from the oldest lots and returns their cost.
+// Tokens beyond what the lots hold were not bought from the curve and cost
+// nothing.
+func takeLots(lots []*lot, amount *big.Int) ([]*lot, *big.Int) {
+	cost := new(big.Int)
+	remaining := new(big.Int).Set(amount)
+	for len(lots) > 0 && remaining.Sign() > 0 {
+		head := lots[0]
+		if head.amount.Cmp(remaining) <= 0 {
+			cost.Add(cost, head.cost)
+			remaining.Sub(remaining, head.amount)
+			lots = lots[1:]
+			continue
+		}
+
+		part := new(big.Int).Mul(head.cost, remaining)
+		part.Quo(part, head.amount)
+		cost.Add(cost, part)
+		head.cost = new(big.Int).Sub(head.cost, part)
+		head.amount = new(big.Int).Sub(head.amount, remaining)
+		remaining.SetInt64(0)
+	}
+	return lots, cost
+}
+
+// FromTrades replays a wallet's trades in one circle, in chain order,
+// and values the resulting position at price, in wei per whole token. It
+// returns the profit and loss under first-in-first-out and average cost.
+//
+// balance is the wallet's actual holding, which transfers can move away from
+// what the trades alone leave. Tokens sent away leave without realizing
+// anything, oldest first under FIFO and pro rata under average cost; tokens
+// received carry no cost.
+func FromTrades(trades []*models.Trade, balance, price *big.Int) (fifo, average *Position, err error) {
+	var lots []*lot
+	fifoRealized := new(big.Int)
+	avgAmount, avgCost, avgRealized := new(big.Int), new(big.Int), new(big.Int)
+
+	for _, trade := range trades {
+		amount, err := web3.ParseUnits(trade.TokenAmount, 18)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid token amount of trade %d: %w", trade.TradeID, err)
+		}
+		eth, err := web3.ParseUnits(trade.ETHAmount, 18)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid ETH amount of trade %d: %w", trade.TradeID, err)
+		}
+
+		switch trade.TradeType {
+		case "BUY":
+			lots = append(lots, &lot{amount: amount, cost: eth})
+			avgAmount.Add(avgAmount, amount)
+			avgCost.Add(avgCost, eth)
+
+		case "SELL":
+			var sold *big.Int
+			lots, sold = takeLots(lots, amount)
+			fifoRealized.Add(fifoRealized, new(big.Int).Sub(eth, sold))
+
+			sold = takeAverage(avgAmount, avgCost, amount)
+			avgRealized.Add(avgRealized, new(big.Int).Sub(eth, sold))
+		}
+	}
+
+	// Match the open lots to the actual balance
+	open := new(big.Int)
+	for _, l := range lots {
+		open.Add(open, l.amount)
+	}
+	if open.Cmp(balance) > 0 {
+		lots, _ = takeLots(lots, new(big.Int).Sub(open, balance))
+	}
+	fifoCost := new(big.Int)
+	for _, l := range lots {
+		fifoCost.Add(fifoCost, l.cost)
+	}
+	if avgAmount.Cmp(balance) > 0 {
+		takeAverage(avgAmount, avgCost, new(big.Int).Sub(avgAmount, balance))
+	}
+
+	value := TokenValue(balance, price)
+
+	fifo = &Position{
+		CostBasis:  fifoCost,
+		Realized:   fifoRealized,
+		Unrealized: new(big.Int).Sub(value, fifoCost),
+	}
+	average = &Position{
+		CostBasis:  avgCost,
+		Realized:   avgRealized,
+		Unrealized: new(big.Int).Sub(value, avgCost),
+	}
+	return fifo, average, nil
+}
+
+// takeAverage removes amount tokens from an average cost position in place and
+// returns the cost they carried
+func takeAverage(held, cost, amount *big.Int) *big.Int {
+	if held.Sign() == 0 {
+		return new(big.Int)
+	}
+	if amount.Cmp(held) >= 0 {
+		sold := new(big.Int).Set(cost)
+		held.SetInt64(0)
+		cost.SetInt64(0)
+		return sold
+	}
+
+	sold := new(big.Int).Mul(cost, amount)
+	sold.Quo(sold, held)
+	held.Sub(held, amount)
+	cost.Sub(cost, sold)
+	return sold
+}
+
+// TokenValue returns what amount tokens are worth at price, both in wei
+func TokenValue(amount, price *big.Int) *big.Int {
+	value := new(big.Int).Mul(amount, price)
+	return value.Quo(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+}
@@ -0,0 +1,108 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fast-socialfi/backend/internal/database"
+)
+
+// Broker carries published frames to the hub of every replica
+type Broker interface {
+	// Publish sends a frame to the subscribers of a channel
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe passes every published frame to deliver until the context
+	// is cancelled
+	Subscribe(ctx context.Context, deliver func(channel string, payload []byte)) error
+}
+
+// MemoryBroker delivers frames within the process. Events only reach clients
+// connected to the replica that produced them.
+type MemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[int]func(channel string, payload []byte)
+	next        int
+}
+
+// NewMemoryBroker creates an in-process broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{subscribers: make(map[int]func(string, []byte))}
+}
+
+// Publish hands the frame to every subscriber
+func (b *MemoryBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, deliver := range b.subscribers {
+		deliver(channel, payload)
+	}
+	return nil
+}
+
+// Subscribe registers deliver until the context is cancelled
+func (b *MemoryBroker) Subscribe(ctx context.Context, deliver func(channel string, payload []byte)) error {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.subscribers[id] = deliver
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.subscribers, id)
+	b.mu.Unlock()
+	return ctx.Err()
+}
+
+// redisChannelPrefix namespaces feed channels within Redis pub/sub
+const redisChannelPrefix = "ws:"
+
+// RedisBroker fans frames out through Redis pub/sub so an event produced on
+// one API replica reaches clients connected to any of them
+type RedisBroker struct {
+	client *database.RedisClient
+}
+
+// NewRedisBroker creates a Redis-backed broker
+func NewRedisBroker(client *database.RedisClient) *RedisBroker {
+	return &RedisBroker{client: client}
+}
+
+// Publish sends the frame to the channel's Redis channel
+func (b *RedisBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	return b.client.Client.Publish(ctx, redisChannelPrefix+channel, payload).Err()
+}
+
+// Subscribe listens on every feed channel. Each replica receives all events
+// and the hub drops those no local client subscribed to.
+func (b *RedisBroker) Subscribe(ctx context.Context, deliver func(channel string, payload []byte)) error {
+	sub := b.client.Client.PSubscribe(ctx, redisChannelPrefix+"*")
+	defer sub.Close()
+
+	// Confirm the subscription first so a Redis failure is reported rather
+	// than leaving the feed silently empty
+	if _, err := sub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to feed channels: %w", err)
+	}
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return fmt.Errorf("feed subscription closed")
+			}
+			deliver(strings.TrimPrefix(msg.Channel, redisChannelPrefix), []byte(msg.Payload))
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package realtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrInvalidChannel is returned when subscribing to a malformed channel
+	ErrInvalidChannel = errors.New("invalid channel")
+	// ErrChannelForbidden is returned when subscribing to another user's channel
+	ErrChannelForbidden = errors.New("channel belongs to another user")
+)
+
+// Event names published on each channel
+const (
+	EventTrade        = "trade"
+	EventPrice        = "price"
+	EventNotification = "notification"
+)
+
+// Publisher sends an event to every subscriber of a channel, on this replica
+// and on every other replica sharing the broker
+type Publisher interface {
+	Publish(ctx context.Context, channel, event string, data interface{}) error
+}
+
+// CircleTradesChannel carries every trade of a circle's token
+func CircleTradesChannel(circleID uint64) string {
+	return fmt.Sprintf("circle:%d:trades", circleID)
+}
+
+// CirclePriceChannel carries a circle token's curve price after each indexed
+// batch that traded it
+func CirclePriceChannel(circleID uint64) string {
+	return fmt.Sprintf("circle:%d:price", circleID)
+}
+
+// UserNotificationsChannel carries a wallet's new notifications
+func UserNotificationsChannel(address string) string {
+	return "user:" + common.HexToAddress(address).Hex() + ":notifications"
+}
+
+// authorizeChannel validates a channel a client asked for and returns its
+// canonical name. Circle channels are public; a user's channel is only open
+// to that user.
+func authorizeChannel(channel, address string) (string, error) {
+	parts := strings.Split(channel, ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidChannel, channel)
+	}
+
+	switch parts[0] {
+	case "circle":
+		id, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil || id == 0 {
+			return "", fmt.Errorf("%w: %q", ErrInvalidChannel, channel)
+		}
+		switch parts[2] {
+		case "trades":
+			return CircleTradesChannel(id), nil
+		case "price":
+			return CirclePriceChannel(id), nil
+		}
+
+	case "user":
+		if !common.IsHexAddress(parts[1]) || parts[2] != "notifications" {
+			break
+		}
+		if !strings.EqualFold(common.HexToAddress(parts[1]).Hex(), common.HexToAddress(address).Hex()) {
+			return "", ErrChannelForbidden
+		}
+		return UserNotificationsChannel(parts[1]), nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidChannel, channel)
+}
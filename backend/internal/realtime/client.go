@@ -0,0 +1,175 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"github.com/gorilla/websocket"
+)
+
+// closeTryAgainLater is the close code sent to evicted slow consumers, RFC
+// 6455's "try again later"
+const closeTryAgainLater = 1013
+
+// Client is a WebSocket connection to the hub. Frames for it are queued on
+// send and written by its own goroutine, so a slow connection only ever
+// blocks itself.
+type Client struct {
+	hub       *Hub
+	conn      *websocket.Conn
+	address   string
+	expiresAt time.Time
+	send      chan []byte
+
+	// subs is guarded by the hub's mutex
+	subs map[string]struct{}
+
+	closeOnce   sync.Once
+	closed      chan struct{}
+	closeCode   int
+	closeReason string
+}
+
+// enqueue queues a frame without blocking. A client whose queue is full has
+// fallen too far behind and is disconnected.
+func (c *Client) enqueue(payload []byte) {
+	select {
+	case <-c.closed:
+		return
+	default:
+	}
+
+	select {
+	case c.send <- payload:
+	default:
+		logger.Warn("Evicting slow WebSocket client", "address", c.address, "queued", len(c.send))
+		c.close(closeTryAgainLater, "send queue full")
+	}
+}
+
+// reply queues a reply to a command
+func (c *Client) reply(msg *Message) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	c.enqueue(payload)
+}
+
+// close stops the client's subscriptions at once and has the write pump send
+// a close frame with the given code and hang up
+func (c *Client) close(code int, reason string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeReason = reason
+		close(c.closed)
+		c.hub.unregister(c)
+	})
+}
+
+// readPump handles commands until the connection fails or falls silent for
+// longer than the pong timeout
+func (c *Client) readPump() {
+	cfg := c.hub.cfg
+	defer func() {
+		c.close(websocket.CloseNormalClosure, "")
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(cfg.MaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(cfg.PongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(cfg.PongTimeout))
+	})
+
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(cfg.PongTimeout))
+
+		var cmd Command
+		if err := json.Unmarshal(raw, &cmd); err != nil {
+			c.reply(&Message{Type: "error", Error: "malformed command"})
+			continue
+		}
+		c.handle(&cmd)
+	}
+}
+
+// handle applies a subscribe or unsubscribe command
+func (c *Client) handle(cmd *Command) {
+	channel, err := authorizeChannel(cmd.Channel, c.address)
+	if err != nil {
+		c.reply(&Message{Type: "error", Channel: cmd.Channel, Error: err.Error()})
+		return
+	}
+
+	switch cmd.Action {
+	case "subscribe":
+		if err := c.hub.subscribe(c, channel); err != nil {
+			c.reply(&Message{Type: "error", Channel: channel, Error: err.Error()})
+			return
+		}
+		c.reply(&Message{Type: "subscribed", Channel: channel})
+	case "unsubscribe":
+		c.hub.unsubscribe(c, channel)
+		c.reply(&Message{Type: "unsubscribed", Channel: channel})
+	default:
+		c.reply(&Message{Type: "error", Channel: channel, Error: "unknown action " + cmd.Action})
+	}
+}
+
+// writePump writes queued frames and heartbeat pings, and hangs up when the
+// client is closed or its token expires
+func (c *Client) writePump() {
+	cfg := c.hub.cfg
+	ticker := time.NewTicker(cfg.PingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	var expired <-chan time.Time
+	if !c.expiresAt.IsZero() {
+		expiry := time.NewTimer(time.Until(c.expiresAt))
+		defer expiry.Stop()
+		expired = expiry.C
+	}
+
+	for {
+		select {
+		case payload := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(cfg.WriteTimeout))
+			if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(cfg.WriteTimeout))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+
+		case <-expired:
+			c.close(websocket.ClosePolicyViolation, "token expired")
+
+		case <-c.closed:
+			if c.closeCode != websocket.CloseAbnormalClosure {
+				c.conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(c.closeCode, c.closeReason),
+					time.Now().Add(cfg.WriteTimeout))
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package realtime
+
+import (
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+)
+
+// TradeEvent is published on a circle's trades channel for each indexed trade
+type TradeEvent struct {
+	TradeID     uint64    `json:"trade_id"`
+	CircleID    uint64    `json:"circle_id"`
+	TxHash      string    `json:"tx_hash"`
+	TradeType   string    `json:"trade_type"`
+	Trader      string    `json:"trader"`
+	TokenAmount string    `json:"token_amount"`
+	ETHAmount   string    `json:"eth_amount"`
+	Price       string    `json:"price"`
+	BlockNumber uint64    `json:"block_number"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// NewTradeEvent builds the event for a trade whose Trader is loaded
+func NewTradeEvent(trade *models.Trade) *TradeEvent {
+	return &TradeEvent{
+		TradeID:     trade.TradeID,
+		CircleID:    trade.CircleID,
+		TxHash:      trade.TxHash,
+		TradeType:   trade.TradeType,
+		Trader:      trade.Trader.WalletAddress,
+		TokenAmount: trade.TokenAmount,
+		ETHAmount:   trade.ETHAmount,
+		Price:       trade.Price,
+		BlockNumber: trade.BlockNumber,
+		Timestamp:   trade.Timestamp,
+	}
+}
+
+// PriceEvent is published on a circle's price channel with the curve price
+// after the last trade of an indexed batch
+type PriceEvent struct {
+	CircleID    uint64    `json:"circle_id"`
+	Price       string    `json:"price"`
+	BlockNumber uint64    `json:"block_number"`
+	Timestamp   time.Time `json:"timestamp"`
+}
@@ -0,0 +1,199 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/gorilla/websocket"
+)
+
+// Message is a frame sent to clients. Type is "event" for published events
+// and "subscribed", "unsubscribed" or "error" for replies to commands.
+type Message struct {
+	Type    string          `json:"type"`
+	Channel string          `json:"channel,omitempty"`
+	Event   string          `json:"event,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// Command is a frame received from clients. Action is "subscribe" or
+// "unsubscribe".
+type Command struct {
+	Action  string `json:"action"`
+	Channel string `json:"channel"`
+}
+
+// Hub tracks connected clients and their channel subscriptions, and relays
+// events from the broker to the subscribers connected to this replica
+type Hub struct {
+	broker Broker
+	cfg    config.WebSocketConfig
+
+	mu       sync.RWMutex
+	clients  map[*Client]struct{}
+	channels map[string]map[*Client]struct{}
+}
+
+// NewHub creates a new hub
+func NewHub(broker Broker, cfg config.WebSocketConfig) *Hub {
+	if cfg.SendQueueSize <= 0 {
+		cfg.SendQueueSize = 256
+	}
+	if cfg.PingInterval <= 0 {
+		cfg.PingInterval = 30 * time.Second
+	}
+	if cfg.PongTimeout <= cfg.PingInterval {
+		cfg.PongTimeout = 2 * cfg.PingInterval
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = 10 * time.Second
+	}
+	if cfg.MaxMessageSize <= 0 {
+		cfg.MaxMessageSize = 4096
+	}
+	if cfg.MaxSubscriptions <= 0 {
+		cfg.MaxSubscriptions = 50
+	}
+
+	return &Hub{
+		broker:   broker,
+		cfg:      cfg,
+		clients:  make(map[*Client]struct{}),
+		channels: make(map[string]map[*Client]struct{}),
+	}
+}
+
+// Run relays events from the broker until the context is cancelled, then
+// disconnects every client
+func (h *Hub) Run(ctx context.Context) error {
+	err := h.broker.Subscribe(ctx, h.deliver)
+
+	h.mu.RLock()
+	clients := make([]*Client, 0, len(h.clients))
+	for c := range h.clients {
+		clients = append(clients, c)
+	}
+	h.mu.RUnlock()
+	for _, c := range clients {
+		c.close(websocket.CloseGoingAway, "server shutting down")
+	}
+
+	return err
+}
+
+// Publish sends an event to the channel's subscribers on every replica
+func (h *Hub) Publish(ctx context.Context, channel, event string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event, err)
+	}
+	payload, err := json.Marshal(&Message{Type: "event", Channel: channel, Event: event, Data: raw})
+	if err != nil {
+		return err
+	}
+	return h.broker.Publish(ctx, channel, payload)
+}
+
+// Serve runs a connected client until it disconnects, is evicted or its
+// token expires. address is the wallet the client authenticated as; a zero
+// expiresAt keeps the connection open indefinitely.
+func (h *Hub) Serve(conn *websocket.Conn, address string, expiresAt time.Time) {
+	c := &Client{
+		hub:       h,
+		conn:      conn,
+		address:   address,
+		expiresAt: expiresAt,
+		send:      make(chan []byte, h.cfg.SendQueueSize),
+		subs:      make(map[string]struct{}),
+		closed:    make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+
+	go c.writePump()
+	c.readPump()
+}
+
+// ClientCount returns how many clients are connected to this replica
+func (h *Hub) ClientCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+// deliver queues a frame for every local subscriber of its channel. Clients
+// whose queue is full are evicted rather than allowed to hold up the rest.
+func (h *Hub) deliver(channel string, payload []byte) {
+	h.mu.RLock()
+	subscribers := make([]*Client, 0, len(h.channels[channel]))
+	for c := range h.channels[channel] {
+		subscribers = append(subscribers, c)
+	}
+	h.mu.RUnlock()
+
+	for _, c := range subscribers {
+		c.enqueue(payload)
+	}
+}
+
+// subscribe adds a client to a channel
+func (h *Hub) subscribe(c *Client, channel string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[c]; !ok {
+		return fmt.Errorf("client is disconnected")
+	}
+	if _, ok := c.subs[channel]; ok {
+		return nil
+	}
+	if len(c.subs) >= h.cfg.MaxSubscriptions {
+		return fmt.Errorf("subscription limit of %d reached", h.cfg.MaxSubscriptions)
+	}
+
+	if h.channels[channel] == nil {
+		h.channels[channel] = make(map[*Client]struct{})
+	}
+	h.channels[channel][c] = struct{}{}
+	c.subs[channel] = struct{}{}
+	return nil
+}
+
+// unsubscribe removes a client from a channel
+func (h *Hub) unsubscribe(c *Client, channel string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(c, channel)
+}
+
+// unregister removes a client and all of its subscriptions
+func (h *Hub) unregister(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for channel := range c.subs {
+		h.removeLocked(c, channel)
+	}
+	delete(h.clients, c)
+}
+
+func (h *Hub) removeLocked(c *Client, channel string) {
+	delete(c.subs, channel)
+	if subscribers, ok := h.channels[channel]; ok {
+		delete(subscribers, c)
+		if len(subscribers) == 0 {
+			delete(h.channels, channel)
+		}
+	}
+}
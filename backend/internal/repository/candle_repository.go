@@ -0,0 +1,46 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// CandleRepository handles OHLCV candle data access
+type CandleRepository struct {
+	db *gorm.DB
+}
+
+// NewCandleRepository creates a new candle repository
+func NewCandleRepository(db *gorm.DB) *CandleRepository {
+	return &CandleRepository{db: db}
+}
+
+// GetRange retrieves a circle's candles of one interval that open within
+// [from, to), oldest first
+func (r *CandleRepository) GetRange(ctx context.Context, circleID uint64, interval string, from, to time.Time) ([]*models.Candle, error) {
+	var candles []*models.Candle
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ? AND candle_interval = ? AND open_time >= ? AND open_time < ?", circleID, interval, from, to).
+		Order("open_time ASC").
+		Find(&candles).Error
+	return candles, err
+}
+
+// ReplaceRange swaps a circle's candles of one interval that open within
+// [from, to) for the given ones
+func (r *CandleRepository) ReplaceRange(ctx context.Context, circleID uint64, interval string, from, to time.Time, candles []*models.Candle) error {
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ? AND candle_interval = ? AND open_time >= ? AND open_time < ?", circleID, interval, from, to).
+		Delete(&models.Candle{}).Error
+	if err != nil || len(candles) == 0 {
+		return err
+	}
+	return r.db.WithContext(ctx).CreateInBatches(candles, 500).Error
+}
@@ -0,0 +1,76 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CircleEventRepository handles circle lifecycle event data access
+type CircleEventRepository struct {
+	db *gorm.DB
+}
+
+// NewCircleEventRepository creates a new circle event repository
+func NewCircleEventRepository(db *gorm.DB) *CircleEventRepository {
+	return &CircleEventRepository{db: db}
+}
+
+// CreateIfNotExists inserts an event unless it was already recorded for the same log
+func (r *CircleEventRepository) CreateIfNotExists(ctx context.Context, event *models.CircleEvent) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(event).Error
+}
+
+// GetLatest retrieves the most recent event for a circle, or nil if it has none
+func (r *CircleEventRepository) GetLatest(ctx context.Context, circleID uint64) (*models.CircleEvent, error) {
+	var event models.CircleEvent
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ?", circleID).
+		Order("block_number DESC, log_index DESC").
+		First(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetByCircle retrieves the lifecycle history of a circle, newest first
+func (r *CircleEventRepository) GetByCircle(ctx context.Context, circleID uint64, limit, offset int) ([]*models.CircleEvent, error) {
+	var events []*models.CircleEvent
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ?", circleID).
+		Order("block_number DESC, log_index DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+	return events, err
+}
+
+// GetCircleIDsAfterBlock returns the circles with events above the given block
+func (r *CircleEventRepository) GetCircleIDsAfterBlock(ctx context.Context, blockNumber uint64) ([]uint64, error) {
+	var ids []uint64
+	err := r.db.WithContext(ctx).Model(&models.CircleEvent{}).
+		Where("block_number > ?", blockNumber).
+		Distinct().
+		Pluck("circle_id", &ids).Error
+	return ids, err
+}
+
+// DeleteAfterBlock removes events from blocks above the given number
+func (r *CircleEventRepository) DeleteAfterBlock(ctx context.Context, blockNumber uint64) error {
+	return r.db.WithContext(ctx).
+		Where("block_number > ?", blockNumber).
+		Delete(&models.CircleEvent{}).Error
+}
@@ -0,0 +1,87 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CircleMemberRepository handles user-circle relationship data access
+type CircleMemberRepository struct {
+	db *gorm.DB
+}
+
+// NewCircleMemberRepository creates a new circle member repository
+func NewCircleMemberRepository(db *gorm.DB) *CircleMemberRepository {
+	return &CircleMemberRepository{db: db}
+}
+
+// Create creates a new relationship
+func (r *CircleMemberRepository) Create(ctx context.Context, member *models.UserCircleRelationship) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(member).Error
+}
+
+// GetByUserAndCircle retrieves a user's relationship with a circle
+func (r *CircleMemberRepository) GetByUserAndCircle(ctx context.Context, userID, circleID uint64) (*models.UserCircleRelationship, error) {
+	var member models.UserCircleRelationship
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND circle_id = ?", userID, circleID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetByCircleAndWallets retrieves the relationships a circle has with the
+// given wallets, with their users loaded
+func (r *CircleMemberRepository) GetByCircleAndWallets(ctx context.Context, circleID uint64, wallets []string) ([]*models.UserCircleRelationship, error) {
+	var members []*models.UserCircleRelationship
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Joins("JOIN users ON users.user_id = user_circle_relationships.user_id").
+		Where("user_circle_relationships.circle_id = ? AND users.wallet_address IN ?", circleID, wallets).
+		Find(&members).Error
+	return members, err
+}
+
+// GetBalancesAfterBlock retrieves relationships whose cached balance was read
+// after the given block, with their users and circles loaded
+func (r *CircleMemberRepository) GetBalancesAfterBlock(ctx context.Context, blockNumber uint64) ([]*models.UserCircleRelationship, error) {
+	var members []*models.UserCircleRelationship
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Circle").
+		Where("balance_block > ?", blockNumber).
+		Find(&members).Error
+	return members, err
+}
+
+// SaveBalance stores a relationship's cached balance and the posting rights
+// derived from it
+func (r *CircleMemberRepository) SaveBalance(ctx context.Context, member *models.UserCircleRelationship) error {
+	return r.db.WithContext(ctx).Model(&models.UserCircleRelationship{}).
+		Where("uc_relationship_id = ?", member.UCRelationshipID).
+		Updates(map[string]interface{}{
+			"token_balance":       member.TokenBalance,
+			"balance_block":       member.BalanceBlock,
+			"held_since":          member.HeldSince,
+			"can_post":            member.CanPost,
+			"gate_demoted":        member.GateDemoted,
+			"last_interaction_at": time.Now(),
+		}).Error
+}
+
+// GetByCircle retrieves every relationship of a circle
+func (r *CircleMemberRepository) GetByCircle(ctx context.Context, circleID uint64) ([]*models.UserCircleRelationship, error) {
+	var members []*models.UserCircleRelationship
+	err := r.db.WithContext(ctx).Where("circle_id = ?", circleID).Find(&members).Error
+	return members, err
+}
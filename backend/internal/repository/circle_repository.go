@@ -47,6 +47,26 @@ func (r *CircleRepository) GetByChainID(ctx context.Context, chainCircleID uint6
 	return &circle, nil
 }
 
+// GetByTxHash retrieves a circle by its creation transaction hash
+func (r *CircleRepository) GetByTxHash(ctx context.Context, txHash string) (*models.Circle, error) {
+	var circle models.Circle
+	err := r.db.WithContext(ctx).Where("tx_hash = ?", txHash).First(&circle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &circle, nil
+}
+
+// GetByTokenAddress retrieves a circle by its token contract address
+func (r *CircleRepository) GetByTokenAddress(ctx context.Context, tokenAddress string) (*models.Circle, error) {
+	var circle models.Circle
+	err := r.db.WithContext(ctx).Where("token_address = ?", tokenAddress).First(&circle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &circle, nil
+}
+
 // GetByOwner retrieves circles owned by a user
 func (r *CircleRepository) GetByOwner(ctx context.Context, ownerAddress string, limit, offset int) ([]*models.Circle, error) {
 	var circles []*models.Circle
@@ -88,6 +108,41 @@ func (r *CircleRepository) UpdateStats(ctx context.Context, circleID uint64, sta
 		}).Error
 }
 
+// UpdateHolderStats updates the statistics derived from a circle's holders
+func (r *CircleRepository) UpdateHolderStats(ctx context.Context, circleID uint64, stats *models.CircleStats) error {
+	return r.db.WithContext(ctx).Model(&models.Circle{}).
+		Where("id = ?", circleID).
+		Updates(map[string]interface{}{
+			"total_supply":     stats.TotalSupply,
+			"holder_count":     stats.HolderCount,
+			"top10_share":      stats.Top10Share,
+			"gini_coefficient": stats.GiniCoefficient,
+			"updated_at":       time.Now(),
+		}).Error
+}
+
+// ConfirmMined marks circles whose creation was mined at or below the given
+// block as confirmed
+func (r *CircleRepository) ConfirmMined(ctx context.Context, safeBlock uint64) error {
+	created := r.db.Model(&models.CircleEvent{}).
+		Select("circle_id").
+		Where("event_type = ? AND block_number <= ?", "CREATED", safeBlock)
+
+	return r.db.WithContext(ctx).Model(&models.Circle{}).
+		Where("status = ? AND id IN (?)", "mined", created).
+		Update("status", "confirmed").Error
+}
+
+// ListTokenAddresses returns the token addresses of every circle created on
+// chain
+func (r *CircleRepository) ListTokenAddresses(ctx context.Context) ([]string, error) {
+	var addresses []string
+	err := r.db.WithContext(ctx).Model(&models.Circle{}).
+		Where("token_address <> ''").
+		Pluck("token_address", &addresses).Error
+	return addresses, err
+}
+
 // Search searches circles by name or symbol
 func (r *CircleRepository) Search(ctx context.Context, query string, limit, offset int) ([]*models.Circle, error) {
 	var circles []*models.Circle
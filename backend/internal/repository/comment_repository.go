@@ -0,0 +1,166 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Comment sort orders
+const (
+	// CommentSortTop lists the most upvoted comments first
+	CommentSortTop = "top"
+	// CommentSortNew lists the newest comments first
+	CommentSortNew = "new"
+)
+
+// CommentCursor is the position of a comment among its siblings in a sort
+// order. Upvotes is only used by the top order.
+type CommentCursor struct {
+	Upvotes   uint
+	CommentID uint64
+}
+
+// CommentRepository handles comment data access
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository creates a new comment repository
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create inserts a comment and counts it on its post in one transaction
+func (r *CommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(comment).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Post{}).
+			Where("post_id = ?", comment.PostID).
+			UpdateColumn("comment_count", gorm.Expr("comment_count + 1")).Error
+	})
+}
+
+// GetByID retrieves a comment, deleted or not, with its author loaded
+func (r *CommentRepository) GetByID(ctx context.Context, id uint64) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.WithContext(ctx).
+		Preload("Author").
+		First(&comment, "comment_id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// List retrieves up to limit comments of a post with the given parent, or
+// top-level comments when parentID is zero, in sort order starting after the
+// cursor when one is given. Deleted comments are included so threads keep
+// their shape.
+func (r *CommentRepository) List(ctx context.Context, postID, parentID uint64, sort string, after *CommentCursor, limit int) ([]*models.Comment, error) {
+	query := r.db.WithContext(ctx).
+		Preload("Author").
+		Where("post_id = ?", postID)
+	if parentID == 0 {
+		query = query.Where("parent_comment_id IS NULL")
+	} else {
+		query = query.Where("parent_comment_id = ?", parentID)
+	}
+	if after != nil {
+		if sort == CommentSortTop {
+			query = query.Where("upvotes < ? OR (upvotes = ? AND comment_id < ?)", after.Upvotes, after.Upvotes, after.CommentID)
+		} else {
+			query = query.Where("comment_id < ?", after.CommentID)
+		}
+	}
+
+	var comments []*models.Comment
+	err := query.Order(commentOrder(sort)).Limit(limit).Find(&comments).Error
+	return comments, err
+}
+
+// ListReplies retrieves the first perParent replies to each of the given
+// comments in sort order, grouped by parent
+func (r *CommentRepository) ListReplies(ctx context.Context, parentIDs []uint64, sort string, perParent int) ([]*models.Comment, error) {
+	if len(parentIDs) == 0 {
+		return nil, nil
+	}
+
+	order := commentOrder(sort)
+	ranked := r.db.Model(&models.Comment{}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY parent_comment_id ORDER BY "+order+") AS reply_rank").
+		Where("parent_comment_id IN ?", parentIDs)
+
+	var comments []*models.Comment
+	err := r.db.WithContext(ctx).
+		Preload("Author").
+		Table("(?) AS ranked", ranked).
+		Where("reply_rank <= ?", perParent).
+		Order("parent_comment_id, reply_rank").
+		Find(&comments).Error
+	return comments, err
+}
+
+// CountReplies counts the direct replies, deleted or not, to each of the
+// given comments. Comments without replies are absent from the result.
+func (r *CommentRepository) CountReplies(ctx context.Context, parentIDs []uint64) (map[uint64]int, error) {
+	counts := make(map[uint64]int)
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ParentCommentID uint64
+		Replies         int
+	}
+	err := r.db.WithContext(ctx).Model(&models.Comment{}).
+		Select("parent_comment_id, COUNT(*) AS replies").
+		Where("parent_comment_id IN ?", parentIDs).
+		Group("parent_comment_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ParentCommentID] = row.Replies
+	}
+	return counts, nil
+}
+
+// SoftDelete marks a comment deleted and uncounts it from its post in one
+// transaction. It reports false if the comment was already deleted.
+func (r *CommentRepository) SoftDelete(ctx context.Context, comment *models.Comment) (bool, error) {
+	deleted := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Comment{}).
+			Where("comment_id = ? AND is_deleted = ?", comment.CommentID, false).
+			Update("is_deleted", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deleted = true
+		return tx.Model(&models.Post{}).
+			Where("post_id = ? AND comment_count > 0", comment.PostID).
+			UpdateColumn("comment_count", gorm.Expr("comment_count - 1")).Error
+	})
+	return deleted, err
+}
+
+// commentOrder is the ORDER BY clause of a sort order
+func commentOrder(sort string) string {
+	if sort == CommentSortTop {
+		return "upvotes DESC, comment_id DESC"
+	}
+	return "comment_id DESC"
+}
@@ -0,0 +1,72 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CursorRepository handles indexer cursor and block history data access
+type CursorRepository struct {
+	db *gorm.DB
+}
+
+// NewCursorRepository creates a new cursor repository
+func NewCursorRepository(db *gorm.DB) *CursorRepository {
+	return &CursorRepository{db: db}
+}
+
+// Get retrieves a cursor by indexer name
+func (r *CursorRepository) Get(ctx context.Context, name string) (*models.IndexerCursor, error) {
+	var cursor models.IndexerCursor
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&cursor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// Save creates or moves a cursor
+func (r *CursorRepository) Save(ctx context.Context, cursor *models.IndexerCursor) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(cursor).Error
+}
+
+// SaveBlock records the hash of an indexed block
+func (r *CursorRepository) SaveBlock(ctx context.Context, block *models.IndexedBlock) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(block).Error
+}
+
+// GetRecentBlocks retrieves indexed blocks at or below the given number, newest first
+func (r *CursorRepository) GetRecentBlocks(ctx context.Context, atOrBelow uint64, limit int) ([]*models.IndexedBlock, error) {
+	var blocks []*models.IndexedBlock
+	err := r.db.WithContext(ctx).
+		Where("block_number <= ?", atOrBelow).
+		Order("block_number DESC").
+		Limit(limit).
+		Find(&blocks).Error
+	return blocks, err
+}
+
+// DeleteBlocksAfter removes indexed blocks above the given number
+func (r *CursorRepository) DeleteBlocksAfter(ctx context.Context, blockNumber uint64) error {
+	return r.db.WithContext(ctx).
+		Where("block_number > ?", blockNumber).
+		Delete(&models.IndexedBlock{}).Error
+}
+
+// PruneBlocks removes indexed blocks below the given number
+func (r *CursorRepository) PruneBlocks(ctx context.Context, below uint64) error {
+	return r.db.WithContext(ctx).
+		Where("block_number < ?", below).
+		Delete(&models.IndexedBlock{}).Error
+}
@@ -0,0 +1,98 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HolderRepository handles circle token holder data access
+type HolderRepository struct {
+	db *gorm.DB
+}
+
+// NewHolderRepository creates a new holder repository
+func NewHolderRepository(db *gorm.DB) *HolderRepository {
+	return &HolderRepository{db: db}
+}
+
+// Get retrieves a wallet's position in a circle token
+func (r *HolderRepository) Get(ctx context.Context, circleID uint64, holderAddress string) (*models.CircleHolder, error) {
+	var holder models.CircleHolder
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ? AND holder_address = ?", circleID, holderAddress).
+		First(&holder).Error
+	if err != nil {
+		return nil, err
+	}
+	return &holder, nil
+}
+
+// Save creates or replaces a holder's position
+func (r *HolderRepository) Save(ctx context.Context, holder *models.CircleHolder) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "circle_id"}, {Name: "holder_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"balance", "cost_basis", "first_acquired_at", "last_block", "updated_at"}),
+	}).Create(holder).Error
+}
+
+// Delete removes a holder whose balance reached zero
+func (r *HolderRepository) Delete(ctx context.Context, circleID uint64, holderAddress string) error {
+	return r.db.WithContext(ctx).
+		Where("circle_id = ? AND holder_address = ?", circleID, holderAddress).
+		Delete(&models.CircleHolder{}).Error
+}
+
+// DeleteByCircle removes every holder of a circle
+func (r *HolderRepository) DeleteByCircle(ctx context.Context, circleID uint64) error {
+	return r.db.WithContext(ctx).
+		Where("circle_id = ?", circleID).
+		Delete(&models.CircleHolder{}).Error
+}
+
+// GetTopByCircle retrieves a circle's holders, largest balance first
+func (r *HolderRepository) GetTopByCircle(ctx context.Context, circleID uint64, limit, offset int) ([]*models.CircleHolder, error) {
+	var holders []*models.CircleHolder
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ?", circleID).
+		Order("balance DESC, holder_address ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&holders).Error
+	return holders, err
+}
+
+// GetByHolder retrieves every position a wallet holds
+func (r *HolderRepository) GetByHolder(ctx context.Context, holderAddress string) ([]*models.CircleHolder, error) {
+	var holders []*models.CircleHolder
+	err := r.db.WithContext(ctx).
+		Where("holder_address = ?", holderAddress).
+		Order("circle_id ASC").
+		Find(&holders).Error
+	return holders, err
+}
+
+// GetHolderAddresses returns every wallet that holds a circle token
+func (r *HolderRepository) GetHolderAddresses(ctx context.Context) ([]string, error) {
+	var addresses []string
+	err := r.db.WithContext(ctx).Model(&models.CircleHolder{}).
+		Distinct().
+		Order("holder_address ASC").
+		Pluck("holder_address", &addresses).Error
+	return addresses, err
+}
+
+// GetBalances returns the balance of every holder of a circle
+func (r *HolderRepository) GetBalances(ctx context.Context, circleID uint64) ([]string, error) {
+	var balances []string
+	err := r.db.WithContext(ctx).Model(&models.CircleHolder{}).
+		Where("circle_id = ?", circleID).
+		Pluck("balance", &balances).Error
+	return balances, err
+}
@@ -0,0 +1,41 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MembershipRuleRepository handles circle membership rule data access
+type MembershipRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewMembershipRuleRepository creates a new membership rule repository
+func NewMembershipRuleRepository(db *gorm.DB) *MembershipRuleRepository {
+	return &MembershipRuleRepository{db: db}
+}
+
+// GetByCircle retrieves a circle's membership rule
+func (r *MembershipRuleRepository) GetByCircle(ctx context.Context, circleID uint64) (*models.CircleMembershipRule, error) {
+	var rule models.CircleMembershipRule
+	err := r.db.WithContext(ctx).Where("circle_id = ?", circleID).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Save creates or replaces a circle's membership rule
+func (r *MembershipRuleRepository) Save(ctx context.Context, rule *models.CircleMembershipRule) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "circle_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"min_balance", "min_hold_seconds", "updated_at"}),
+	}).Create(rule).Error
+}
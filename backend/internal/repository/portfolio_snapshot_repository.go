@@ -0,0 +1,44 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PortfolioSnapshotRepository handles portfolio history data access
+type PortfolioSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewPortfolioSnapshotRepository creates a new portfolio snapshot repository
+func NewPortfolioSnapshotRepository(db *gorm.DB) *PortfolioSnapshotRepository {
+	return &PortfolioSnapshotRepository{db: db}
+}
+
+// Save creates or replaces a wallet's snapshot at its time
+func (r *PortfolioSnapshotRepository) Save(ctx context.Context, snapshot *models.PortfolioSnapshot) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "wallet_address"}, {Name: "snapshot_at"}},
+		DoUpdates: clause.AssignmentColumns([]string{"total_value", "cost_basis", "realized_pnl", "unrealized_pnl", "position_count"}),
+	}).Create(snapshot).Error
+}
+
+// GetRange retrieves a wallet's snapshots taken within [from, to), oldest
+// first
+func (r *PortfolioSnapshotRepository) GetRange(ctx context.Context, walletAddress string, from, to time.Time, limit int) ([]*models.PortfolioSnapshot, error) {
+	var snapshots []*models.PortfolioSnapshot
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ? AND snapshot_at >= ? AND snapshot_at < ?", walletAddress, from, to).
+		Order("snapshot_at ASC").
+		Limit(limit).
+		Find(&snapshots).Error
+	return snapshots, err
+}
@@ -0,0 +1,95 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostRepository handles post data access
+type PostRepository struct {
+	db *gorm.DB
+}
+
+// NewPostRepository creates a new post repository
+func NewPostRepository(db *gorm.DB) *PostRepository {
+	return &PostRepository{db: db}
+}
+
+// PostFilter selects posts for feeds. Zero fields match every post; deleted
+// posts are never listed. ViewerID hides the posts of authors the viewer
+// blocks or is blocked by.
+type PostFilter struct {
+	AuthorID uint64
+	CircleID uint64
+	ViewerID uint64
+}
+
+// Create creates a new post
+func (r *PostRepository) Create(ctx context.Context, post *models.Post) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(post).Error
+}
+
+// GetByID retrieves a post, deleted or not, with its author and circle loaded
+func (r *PostRepository) GetByID(ctx context.Context, id uint64) (*models.Post, error) {
+	var post models.Post
+	err := r.db.WithContext(ctx).
+		Preload("Author").
+		Preload("Circle").
+		First(&post, "post_id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// List retrieves up to limit undeleted posts matching the filter, newest
+// first, starting below the given post ID when it is not zero
+func (r *PostRepository) List(ctx context.Context, filter *PostFilter, beforeID uint64, limit int) ([]*models.Post, error) {
+	query := r.db.WithContext(ctx).
+		Preload("Author").
+		Preload("Circle").
+		Where("is_deleted = ?", false)
+	if filter.AuthorID != 0 {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+	if filter.CircleID != 0 {
+		query = query.Where("circle_id = ?", filter.CircleID)
+	}
+	if filter.ViewerID != 0 {
+		query = query.Where("author_id NOT IN (?)", blockedUserIDs(r.db, filter.ViewerID))
+	}
+	if beforeID != 0 {
+		query = query.Where("post_id < ?", beforeID)
+	}
+
+	var posts []*models.Post
+	err := query.Order("post_id DESC").Limit(limit).Find(&posts).Error
+	return posts, err
+}
+
+// UpdateContent replaces a post's content reference, title and preview
+func (r *PostRepository) UpdateContent(ctx context.Context, post *models.Post) error {
+	return r.db.WithContext(ctx).Model(&models.Post{}).
+		Where("post_id = ?", post.PostID).
+		Updates(map[string]interface{}{
+			"content_ipfs_hash": post.ContentIPFSHash,
+			"title":             post.Title,
+			"preview_text":      post.PreviewText,
+			"updated_at":        post.UpdatedAt,
+		}).Error
+}
+
+// SoftDelete marks a post deleted. The row and its IPFS content are kept so
+// comments and rewards still resolve.
+func (r *PostRepository) SoftDelete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Model(&models.Post{}).
+		Where("post_id = ?", id).
+		Update("is_deleted", true).Error
+}
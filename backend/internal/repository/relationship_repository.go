@@ -0,0 +1,230 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FollowEntry is a user on a follow list. RelationshipID is the follow that
+// put them there and orders the list; FollowedAt is when it began.
+type FollowEntry struct {
+	models.User
+	RelationshipID uint64
+	FollowedAt     time.Time
+}
+
+// RelationshipRepository handles follow and block data access
+type RelationshipRepository struct {
+	db *gorm.DB
+}
+
+// NewRelationshipRepository creates a new relationship repository
+func NewRelationshipRepository(db *gorm.DB) *RelationshipRepository {
+	return &RelationshipRepository{db: db}
+}
+
+// Exists reports whether one user has the given relationship to another
+func (r *RelationshipRepository) Exists(ctx context.Context, fromUserID uint64, relationshipType string, toUserID uint64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.UserRelationship{}).
+		Where("from_user_id = ? AND relationship_type = ? AND to_user_id = ?", fromUserID, relationshipType, toUserID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsBlocked reports whether either of two users blocks the other
+func (r *RelationshipRepository) IsBlocked(ctx context.Context, userID, otherID uint64) (bool, error) {
+	return isBlocked(r.db.WithContext(ctx), userID, otherID)
+}
+
+// BlockedUserIDs retrieves the users a user blocks or is blocked by
+func (r *RelationshipRepository) BlockedUserIDs(ctx context.Context, userID uint64) ([]uint64, error) {
+	var ids []uint64
+	err := blockedUserIDs(r.db.WithContext(ctx), userID).Scan(&ids).Error
+	return ids, err
+}
+
+// Follow records that one user follows another, counts the follow on both
+// users and notifies the followed user in one transaction. It reports false,
+// changing nothing, if the follow already exists or either user blocks the
+// other.
+func (r *RelationshipRepository) Follow(ctx context.Context, fromUserID, toUserID uint64, notification *models.Notification) (bool, error) {
+	followed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		blocked, err := isBlocked(tx.Clauses(clause.Locking{Strength: "UPDATE"}), fromUserID, toUserID)
+		if err != nil || blocked {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.UserRelationship{
+			FromUserID:       fromUserID,
+			RelationshipType: models.RelationshipFollows,
+			ToUserID:         toUserID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		followed = true
+
+		if err := countFollow(tx, fromUserID, toUserID, 1); err != nil {
+			return err
+		}
+		return NewNotificationRepository(tx).Create(ctx, notification)
+	})
+	return followed, err
+}
+
+// Unfollow removes a follow and uncounts it from both users in one
+// transaction. It reports false if there was no follow.
+func (r *RelationshipRepository) Unfollow(ctx context.Context, fromUserID, toUserID uint64) (bool, error) {
+	unfollowed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		unfollowed, err = unfollow(tx, fromUserID, toUserID)
+		return err
+	})
+	return unfollowed, err
+}
+
+// Block records that one user blocks another and severs the follows between
+// them in both directions, uncounting each, in one transaction. It reports
+// false if the block already existed.
+func (r *RelationshipRepository) Block(ctx context.Context, fromUserID, toUserID uint64) (bool, error) {
+	blocked := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.UserRelationship{
+			FromUserID:       fromUserID,
+			RelationshipType: models.RelationshipBlocks,
+			ToUserID:         toUserID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		blocked = result.RowsAffected > 0
+
+		if _, err := unfollow(tx, fromUserID, toUserID); err != nil {
+			return err
+		}
+		_, err := unfollow(tx, toUserID, fromUserID)
+		return err
+	})
+	return blocked, err
+}
+
+// Unblock removes a block. It reports false if there was no block. Follows
+// the block severed are not restored.
+func (r *RelationshipRepository) Unblock(ctx context.Context, fromUserID, toUserID uint64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("from_user_id = ? AND relationship_type = ? AND to_user_id = ?", fromUserID, models.RelationshipBlocks, toUserID).
+		Delete(&models.UserRelationship{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListFollowers retrieves up to limit of a user's followers, most recent
+// follow first, starting below the given relationship ID when it is not zero
+func (r *RelationshipRepository) ListFollowers(ctx context.Context, userID, beforeID uint64, limit int) ([]*FollowEntry, error) {
+	query := r.follows(ctx, "from_user_id").Where("f.to_user_id = ?", userID)
+	return listFollows(query, beforeID, limit)
+}
+
+// ListFollowing retrieves up to limit of the users a user follows, most
+// recent follow first, starting below the given relationship ID when it is
+// not zero
+func (r *RelationshipRepository) ListFollowing(ctx context.Context, userID, beforeID uint64, limit int) ([]*FollowEntry, error) {
+	query := r.follows(ctx, "to_user_id").Where("f.from_user_id = ?", userID)
+	return listFollows(query, beforeID, limit)
+}
+
+// ListMutuals retrieves up to limit of the users a user follows who follow
+// them back, most recent follow by the user first, starting below the given
+// relationship ID when it is not zero
+func (r *RelationshipRepository) ListMutuals(ctx context.Context, userID, beforeID uint64, limit int) ([]*FollowEntry, error) {
+	query := r.follows(ctx, "to_user_id").
+		Joins("JOIN user_relationships b ON b.from_user_id = f.to_user_id AND b.to_user_id = f.from_user_id AND b.relationship_type = ?", models.RelationshipFollows).
+		Where("f.from_user_id = ?", userID)
+	return listFollows(query, beforeID, limit)
+}
+
+// follows selects follows, aliased f, joined to the user in the given column
+func (r *RelationshipRepository) follows(ctx context.Context, userColumn string) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("user_relationships f").
+		Select("users.*, f.relationship_id, f.created_at AS followed_at").
+		Joins("JOIN users ON users.user_id = f."+userColumn).
+		Where("f.relationship_type = ?", models.RelationshipFollows)
+}
+
+// listFollows pages a follows query by relationship ID, newest first
+func listFollows(query *gorm.DB, beforeID uint64, limit int) ([]*FollowEntry, error) {
+	if beforeID != 0 {
+		query = query.Where("f.relationship_id < ?", beforeID)
+	}
+	var entries []*FollowEntry
+	err := query.Order("f.relationship_id DESC").Limit(limit).Scan(&entries).Error
+	return entries, err
+}
+
+// unfollow removes a follow within a transaction and uncounts it from both
+// users, reporting false if there was no follow
+func unfollow(tx *gorm.DB, fromUserID, toUserID uint64) (bool, error) {
+	result := tx.
+		Where("from_user_id = ? AND relationship_type = ? AND to_user_id = ?", fromUserID, models.RelationshipFollows, toUserID).
+		Delete(&models.UserRelationship{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	return true, countFollow(tx, fromUserID, toUserID, -1)
+}
+
+// countFollow adds delta to the following count of the follower and the
+// follower count of the followed user. Counts never drop below zero.
+func countFollow(tx *gorm.DB, fromUserID, toUserID uint64, delta int) error {
+	counters := []struct {
+		userID uint64
+		column string
+	}{
+		{fromUserID, "following_count"},
+		{toUserID, "follower_count"},
+	}
+	for _, counter := range counters {
+		query := tx.Model(&models.User{}).Where("user_id = ?", counter.userID)
+		if delta < 0 {
+			query = query.Where(counter.column+" >= ?", -delta)
+		}
+		err := query.UpdateColumn(counter.column, gorm.Expr(counter.column+" + ?", delta)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBlocked reports whether either of two users blocks the other
+func isBlocked(db *gorm.DB, userID, otherID uint64) (bool, error) {
+	var count int64
+	err := db.Model(&models.UserRelationship{}).
+		Where("relationship_type = ?", models.RelationshipBlocks).
+		Where("(from_user_id = ? AND to_user_id = ?) OR (from_user_id = ? AND to_user_id = ?)", userID, otherID, otherID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// blockedUserIDs is a subquery of the users a user blocks or is blocked by
+func blockedUserIDs(db *gorm.DB, userID uint64) *gorm.DB {
+	return db.Raw(
+		"SELECT to_user_id FROM user_relationships WHERE from_user_id = ? AND relationship_type = ? "+
+			"UNION SELECT from_user_id FROM user_relationships WHERE to_user_id = ? AND relationship_type = ?",
+		userID, models.RelationshipBlocks, userID, models.RelationshipBlocks,
+	)
+}
@@ -0,0 +1,95 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// SessionRepository handles user session data access
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// Create creates a new session
+func (r *SessionRepository) Create(ctx context.Context, session *models.UserSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+// GetByID retrieves a session by ID
+func (r *SessionRepository) GetByID(ctx context.Context, sessionID string) (*models.UserSession, error) {
+	var session models.UserSession
+	err := r.db.WithContext(ctx).Where("session_id = ?", sessionID).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Rotate swaps the session's refresh token hash and extends its expiry, but
+// only if the presented hash is still the current one and the session is
+// live. It reports whether the swap happened, so of two concurrent refreshes
+// only one succeeds.
+func (r *SessionRepository) Rotate(ctx context.Context, sessionID, oldHash, newHash, userAgent, ipAddress string, now, expiresAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("session_id = ? AND refresh_token_hash = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, oldHash, now).
+		Updates(map[string]interface{}{
+			"refresh_token_hash": newHash,
+			"user_agent":         userAgent,
+			"ip_address":         ipAddress,
+			"last_seen_at":       now,
+			"expires_at":         expiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetActiveByUser retrieves a user's live sessions, most recently used first
+func (r *SessionRepository) GetActiveByUser(ctx context.Context, userID uint64, now time.Time) ([]*models.UserSession, error) {
+	var sessions []*models.UserSession
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Revoke marks a session revoked
+func (r *SessionRepository) Revoke(ctx context.Context, sessionID string, now time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", now).Error
+}
+
+// RevokeAllByUser marks every live session of a user revoked and returns
+// their IDs
+func (r *SessionRepository) RevokeAllByUser(ctx context.Context, userID uint64, now time.Time) ([]string, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.UserSession{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Pluck("session_id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&models.UserSession{}).
+			Where("session_id IN ?", ids).
+			Update("revoked_at", now).Error
+	})
+	return ids, err
+}
@@ -0,0 +1,118 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TipRepository handles post tip data access
+type TipRepository struct {
+	db *gorm.DB
+}
+
+// NewTipRepository creates a new tip repository
+func NewTipRepository(db *gorm.DB) *TipRepository {
+	return &TipRepository{db: db}
+}
+
+// GetByTxHash retrieves the tip recorded for a transaction
+func (r *TipRepository) GetByTxHash(ctx context.Context, txHash string) (*models.PostTip, error) {
+	var tip models.PostTip
+	err := r.db.WithContext(ctx).Where("tx_hash = ?", txHash).First(&tip).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tip, nil
+}
+
+// CreateIntent stores a prepared tip and drops the tipper's expired ones
+func (r *TipRepository) CreateIntent(ctx context.Context, intent *models.PostTipIntent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("tipper_id = ? AND expires_at <= ?", intent.TipperID, time.Now()).
+			Delete(&models.PostTipIntent{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(intent).Error
+	})
+}
+
+// ListIntents retrieves a tipper's unexpired tips prepared for a post in the
+// given token, empty for ETH, with a nonce no later than the given one,
+// oldest first
+func (r *TipRepository) ListIntents(ctx context.Context, postID, tipperID uint64, tokenAddress string, nonce uint64) ([]*models.PostTipIntent, error) {
+	var intents []*models.PostTipIntent
+	err := r.db.WithContext(ctx).
+		Where("post_id = ? AND tipper_id = ? AND token_address = ?", postID, tipperID, tokenAddress).
+		Where("nonce <= ? AND expires_at > ?", nonce, time.Now()).
+		Order("intent_id ASC").
+		Find(&intents).Error
+	return intents, err
+}
+
+// Record stores a tip, uses up the intent it was prepared with, adds it to
+// the post's ETH or token reward total and notifies the author in one
+// transaction. A transaction is only counted once: Record reports false,
+// changing nothing, if its tip already exists. It fails with
+// gorm.ErrRecordNotFound if the intent was used up by another tip first.
+func (r *TipRepository) Record(ctx context.Context, tip *models.PostTip, intentID uint64, notification *models.Notification) (bool, error) {
+	recorded := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(tip)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		result = tx.Where("intent_id = ?", intentID).Delete(&models.PostTipIntent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		recorded = true
+
+		column := tipColumn(tip.TokenAddress != "")
+		err := tx.Model(&models.Post{}).
+			Where("post_id = ?", tip.PostID).
+			UpdateColumn(column, gorm.Expr(column+" + CAST(? AS DECIMAL(30,18))", tip.Amount)).Error
+		if err != nil {
+			return err
+		}
+		return NewNotificationRepository(tx).Create(ctx, notification)
+	})
+	return recorded, err
+}
+
+// Leaderboard retrieves a circle's undeleted posts with the largest ETH, or
+// circle token, tip totals, authors loaded. Posts never tipped are left out.
+func (r *TipRepository) Leaderboard(ctx context.Context, circleID uint64, byToken bool, limit int) ([]*models.Post, error) {
+	column := tipColumn(byToken)
+	var posts []*models.Post
+	err := r.db.WithContext(ctx).
+		Preload("Author").
+		Where("circle_id = ? AND is_deleted = ? AND "+column+" > 0", circleID, false).
+		Order(column + " DESC, post_id ASC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+// tipColumn is the post column totalling tips in ETH or the circle token
+func tipColumn(byToken bool) string {
+	if byToken {
+		return "token_reward_amount"
+	}
+	return "reward_amount"
+}
@@ -0,0 +1,59 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokenTransferRepository handles circle token transfer data access
+type TokenTransferRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenTransferRepository creates a new token transfer repository
+func NewTokenTransferRepository(db *gorm.DB) *TokenTransferRepository {
+	return &TokenTransferRepository{db: db}
+}
+
+// CreateIfNotExists inserts a transfer unless it was already recorded for the
+// same log, reporting whether it was inserted
+func (r *TokenTransferRepository) CreateIfNotExists(ctx context.Context, transfer *models.TokenTransfer) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(transfer)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetByCircle retrieves every transfer of a circle's token in chain order
+func (r *TokenTransferRepository) GetByCircle(ctx context.Context, circleID uint64) ([]*models.TokenTransfer, error) {
+	var transfers []*models.TokenTransfer
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ?", circleID).
+		Order("block_number ASC, log_index ASC").
+		Find(&transfers).Error
+	return transfers, err
+}
+
+// GetCircleIDsAfterBlock returns the circles with transfers above the given block
+func (r *TokenTransferRepository) GetCircleIDsAfterBlock(ctx context.Context, blockNumber uint64) ([]uint64, error) {
+	var ids []uint64
+	err := r.db.WithContext(ctx).Model(&models.TokenTransfer{}).
+		Where("block_number > ?", blockNumber).
+		Distinct().
+		Pluck("circle_id", &ids).Error
+	return ids, err
+}
+
+// DeleteAfterBlock removes transfers from blocks above the given number
+func (r *TokenTransferRepository) DeleteAfterBlock(ctx context.Context, blockNumber uint64) error {
+	return r.db.WithContext(ctx).
+		Where("block_number > ?", blockNumber).
+		Delete(&models.TokenTransfer{}).Error
+}
@@ -0,0 +1,178 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TradeRepository handles trade data access
+type TradeRepository struct {
+	db *gorm.DB
+}
+
+// NewTradeRepository creates a new trade repository
+func NewTradeRepository(db *gorm.DB) *TradeRepository {
+	return &TradeRepository{db: db}
+}
+
+// TradeFilter selects trades for history queries. Zero fields match every
+// trade.
+type TradeFilter struct {
+	TraderID     uint64
+	CircleID     uint64
+	TradeType    string
+	From         time.Time // inclusive
+	To           time.Time // exclusive
+	MinETHAmount string
+	// Ascending lists the oldest trades first instead of the newest
+	Ascending bool
+}
+
+// TradeCursor is the position of a trade in history order. Trades sharing a
+// timestamp are ordered by ID, so the pair is unique.
+type TradeCursor struct {
+	Timestamp time.Time
+	TradeID   uint64
+}
+
+// List retrieves up to limit trades matching the filter, in history order,
+// starting after the cursor when one is given
+func (r *TradeRepository) List(ctx context.Context, filter *TradeFilter, after *TradeCursor, limit int) ([]*models.Trade, error) {
+	query := r.db.WithContext(ctx).Preload("Trader").Preload("Circle")
+	if filter.TraderID != 0 {
+		query = query.Where("trader_id = ?", filter.TraderID)
+	}
+	if filter.CircleID != 0 {
+		query = query.Where("circle_id = ?", filter.CircleID)
+	}
+	if filter.TradeType != "" {
+		query = query.Where("trade_type = ?", filter.TradeType)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("timestamp >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("timestamp < ?", filter.To)
+	}
+	if filter.MinETHAmount != "" {
+		// Compare as a decimal rather than as the string the amount is bound as
+		query = query.Where("eth_amount >= CAST(? AS DECIMAL(30,18))", filter.MinETHAmount)
+	}
+
+	order, cmp := "timestamp DESC, trade_id DESC", "<"
+	if filter.Ascending {
+		order, cmp = "timestamp ASC, trade_id ASC", ">"
+	}
+	if after != nil {
+		query = query.Where("timestamp "+cmp+" ? OR (timestamp = ? AND trade_id "+cmp+" ?)",
+			after.Timestamp, after.Timestamp, after.TradeID)
+	}
+
+	var trades []*models.Trade
+	err := query.Order(order).Limit(limit).Find(&trades).Error
+	return trades, err
+}
+
+// CreateIfNotExists inserts a trade unless one already exists for the same
+// transaction hash and log index, so replaying events is harmless
+func (r *TradeRepository) CreateIfNotExists(ctx context.Context, trade *models.Trade) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(trade).Error
+}
+
+// GetByTxHash retrieves trades emitted by a transaction
+func (r *TradeRepository) GetByTxHash(ctx context.Context, txHash string) ([]*models.Trade, error) {
+	var trades []*models.Trade
+	err := r.db.WithContext(ctx).
+		Where("tx_hash = ?", txHash).
+		Order("log_index ASC").
+		Find(&trades).Error
+	return trades, err
+}
+
+// GetByBlockRange retrieves trades mined in blocks from..to inclusive, in
+// chain order and with their traders
+func (r *TradeRepository) GetByBlockRange(ctx context.Context, from, to uint64) ([]*models.Trade, error) {
+	var trades []*models.Trade
+	err := r.db.WithContext(ctx).
+		Preload("Trader").
+		Order("block_number ASC, log_index ASC").
+		Where("block_number >= ? AND block_number <= ?", from, to).
+		Find(&trades).Error
+	return trades, err
+}
+
+// GetAfterBlock retrieves trades from blocks above the given number
+func (r *TradeRepository) GetAfterBlock(ctx context.Context, blockNumber uint64) ([]*models.Trade, error) {
+	var trades []*models.Trade
+	err := r.db.WithContext(ctx).
+		Where("block_number > ?", blockNumber).
+		Find(&trades).Error
+	return trades, err
+}
+
+// GetByCircleAndTimeRange retrieves a circle's trades within [from, to) in
+// the order they were executed
+func (r *TradeRepository) GetByCircleAndTimeRange(ctx context.Context, circleID uint64, from, to time.Time) ([]*models.Trade, error) {
+	var trades []*models.Trade
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ? AND timestamp >= ? AND timestamp < ?", circleID, from, to).
+		Order("timestamp ASC, block_number ASC, log_index ASC").
+		Find(&trades).Error
+	return trades, err
+}
+
+// GetByTrader retrieves all of a user's trades in chain order
+func (r *TradeRepository) GetByTrader(ctx context.Context, traderID uint64) ([]*models.Trade, error) {
+	var trades []*models.Trade
+	err := r.db.WithContext(ctx).
+		Where("trader_id = ?", traderID).
+		Order("block_number ASC, log_index ASC").
+		Find(&trades).Error
+	return trades, err
+}
+
+// GetCircleIDs returns the circles that have been traded
+func (r *TradeRepository) GetCircleIDs(ctx context.Context) ([]uint64, error) {
+	var ids []uint64
+	err := r.db.WithContext(ctx).Model(&models.Trade{}).
+		Distinct().
+		Pluck("circle_id", &ids).Error
+	return ids, err
+}
+
+// GetFirstAndLastByCircle retrieves a circle's earliest and latest trades
+func (r *TradeRepository) GetFirstAndLastByCircle(ctx context.Context, circleID uint64) (*models.Trade, *models.Trade, error) {
+	var first, last models.Trade
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ?", circleID).
+		Order("timestamp ASC, block_number ASC, log_index ASC").
+		First(&first).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	err = r.db.WithContext(ctx).
+		Where("circle_id = ?", circleID).
+		Order("timestamp DESC, block_number DESC, log_index DESC").
+		First(&last).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return &first, &last, nil
+}
+
+// DeleteAfterBlock removes trades from blocks above the given number
+func (r *TradeRepository) DeleteAfterBlock(ctx context.Context, blockNumber uint64) error {
+	return r.db.WithContext(ctx).
+		Where("block_number > ?", blockNumber).
+		Delete(&models.Trade{}).Error
+}
@@ -66,6 +66,67 @@ func (r *TransactionRepository) Update(ctx context.Context, tx *models.Transacti
 	return r.db.WithContext(ctx).Save(tx).Error
 }
 
+// Replace points a transaction at a new broadcast of its nonce in one
+// transaction. The broadcast it supersedes is kept, so the transaction can
+// still settle on it if it is the one mined, and a circle created by the
+// transaction follows the new hash.
+func (r *TransactionRepository) Replace(ctx context.Context, row *models.Transaction, superseded *models.TransactionReplacement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		superseded.TransactionID = row.ID
+		if err := tx.Create(superseded).Error; err != nil {
+			return err
+		}
+		if err := moveCircleTxHash(tx, superseded.TxHash, row.TxHash); err != nil {
+			return err
+		}
+		return tx.Save(row).Error
+	})
+}
+
+// GetSuperseded retrieves the broadcasts a transaction's current hash
+// superseded, oldest first
+func (r *TransactionRepository) GetSuperseded(ctx context.Context, transactionID uint64) ([]*models.TransactionReplacement, error) {
+	var replacements []*models.TransactionReplacement
+	err := r.db.WithContext(ctx).
+		Where("transaction_id = ?", transactionID).
+		Order("id ASC").
+		Find(&replacements).Error
+	return replacements, err
+}
+
+// Restore settles a transaction on a superseded broadcast that was mined in
+// place of its current hash. The row takes back the hash, type, recipient
+// and amount it had under that broadcast, the current hash is kept as
+// superseded in its place and a circle created by the transaction follows,
+// all in one transaction.
+func (r *TransactionRepository) Restore(ctx context.Context, row *models.Transaction, mined *models.TransactionReplacement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(mined).Error; err != nil {
+			return err
+		}
+		current := &models.TransactionReplacement{
+			TransactionID: row.ID,
+			TxHash:        row.TxHash,
+			TxType:        row.TxType,
+			ToAddress:     row.ToAddress,
+			Amount:        row.Amount,
+		}
+		if err := tx.Create(current).Error; err != nil {
+			return err
+		}
+		if err := moveCircleTxHash(tx, current.TxHash, mined.TxHash); err != nil {
+			return err
+		}
+
+		row.TxHash = mined.TxHash
+		row.ReplacedHash = ""
+		row.TxType = mined.TxType
+		row.ToAddress = mined.ToAddress
+		row.Amount = mined.Amount
+		return tx.Save(row).Error
+	})
+}
+
 // GetPendingTransactions retrieves pending transactions
 func (r *TransactionRepository) GetPendingTransactions(ctx context.Context) ([]*models.Transaction, error) {
 	var txs []*models.Transaction
@@ -76,6 +137,51 @@ func (r *TransactionRepository) GetPendingTransactions(ctx context.Context) ([]*
 	return txs, err
 }
 
+// GetUnsettled retrieves transactions that are waiting to be mined or
+// confirmed, those the reconciler has gone longest without checking first.
+// Rows never checked sort first, and a row that stays unsettled moves to the
+// back once checked, so it cannot starve the rows behind it.
+func (r *TransactionRepository) GetUnsettled(ctx context.Context, limit int) ([]*models.Transaction, error) {
+	var txs []*models.Transaction
+	err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{"pending", "mined"}).
+		Order("checked_at ASC").
+		Order("id ASC").
+		Limit(limit).
+		Find(&txs).Error
+	return txs, err
+}
+
+// MarkChecked records when the reconciler last looked the given
+// transactions up
+func (r *TransactionRepository) MarkChecked(ctx context.Context, ids []uint64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&models.Transaction{}).
+		Where("id IN ?", ids).
+		Update("checked_at", at).Error
+}
+
+// ResetAfterBlock returns transactions mined above the given block to the
+// pending state after their blocks were orphaned
+func (r *TransactionRepository) ResetAfterBlock(ctx context.Context, blockNumber uint64) error {
+	return r.db.WithContext(ctx).Model(&models.Transaction{}).
+		Where("block_number > ?", blockNumber).
+		Updates(map[string]interface{}{
+			"status":       "pending",
+			"block_number": 0,
+			"block_hash":   "",
+		}).Error
+}
+
+// ConfirmMined marks mined transactions at or below the given block as confirmed
+func (r *TransactionRepository) ConfirmMined(ctx context.Context, safeBlock uint64) error {
+	return r.db.WithContext(ctx).Model(&models.Transaction{}).
+		Where("status = ? AND block_number > 0 AND block_number <= ?", "mined", safeBlock).
+		Update("status", "confirmed").Error
+}
+
 // GetVolumeStats retrieves volume statistics for a circle
 func (r *TransactionRepository) GetVolumeStats(ctx context.Context, circleID uint64, since time.Time) (*models.VolumeStats, error) {
 	var stats models.VolumeStats
@@ -91,3 +197,11 @@ func (r *TransactionRepository) GetVolumeStats(ctx context.Context, circleID uin
 
 	return &stats, nil
 }
+
+// moveCircleTxHash points the circle created by a transaction at another
+// broadcast of it
+func moveCircleTxHash(tx *gorm.DB, fromHash, toHash string) error {
+	return tx.Model(&models.Circle{}).
+		Where("tx_hash = ?", fromHash).
+		Update("tx_hash", toHash).Error
+}
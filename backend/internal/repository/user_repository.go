@@ -6,7 +6,6 @@ package repository
 
 import (
 	"context"
-	"time"
 
 	"github.com/fast-socialfi/backend/internal/models"
 	"gorm.io/gorm"
@@ -37,6 +36,19 @@ func (r *UserRepository) GetByAddress(ctx context.Context, address string) (*mod
 	return &user, nil
 }
 
+// GetOrCreateByAddress retrieves a user by wallet address, creating a bare
+// profile if the address has not been seen before
+func (r *UserRepository) GetOrCreateByAddress(ctx context.Context, address string) (*models.User, error) {
+	user := models.User{WalletAddress: address}
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ?", address).
+		FirstOrCreate(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(ctx context.Context, id uint64) (*models.User, error) {
 	var user models.User
@@ -0,0 +1,183 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VoteTally is a target's vote counters. Comments have no downvotes.
+type VoteTally struct {
+	Upvotes   uint
+	Downvotes uint
+}
+
+// VoteRepository handles the vote ledger and the counters it backs
+type VoteRepository struct {
+	db *gorm.DB
+}
+
+// NewVoteRepository creates a new vote repository
+func NewVoteRepository(db *gorm.DB) *VoteRepository {
+	return &VoteRepository{db: db}
+}
+
+// Cast sets a user's vote on a target to value, +1 or -1 with the given
+// weight, or retracts it when value is zero. The ledger row and the target's
+// counters change in one transaction with the target row locked, so
+// concurrent votes on it serialize. Casting the vote already standing,
+// weight aside, changes nothing, which makes retries safe; Cast reports
+// whether anything changed along with the counters that result.
+func (r *VoteRepository) Cast(ctx context.Context, userID uint64, targetType string, targetID uint64, value int8, weight uint) (*VoteTally, bool, error) {
+	table, key, columns := voteTarget(targetType)
+
+	var tally VoteTally
+	changed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(table).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select(columns).
+			Where(key+" = ?", targetID).
+			Take(&tally).Error
+		if err != nil {
+			return err
+		}
+
+		var existing *models.Vote
+		var vote models.Vote
+		err = tx.Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+			Take(&vote).Error
+		switch {
+		case err == nil:
+			existing = &vote
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		if (existing == nil && value == 0) || (existing != nil && existing.Value == value) {
+			return nil
+		}
+		changed = true
+
+		var up, down int64
+		if existing != nil {
+			if existing.Value > 0 {
+				up -= int64(existing.Weight)
+			} else {
+				down -= int64(existing.Weight)
+			}
+		}
+		switch {
+		case value > 0:
+			up += int64(weight)
+		case value < 0:
+			down += int64(weight)
+		}
+
+		switch {
+		case value == 0:
+			err = tx.Delete(existing).Error
+		case existing == nil:
+			err = tx.Create(&models.Vote{
+				UserID:     userID,
+				TargetType: targetType,
+				TargetID:   targetID,
+				Value:      value,
+				Weight:     weight,
+			}).Error
+		default:
+			err = tx.Model(existing).Updates(map[string]interface{}{"value": value, "weight": weight}).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		updates := make(map[string]interface{})
+		if up != 0 {
+			updates["upvotes"] = counterDelta("upvotes", up)
+			tally.Upvotes = applyDelta(tally.Upvotes, up)
+		}
+		if down != 0 {
+			updates["downvotes"] = counterDelta("downvotes", down)
+			tally.Downvotes = applyDelta(tally.Downvotes, down)
+		}
+		return tx.Table(table).Where(key+" = ?", targetID).UpdateColumns(updates).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &tally, changed, nil
+}
+
+// Reconcile recomputes every post's and comment's counters from the ledger,
+// summing the weights of their votes, and returns how many targets had
+// drifted
+func (r *VoteRepository) Reconcile(ctx context.Context) (int64, error) {
+	var fixed int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		postUp := r.voteSum(models.VoteTargetPost, "posts.post_id", "value > 0")
+		postDown := r.voteSum(models.VoteTargetPost, "posts.post_id", "value < 0")
+		result := tx.Model(&models.Post{}).
+			Where("upvotes <> (?) OR downvotes <> (?)", postUp, postDown).
+			UpdateColumns(map[string]interface{}{
+				"upvotes":   gorm.Expr("(?)", postUp),
+				"downvotes": gorm.Expr("(?)", postDown),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		fixed += result.RowsAffected
+
+		commentUp := r.voteSum(models.VoteTargetComment, "comments.comment_id", "value > 0")
+		result = tx.Model(&models.Comment{}).
+			Where("upvotes <> (?)", commentUp).
+			UpdateColumn("upvotes", gorm.Expr("(?)", commentUp))
+		if result.Error != nil {
+			return result.Error
+		}
+		fixed += result.RowsAffected
+		return nil
+	})
+	return fixed, err
+}
+
+// voteSum is a correlated subquery summing the weights of the votes on the
+// target in the outer query's key column that match the condition
+func (r *VoteRepository) voteSum(targetType, key, condition string) *gorm.DB {
+	return r.db.Model(&models.Vote{}).
+		Select("COALESCE(SUM(weight), 0)").
+		Where("target_type = ? AND target_id = "+key+" AND "+condition, targetType)
+}
+
+// voteTarget is the table, key column and counter columns of a target type
+func voteTarget(targetType string) (string, string, string) {
+	if targetType == models.VoteTargetComment {
+		return "comments", "comment_id", "upvotes"
+	}
+	return "posts", "post_id", "upvotes, downvotes"
+}
+
+// counterDelta is an update expression moving an unsigned counter by delta
+// without letting it drop below zero
+func counterDelta(column string, delta int64) clause.Expr {
+	if delta > 0 {
+		return gorm.Expr(column+" + ?", delta)
+	}
+	return gorm.Expr("CASE WHEN "+column+" >= ? THEN "+column+" - ? ELSE 0 END", -delta, -delta)
+}
+
+// applyDelta moves a counter read in the transaction the way counterDelta
+// moves it in the database
+func applyDelta(counter uint, delta int64) uint {
+	if delta < 0 && uint64(-delta) > uint64(counter) {
+		return 0
+	}
+	return uint(int64(counter) + delta)
+}
@@ -0,0 +1,220 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/siwe"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrLoginRejected is returned when a well-formed sign-in message was not
+	// issued for this service or is outside its validity window
+	ErrLoginRejected = errors.New("sign-in message rejected")
+	// ErrLoginNonce is returned when the message nonce was never issued, has
+	// expired or was already used
+	ErrLoginNonce = errors.New("nonce is unknown, expired or already used")
+)
+
+// loginClockSkew tolerates wallets whose clocks run slightly ahead
+const loginClockSkew = time.Minute
+
+// TokenIssuer mints session access tokens and refuses them once the
+// session is revoked
+type TokenIssuer interface {
+	GenerateSessionToken(userAddress, sessionID, role string, duration time.Duration) (string, error)
+	RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error
+}
+
+// AuthService handles Sign-In With Ethereum logins and the sessions they open
+type AuthService struct {
+	userRepo    *repository.UserRepository
+	sessionRepo *repository.SessionRepository
+	verifier    *SignatureVerifier
+	tokens      TokenIssuer
+	nonces      LoginNonceStore
+	siweCfg     config.SIWEConfig
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+}
+
+// NewAuthService creates a new auth service. web3Svc is used to verify
+// smart contract wallet signatures and may be nil to accept only
+// externally owned accounts.
+func NewAuthService(
+	userRepo *repository.UserRepository,
+	sessionRepo *repository.SessionRepository,
+	web3Svc *web3.Web3Service,
+	tokens TokenIssuer,
+	siweCfg config.SIWEConfig,
+	jwtCfg config.JWTConfig,
+) *AuthService {
+	return &AuthService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		verifier:    NewSignatureVerifier(web3Svc),
+		tokens:      tokens,
+		nonces:      NewMemoryLoginNonceStore(),
+		siweCfg:     siweCfg,
+		accessTTL:   jwtCfg.AccessExpiration,
+		refreshTTL:  jwtCfg.RefreshExpiration,
+	}
+}
+
+// SetNonceStore replaces the in-process nonce store, e.g. with a
+// RedisLoginNonceStore when several replicas serve the API
+func (s *AuthService) SetNonceStore(store LoginNonceStore) {
+	s.nonces = store
+}
+
+// LoginNonceResponse carries the values a wallet needs to build the
+// sign-in message
+type LoginNonceResponse struct {
+	Nonce     string    `json:"nonce"`
+	Domain    string    `json:"domain"`
+	ChainID   int64     `json:"chain_id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LoginRequest represents a signed EIP-4361 message
+type LoginRequest struct {
+	Message   string `json:"message" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
+}
+
+// ClientInfo describes the device a session is opened or refreshed from
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// LoginResponse represents the tokens issued to a session
+type LoginResponse struct {
+	SessionID        string       `json:"session_id"`
+	AccessToken      string       `json:"access_token"`
+	TokenType        string       `json:"token_type"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	RefreshToken     string       `json:"refresh_token"`
+	RefreshExpiresAt time.Time    `json:"refresh_expires_at"`
+	User             *models.User `json:"user"`
+}
+
+// IssueNonce hands out a single-use nonce for a sign-in message
+func (s *AuthService) IssueNonce(ctx context.Context) (*LoginNonceResponse, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	nonce := hex.EncodeToString(buf)
+
+	if err := s.nonces.Save(ctx, nonce, s.siweCfg.NonceTTL); err != nil {
+		return nil, fmt.Errorf("failed to store nonce: %w", err)
+	}
+
+	now := time.Now().UTC()
+	return &LoginNonceResponse{
+		Nonce:     nonce,
+		Domain:    s.siweCfg.Domain,
+		ChainID:   s.siweCfg.ChainID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.siweCfg.NonceTTL),
+	}, nil
+}
+
+// Login verifies a signed sign-in message, creates the user on first login
+// and opens a session for the device
+func (s *AuthService) Login(ctx context.Context, req *LoginRequest, client ClientInfo) (*LoginResponse, error) {
+	msg, err := siwe.Parse(req.Message)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMessage(msg, time.Now()); err != nil {
+		return nil, err
+	}
+
+	signature, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", siwe.ErrInvalidSignature, err)
+	}
+	if err := s.verifier.Verify(ctx, msg.Address, []byte(req.Message), signature); err != nil {
+		return nil, err
+	}
+
+	// Only a message that checks out uses up its nonce, so a forged request
+	// cannot burn the nonce of a login in progress
+	ok, err := s.nonces.Consume(ctx, msg.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume nonce: %w", err)
+	}
+	if !ok {
+		return nil, ErrLoginNonce
+	}
+
+	user, err := s.getOrCreateUser(ctx, msg.Address.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.openSession(ctx, user, client)
+}
+
+// checkMessage applies the EIP-4361 validation rules that do not depend on
+// the signature
+func (s *AuthService) checkMessage(msg *siwe.Message, now time.Time) error {
+	if msg.Domain != s.siweCfg.Domain {
+		return fmt.Errorf("%w: issued for domain %s", ErrLoginRejected, msg.Domain)
+	}
+	if msg.ChainID != s.siweCfg.ChainID {
+		return fmt.Errorf("%w: issued for chain %d", ErrLoginRejected, msg.ChainID)
+	}
+	if msg.IssuedAt.After(now.Add(loginClockSkew)) {
+		return fmt.Errorf("%w: issued in the future", ErrLoginRejected)
+	}
+	// A nonce outlives neither its TTL nor a message claiming to be older
+	if now.Sub(msg.IssuedAt) > s.siweCfg.NonceTTL+loginClockSkew {
+		return fmt.Errorf("%w: issued too long ago", ErrLoginRejected)
+	}
+	if msg.ExpirationTime != nil && !now.Before(*msg.ExpirationTime) {
+		return fmt.Errorf("%w: message expired", ErrLoginRejected)
+	}
+	if msg.NotBefore != nil && now.Add(loginClockSkew).Before(*msg.NotBefore) {
+		return fmt.Errorf("%w: message not yet valid", ErrLoginRejected)
+	}
+	return nil
+}
+
+// getOrCreateUser loads the wallet's user, creating it on first login
+func (s *AuthService) getOrCreateUser(ctx context.Context, address string) (*models.User, error) {
+	user, err := s.userRepo.GetByAddress(ctx, address)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	user = &models.User{WalletAddress: address}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		// A concurrent first login may have created the row in the meantime
+		if existing, getErr := s.userRepo.GetByAddress(ctx, address); getErr == nil {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return user, nil
+}
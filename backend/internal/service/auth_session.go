@@ -0,0 +1,268 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidRefreshToken is returned for an unknown, expired or revoked
+	// refresh token
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when a refresh token that was already
+	// rotated is presented again; the session is revoked as compromised
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected, session revoked")
+	// ErrSessionNotFound is returned for a session the caller does not own
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// RefreshRequest represents a request to rotate a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// SessionResponse represents a signed-in device
+type SessionResponse struct {
+	*models.UserSession
+	Current bool `json:"current"`
+}
+
+// LogoutAllResponse represents the outcome of signing out everywhere
+type LogoutAllResponse struct {
+	RevokedSessions int    `json:"revoked_sessions"`
+	Message         string `json:"message"`
+}
+
+// Refresh rotates a refresh token and issues a new access token for its
+// session. Every refresh token works once; presenting an old one again ends
+// the session, since either the device or whoever copied the token is no
+// longer the legitimate holder.
+func (s *AuthService) Refresh(ctx context.Context, req *RefreshRequest, client ClientInfo) (*LoginResponse, error) {
+	sessionID, _, ok := strings.Cut(req.RefreshToken, ".")
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil || !now.Before(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	presented := hashRefreshToken(req.RefreshToken)
+	if presented != session.RefreshTokenHash {
+		if err := s.revokeSessions(ctx, session.SessionID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	refreshToken, err := newRefreshToken(session.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	refreshExpiresAt := now.Add(s.refreshTTL)
+	rotated, err := s.sessionRepo.Rotate(ctx, session.SessionID, presented, hashRefreshToken(refreshToken),
+		client.UserAgent, client.IPAddress, now, refreshExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		// Another request rotated the same token first
+		if err := s.revokeSessions(ctx, session.SessionID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	user, err := s.userRepo.GetByAddress(ctx, session.WalletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return s.issueTokens(user, session.SessionID, refreshToken, refreshExpiresAt)
+}
+
+// Logout ends the session the caller's access token belongs to
+func (s *AuthService) Logout(ctx context.Context, userAddress, sessionID string) error {
+	if _, err := s.getOwnedSession(ctx, userAddress, sessionID); err != nil {
+		return err
+	}
+	return s.revokeSessions(ctx, sessionID)
+}
+
+// LogoutAll ends every session of the user, on all devices
+func (s *AuthService) LogoutAll(ctx context.Context, userAddress string) (*LogoutAllResponse, error) {
+	user, err := s.userRepo.GetByAddress(ctx, userAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	ids, err := s.sessionRepo.RevokeAllByUser(ctx, user.UserID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	for _, id := range ids {
+		if err := s.tokens.RevokeSession(ctx, id, s.accessTTL); err != nil {
+			return nil, fmt.Errorf("failed to revoke access tokens: %w", err)
+		}
+	}
+
+	return &LogoutAllResponse{
+		RevokedSessions: len(ids),
+		Message:         "Signed out on all devices",
+	}, nil
+}
+
+// ListSessions retrieves the user's signed-in devices, flagging the one the
+// request came from
+func (s *AuthService) ListSessions(ctx context.Context, userAddress, currentSessionID string) ([]*SessionResponse, error) {
+	user, err := s.userRepo.GetByAddress(ctx, userAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	sessions, err := s.sessionRepo.GetActiveByUser(ctx, user.UserID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	result := make([]*SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, &SessionResponse{
+			UserSession: session,
+			Current:     session.SessionID == currentSessionID,
+		})
+	}
+	return result, nil
+}
+
+// RevokeSession ends one of the user's sessions, e.g. a device whose token
+// was compromised
+func (s *AuthService) RevokeSession(ctx context.Context, userAddress, sessionID string) error {
+	return s.Logout(ctx, userAddress, sessionID)
+}
+
+// openSession records a new session for the user and issues its tokens
+func (s *AuthService) openSession(ctx context.Context, user *models.User, client ClientInfo) (*LoginResponse, error) {
+	sessionID := uuid.NewString()
+	refreshToken, err := newRefreshToken(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &models.UserSession{
+		SessionID:        sessionID,
+		UserID:           user.UserID,
+		WalletAddress:    user.WalletAddress,
+		RefreshTokenHash: hashRefreshToken(refreshToken),
+		UserAgent:        truncate(client.UserAgent, 255),
+		IPAddress:        client.IPAddress,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(s.refreshTTL),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return s.issueTokens(user, sessionID, refreshToken, session.ExpiresAt)
+}
+
+// issueTokens mints the access token that accompanies a refresh token
+func (s *AuthService) issueTokens(user *models.User, sessionID, refreshToken string, refreshExpiresAt time.Time) (*LoginResponse, error) {
+	expiresAt := time.Now().Add(s.accessTTL)
+	accessToken, err := s.tokens.GenerateSessionToken(user.WalletAddress, sessionID, user.PlatformRole, s.accessTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &LoginResponse{
+		SessionID:        sessionID,
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+		User:             user,
+	}, nil
+}
+
+// getOwnedSession loads a session belonging to the user. Sessions of other
+// users are reported as missing rather than forbidden.
+func (s *AuthService) getOwnedSession(ctx context.Context, userAddress, sessionID string) (*models.UserSession, error) {
+	if sessionID == "" {
+		return nil, ErrSessionNotFound
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if !strings.EqualFold(session.WalletAddress, userAddress) {
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+// revokeSessions marks sessions revoked and refuses their outstanding access
+// tokens for as long as those could still be valid
+func (s *AuthService) revokeSessions(ctx context.Context, ids ...string) error {
+	now := time.Now()
+	for _, id := range ids {
+		if err := s.sessionRepo.Revoke(ctx, id, now); err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+		if err := s.tokens.RevokeSession(ctx, id, s.accessTTL); err != nil {
+			return fmt.Errorf("failed to revoke access tokens: %w", err)
+		}
+	}
+	return nil
+}
+
+// newRefreshToken generates an opaque refresh token. The session ID prefix
+// lets a presented token be looked up without storing it.
+func newRefreshToken(sessionID string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return sessionID + "." + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,101 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/candles"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// maxCandles caps how many buckets one candle request may span
+const maxCandles = 1000
+
+// ErrInvalidCandleQuery is returned for an unknown interval or a bad time range
+var ErrInvalidCandleQuery = errors.New("invalid candle query")
+
+// CandleService serves the OHLCV candles built by the indexer
+type CandleService struct {
+	circleRepo *repository.CircleRepository
+	candleRepo *repository.CandleRepository
+}
+
+// NewCandleService creates a new candle service
+func NewCandleService(circleRepo *repository.CircleRepository, candleRepo *repository.CandleRepository) *CandleService {
+	return &CandleService{
+		circleRepo: circleRepo,
+		candleRepo: candleRepo,
+	}
+}
+
+// CandleQuery selects candles by interval and a [From, To) range of unix
+// seconds. A zero To means now and a zero From means maxCandles buckets
+// before To.
+type CandleQuery struct {
+	Interval string
+	From     int64
+	To       int64
+}
+
+// CandlesResponse represents a circle's candles over a time range. Buckets
+// without trades are omitted rather than filled.
+type CandlesResponse struct {
+	CircleID uint64           `json:"circle_id"`
+	Interval string           `json:"interval"`
+	From     time.Time        `json:"from"`
+	To       time.Time        `json:"to"`
+	Candles  []*models.Candle `json:"candles"`
+}
+
+// GetCandles retrieves a circle's candles of one interval, oldest first
+func (s *CandleService) GetCandles(ctx context.Context, circleID uint64, query *CandleQuery) (*CandlesResponse, error) {
+	interval, ok := candles.Lookup(query.Interval)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported interval %q", ErrInvalidCandleQuery, query.Interval)
+	}
+
+	to := time.Now().UTC()
+	if query.To != 0 {
+		to = time.Unix(query.To, 0).UTC()
+	}
+	from := to.Add(-(maxCandles - 1) * interval.Duration)
+	if query.From != 0 {
+		from = time.Unix(query.From, 0).UTC()
+	}
+	from = candles.OpenTime(from, interval.Duration)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidCandleQuery)
+	}
+	if to.Sub(from) > maxCandles*interval.Duration {
+		return nil, fmt.Errorf("%w: range spans more than %d %s candles", ErrInvalidCandleQuery, maxCandles, interval.Name)
+	}
+
+	circle, err := s.circleRepo.GetByID(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCircleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+
+	candles, err := s.candleRepo.GetRange(ctx, circle.ID, interval.Name, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candles: %w", err)
+	}
+
+	return &CandlesResponse{
+		CircleID: circle.ID,
+		Interval: interval.Name,
+		From:     from,
+		To:       to,
+		Candles:  candles,
+	}, nil
+}
@@ -0,0 +1,267 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrCircleNotFound is returned for an unknown circle ID
+	ErrCircleNotFound = errors.New("circle not found")
+	// ErrNotCircleOwner is returned when the caller does not own the circle
+	ErrNotCircleOwner = errors.New("caller is not the circle owner")
+	// ErrCircleStateConflict is returned when a lifecycle change does not
+	// apply to the circle's current state
+	ErrCircleStateConflict = errors.New("circle state does not allow this change")
+)
+
+// lifecycleMethods maps the CircleFactory lifecycle methods to the
+// transaction types they are recorded and indexed under
+var lifecycleMethods = map[string]string{
+	web3.MethodDeactivateCircle:        "deactivate_circle",
+	web3.MethodReactivateCircle:        "reactivate_circle",
+	web3.MethodTransferCircleOwnership: "transfer_circle_ownership",
+}
+
+// CircleLifecycleRequest represents an owner's request to prepare a
+// deactivate or reactivate transaction
+type CircleLifecycleRequest struct {
+	FromAddress string `json:"from_address"`
+}
+
+// TransferCircleOwnershipRequest represents an owner's request to prepare an
+// ownership transfer transaction
+type TransferCircleOwnershipRequest struct {
+	NewOwner    string `json:"new_owner" binding:"required"`
+	FromAddress string `json:"from_address"`
+}
+
+// SubmitCircleLifecycleRequest represents a wallet-signed lifecycle transaction
+type SubmitCircleLifecycleRequest struct {
+	SignedTx    string `json:"signed_tx" binding:"required"`
+	FromAddress string `json:"from_address"`
+}
+
+// CircleLifecycleResponse represents a submitted lifecycle transaction
+type CircleLifecycleResponse struct {
+	CircleID uint64 `json:"circle_id"`
+	TxHash   string `json:"tx_hash"`
+	Action   string `json:"action"`
+	Message  string `json:"message"`
+	Warning  string `json:"warning,omitempty"`
+}
+
+// PrepareDeactivateCircle builds an unsigned deactivateCircle transaction for the circle owner
+func (s *CircleService) PrepareDeactivateCircle(ctx context.Context, circleID uint64, req *CircleLifecycleRequest) (*PreparedTxResponse, error) {
+	circle, err := s.getOwnedCircle(ctx, circleID, req.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLifecycleChange(circle, web3.MethodDeactivateCircle, common.Address{}); err != nil {
+		return nil, err
+	}
+
+	unsignedTx, err := s.web3Svc.PrepareDeactivateCircle(ctx, common.HexToAddress(req.FromAddress), new(big.Int).SetUint64(circle.ChainCircleID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare deactivation: %w", err)
+	}
+
+	return &PreparedTxResponse{
+		Transaction: unsignedTx,
+		Message:     "Sign the transaction with your wallet and submit it",
+	}, nil
+}
+
+// PrepareReactivateCircle builds an unsigned reactivateCircle transaction for the circle owner
+func (s *CircleService) PrepareReactivateCircle(ctx context.Context, circleID uint64, req *CircleLifecycleRequest) (*PreparedTxResponse, error) {
+	circle, err := s.getOwnedCircle(ctx, circleID, req.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLifecycleChange(circle, web3.MethodReactivateCircle, common.Address{}); err != nil {
+		return nil, err
+	}
+
+	unsignedTx, err := s.web3Svc.PrepareReactivateCircle(ctx, common.HexToAddress(req.FromAddress), new(big.Int).SetUint64(circle.ChainCircleID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare reactivation: %w", err)
+	}
+
+	return &PreparedTxResponse{
+		Transaction: unsignedTx,
+		Message:     "Sign the transaction with your wallet and submit it",
+	}, nil
+}
+
+// PrepareTransferCircleOwnership builds an unsigned transferCircleOwnership
+// transaction for the circle owner
+func (s *CircleService) PrepareTransferCircleOwnership(ctx context.Context, circleID uint64, req *TransferCircleOwnershipRequest) (*PreparedTxResponse, error) {
+	if !common.IsHexAddress(req.NewOwner) {
+		return nil, fmt.Errorf("invalid new owner address: %s", req.NewOwner)
+	}
+
+	circle, err := s.getOwnedCircle(ctx, circleID, req.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+	newOwner := common.HexToAddress(req.NewOwner)
+	if err := checkLifecycleChange(circle, web3.MethodTransferCircleOwnership, newOwner); err != nil {
+		return nil, err
+	}
+
+	unsignedTx, err := s.web3Svc.PrepareTransferCircleOwnership(ctx, common.HexToAddress(req.FromAddress), new(big.Int).SetUint64(circle.ChainCircleID), newOwner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare ownership transfer: %w", err)
+	}
+
+	return &PreparedTxResponse{
+		Transaction: unsignedTx,
+		Message:     "Sign the transaction with your wallet and submit it",
+	}, nil
+}
+
+// SubmitCircleLifecycle verifies and broadcasts a wallet-signed deactivate,
+// reactivate or ownership transfer transaction. The circle itself changes
+// once the indexer reaches the block the transaction is mined in.
+func (s *CircleService) SubmitCircleLifecycle(ctx context.Context, circleID uint64, req *SubmitCircleLifecycleRequest) (*CircleLifecycleResponse, error) {
+	circle, err := s.getOwnedCircle(ctx, circleID, req.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	from := common.HexToAddress(req.FromAddress)
+	signedTx, call, err := s.web3Svc.VerifyFactoryTx(req.SignedTx, from, web3.MethodDeactivateCircle, web3.MethodReactivateCircle, web3.MethodTransferCircleOwnership)
+	if err != nil {
+		return nil, err
+	}
+
+	// The calldata must change this circle, not another one the sender owns
+	chainID, ok := call.Args[0].(*big.Int)
+	if !ok || !chainID.IsUint64() || chainID.Uint64() != circle.ChainCircleID {
+		return nil, fmt.Errorf("%w: transaction does not change circle %d", web3.ErrInvalidTransaction, circle.ID)
+	}
+
+	var newOwner common.Address
+	if call.Method == web3.MethodTransferCircleOwnership {
+		newOwner, _ = call.Args[1].(common.Address)
+	}
+	if err := checkLifecycleChange(circle, call.Method, newOwner); err != nil {
+		return nil, err
+	}
+
+	if err := s.web3Svc.SendSignedTx(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	txType := lifecycleMethods[call.Method]
+	tx := &models.Transaction{
+		CircleID:     circle.ID,
+		TxHash:       signedTx.Hash().Hex(),
+		TxType:       txType,
+		FromAddress:  from.Hex(),
+		ToAddress:    signedTx.To().Hex(),
+		Amount:       "0",
+		Status:       "pending",
+		TokenAddress: circle.TokenAddress,
+		Timestamp:    time.Now(),
+	}
+
+	resp := &CircleLifecycleResponse{
+		CircleID: circle.ID,
+		TxHash:   tx.TxHash,
+		Action:   txType,
+		Message:  "Circle transaction submitted",
+	}
+
+	// The transaction is already broadcast, so failing the request would only
+	// invite a second one; the indexer applies the change if it mines
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		logger.Error("Failed to record circle transaction", "circle_id", circle.ID, "tx_hash", tx.TxHash, "error", err)
+		resp.Warning = "Transaction was broadcast but not recorded: " + err.Error()
+	}
+
+	return resp, nil
+}
+
+// GetCircleEvents retrieves the lifecycle audit trail of a circle, newest first
+func (s *CircleService) GetCircleEvents(ctx context.Context, circleID uint64, limit, offset int) ([]*models.CircleEvent, error) {
+	if _, err := s.getCircle(ctx, circleID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.GetByCircle(ctx, circleID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle events: %w", err)
+	}
+	return events, nil
+}
+
+// getCircle loads a circle, mapping a missing row to ErrCircleNotFound
+func (s *CircleService) getCircle(ctx context.Context, circleID uint64) (*models.Circle, error) {
+	circle, err := s.circleRepo.GetByID(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCircleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+	return circle, nil
+}
+
+// getOwnedCircle loads a circle confirmed on-chain and checks the sender owns it
+func (s *CircleService) getOwnedCircle(ctx context.Context, circleID uint64, fromAddress string) (*models.Circle, error) {
+	if !common.IsHexAddress(fromAddress) {
+		return nil, fmt.Errorf("invalid sender address: %s", fromAddress)
+	}
+
+	circle, err := s.getCircle(ctx, circleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if circle.ChainCircleID == 0 {
+		return nil, fmt.Errorf("%w: circle not yet confirmed on blockchain", ErrCircleStateConflict)
+	}
+	if !strings.EqualFold(circle.OwnerAddress, fromAddress) {
+		return nil, ErrNotCircleOwner
+	}
+
+	return circle, nil
+}
+
+// checkLifecycleChange applies the contract's preconditions so requests that
+// would revert are rejected before the user signs anything
+func checkLifecycleChange(circle *models.Circle, method string, newOwner common.Address) error {
+	switch method {
+	case web3.MethodDeactivateCircle:
+		if !circle.Active {
+			return fmt.Errorf("%w: circle is already inactive", ErrCircleStateConflict)
+		}
+	case web3.MethodReactivateCircle:
+		if circle.Active {
+			return fmt.Errorf("%w: circle is already active", ErrCircleStateConflict)
+		}
+	case web3.MethodTransferCircleOwnership:
+		if newOwner == (common.Address{}) {
+			return fmt.Errorf("invalid new owner address: %s", newOwner.Hex())
+		}
+		if strings.EqualFold(newOwner.Hex(), circle.OwnerAddress) {
+			return fmt.Errorf("%w: new owner already owns the circle", ErrCircleStateConflict)
+		}
+	}
+	return nil
+}
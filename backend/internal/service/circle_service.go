@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fast-socialfi/backend/internal/models"
@@ -20,6 +21,7 @@ type CircleService struct {
 	circleRepo *repository.CircleRepository
 	userRepo   *repository.UserRepository
 	txRepo     *repository.TransactionRepository
+	eventRepo  *repository.CircleEventRepository
 	web3Svc    *web3.Web3Service
 }
 
@@ -28,17 +30,19 @@ func NewCircleService(
 	circleRepo *repository.CircleRepository,
 	userRepo *repository.UserRepository,
 	txRepo *repository.TransactionRepository,
+	eventRepo *repository.CircleEventRepository,
 	web3Svc *web3.Web3Service,
 ) *CircleService {
 	return &CircleService{
 		circleRepo: circleRepo,
 		userRepo:   userRepo,
 		txRepo:     txRepo,
+		eventRepo:  eventRepo,
 		web3Svc:    web3Svc,
 	}
 }
 
-// CreateCircleRequest represents a request to create a circle
+// CreateCircleRequest represents a request to prepare a circle creation transaction
 type CreateCircleRequest struct {
 	Name        string   `json:"name" binding:"required,min=3,max=50"`
 	Symbol      string   `json:"symbol" binding:"required,min=2,max=10"`
@@ -48,7 +52,19 @@ type CreateCircleRequest struct {
 	K           *big.Int `json:"k"`
 	M           *big.Int `json:"m"`
 	N           *big.Int `json:"n"`
-	PrivateKey  string   `json:"private_key" binding:"required"`
+	FromAddress string   `json:"from_address"`
+}
+
+// SubmitCreateCircleRequest represents a wallet-signed circle creation transaction
+type SubmitCreateCircleRequest struct {
+	SignedTx    string `json:"signed_tx" binding:"required"`
+	FromAddress string `json:"from_address"`
+}
+
+// PrepareCreateCircleResponse represents an unsigned circle creation transaction
+type PrepareCreateCircleResponse struct {
+	Transaction *web3.UnsignedTx `json:"transaction"`
+	Message     string           `json:"message"`
 }
 
 // CreateCircleResponse represents the response for circle creation
@@ -59,15 +75,18 @@ type CreateCircleResponse struct {
 	Message       string `json:"message"`
 }
 
-// CreateCircle creates a new circle
-func (s *CircleService) CreateCircle(ctx context.Context, req *CreateCircleRequest) (*CreateCircleResponse, error) {
+// PrepareCreateCircle builds an unsigned circle creation transaction for the user's wallet
+func (s *CircleService) PrepareCreateCircle(ctx context.Context, req *CreateCircleRequest) (*PrepareCreateCircleResponse, error) {
+	if !common.IsHexAddress(req.FromAddress) {
+		return nil, fmt.Errorf("invalid sender address: %s", req.FromAddress)
+	}
+
 	// Validate curve type parameters
 	if err := s.validateCurveParams(req.CurveType, req.K, req.M, req.N); err != nil {
 		return nil, fmt.Errorf("invalid curve parameters: %w", err)
 	}
 
-	// Create circle on blockchain
-	txHash, err := s.web3Svc.CreateCircle(ctx, req.PrivateKey, web3.CreateCircleParams{
+	unsignedTx, err := s.web3Svc.PrepareCreateCircle(ctx, common.HexToAddress(req.FromAddress), web3.CreateCircleParams{
 		Name:        req.Name,
 		Symbol:      req.Symbol,
 		Description: req.Description,
@@ -78,23 +97,77 @@ func (s *CircleService) CreateCircle(ctx context.Context, req *CreateCircleReque
 		N:           req.N,
 	})
 	if err != nil {
+		return nil, fmt.Errorf("failed to prepare circle creation: %w", err)
+	}
+
+	return &PrepareCreateCircleResponse{
+		Transaction: unsignedTx,
+		Message:     "Sign the transaction with your wallet and submit it",
+	}, nil
+}
+
+// SubmitCreateCircle verifies and broadcasts a wallet-signed circle creation transaction
+func (s *CircleService) SubmitCreateCircle(ctx context.Context, req *SubmitCreateCircleRequest) (*CreateCircleResponse, error) {
+	if !common.IsHexAddress(req.FromAddress) {
+		return nil, fmt.Errorf("invalid sender address: %s", req.FromAddress)
+	}
+
+	from := common.HexToAddress(req.FromAddress)
+	signedTx, call, err := s.web3Svc.VerifyFactoryTx(req.SignedTx, from, web3.MethodCreateCircle)
+	if err != nil {
+		return nil, err
+	}
+
+	// Arguments follow the createCircle ABI: name, symbol, description, curveType, basePrice, k, m, n
+	name, _ := call.Args[0].(string)
+	symbol, _ := call.Args[1].(string)
+	description, _ := call.Args[2].(string)
+	curveType, _ := call.Args[3].(uint8)
+	k, _ := call.Args[5].(*big.Int)
+	m, _ := call.Args[6].(*big.Int)
+	n, _ := call.Args[7].(*big.Int)
+
+	if err := s.validateCurveParams(curveType, k, m, n); err != nil {
+		return nil, fmt.Errorf("%w: invalid curve parameters: %v", web3.ErrInvalidTransaction, err)
+	}
+
+	if err := s.web3Svc.SendSignedTx(ctx, signedTx); err != nil {
 		return nil, fmt.Errorf("failed to create circle on blockchain: %w", err)
 	}
 
+	txHash := signedTx.Hash().Hex()
+
 	// Store in database (pending state)
 	circle := &models.Circle{
-		Name:        req.Name,
-		Symbol:      req.Symbol,
-		Description: req.Description,
-		CurveType:   req.CurveType,
-		Status:      "pending",
-		TxHash:      txHash,
+		OwnerAddress: from.Hex(),
+		Name:         name,
+		Symbol:       symbol,
+		Description:  description,
+		CurveType:    curveType,
+		Status:       "pending",
+		TxHash:       txHash,
 	}
 
 	if err := s.circleRepo.Create(ctx, circle); err != nil {
 		return nil, fmt.Errorf("failed to store circle: %w", err)
 	}
 
+	tx := &models.Transaction{
+		CircleID:    circle.ID,
+		TxHash:      txHash,
+		TxType:      "create_circle",
+		FromAddress: from.Hex(),
+		ToAddress:   signedTx.To().Hex(),
+		Amount:      signedTx.Value().String(),
+		Status:      "pending",
+		Timestamp:   time.Now(),
+	}
+
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		// Log error but don't fail
+		fmt.Printf("failed to record transaction: %v\n", err)
+	}
+
 	return &CreateCircleResponse{
 		CircleID: circle.ID,
 		TxHash:   txHash,
@@ -180,7 +253,7 @@ func (s *CircleService) UpdateCircleFromBlockchain(ctx context.Context, circleID
 	// Update database
 	circle.OwnerAddress = chainCircle.Owner.Hex()
 	circle.TokenAddress = chainCircle.TokenAddress.Hex()
-	circle.BondingCurveAddress = chainCircle.BondingCurve.Hex()
+	circle.BondingCurveAddress = chainCircle.BondingCurveAddress.Hex()
 	circle.Active = chainCircle.Active
 	circle.Status = "confirmed"
 
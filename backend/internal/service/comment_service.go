@@ -0,0 +1,473 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultCommentDepth is how many levels of a thread are loaded when a
+	// request does not say
+	defaultCommentDepth = 3
+	// maxCommentDepth caps how many levels one request may load
+	maxCommentDepth = 10
+	// defaultCommentPageSize is how many comments the first level holds
+	defaultCommentPageSize = 20
+	// maxCommentPageSize caps the first level
+	maxCommentPageSize = 100
+	// defaultReplyPageSize is how many replies each deeper branch holds
+	defaultReplyPageSize = 5
+	// maxReplyPageSize caps each deeper branch
+	maxReplyPageSize = 50
+	// maxCommentLength bounds a comment in characters
+	maxCommentLength = 10000
+)
+
+var (
+	// ErrCommentNotFound is returned when a comment does not exist, belongs
+	// to another post or, when replying, was deleted
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrNotCommentAuthor is returned when someone other than the author, or
+	// a moderator of the post's circle, tries to delete a comment
+	ErrNotCommentAuthor = errors.New("user may not change the comment")
+	// ErrInvalidComment is returned for a malformed comment or thread query
+	ErrInvalidComment = errors.New("invalid comment")
+)
+
+// CommentService manages threaded comments on posts
+type CommentService struct {
+	commentRepo   *repository.CommentRepository
+	postRepo      *repository.PostRepository
+	userRepo      *repository.UserRepository
+	relRepo       *repository.RelationshipRepository
+	membershipSvc *MembershipService
+}
+
+// NewCommentService creates a new comment service
+func NewCommentService(
+	commentRepo *repository.CommentRepository,
+	postRepo *repository.PostRepository,
+	userRepo *repository.UserRepository,
+	relRepo *repository.RelationshipRepository,
+	membershipSvc *MembershipService,
+) *CommentService {
+	return &CommentService{
+		commentRepo:   commentRepo,
+		postRepo:      postRepo,
+		userRepo:      userRepo,
+		relRepo:       relRepo,
+		membershipSvc: membershipSvc,
+	}
+}
+
+// CreateCommentRequest represents a comment on a post, or a reply to one of
+// its comments
+type CreateCommentRequest struct {
+	Content         string  `json:"content" binding:"required"`
+	ParentCommentID *uint64 `json:"parent_comment_id"`
+}
+
+// CommentQuery selects part of a post's comment tree. Depth is how many
+// levels to load, Limit how many comments the first level holds and Replies
+// how many each deeper branch holds. Cursor is a NextCursor or MoreReplies
+// token from an earlier response and continues that branch. Viewer is the
+// wallet reading the thread, if known, whose blocks hide comments.
+type CommentQuery struct {
+	Sort    string
+	Depth   int
+	Limit   int
+	Replies int
+	Cursor  string
+	Viewer  string
+}
+
+// CommentNode is a comment with the replies loaded beneath it. Deleted
+// comments, and hidden comments by users the viewer blocks or is blocked by,
+// keep their place in the tree without their content or author.
+// MoreReplies continues the branch when ReplyCount is more than the replies
+// loaded, including when the depth limit stopped loading altogether.
+type CommentNode struct {
+	CommentID       uint64         `json:"comment_id"`
+	PostID          uint64         `json:"post_id"`
+	ParentCommentID *uint64        `json:"parent_comment_id"`
+	Author          string         `json:"author,omitempty"`
+	Content         string         `json:"content"`
+	Upvotes         uint           `json:"upvotes"`
+	IsDeleted       bool           `json:"is_deleted"`
+	IsHidden        bool           `json:"is_hidden,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ReplyCount      int            `json:"reply_count"`
+	Replies         []*CommentNode `json:"replies"`
+	MoreReplies     string         `json:"more_replies,omitempty"`
+}
+
+func newCommentNode(comment *models.Comment) *CommentNode {
+	node := &CommentNode{
+		CommentID:       comment.CommentID,
+		PostID:          comment.PostID,
+		ParentCommentID: comment.ParentCommentID,
+		Upvotes:         comment.Upvotes,
+		IsDeleted:       comment.IsDeleted,
+		CreatedAt:       comment.CreatedAt,
+		Replies:         []*CommentNode{},
+	}
+	if !comment.IsDeleted {
+		node.Author = comment.Author.WalletAddress
+		node.Content = comment.Content
+	}
+	return node
+}
+
+// threadNode is newCommentNode for a thread being read, hiding the comment
+// if its author is among the hidden users
+func threadNode(comment *models.Comment, hidden map[uint64]bool) *CommentNode {
+	node := newCommentNode(comment)
+	if hidden[comment.AuthorID] && !comment.IsDeleted {
+		node.Author, node.Content, node.IsHidden = "", "", true
+	}
+	return node
+}
+
+// CommentThreadResponse is a level of a comment tree with the replies
+// loaded beneath it
+type CommentThreadResponse struct {
+	Comments   []*CommentNode `json:"comments"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// AddComment comments on a post or replies to one of its comments. Comments
+// on circle posts need the right to post in the circle.
+func (s *CommentService) AddComment(ctx context.Context, postID uint64, authorAddress string, req *CreateCommentRequest) (*CommentNode, error) {
+	if !common.IsHexAddress(authorAddress) {
+		return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidComment, authorAddress)
+	}
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, fmt.Errorf("%w: content is required", ErrInvalidComment)
+	}
+	if utf8.RuneCountInString(content) > maxCommentLength {
+		return nil, fmt.Errorf("%w: content is longer than %d characters", ErrInvalidComment, maxCommentLength)
+	}
+
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentCommentID != nil {
+		parent, err := s.getComment(ctx, post.PostID, *req.ParentCommentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.IsDeleted {
+			return nil, ErrCommentNotFound
+		}
+	}
+	if post.CircleID != nil {
+		if _, err := s.membershipSvc.AuthorizePost(ctx, *post.CircleID, authorAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	author, err := s.userRepo.GetOrCreateByAddress(ctx, common.HexToAddress(authorAddress).Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	comment := &models.Comment{
+		PostID:          post.PostID,
+		AuthorID:        author.UserID,
+		ParentCommentID: req.ParentCommentID,
+		Content:         content,
+		Author:          *author,
+	}
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+	return newCommentNode(comment), nil
+}
+
+// GetComments loads a post's comment tree, or continues a branch of it when
+// the query carries a cursor. Comments by users the viewer blocks, or is
+// blocked by, are hidden.
+func (s *CommentService) GetComments(ctx context.Context, postID uint64, query *CommentQuery) (*CommentThreadResponse, error) {
+	depth := clampPageSize(query.Depth, defaultCommentDepth, maxCommentDepth)
+	limit := clampPageSize(query.Limit, defaultCommentPageSize, maxCommentPageSize)
+	replies := clampPageSize(query.Replies, defaultReplyPageSize, maxReplyPageSize)
+
+	sort := strings.ToLower(query.Sort)
+	switch sort {
+	case "":
+		sort = repository.CommentSortTop
+	case repository.CommentSortTop, repository.CommentSortNew:
+	default:
+		return nil, fmt.Errorf("%w: sort must be top or new", ErrInvalidComment)
+	}
+
+	var parentID uint64
+	var after *repository.CommentCursor
+	if query.Cursor != "" {
+		cursor, err := decodeCommentCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		// A branch continues in the order it was loaded in
+		parentID, sort, after = cursor.parentID, cursor.sort, cursor.after
+	}
+
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != 0 {
+		if _, err := s.getComment(ctx, post.PostID, parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	hidden, err := s.hiddenAuthors(ctx, query.Viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra row tells whether another page follows
+	comments, err := s.commentRepo.List(ctx, post.PostID, parentID, sort, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	resp := &CommentThreadResponse{Comments: []*CommentNode{}}
+	if len(comments) > limit {
+		comments = comments[:limit]
+		last := comments[limit-1]
+		resp.NextCursor = encodeCommentCursor(parentID, sort, &repository.CommentCursor{Upvotes: last.Upvotes, CommentID: last.CommentID})
+	}
+	for _, comment := range comments {
+		resp.Comments = append(resp.Comments, threadNode(comment, hidden))
+	}
+
+	if err := s.loadReplies(ctx, resp.Comments, sort, depth, replies, hidden); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteComment soft-deletes a comment. Its replies stay in place. The
+// author and moderators of the post's circle may delete it.
+func (s *CommentService) DeleteComment(ctx context.Context, commentID uint64, userAddress string) error {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrCommentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment.IsDeleted {
+		return ErrCommentNotFound
+	}
+
+	if !strings.EqualFold(comment.Author.WalletAddress, userAddress) {
+		post, err := s.postRepo.GetByID(ctx, comment.PostID)
+		if err != nil {
+			return fmt.Errorf("failed to get post: %w", err)
+		}
+		if post.CircleID == nil {
+			return ErrNotCommentAuthor
+		}
+		moderator, err := s.membershipSvc.IsModerator(ctx, *post.CircleID, userAddress)
+		if err != nil {
+			return err
+		}
+		if !moderator {
+			return ErrNotCommentAuthor
+		}
+	}
+
+	deleted, err := s.commentRepo.SoftDelete(ctx, comment)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if !deleted {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+// loadReplies fills in the replies beneath a level of the tree, one query
+// per level, until depth levels are loaded. Branches cut short by the page
+// size or the depth limit get a MoreReplies cursor.
+func (s *CommentService) loadReplies(ctx context.Context, level []*CommentNode, sort string, depth, perBranch int, hidden map[uint64]bool) error {
+	for d := 1; len(level) > 0; d++ {
+		ids := make([]uint64, 0, len(level))
+		for _, node := range level {
+			ids = append(ids, node.CommentID)
+		}
+		counts, err := s.commentRepo.CountReplies(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to count replies: %w", err)
+		}
+
+		var children []*models.Comment
+		if d < depth {
+			children, err = s.commentRepo.ListReplies(ctx, ids, sort, perBranch)
+			if err != nil {
+				return fmt.Errorf("failed to get replies: %w", err)
+			}
+		}
+
+		byID := make(map[uint64]*CommentNode, len(level))
+		for _, node := range level {
+			byID[node.CommentID] = node
+		}
+		next := make([]*CommentNode, 0, len(children))
+		for _, child := range children {
+			parent := byID[*child.ParentCommentID]
+			node := threadNode(child, hidden)
+			parent.Replies = append(parent.Replies, node)
+			next = append(next, node)
+		}
+		for _, node := range level {
+			node.ReplyCount = counts[node.CommentID]
+			if node.ReplyCount > len(node.Replies) {
+				var after *repository.CommentCursor
+				if loaded := len(node.Replies); loaded > 0 {
+					tail := node.Replies[loaded-1]
+					after = &repository.CommentCursor{Upvotes: tail.Upvotes, CommentID: tail.CommentID}
+				}
+				node.MoreReplies = encodeCommentCursor(node.CommentID, sort, after)
+			}
+		}
+		level = next
+	}
+	return nil
+}
+
+// hiddenAuthors finds the users whose comments are hidden from a viewer: the
+// users the viewer blocks or is blocked by
+func (s *CommentService) hiddenAuthors(ctx context.Context, viewerAddress string) (map[uint64]bool, error) {
+	hidden := make(map[uint64]bool)
+	if !common.IsHexAddress(viewerAddress) {
+		return hidden, nil
+	}
+	viewer, err := s.userRepo.GetByAddress(ctx, common.HexToAddress(viewerAddress).Hex())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return hidden, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	ids, err := s.relRepo.BlockedUserIDs(ctx, viewer.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+	for _, id := range ids {
+		hidden[id] = true
+	}
+	return hidden, nil
+}
+
+// getPost loads an undeleted post
+func (s *CommentService) getPost(ctx context.Context, postID uint64) (*models.Post, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post.IsDeleted {
+		return nil, ErrPostNotFound
+	}
+	return post, nil
+}
+
+// getComment loads a comment of the given post, deleted or not
+func (s *CommentService) getComment(ctx context.Context, postID, commentID uint64) (*models.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment.PostID != postID {
+		return nil, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// commentCursor is a decoded branch cursor
+type commentCursor struct {
+	parentID uint64
+	sort     string
+	after    *repository.CommentCursor
+}
+
+// encodeCommentCursor renders a position in a branch as an opaque page
+// token. A nil position starts the branch from the top.
+func encodeCommentCursor(parentID uint64, sort string, after *repository.CommentCursor) string {
+	raw := strconv.FormatUint(parentID, 10) + ":" + sort
+	if after != nil {
+		raw += ":" + strconv.FormatUint(uint64(after.Upvotes), 10) + ":" + strconv.FormatUint(after.CommentID, 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCommentCursor parses a page token from encodeCommentCursor
+func decodeCommentCursor(token string) (*commentCursor, error) {
+	malformed := fmt.Errorf("%w: malformed cursor", ErrInvalidComment)
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, malformed
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 2 && len(parts) != 4 {
+		return nil, malformed
+	}
+
+	cursor := &commentCursor{sort: parts[1]}
+	if cursor.sort != repository.CommentSortTop && cursor.sort != repository.CommentSortNew {
+		return nil, malformed
+	}
+	if cursor.parentID, err = strconv.ParseUint(parts[0], 10, 64); err != nil {
+		return nil, malformed
+	}
+	if len(parts) == 4 {
+		upvotes, err := strconv.ParseUint(parts[2], 10, 32)
+		if err != nil {
+			return nil, malformed
+		}
+		commentID, err := strconv.ParseUint(parts[3], 10, 64)
+		if err != nil {
+			return nil, malformed
+		}
+		cursor.after = &repository.CommentCursor{Upvotes: uint(upvotes), CommentID: commentID}
+	}
+	return cursor, nil
+}
+
+// clampPageSize applies a default to an unset size and caps it
+func clampPageSize(size, fallback, limit int) int {
+	if size <= 0 {
+		return fallback
+	}
+	if size > limit {
+		return limit
+	}
+	return size
+}
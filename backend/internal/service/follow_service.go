@@ -0,0 +1,274 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultFollowPageSize is the page size of follow lists when a request
+	// does not set one
+	defaultFollowPageSize = 20
+	// maxFollowPageSize caps how many users one page of a follow list holds
+	maxFollowPageSize = 100
+)
+
+var (
+	// ErrUserNotFound is returned when a wallet has no profile
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidFollow is returned for a malformed address or cursor, or for
+	// following or blocking oneself
+	ErrInvalidFollow = errors.New("invalid follow")
+	// ErrUserBlocked is returned when following a user one blocks or is
+	// blocked by
+	ErrUserBlocked = errors.New("user is blocked")
+)
+
+// FollowService manages the follow graph: follows, blocks and the follower
+// lists built from them
+type FollowService struct {
+	relRepo  *repository.RelationshipRepository
+	userRepo *repository.UserRepository
+}
+
+// NewFollowService creates a new follow service
+func NewFollowService(relRepo *repository.RelationshipRepository, userRepo *repository.UserRepository) *FollowService {
+	return &FollowService{
+		relRepo:  relRepo,
+		userRepo: userRepo,
+	}
+}
+
+// FollowQuery pages a follow list. Cursor is the NextCursor of the previous
+// page.
+type FollowQuery struct {
+	Cursor string
+	Limit  int
+}
+
+// RelationshipResponse is how a user stands toward another after following,
+// unfollowing, blocking or unblocking them, with the other user's counts
+type RelationshipResponse struct {
+	Address        string `json:"address"`
+	Following      bool   `json:"following"`
+	FollowedBy     bool   `json:"followed_by"`
+	Blocking       bool   `json:"blocking"`
+	FollowerCount  uint   `json:"follower_count"`
+	FollowingCount uint   `json:"following_count"`
+}
+
+// FollowUser is a user on a follow list and when the follow began
+type FollowUser struct {
+	*models.User
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// FollowListResponse is a page of a follow list
+type FollowListResponse struct {
+	Users      []*FollowUser `json:"users"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// Follow makes one user follow another and notifies the followed user.
+// Following a user again changes nothing. Users who block each other, in
+// either direction, cannot follow each other.
+func (s *FollowService) Follow(ctx context.Context, followerAddress, address string) (*RelationshipResponse, error) {
+	follower, target, err := s.resolvePair(ctx, followerAddress, address, "follow")
+	if err != nil {
+		return nil, err
+	}
+
+	blocked, err := s.relRepo.IsBlocked(ctx, follower.UserID, target.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocks: %w", err)
+	}
+	if blocked {
+		return nil, ErrUserBlocked
+	}
+
+	content := fmt.Sprintf("%s started following you", follower.WalletAddress)
+	notification := &models.Notification{
+		UserID:           target.UserID,
+		NotificationType: "NEW_FOLLOWER",
+		Title:            "You have a new follower",
+		Content:          &content,
+		RelatedUserID:    &follower.UserID,
+	}
+	if _, err := s.relRepo.Follow(ctx, follower.UserID, target.UserID, notification); err != nil {
+		return nil, fmt.Errorf("failed to follow user: %w", err)
+	}
+	return s.relationship(ctx, follower, target)
+}
+
+// Unfollow stops one user following another. Unfollowing a user one does not
+// follow changes nothing.
+func (s *FollowService) Unfollow(ctx context.Context, followerAddress, address string) (*RelationshipResponse, error) {
+	follower, target, err := s.resolvePair(ctx, followerAddress, address, "unfollow")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.relRepo.Unfollow(ctx, follower.UserID, target.UserID); err != nil {
+		return nil, fmt.Errorf("failed to unfollow user: %w", err)
+	}
+	return s.relationship(ctx, follower, target)
+}
+
+// Block makes one user block another, severing the follows between them in
+// both directions. Until unblocked, neither can follow the other and each is
+// hidden from the other's feeds and comment threads.
+func (s *FollowService) Block(ctx context.Context, blockerAddress, address string) (*RelationshipResponse, error) {
+	blocker, target, err := s.resolvePair(ctx, blockerAddress, address, "block")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.relRepo.Block(ctx, blocker.UserID, target.UserID); err != nil {
+		return nil, fmt.Errorf("failed to block user: %w", err)
+	}
+	return s.relationship(ctx, blocker, target)
+}
+
+// Unblock lifts one user's block of another. Severed follows stay severed.
+func (s *FollowService) Unblock(ctx context.Context, blockerAddress, address string) (*RelationshipResponse, error) {
+	blocker, target, err := s.resolvePair(ctx, blockerAddress, address, "unblock")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.relRepo.Unblock(ctx, blocker.UserID, target.UserID); err != nil {
+		return nil, fmt.Errorf("failed to unblock user: %w", err)
+	}
+	return s.relationship(ctx, blocker, target)
+}
+
+// ListFollowers retrieves a page of a wallet's followers, most recent first
+func (s *FollowService) ListFollowers(ctx context.Context, address string, query *FollowQuery) (*FollowListResponse, error) {
+	return s.list(ctx, address, query, s.relRepo.ListFollowers)
+}
+
+// ListFollowing retrieves a page of the users a wallet follows, most recent
+// first
+func (s *FollowService) ListFollowing(ctx context.Context, address string, query *FollowQuery) (*FollowListResponse, error) {
+	return s.list(ctx, address, query, s.relRepo.ListFollowing)
+}
+
+// ListMutuals retrieves a page of the users a wallet follows who follow it
+// back, most recently followed first
+func (s *FollowService) ListMutuals(ctx context.Context, address string, query *FollowQuery) (*FollowListResponse, error) {
+	return s.list(ctx, address, query, s.relRepo.ListMutuals)
+}
+
+// list pages a follow list of a wallet. Wallets without a profile have empty
+// lists.
+func (s *FollowService) list(
+	ctx context.Context,
+	address string,
+	query *FollowQuery,
+	fetch func(ctx context.Context, userID, beforeID uint64, limit int) ([]*repository.FollowEntry, error),
+) (*FollowListResponse, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidFollow, address)
+	}
+	limit := clampPageSize(query.Limit, defaultFollowPageSize, maxFollowPageSize)
+
+	var beforeID uint64
+	if query.Cursor != "" {
+		id, err := strconv.ParseUint(query.Cursor, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidFollow)
+		}
+		beforeID = id
+	}
+
+	resp := &FollowListResponse{Users: []*FollowUser{}}
+	user, err := s.userRepo.GetByAddress(ctx, common.HexToAddress(address).Hex())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// One extra row tells whether another page follows
+	entries, err := fetch(ctx, user.UserID, beforeID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follows: %w", err)
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		resp.NextCursor = strconv.FormatUint(entries[limit-1].RelationshipID, 10)
+	}
+	for _, entry := range entries {
+		user := entry.User
+		resp.Users = append(resp.Users, &FollowUser{User: &user, FollowedAt: entry.FollowedAt})
+	}
+	return resp, nil
+}
+
+// resolvePair loads the user acting, creating a bare profile for a new
+// wallet, and the user acted on, who must have a profile and be someone else
+func (s *FollowService) resolvePair(ctx context.Context, actorAddress, address, action string) (*models.User, *models.User, error) {
+	if !common.IsHexAddress(actorAddress) {
+		return nil, nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidFollow, actorAddress)
+	}
+	if !common.IsHexAddress(address) {
+		return nil, nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidFollow, address)
+	}
+	if common.HexToAddress(actorAddress) == common.HexToAddress(address) {
+		return nil, nil, fmt.Errorf("%w: users cannot %s themselves", ErrInvalidFollow, action)
+	}
+
+	target, err := s.userRepo.GetByAddress(ctx, common.HexToAddress(address).Hex())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	actor, err := s.userRepo.GetOrCreateByAddress(ctx, common.HexToAddress(actorAddress).Hex())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return actor, target, nil
+}
+
+// relationship reports how a user now stands toward another, rereading the
+// other user's counts
+func (s *FollowService) relationship(ctx context.Context, user, other *models.User) (*RelationshipResponse, error) {
+	other, err := s.userRepo.GetByAddress(ctx, other.WalletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	resp := &RelationshipResponse{
+		Address:        other.WalletAddress,
+		FollowerCount:  other.FollowerCount,
+		FollowingCount: other.FollowingCount,
+	}
+
+	checks := []struct {
+		from, to         uint64
+		relationshipType string
+		result           *bool
+	}{
+		{user.UserID, other.UserID, models.RelationshipFollows, &resp.Following},
+		{other.UserID, user.UserID, models.RelationshipFollows, &resp.FollowedBy},
+		{user.UserID, other.UserID, models.RelationshipBlocks, &resp.Blocking},
+	}
+	for _, check := range checks {
+		*check.result, err = s.relRepo.Exists(ctx, check.from, check.relationshipType, check.to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get relationship: %w", err)
+		}
+	}
+	return resp, nil
+}
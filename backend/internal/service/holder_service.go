@@ -0,0 +1,87 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// HolderService serves the per-circle cap table built by the indexer
+type HolderService struct {
+	circleRepo *repository.CircleRepository
+	holderRepo *repository.HolderRepository
+}
+
+// NewHolderService creates a new holder service
+func NewHolderService(circleRepo *repository.CircleRepository, holderRepo *repository.HolderRepository) *HolderService {
+	return &HolderService{
+		circleRepo: circleRepo,
+		holderRepo: holderRepo,
+	}
+}
+
+// HolderEntry is a holder's position with its share of the circle's supply
+type HolderEntry struct {
+	*models.CircleHolder
+	Share float64 `json:"share"`
+}
+
+// HoldersResponse represents a page of a circle's cap table
+type HoldersResponse struct {
+	CircleID        uint64         `json:"circle_id"`
+	TotalSupply     string         `json:"total_supply"`
+	HolderCount     int            `json:"holder_count"`
+	Top10Share      float64        `json:"top10_share"`
+	GiniCoefficient float64        `json:"gini_coefficient"`
+	Holders         []*HolderEntry `json:"holders"`
+	Limit           int            `json:"limit"`
+	Offset          int            `json:"offset"`
+}
+
+// GetTopHolders retrieves a page of a circle's holders, largest first, along
+// with the circle's supply and concentration metrics
+func (s *HolderService) GetTopHolders(ctx context.Context, circleID uint64, limit, offset int) (*HoldersResponse, error) {
+	circle, err := s.circleRepo.GetByID(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCircleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+
+	holders, err := s.holderRepo.GetTopByCircle(ctx, circle.ID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holders: %w", err)
+	}
+
+	supply, _ := new(big.Float).SetString(circle.TotalSupply)
+	entries := make([]*HolderEntry, 0, len(holders))
+	for _, holder := range holders {
+		entry := &HolderEntry{CircleHolder: holder}
+		balance, ok := new(big.Float).SetString(holder.Balance)
+		if ok && supply != nil && supply.Sign() > 0 {
+			entry.Share, _ = balance.Quo(balance, supply).Float64()
+		}
+		entries = append(entries, entry)
+	}
+
+	return &HoldersResponse{
+		CircleID:        circle.ID,
+		TotalSupply:     circle.TotalSupply,
+		HolderCount:     circle.HolderCount,
+		Top10Share:      circle.Top10Share,
+		GiniCoefficient: circle.GiniCoefficient,
+		Holders:         entries,
+		Limit:           limit,
+		Offset:          offset,
+	}, nil
+}
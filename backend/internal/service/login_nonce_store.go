@@ -0,0 +1,87 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/database"
+)
+
+// LoginNonceStore keeps the sign-in nonces handed out to wallets. A nonce can
+// be consumed once, and only before its TTL runs out.
+type LoginNonceStore interface {
+	Save(ctx context.Context, nonce string, ttl time.Duration) error
+	// Consume removes the nonce and reports whether it was still outstanding
+	Consume(ctx context.Context, nonce string) (bool, error)
+}
+
+// MemoryLoginNonceStore keeps nonces in process. A login must be verified by
+// the replica that issued its nonce.
+type MemoryLoginNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+}
+
+// NewMemoryLoginNonceStore creates an in-process login nonce store
+func NewMemoryLoginNonceStore() *MemoryLoginNonceStore {
+	return &MemoryLoginNonceStore{nonces: make(map[string]time.Time)}
+}
+
+// Save stores a nonce and drops the expired ones
+func (s *MemoryLoginNonceStore) Save(ctx context.Context, nonce string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for n, expiresAt := range s.nonces {
+		if now.After(expiresAt) {
+			delete(s.nonces, n)
+		}
+	}
+	s.nonces[nonce] = now.Add(ttl)
+	return nil
+}
+
+// Consume removes a nonce
+func (s *MemoryLoginNonceStore) Consume(ctx context.Context, nonce string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.nonces[nonce]
+	if !ok {
+		return false, nil
+	}
+	delete(s.nonces, nonce)
+	return time.Now().Before(expiresAt), nil
+}
+
+// RedisLoginNonceStore shares nonces through Redis so any API replica can
+// verify a login
+type RedisLoginNonceStore struct {
+	client *database.RedisClient
+}
+
+// NewRedisLoginNonceStore creates a Redis-backed login nonce store
+func NewRedisLoginNonceStore(client *database.RedisClient) *RedisLoginNonceStore {
+	return &RedisLoginNonceStore{client: client}
+}
+
+// Save writes a nonce that Redis expires after the TTL
+func (s *RedisLoginNonceStore) Save(ctx context.Context, nonce string, ttl time.Duration) error {
+	return s.client.Set(ctx, "siwe:nonce:"+nonce, 1, ttl)
+}
+
+// Consume deletes the nonce key. DEL is atomic, so when two requests race
+// with the same nonce only one of them sees it removed.
+func (s *RedisLoginNonceStore) Consume(ctx context.Context, nonce string) (bool, error) {
+	removed, err := s.client.Client.Del(ctx, "siwe:nonce:"+nonce).Result()
+	if err != nil {
+		return false, err
+	}
+	return removed == 1, nil
+}
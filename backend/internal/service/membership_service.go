@@ -0,0 +1,316 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/membership"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotCircleMember is returned when the caller has not joined the circle
+	ErrNotCircleMember = errors.New("user is not a member of the circle")
+	// ErrAlreadyCircleMember is returned when joining a circle twice
+	ErrAlreadyCircleMember = errors.New("user is already a member of the circle")
+	// ErrMembershipRequirement is returned when the caller's token holding
+	// does not satisfy the circle's membership rule
+	ErrMembershipRequirement = errors.New("token holding does not meet the circle's membership rule")
+	// ErrPostingRestricted is returned when a moderator has muted the member
+	ErrPostingRestricted = errors.New("member is not allowed to post in the circle")
+	// ErrInvalidMembershipRule is returned for a malformed minimum balance
+	ErrInvalidMembershipRule = errors.New("invalid membership rule")
+)
+
+// MembershipService gates circle membership and posting on the member's
+// on-chain balance of the circle token
+type MembershipService struct {
+	circleRepo *repository.CircleRepository
+	userRepo   *repository.UserRepository
+	memberRepo *repository.CircleMemberRepository
+	ruleRepo   *repository.MembershipRuleRepository
+	web3Svc    *web3.Web3Service
+}
+
+// NewMembershipService creates a new membership service
+func NewMembershipService(
+	circleRepo *repository.CircleRepository,
+	userRepo *repository.UserRepository,
+	memberRepo *repository.CircleMemberRepository,
+	ruleRepo *repository.MembershipRuleRepository,
+	web3Svc *web3.Web3Service,
+) *MembershipService {
+	return &MembershipService{
+		circleRepo: circleRepo,
+		userRepo:   userRepo,
+		memberRepo: memberRepo,
+		ruleRepo:   ruleRepo,
+		web3Svc:    web3Svc,
+	}
+}
+
+// SetMembershipRuleRequest represents an owner's change to a circle's
+// membership rule. MinBalance is in whole tokens, e.g. "10.5".
+type SetMembershipRuleRequest struct {
+	MinBalance     string `json:"min_balance" binding:"required"`
+	MinHoldSeconds uint64 `json:"min_hold_seconds"`
+	FromAddress    string `json:"from_address"`
+}
+
+// GetMembershipRule retrieves a circle's membership rule. Circles without a
+// rule get a zero rule, which lets anyone join and post.
+func (s *MembershipService) GetMembershipRule(ctx context.Context, circleID uint64) (*models.CircleMembershipRule, error) {
+	if _, err := s.getCircle(ctx, circleID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.getRule(ctx, circleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		rule = &models.CircleMembershipRule{CircleID: circleID, MinBalance: "0"}
+	}
+	return rule, nil
+}
+
+// SetMembershipRule replaces a circle's membership rule and re-applies it to
+// every member's cached balance, so members who no longer qualify become
+// read-only straight away
+func (s *MembershipService) SetMembershipRule(ctx context.Context, circleID uint64, req *SetMembershipRuleRequest) (*models.CircleMembershipRule, error) {
+	minBalance, err := web3.ParseUnits(req.MinBalance, 18)
+	if err != nil || minBalance.Sign() < 0 {
+		return nil, fmt.Errorf("%w: invalid minimum balance %q", ErrInvalidMembershipRule, req.MinBalance)
+	}
+
+	circle, err := s.getCircle(ctx, circleID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(circle.OwnerAddress, req.FromAddress) {
+		return nil, ErrNotCircleOwner
+	}
+
+	rule := &models.CircleMembershipRule{
+		CircleID:       circle.ID,
+		MinBalance:     web3.FormatUnits(minBalance, 18),
+		MinHoldSeconds: req.MinHoldSeconds,
+	}
+	if err := s.ruleRepo.Save(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save membership rule: %w", err)
+	}
+
+	members, err := s.memberRepo.GetByCircle(ctx, circle.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load circle members: %w", err)
+	}
+	now := time.Now().UTC()
+	for _, member := range members {
+		balance, err := web3.ParseUnits(member.TokenBalance, 18)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cached balance for member %d: %w", member.UserID, err)
+		}
+		membership.ApplyBalance(member, rule, balance, member.BalanceBlock, now)
+		if err := s.memberRepo.SaveBalance(ctx, member); err != nil {
+			return nil, fmt.Errorf("failed to update member %d: %w", member.UserID, err)
+		}
+	}
+
+	return rule, nil
+}
+
+// JoinCircle makes the user a member of a circle. The user's token balance is
+// read on-chain and must meet the circle's minimum; members still inside the
+// hold period join read-only and can post once it has passed.
+func (s *MembershipService) JoinCircle(ctx context.Context, circleID uint64, userAddress string) (*models.UserCircleRelationship, error) {
+	if !common.IsHexAddress(userAddress) {
+		return nil, fmt.Errorf("invalid wallet address: %s", userAddress)
+	}
+
+	circle, err := s.getCircle(ctx, circleID)
+	if err != nil {
+		return nil, err
+	}
+	if circle.TokenAddress == "" || !circle.Active {
+		return nil, fmt.Errorf("%w: circle is not open for new members", ErrCircleStateConflict)
+	}
+
+	user, err := s.userRepo.GetOrCreateByAddress(ctx, common.HexToAddress(userAddress).Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	_, err = s.memberRepo.GetByUserAndCircle(ctx, user.UserID, circle.ID)
+	if err == nil {
+		return nil, ErrAlreadyCircleMember
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get circle relationship: %w", err)
+	}
+
+	member := &models.UserCircleRelationship{
+		UserID:           user.UserID,
+		CircleID:         circle.ID,
+		RelationshipType: "MEMBER",
+		CanPost:          true,
+		JoinedAt:         time.Now(),
+		User:             *user,
+	}
+	if strings.EqualFold(circle.OwnerAddress, user.WalletAddress) {
+		member.RelationshipType = "OWNS"
+		member.CanModerate = true
+		member.CanInvite = true
+	}
+
+	rule, err := s.refresh(ctx, circle, member)
+	if err != nil {
+		return nil, err
+	}
+	if member.HeldSince == nil && member.RelationshipType != "OWNS" {
+		return nil, fmt.Errorf("%w: at least %s %s is required to join", ErrMembershipRequirement, rule.MinBalance, circle.Symbol)
+	}
+
+	if err := s.memberRepo.Create(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to join circle: %w", err)
+	}
+	if !member.CanPost {
+		// GORM skips the false CanPost on create and the column defaults to true
+		if err := s.memberRepo.SaveBalance(ctx, member); err != nil {
+			return nil, fmt.Errorf("failed to join circle: %w", err)
+		}
+	}
+
+	return member, nil
+}
+
+// GetMembership retrieves the user's relationship with a circle after
+// refreshing its cached balance from the chain
+func (s *MembershipService) GetMembership(ctx context.Context, circleID uint64, userAddress string) (*models.UserCircleRelationship, error) {
+	circle, member, err := s.getMember(ctx, circleID, userAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.refresh(ctx, circle, member); err != nil {
+		return nil, err
+	}
+	if err := s.memberRepo.SaveBalance(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to update membership: %w", err)
+	}
+	return member, nil
+}
+
+// AuthorizePost checks the user may post in a circle right now. The balance
+// is re-read on-chain rather than trusted from the cache, so a holder who has
+// just sold below the minimum is refused even before the indexer catches up.
+func (s *MembershipService) AuthorizePost(ctx context.Context, circleID uint64, userAddress string) (*models.UserCircleRelationship, error) {
+	member, err := s.GetMembership(ctx, circleID, userAddress)
+	if err != nil {
+		return nil, err
+	}
+	if member.CanPost {
+		return member, nil
+	}
+	if member.GateDemoted {
+		return nil, fmt.Errorf("%w: balance of %s is below the minimum or held too briefly", ErrMembershipRequirement, member.TokenBalance)
+	}
+	return nil, ErrPostingRestricted
+}
+
+// IsModerator reports whether the user moderates a circle. Users who have
+// not joined the circle are not moderators.
+func (s *MembershipService) IsModerator(ctx context.Context, circleID uint64, userAddress string) (bool, error) {
+	if !common.IsHexAddress(userAddress) {
+		return false, nil
+	}
+	_, member, err := s.getMember(ctx, circleID, userAddress)
+	if errors.Is(err, ErrNotCircleMember) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return member.CanModerate, nil
+}
+
+// refresh reads a member's current balance and applies the circle's rule,
+// returning the rule it applied
+func (s *MembershipService) refresh(ctx context.Context, circle *models.Circle, member *models.UserCircleRelationship) (*models.CircleMembershipRule, error) {
+	rule, err := s.getRule(ctx, circle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, head, err := s.web3Svc.CurrentTokenBalance(ctx, common.HexToAddress(circle.TokenAddress), common.HexToAddress(member.User.WalletAddress))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token balance: %w", err)
+	}
+
+	membership.ApplyBalance(member, rule, balance, head.Number.Uint64(), time.Now().UTC())
+	if rule == nil {
+		rule = &models.CircleMembershipRule{CircleID: circle.ID, MinBalance: "0"}
+	}
+	return rule, nil
+}
+
+// getMember loads a circle and the user's relationship with it
+func (s *MembershipService) getMember(ctx context.Context, circleID uint64, userAddress string) (*models.Circle, *models.UserCircleRelationship, error) {
+	circle, err := s.getCircle(ctx, circleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.GetByAddress(ctx, common.HexToAddress(userAddress).Hex())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrNotCircleMember
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	member, err := s.memberRepo.GetByUserAndCircle(ctx, user.UserID, circle.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrNotCircleMember
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get circle relationship: %w", err)
+	}
+	member.User = *user
+
+	return circle, member, nil
+}
+
+// getCircle loads a circle, mapping a missing row to ErrCircleNotFound
+func (s *MembershipService) getCircle(ctx context.Context, circleID uint64) (*models.Circle, error) {
+	circle, err := s.circleRepo.GetByID(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCircleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+	return circle, nil
+}
+
+// getRule loads a circle's membership rule, or nil if the circle has none
+func (s *MembershipService) getRule(ctx context.Context, circleID uint64) (*models.CircleMembershipRule, error) {
+	rule, err := s.ruleRepo.GetByCircle(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get membership rule: %w", err)
+	}
+	return rule, nil
+}
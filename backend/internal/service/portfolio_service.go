@@ -0,0 +1,387 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/pnl"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxPortfolioSnapshots caps how many snapshots one history request returns
+	maxPortfolioSnapshots = 1000
+	// defaultPortfolioHistory is the history range served when none is given
+	defaultPortfolioHistory = 30 * 24 * time.Hour
+)
+
+// ErrInvalidPortfolioQuery is returned for a malformed wallet address or a
+// bad history range
+var ErrInvalidPortfolioQuery = errors.New("invalid portfolio query")
+
+// PortfolioService values wallets' circle token positions and tracks their
+// profit and loss
+type PortfolioService struct {
+	circleRepo   *repository.CircleRepository
+	userRepo     *repository.UserRepository
+	holderRepo   *repository.HolderRepository
+	tradeRepo    *repository.TradeRepository
+	snapshotRepo *repository.PortfolioSnapshotRepository
+	web3Svc      *web3.Web3Service
+}
+
+// NewPortfolioService creates a new portfolio service
+func NewPortfolioService(
+	circleRepo *repository.CircleRepository,
+	userRepo *repository.UserRepository,
+	holderRepo *repository.HolderRepository,
+	tradeRepo *repository.TradeRepository,
+	snapshotRepo *repository.PortfolioSnapshotRepository,
+	web3Svc *web3.Web3Service,
+) *PortfolioService {
+	return &PortfolioService{
+		circleRepo:   circleRepo,
+		userRepo:     userRepo,
+		holderRepo:   holderRepo,
+		tradeRepo:    tradeRepo,
+		snapshotRepo: snapshotRepo,
+		web3Svc:      web3Svc,
+	}
+}
+
+// PnLSummary is a cost basis and profit and loss in ETH under one cost method
+type PnLSummary struct {
+	CostBasis     string `json:"cost_basis"`
+	RealizedPnL   string `json:"realized_pnl"`
+	UnrealizedPnL string `json:"unrealized_pnl"`
+	TotalPnL      string `json:"total_pnl"`
+}
+
+// PortfolioPosition is a wallet's holding of one circle token, valued at the
+// bonding curve's current price. Positions that have been sold out are kept
+// for their realized profit and loss.
+type PortfolioPosition struct {
+	CircleID     uint64     `json:"circle_id"`
+	Name         string     `json:"name"`
+	Symbol       string     `json:"symbol"`
+	TokenAddress string     `json:"token_address"`
+	Balance      string     `json:"balance"`
+	Price        string     `json:"price"`
+	Value        string     `json:"value"`
+	FIFO         PnLSummary `json:"fifo"`
+	AverageCost  PnLSummary `json:"average_cost"`
+}
+
+// PortfolioResponse represents a wallet's positions and their totals
+type PortfolioResponse struct {
+	Address     string               `json:"address"`
+	Positions   []*PortfolioPosition `json:"positions"`
+	TotalValue  string               `json:"total_value"`
+	FIFO        PnLSummary           `json:"fifo"`
+	AverageCost PnLSummary           `json:"average_cost"`
+	AsOf        time.Time            `json:"as_of"`
+}
+
+// PortfolioHistoryResponse represents a wallet's portfolio snapshots over a
+// time range
+type PortfolioHistoryResponse struct {
+	Address   string                      `json:"address"`
+	From      time.Time                   `json:"from"`
+	To        time.Time                   `json:"to"`
+	Snapshots []*models.PortfolioSnapshot `json:"snapshots"`
+}
+
+// pnlTotals accumulates profit and loss across positions
+type pnlTotals struct {
+	costBasis, realized, unrealized *big.Int
+}
+
+func newPnLTotals() *pnlTotals {
+	return &pnlTotals{costBasis: new(big.Int), realized: new(big.Int), unrealized: new(big.Int)}
+}
+
+func (t *pnlTotals) add(p *pnl.Position) {
+	t.costBasis.Add(t.costBasis, p.CostBasis)
+	t.realized.Add(t.realized, p.Realized)
+	t.unrealized.Add(t.unrealized, p.Unrealized)
+}
+
+func (t *pnlTotals) summary() PnLSummary {
+	return pnlSummary(&pnl.Position{CostBasis: t.costBasis, Realized: t.realized, Unrealized: t.unrealized})
+}
+
+func pnlSummary(p *pnl.Position) PnLSummary {
+	return PnLSummary{
+		CostBasis:     web3.FormatUnits(p.CostBasis, 18),
+		RealizedPnL:   web3.FormatUnits(p.Realized, 18),
+		UnrealizedPnL: web3.FormatUnits(p.Unrealized, 18),
+		TotalPnL:      web3.FormatUnits(p.Total(), 18),
+	}
+}
+
+// GetPortfolio values every circle token the wallet holds or has traded
+func (s *PortfolioService) GetPortfolio(ctx context.Context, address string) (*PortfolioResponse, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidPortfolioQuery, address)
+	}
+
+	portfolio, _, err := s.portfolio(ctx, common.HexToAddress(address).Hex(), make(map[uint64]*big.Int))
+	return portfolio, err
+}
+
+// GetHistory retrieves a wallet's portfolio snapshots within [from, to), in
+// unix seconds. A zero to means now and a zero from means 30 days before to.
+func (s *PortfolioService) GetHistory(ctx context.Context, address string, from, to int64) (*PortfolioHistoryResponse, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidPortfolioQuery, address)
+	}
+
+	end := time.Now().UTC()
+	if to != 0 {
+		end = time.Unix(to, 0).UTC()
+	}
+	start := end.Add(-defaultPortfolioHistory)
+	if from != 0 {
+		start = time.Unix(from, 0).UTC()
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidPortfolioQuery)
+	}
+
+	wallet := common.HexToAddress(address).Hex()
+	snapshots, err := s.snapshotRepo.GetRange(ctx, wallet, start, end, maxPortfolioSnapshots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio snapshots: %w", err)
+	}
+
+	return &PortfolioHistoryResponse{
+		Address:   wallet,
+		From:      start,
+		To:        end,
+		Snapshots: snapshots,
+	}, nil
+}
+
+// SnapshotAll records the portfolio of every wallet holding a circle token at
+// the given time. Snapshots are keyed by wallet and time, so repeating a run
+// for the same time replaces its snapshots.
+func (s *PortfolioService) SnapshotAll(ctx context.Context, at time.Time) (int, error) {
+	addresses, err := s.holderRepo.GetHolderAddresses(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get holders: %w", err)
+	}
+
+	// Every wallet is valued at the same prices
+	prices := make(map[uint64]*big.Int)
+	taken := 0
+	for _, address := range addresses {
+		if common.HexToAddress(address) == (common.Address{}) {
+			continue
+		}
+
+		portfolio, average, err := s.portfolio(ctx, address, prices)
+		if err != nil {
+			return taken, fmt.Errorf("failed to value portfolio of %s: %w", address, err)
+		}
+
+		err = s.snapshotRepo.Save(ctx, &models.PortfolioSnapshot{
+			WalletAddress: address,
+			SnapshotAt:    at.UTC(),
+			TotalValue:    portfolio.TotalValue,
+			CostBasis:     web3.FormatUnits(average.costBasis, 18),
+			RealizedPnL:   web3.FormatUnits(average.realized, 18),
+			UnrealizedPnL: web3.FormatUnits(average.unrealized, 18),
+			PositionCount: len(portfolio.Positions),
+		})
+		if err != nil {
+			return taken, fmt.Errorf("failed to save portfolio snapshot of %s: %w", address, err)
+		}
+		taken++
+	}
+	return taken, nil
+}
+
+// RunSnapshots snapshots every portfolio at the start of each interval until
+// the context is cancelled
+func (s *PortfolioService) RunSnapshots(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		at := time.Now().UTC().Truncate(interval)
+		taken, err := s.SnapshotAll(ctx, at)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Error("Portfolio snapshot failed", "error", err)
+		} else {
+			logger.Info("Portfolio snapshots taken", "count", taken, "at", at)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// portfolio values a wallet's positions at the given prices, fetching and
+// caching the price of any circle not yet in the map. It also returns the
+// average cost totals in wei for snapshots.
+func (s *PortfolioService) portfolio(ctx context.Context, address string, prices map[uint64]*big.Int) (*PortfolioResponse, *pnlTotals, error) {
+	balances := make(map[uint64]*big.Int)
+	holdings, err := s.holderRepo.GetByHolder(ctx, address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get holdings: %w", err)
+	}
+	for _, holding := range holdings {
+		balance, err := web3.ParseUnits(holding.Balance, 18)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid balance in circle %d: %w", holding.CircleID, err)
+		}
+		balances[holding.CircleID] = balance
+	}
+
+	trades := make(map[uint64][]*models.Trade)
+	user, err := s.userRepo.GetByAddress(ctx, address)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user != nil {
+		rows, err := s.tradeRepo.GetByTrader(ctx, user.UserID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get trades: %w", err)
+		}
+		for _, trade := range rows {
+			trades[trade.CircleID] = append(trades[trade.CircleID], trade)
+		}
+	}
+
+	ids := make([]uint64, 0, len(balances)+len(trades))
+	for id := range balances {
+		ids = append(ids, id)
+	}
+	for id := range trades {
+		if _, ok := balances[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	fifoTotals, averageTotals := newPnLTotals(), newPnLTotals()
+	totalValue := new(big.Int)
+	positions := make([]*PortfolioPosition, 0, len(ids))
+	values := make(map[*PortfolioPosition]*big.Int)
+	for _, id := range ids {
+		circle, err := s.circleRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get circle %d: %w", id, err)
+		}
+
+		balance, ok := balances[id]
+		if !ok {
+			// Holder rows are removed once empty, so a traded circle without
+			// one was either sold out or not indexed yet; the chain knows
+			if balance, err = s.chainBalance(ctx, circle, address); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		price, ok := prices[id]
+		if !ok {
+			price = s.currentPrice(ctx, circle)
+			prices[id] = price
+		}
+
+		fifo, average, err := pnl.FromTrades(trades[id], balance, price)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to compute profit and loss in circle %d: %w", id, err)
+		}
+		value := pnl.TokenValue(balance, price)
+		totalValue.Add(totalValue, value)
+		fifoTotals.add(fifo)
+		averageTotals.add(average)
+
+		position := &PortfolioPosition{
+			CircleID:     circle.ID,
+			Name:         circle.Name,
+			Symbol:       circle.Symbol,
+			TokenAddress: circle.TokenAddress,
+			Balance:      web3.FormatUnits(balance, 18),
+			Price:        web3.FormatUnits(price, 18),
+			Value:        web3.FormatUnits(value, 18),
+			FIFO:         pnlSummary(fifo),
+			AverageCost:  pnlSummary(average),
+		}
+		values[position] = value
+		positions = append(positions, position)
+	}
+
+	// Largest positions first
+	sort.Slice(positions, func(i, j int) bool {
+		if c := values[positions[i]].Cmp(values[positions[j]]); c != 0 {
+			return c > 0
+		}
+		return positions[i].CircleID < positions[j].CircleID
+	})
+
+	return &PortfolioResponse{
+		Address:     address,
+		Positions:   positions,
+		TotalValue:  web3.FormatUnits(totalValue, 18),
+		FIFO:        fifoTotals.summary(),
+		AverageCost: averageTotals.summary(),
+		AsOf:        time.Now().UTC(),
+	}, averageTotals, nil
+}
+
+// chainBalance reads a wallet's balance of a circle token from the chain
+func (s *PortfolioService) chainBalance(ctx context.Context, circle *models.Circle, address string) (*big.Int, error) {
+	if circle.TokenAddress == "" {
+		return new(big.Int), nil
+	}
+	balance, err := s.web3Svc.GetTokenBalance(ctx, common.HexToAddress(circle.TokenAddress), common.HexToAddress(address))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token balance in circle %d: %w", circle.ID, err)
+	}
+	return balance, nil
+}
+
+// currentPrice reads a circle token's price from the bonding curve, falling
+// back to the price of its last indexed trade when the curve cannot be read
+func (s *PortfolioService) currentPrice(ctx context.Context, circle *models.Circle) *big.Int {
+	if circle.TokenAddress != "" {
+		price, err := s.web3Svc.GetCurrentPrice(ctx, common.HexToAddress(circle.TokenAddress))
+		if err == nil {
+			return price
+		}
+		logger.Warn("Failed to read curve price, using last trade", "circle_id", circle.ID, "error", err)
+	}
+
+	_, last, err := s.tradeRepo.GetFirstAndLastByCircle(ctx, circle.ID)
+	if err != nil {
+		return new(big.Int)
+	}
+	price, err := web3.ParseUnits(last.Price, 18)
+	if err != nil {
+		return new(big.Int)
+	}
+	return price
+}
@@ -0,0 +1,498 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/ipfs"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultPostPageSize is the page size when a request does not set one
+	defaultPostPageSize = 20
+	// maxPostPageSize caps how many posts one page may hold
+	maxPostPageSize = 100
+	// maxPostBodySize bounds a post body in bytes
+	maxPostBodySize = 32 << 10
+	// maxPostTitleLength bounds a post title in characters
+	maxPostTitleLength = 255
+	// previewLength is how many characters of the body a preview keeps
+	previewLength = 280
+	// postContentVersion is the version of the document stored on IPFS
+	postContentVersion = 1
+)
+
+var (
+	// ErrPostNotFound is returned when a post does not exist or was deleted
+	ErrPostNotFound = errors.New("post not found")
+	// ErrNotPostAuthor is returned when someone other than the author, or a
+	// moderator of the post's circle, tries to change a post
+	ErrNotPostAuthor = errors.New("user may not change the post")
+	// ErrInvalidPost is returned for a malformed post or post query
+	ErrInvalidPost = errors.New("invalid post")
+)
+
+// postContentTypes are the content types posts can be created with
+var postContentTypes = map[string]bool{
+	"TEXT":  true,
+	"IMAGE": true,
+	"VIDEO": true,
+	"LINK":  true,
+}
+
+// PostService manages posts. Post bodies live on IPFS; the database keeps the
+// CID, the title and a plain-text preview for feeds.
+type PostService struct {
+	postRepo      *repository.PostRepository
+	userRepo      *repository.UserRepository
+	circleRepo    *repository.CircleRepository
+	membershipSvc *MembershipService
+	ipfs          *ipfs.Client
+}
+
+// NewPostService creates a new post service
+func NewPostService(
+	postRepo *repository.PostRepository,
+	userRepo *repository.UserRepository,
+	circleRepo *repository.CircleRepository,
+	membershipSvc *MembershipService,
+	ipfsClient *ipfs.Client,
+) *PostService {
+	return &PostService{
+		postRepo:      postRepo,
+		userRepo:      userRepo,
+		circleRepo:    circleRepo,
+		membershipSvc: membershipSvc,
+		ipfs:          ipfsClient,
+	}
+}
+
+// PostContent is the document stored on IPFS for a post
+type PostContent struct {
+	Version     int    `json:"version"`
+	Author      string `json:"author"`
+	ContentType string `json:"content_type"`
+	Title       string `json:"title,omitempty"`
+	Body        string `json:"body,omitempty"`
+	MediaURL    string `json:"media_url,omitempty"`
+}
+
+// CreatePostRequest represents a new post. Posts without a circle go to the
+// author's public feed; circle posts require the right to post there.
+// IMAGE, VIDEO and LINK posts need a media URL.
+type CreatePostRequest struct {
+	CircleID    *uint64 `json:"circle_id"`
+	ContentType string  `json:"content_type"`
+	Title       string  `json:"title"`
+	Body        string  `json:"body"`
+	MediaURL    string  `json:"media_url"`
+}
+
+// UpdatePostRequest represents an edit to a post. Omitted fields are kept.
+type UpdatePostRequest struct {
+	Title    *string `json:"title"`
+	Body     *string `json:"body"`
+	MediaURL *string `json:"media_url"`
+}
+
+// PostQuery selects and pages a feed. Cursor is the NextCursor of the
+// previous page. Viewer is the wallet reading the feed, if known, whose
+// blocks hide posts.
+type PostQuery struct {
+	CircleID uint64
+	Author   string
+	Viewer   string
+	Cursor   string
+	Limit    int
+}
+
+// PostResponse is a post with a link to its content on the gateway, and the
+// content itself when a single post is read
+type PostResponse struct {
+	*models.Post
+	ContentURL string       `json:"content_url"`
+	Content    *PostContent `json:"content,omitempty"`
+}
+
+// PostListResponse is a page of posts
+type PostListResponse struct {
+	Posts      []*PostResponse `json:"posts"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// CreatePost uploads a post's content to IPFS and records the post
+func (s *PostService) CreatePost(ctx context.Context, authorAddress string, req *CreatePostRequest) (*PostResponse, error) {
+	if !common.IsHexAddress(authorAddress) {
+		return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidPost, authorAddress)
+	}
+
+	contentType := strings.ToUpper(req.ContentType)
+	if contentType == "" {
+		contentType = "TEXT"
+	}
+	content := &PostContent{
+		Version:     postContentVersion,
+		Author:      common.HexToAddress(authorAddress).Hex(),
+		ContentType: contentType,
+		Title:       strings.TrimSpace(req.Title),
+		Body:        req.Body,
+		MediaURL:    strings.TrimSpace(req.MediaURL),
+	}
+	if err := validatePostContent(content); err != nil {
+		return nil, err
+	}
+
+	if req.CircleID != nil {
+		if err := s.authorizeCirclePost(ctx, *req.CircleID, content.Author); err != nil {
+			return nil, err
+		}
+	}
+
+	author, err := s.userRepo.GetOrCreateByAddress(ctx, content.Author)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	cid, err := s.upload(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	post := &models.Post{
+		AuthorID:          author.UserID,
+		CircleID:          req.CircleID,
+		ContentIPFSHash:   cid,
+		ContentType:       contentType,
+		Title:             optionalText(content.Title),
+		PreviewText:       optionalText(extractPreview(content.Body)),
+		RewardAmount:      "0",
+		TokenRewardAmount: "0",
+		ModerationStatus:  "APPROVED",
+	}
+	if err := s.postRepo.Create(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	created, err := s.postRepo.GetByID(ctx, post.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	return s.newPostResponse(created, content), nil
+}
+
+// GetPost retrieves a post with its content read from the gateway
+func (s *PostService) GetPost(ctx context.Context, postID uint64) (*PostResponse, error) {
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.fetch(ctx, post.ContentIPFSHash)
+	if err != nil {
+		return nil, err
+	}
+	return s.newPostResponse(post, content), nil
+}
+
+// ListPosts retrieves a page of posts, newest first, with their previews but
+// not their content. Posts by users the viewer blocks, or is blocked by, are
+// left out.
+func (s *PostService) ListPosts(ctx context.Context, query *PostQuery) (*PostListResponse, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultPostPageSize
+	}
+	if limit > maxPostPageSize {
+		limit = maxPostPageSize
+	}
+
+	var beforeID uint64
+	if query.Cursor != "" {
+		id, err := strconv.ParseUint(query.Cursor, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidPost)
+		}
+		beforeID = id
+	}
+
+	resp := &PostListResponse{Posts: []*PostResponse{}}
+	filter := &repository.PostFilter{}
+	if query.CircleID != 0 {
+		if _, err := s.getCircle(ctx, query.CircleID); err != nil {
+			return nil, err
+		}
+		filter.CircleID = query.CircleID
+	}
+	if query.Author != "" {
+		if !common.IsHexAddress(query.Author) {
+			return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidPost, query.Author)
+		}
+		author, err := s.userRepo.GetByAddress(ctx, common.HexToAddress(query.Author).Hex())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		filter.AuthorID = author.UserID
+	}
+	if query.Viewer != "" && common.IsHexAddress(query.Viewer) {
+		viewer, err := s.userRepo.GetByAddress(ctx, common.HexToAddress(query.Viewer).Hex())
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if viewer != nil {
+			filter.ViewerID = viewer.UserID
+		}
+	}
+
+	// One extra row tells whether another page follows
+	posts, err := s.postRepo.List(ctx, filter, beforeID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+	if len(posts) > limit {
+		posts = posts[:limit]
+		resp.NextCursor = strconv.FormatUint(posts[limit-1].PostID, 10)
+	}
+	for _, post := range posts {
+		resp.Posts = append(resp.Posts, s.newPostResponse(post, nil))
+	}
+	return resp, nil
+}
+
+// UpdatePost edits a post by uploading a new version of its content. Only
+// the author may edit, and circle posts only while the author may still post
+// in the circle.
+func (s *PostService) UpdatePost(ctx context.Context, postID uint64, authorAddress string, req *UpdatePostRequest) (*PostResponse, error) {
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(post.Author.WalletAddress, authorAddress) {
+		return nil, ErrNotPostAuthor
+	}
+	if post.CircleID != nil {
+		if err := s.authorizeCirclePost(ctx, *post.CircleID, post.Author.WalletAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := s.fetch(ctx, post.ContentIPFSHash)
+	if err != nil {
+		return nil, err
+	}
+	content.Version = postContentVersion
+	content.ContentType = post.ContentType
+	if req.Title != nil {
+		content.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Body != nil {
+		content.Body = *req.Body
+	}
+	if req.MediaURL != nil {
+		content.MediaURL = strings.TrimSpace(*req.MediaURL)
+	}
+	if err := validatePostContent(content); err != nil {
+		return nil, err
+	}
+
+	cid, err := s.upload(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	post.ContentIPFSHash = cid
+	post.Title = optionalText(content.Title)
+	post.PreviewText = optionalText(extractPreview(content.Body))
+	post.UpdatedAt = time.Now()
+	if err := s.postRepo.UpdateContent(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+	return s.newPostResponse(post, content), nil
+}
+
+// DeletePost soft-deletes a post. The author and moderators of the post's
+// circle may delete it.
+func (s *PostService) DeletePost(ctx context.Context, postID uint64, userAddress string) error {
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(post.Author.WalletAddress, userAddress) {
+		if post.CircleID == nil {
+			return ErrNotPostAuthor
+		}
+		moderator, err := s.membershipSvc.IsModerator(ctx, *post.CircleID, userAddress)
+		if err != nil {
+			return err
+		}
+		if !moderator {
+			return ErrNotPostAuthor
+		}
+	}
+
+	if err := s.postRepo.SoftDelete(ctx, post.PostID); err != nil {
+		return fmt.Errorf("failed to delete post: %w", err)
+	}
+	return nil
+}
+
+// authorizeCirclePost checks the author may post in an active circle
+func (s *PostService) authorizeCirclePost(ctx context.Context, circleID uint64, authorAddress string) error {
+	circle, err := s.getCircle(ctx, circleID)
+	if err != nil {
+		return err
+	}
+	if !circle.Active {
+		return fmt.Errorf("%w: circle is not active", ErrCircleStateConflict)
+	}
+	_, err = s.membershipSvc.AuthorizePost(ctx, circle.ID, authorAddress)
+	return err
+}
+
+// upload stores a post document on IPFS
+func (s *PostService) upload(ctx context.Context, content *PostContent) (string, error) {
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode post content: %w", err)
+	}
+	cid, err := s.ipfs.Add(ctx, "post.json", raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload post content: %w", err)
+	}
+	return cid, nil
+}
+
+// fetch reads a post document from the gateway
+func (s *PostService) fetch(ctx context.Context, cid string) (*PostContent, error) {
+	raw, err := s.ipfs.Cat(ctx, cid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read post content: %w", err)
+	}
+	var content PostContent
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil, fmt.Errorf("%w: malformed post content at %s", ipfs.ErrUnavailable, cid)
+	}
+	return &content, nil
+}
+
+// getPost loads an undeleted post, mapping a missing or deleted row to
+// ErrPostNotFound
+func (s *PostService) getPost(ctx context.Context, postID uint64) (*models.Post, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post.IsDeleted {
+		return nil, ErrPostNotFound
+	}
+	return post, nil
+}
+
+// getCircle loads a circle, mapping a missing row to ErrCircleNotFound
+func (s *PostService) getCircle(ctx context.Context, circleID uint64) (*models.Circle, error) {
+	circle, err := s.circleRepo.GetByID(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCircleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+	return circle, nil
+}
+
+func (s *PostService) newPostResponse(post *models.Post, content *PostContent) *PostResponse {
+	return &PostResponse{
+		Post:       post,
+		ContentURL: s.ipfs.GatewayURL(post.ContentIPFSHash),
+		Content:    content,
+	}
+}
+
+// validatePostContent checks a post document before it is uploaded
+func validatePostContent(content *PostContent) error {
+	if !postContentTypes[content.ContentType] {
+		return fmt.Errorf("%w: content type must be TEXT, IMAGE, VIDEO or LINK", ErrInvalidPost)
+	}
+	if utf8.RuneCountInString(content.Title) > maxPostTitleLength {
+		return fmt.Errorf("%w: title is longer than %d characters", ErrInvalidPost, maxPostTitleLength)
+	}
+	if len(content.Body) > maxPostBodySize {
+		return fmt.Errorf("%w: body is larger than %d bytes", ErrInvalidPost, maxPostBodySize)
+	}
+	if !utf8.ValidString(content.Body) {
+		return fmt.Errorf("%w: body is not valid UTF-8", ErrInvalidPost)
+	}
+
+	if content.MediaURL != "" {
+		media, err := url.Parse(content.MediaURL)
+		if err != nil || media.Host == "" || (media.Scheme != "https" && media.Scheme != "http" && media.Scheme != "ipfs") {
+			return fmt.Errorf("%w: media URL must be an http(s) or ipfs URL", ErrInvalidPost)
+		}
+	}
+	switch {
+	case content.ContentType == "TEXT" && strings.TrimSpace(content.Body) == "":
+		return fmt.Errorf("%w: text posts need a body", ErrInvalidPost)
+	case content.ContentType != "TEXT" && content.MediaURL == "":
+		return fmt.Errorf("%w: %s posts need a media URL", ErrInvalidPost, strings.ToLower(content.ContentType))
+	}
+	return nil
+}
+
+var (
+	previewTags     = regexp.MustCompile(`<[^>]*>`)
+	previewImages   = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	previewLinks    = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	previewPrefixes = regexp.MustCompile(`(?m)^\s*(?:#{1,6}\s+|>\s*|[-*+]\s+|\d+\.\s+)`)
+	previewMarks    = regexp.MustCompile("[*_~`]+")
+)
+
+// extractPreview turns a Markdown body into a single line of plain text, cut
+// at a word boundary after previewLength characters
+func extractPreview(body string) string {
+	text := previewTags.ReplaceAllString(body, " ")
+	text = previewImages.ReplaceAllString(text, "$1")
+	text = previewLinks.ReplaceAllString(text, "$1")
+	text = previewPrefixes.ReplaceAllString(text, "")
+	text = previewMarks.ReplaceAllString(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+
+	if utf8.RuneCountInString(text) <= previewLength {
+		return text
+	}
+	runes := []rune(text)[:previewLength]
+	cut := string(runes)
+	if space := strings.LastIndex(cut, " "); space > previewLength/2 {
+		cut = cut[:space]
+	}
+	return strings.TrimRight(cut, " .,;:") + "…"
+}
+
+// optionalText maps empty text to a NULL column
+func optionalText(text string) *string {
+	if text == "" {
+		return nil
+	}
+	return &text
+}
@@ -0,0 +1,113 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/database"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrQuoteNotFound is returned for an unknown quote ID
+	ErrQuoteNotFound = errors.New("quote not found")
+	// ErrQuoteExpired is returned when a quote is redeemed after its expiry
+	ErrQuoteExpired = errors.New("quote expired")
+	// ErrQuoteMismatch is returned when a quote is redeemed for another trade
+	ErrQuoteMismatch = errors.New("quote does not match request")
+	// ErrInvalidQuote is returned for a quote request that cannot be priced
+	ErrInvalidQuote = errors.New("invalid quote request")
+)
+
+// quoteRetention is how long a quote is kept past its expiry, so late
+// redemptions are told the quote expired rather than that it never existed
+const quoteRetention = time.Minute
+
+// QuoteStore keeps issued quotes until they expire
+type QuoteStore interface {
+	Save(ctx context.Context, quote *TradeQuote) error
+	// Get returns the quote or ErrQuoteNotFound
+	Get(ctx context.Context, id string) (*TradeQuote, error)
+}
+
+// MemoryQuoteStore keeps quotes in process. Quotes are only redeemable on
+// the replica that issued them.
+type MemoryQuoteStore struct {
+	mu     sync.Mutex
+	quotes map[string]*TradeQuote
+}
+
+// NewMemoryQuoteStore creates an in-process quote store
+func NewMemoryQuoteStore() *MemoryQuoteStore {
+	return &MemoryQuoteStore{quotes: make(map[string]*TradeQuote)}
+}
+
+// Save stores a quote and drops the ones past retention
+func (s *MemoryQuoteStore) Save(ctx context.Context, quote *TradeQuote) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, q := range s.quotes {
+		if time.Since(q.ExpiresAt) > quoteRetention {
+			delete(s.quotes, id)
+		}
+	}
+	s.quotes[quote.QuoteID] = quote
+	return nil
+}
+
+// Get returns a stored quote
+func (s *MemoryQuoteStore) Get(ctx context.Context, id string) (*TradeQuote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	quote, ok := s.quotes[id]
+	if !ok {
+		return nil, ErrQuoteNotFound
+	}
+	return quote, nil
+}
+
+// RedisQuoteStore shares quotes through Redis so any API replica can redeem them
+type RedisQuoteStore struct {
+	client *database.RedisClient
+}
+
+// NewRedisQuoteStore creates a Redis-backed quote store
+func NewRedisQuoteStore(client *database.RedisClient) *RedisQuoteStore {
+	return &RedisQuoteStore{client: client}
+}
+
+// Save writes a quote that Redis expires after retention
+func (s *RedisQuoteStore) Save(ctx context.Context, quote *TradeQuote) error {
+	raw, err := json.Marshal(quote)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(quote.ExpiresAt) + quoteRetention
+	return s.client.Set(ctx, "quote:"+quote.QuoteID, raw, ttl)
+}
+
+// Get reads a quote
+func (s *RedisQuoteStore) Get(ctx context.Context, id string) (*TradeQuote, error) {
+	raw, err := s.client.Get(ctx, "quote:"+id)
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrQuoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var quote TradeQuote
+	if err := json.Unmarshal([]byte(raw), &quote); err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
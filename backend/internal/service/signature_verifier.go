@@ -0,0 +1,54 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/siwe"
+	"github.com/fast-socialfi/backend/internal/web3"
+)
+
+// SignatureVerifier checks personal_sign (EIP-191) signatures for both kinds
+// of wallet: externally owned accounts sign with their key, smart contract
+// wallets such as Safe answer through EIP-1271, and wallets that are not
+// deployed yet present an ERC-6492 wrapped signature.
+type SignatureVerifier struct {
+	web3Svc *web3.Web3Service
+}
+
+// NewSignatureVerifier creates a new signature verifier. Without a Web3
+// service only externally owned accounts can be verified.
+func NewSignatureVerifier(web3Svc *web3.Web3Service) *SignatureVerifier {
+	return &SignatureVerifier{web3Svc: web3Svc}
+}
+
+// Verify returns nil if account signed message, siwe.ErrInvalidSignature if
+// it did not, and any other error if the chain could not be asked
+func (v *SignatureVerifier) Verify(ctx context.Context, account common.Address, message []byte, signature []byte) error {
+	if !web3.IsERC6492Signature(signature) && len(signature) == 65 {
+		// ECDSA recovery is free, so it is tried before any RPC call. A
+		// contract wallet whose owner key signed still gets its say below.
+		if signer, err := siwe.RecoverSigner(string(message), signature); err == nil && signer == account {
+			return nil
+		}
+	}
+
+	if v.web3Svc == nil {
+		return fmt.Errorf("%w: not signed by %s", siwe.ErrInvalidSignature, account.Hex())
+	}
+
+	valid, err := v.web3Svc.IsValidSignature(ctx, account, common.BytesToHash(accounts.TextHash(message)), signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify contract wallet signature: %w", err)
+	}
+	if !valid {
+		return fmt.Errorf("%w: not signed by %s", siwe.ErrInvalidSignature, account.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"gorm.io/gorm"
+)
+
+// Tip assets
+const (
+	// TipAssetETH tips in ETH
+	TipAssetETH = "eth"
+	// TipAssetToken tips in the token of the post's circle
+	TipAssetToken = "token"
+)
+
+const (
+	// defaultLeaderboardSize is how many posts a leaderboard lists when a
+	// request does not say
+	defaultLeaderboardSize = 20
+	// maxLeaderboardSize caps a leaderboard
+	maxLeaderboardSize = 100
+	// tipIntentTTL is how long a prepared tip can be submitted
+	tipIntentTTL = 24 * time.Hour
+)
+
+var (
+	// ErrInvalidTip is returned for a malformed tip, a tip to oneself or a
+	// token tip on a post outside any circle
+	ErrInvalidTip = errors.New("invalid tip")
+	// ErrTipClaimed is returned when a transaction was already recorded as a
+	// tip to another post or from another wallet
+	ErrTipClaimed = errors.New("transaction was already claimed as a tip")
+	// ErrTipNotPrepared is returned when a transfer does not match a tip
+	// prepared for the post, such as one sent before the tip was prepared
+	ErrTipNotPrepared = errors.New("transaction does not match a prepared tip")
+)
+
+// txHashPattern matches a transaction hash
+var txHashPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+// TipService manages on-chain tips to post authors
+type TipService struct {
+	tipRepo       *repository.TipRepository
+	postRepo      *repository.PostRepository
+	userRepo      *repository.UserRepository
+	circleRepo    *repository.CircleRepository
+	web3Svc       *web3.Web3Service
+	confirmations uint64
+}
+
+// NewTipService creates a new tip service. Tips are counted once their
+// transaction is buried under confirmations blocks.
+func NewTipService(
+	tipRepo *repository.TipRepository,
+	postRepo *repository.PostRepository,
+	userRepo *repository.UserRepository,
+	circleRepo *repository.CircleRepository,
+	web3Svc *web3.Web3Service,
+	confirmations uint64,
+) *TipService {
+	return &TipService{
+		tipRepo:       tipRepo,
+		postRepo:      postRepo,
+		userRepo:      userRepo,
+		circleRepo:    circleRepo,
+		web3Svc:       web3Svc,
+		confirmations: confirmations,
+	}
+}
+
+// PrepareTipRequest represents a tip to prepare. Asset is eth, the default,
+// or token for the token of the post's circle; Amount is in whole units.
+type PrepareTipRequest struct {
+	Asset  string `json:"asset"`
+	Amount string `json:"amount" binding:"required"`
+}
+
+// SubmitTipRequest represents the mined transaction of a tip
+type SubmitTipRequest struct {
+	Asset  string `json:"asset"`
+	TxHash string `json:"tx_hash" binding:"required"`
+}
+
+// TipResponse represents a recorded tip and the post's tip totals.
+// Recorded is false when the transaction had already been counted.
+type TipResponse struct {
+	Tip               *models.PostTip `json:"tip"`
+	Recorded          bool            `json:"recorded"`
+	RewardAmount      string          `json:"reward_amount"`
+	TokenRewardAmount string          `json:"token_reward_amount"`
+}
+
+// TipLeaderboardEntry represents a post's place on a circle's leaderboard
+type TipLeaderboardEntry struct {
+	Rank int `json:"rank"`
+	*models.Post
+}
+
+// TipLeaderboardResponse represents a circle's most tipped posts
+type TipLeaderboardResponse struct {
+	CircleID uint64                 `json:"circle_id"`
+	Asset    string                 `json:"asset"`
+	Posts    []*TipLeaderboardEntry `json:"posts"`
+}
+
+// PrepareTip builds an unsigned transfer from the tipper to the post's
+// author for their wallet to sign and send, and records it so only a
+// transfer sent from then on can be submitted as the tip
+func (s *TipService) PrepareTip(ctx context.Context, postID uint64, tipperAddress string, req *PrepareTipRequest) (*PreparedTxResponse, error) {
+	amount, err := web3.ParseUnits(req.Amount, 18)
+	if err != nil || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: amount must be a positive number", ErrInvalidTip)
+	}
+
+	post, token, symbol, err := s.resolve(ctx, postID, tipperAddress, req.Asset)
+	if err != nil {
+		return nil, err
+	}
+	tipper, err := s.userRepo.GetOrCreateByAddress(ctx, common.HexToAddress(tipperAddress).Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	author := common.HexToAddress(post.Author.WalletAddress)
+	unsignedTx, err := s.web3Svc.PrepareTip(ctx, common.HexToAddress(tipper.WalletAddress), author, token, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare tip: %w", err)
+	}
+
+	intent := &models.PostTipIntent{
+		PostID:    post.PostID,
+		TipperID:  tipper.UserID,
+		Amount:    web3.FormatUnits(amount, 18),
+		Nonce:     unsignedTx.Nonce,
+		ExpiresAt: time.Now().Add(tipIntentTTL),
+	}
+	if token != (common.Address{}) {
+		intent.TokenAddress = token.Hex()
+	}
+	if err := s.tipRepo.CreateIntent(ctx, intent); err != nil {
+		return nil, fmt.Errorf("failed to store tip: %w", err)
+	}
+
+	return &PreparedTxResponse{
+		Transaction: unsignedTx,
+		Message:     fmt.Sprintf("Sign this transaction to tip %s %s to %s, then submit its hash", web3.FormatUnits(amount, 18), symbol, author.Hex()),
+	}, nil
+}
+
+// SubmitTip verifies a mined tip against the chain and credits it to the
+// post. The transfer must match a tip prepared for the post and have been
+// sent no earlier than it was prepared; each prepared tip is credited once.
+// Submitting the same transaction again changes nothing.
+func (s *TipService) SubmitTip(ctx context.Context, postID uint64, tipperAddress string, req *SubmitTipRequest) (*TipResponse, error) {
+	if !txHashPattern.MatchString(req.TxHash) {
+		return nil, fmt.Errorf("%w: invalid transaction hash %s", ErrInvalidTip, req.TxHash)
+	}
+	txHash := common.HexToHash(req.TxHash)
+
+	post, token, symbol, err := s.resolve(ctx, postID, tipperAddress, req.Asset)
+	if err != nil {
+		return nil, err
+	}
+	tipper, err := s.userRepo.GetOrCreateByAddress(ctx, common.HexToAddress(tipperAddress).Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	existing, err := s.tipRepo.GetByTxHash(ctx, txHash.Hex())
+	if err == nil {
+		return s.replay(ctx, post, tipper, existing)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get tip: %w", err)
+	}
+
+	author := common.HexToAddress(post.Author.WalletAddress)
+	verified, err := s.web3Svc.VerifyTip(ctx, txHash, common.HexToAddress(tipper.WalletAddress), author, token, s.confirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	tip := &models.PostTip{
+		PostID:      post.PostID,
+		CircleID:    post.CircleID,
+		TipperID:    tipper.UserID,
+		AuthorID:    post.AuthorID,
+		TxHash:      txHash.Hex(),
+		Amount:      web3.FormatUnits(verified.Amount, 18),
+		BlockNumber: verified.BlockNumber,
+	}
+	if token != (common.Address{}) {
+		tip.TokenAddress = token.Hex()
+	}
+	intent, err := s.matchIntent(ctx, tip, verified)
+	if err != nil {
+		return nil, err
+	}
+
+	content := fmt.Sprintf("%s tipped %s %s on your post", tipper.WalletAddress, tip.Amount, symbol)
+	notification := &models.Notification{
+		UserID:           post.AuthorID,
+		NotificationType: "POST_REWARD",
+		Title:            "Your post was tipped",
+		Content:          &content,
+		RelatedUserID:    &tipper.UserID,
+		RelatedPostID:    &post.PostID,
+		RelatedCircleID:  post.CircleID,
+	}
+
+	recorded, err := s.tipRepo.Record(ctx, tip, intent.IntentID, notification)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Another transaction was credited to the prepared tip first
+		return nil, ErrTipNotPrepared
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record tip: %w", err)
+	}
+	if !recorded {
+		// Another request recorded the transaction first
+		existing, err := s.tipRepo.GetByTxHash(ctx, tip.TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tip: %w", err)
+		}
+		return s.replay(ctx, post, tipper, existing)
+	}
+	return s.response(ctx, post.PostID, tip, true)
+}
+
+// GetLeaderboard lists a circle's most tipped posts in ETH or, with the
+// token asset, in the circle's token
+func (s *TipService) GetLeaderboard(ctx context.Context, circleID uint64, asset string, limit int) (*TipLeaderboardResponse, error) {
+	asset, err := tipAsset(asset)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.circleRepo.GetByID(ctx, circleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCircleNotFound
+		}
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+
+	limit = clampPageSize(limit, defaultLeaderboardSize, maxLeaderboardSize)
+	posts, err := s.tipRepo.Leaderboard(ctx, circleID, asset == TipAssetToken, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	resp := &TipLeaderboardResponse{
+		CircleID: circleID,
+		Asset:    asset,
+		Posts:    make([]*TipLeaderboardEntry, 0, len(posts)),
+	}
+	for i, post := range posts {
+		resp.Posts = append(resp.Posts, &TipLeaderboardEntry{Rank: i + 1, Post: post})
+	}
+	return resp, nil
+}
+
+// resolve loads the post a tip goes to and works out the token and symbol it
+// is paid in, the zero address standing for ETH
+func (s *TipService) resolve(ctx context.Context, postID uint64, tipperAddress, asset string) (*models.Post, common.Address, string, error) {
+	if !common.IsHexAddress(tipperAddress) {
+		return nil, common.Address{}, "", fmt.Errorf("%w: invalid wallet address %s", ErrInvalidTip, tipperAddress)
+	}
+	asset, err := tipAsset(asset)
+	if err != nil {
+		return nil, common.Address{}, "", err
+	}
+
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, common.Address{}, "", ErrPostNotFound
+	}
+	if err != nil {
+		return nil, common.Address{}, "", fmt.Errorf("failed to get post: %w", err)
+	}
+	if post.IsDeleted {
+		return nil, common.Address{}, "", ErrPostNotFound
+	}
+	if strings.EqualFold(post.Author.WalletAddress, tipperAddress) {
+		return nil, common.Address{}, "", fmt.Errorf("%w: authors cannot tip their own posts", ErrInvalidTip)
+	}
+
+	if asset == TipAssetETH {
+		return post, common.Address{}, "ETH", nil
+	}
+	if post.CircleID == nil || post.Circle == nil || !common.IsHexAddress(post.Circle.TokenAddress) {
+		return nil, common.Address{}, "", fmt.Errorf("%w: only circle posts can be tipped in tokens", ErrInvalidTip)
+	}
+	return post, common.HexToAddress(post.Circle.TokenAddress), post.Circle.Symbol, nil
+}
+
+// matchIntent finds the prepared tip a verified transfer pays: one for the
+// same post, tipper, asset and amount prepared at or before the transfer's
+// nonce
+func (s *TipService) matchIntent(ctx context.Context, tip *models.PostTip, verified *web3.Tip) (*models.PostTipIntent, error) {
+	intents, err := s.tipRepo.ListIntents(ctx, tip.PostID, tip.TipperID, tip.TokenAddress, verified.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prepared tips: %w", err)
+	}
+	for _, intent := range intents {
+		amount, err := web3.ParseUnits(intent.Amount, 18)
+		if err == nil && amount.Cmp(verified.Amount) == 0 {
+			return intent, nil
+		}
+	}
+	return nil, ErrTipNotPrepared
+}
+
+// replay answers a resubmitted tip transaction, which must have been
+// recorded for the same post and tipper
+func (s *TipService) replay(ctx context.Context, post *models.Post, tipper *models.User, existing *models.PostTip) (*TipResponse, error) {
+	if existing.PostID != post.PostID || existing.TipperID != tipper.UserID {
+		return nil, ErrTipClaimed
+	}
+	return s.response(ctx, post.PostID, existing, false)
+}
+
+// response reports a tip with the post's totals after it
+func (s *TipService) response(ctx context.Context, postID uint64, tip *models.PostTip, recorded bool) (*TipResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	return &TipResponse{
+		Tip:               tip,
+		Recorded:          recorded,
+		RewardAmount:      post.RewardAmount,
+		TokenRewardAmount: post.TokenRewardAmount,
+	}, nil
+}
+
+// tipAsset normalizes a tip asset, defaulting to ETH
+func tipAsset(asset string) (string, error) {
+	switch strings.ToLower(asset) {
+	case "", TipAssetETH:
+		return TipAssetETH, nil
+	case TipAssetToken:
+		return TipAssetToken, nil
+	}
+	return "", fmt.Errorf("%w: asset must be eth or token", ErrInvalidTip)
+}
@@ -0,0 +1,405 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultTradePageSize is the page size when a request does not set one
+	defaultTradePageSize = 50
+	// maxTradePageSize caps how many trades one page may hold
+	maxTradePageSize = 200
+	// tradeExportBatchSize is how many trades an export reads at a time
+	tradeExportBatchSize = 500
+)
+
+// ErrInvalidTradeQuery is returned for a malformed filter, cursor or export
+// format
+var ErrInvalidTradeQuery = errors.New("invalid trade history query")
+
+// TradeHistoryService serves indexed trades per wallet and per circle
+type TradeHistoryService struct {
+	circleRepo *repository.CircleRepository
+	userRepo   *repository.UserRepository
+	tradeRepo  *repository.TradeRepository
+}
+
+// NewTradeHistoryService creates a new trade history service
+func NewTradeHistoryService(
+	circleRepo *repository.CircleRepository,
+	userRepo *repository.UserRepository,
+	tradeRepo *repository.TradeRepository,
+) *TradeHistoryService {
+	return &TradeHistoryService{
+		circleRepo: circleRepo,
+		userRepo:   userRepo,
+		tradeRepo:  tradeRepo,
+	}
+}
+
+// TradeHistoryQuery filters and pages trade history. From and To bound a
+// [From, To) range in unix seconds, MinSize is the smallest ETH amount to
+// include and Cursor is the NextCursor of the previous page. Zero values do
+// not filter.
+type TradeHistoryQuery struct {
+	TradeType string
+	From      int64
+	To        int64
+	MinSize   string
+	Cursor    string
+	Limit     int
+}
+
+// TradeEntry is a trade in history listings and exports
+type TradeEntry struct {
+	TradeID      uint64    `json:"trade_id"`
+	TxHash       string    `json:"tx_hash"`
+	LogIndex     uint      `json:"log_index"`
+	CircleID     uint64    `json:"circle_id"`
+	CircleSymbol string    `json:"circle_symbol"`
+	Trader       string    `json:"trader"`
+	TradeType    string    `json:"trade_type"`
+	TokenAmount  string    `json:"token_amount"`
+	ETHAmount    string    `json:"eth_amount"`
+	Price        string    `json:"price"`
+	Fee          string    `json:"fee"`
+	BlockNumber  uint64    `json:"block_number"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func newTradeEntry(trade *models.Trade) *TradeEntry {
+	return &TradeEntry{
+		TradeID:      trade.TradeID,
+		TxHash:       trade.TxHash,
+		LogIndex:     trade.LogIndex,
+		CircleID:     trade.CircleID,
+		CircleSymbol: trade.Circle.Symbol,
+		Trader:       trade.Trader.WalletAddress,
+		TradeType:    trade.TradeType,
+		TokenAmount:  trade.TokenAmount,
+		ETHAmount:    trade.ETHAmount,
+		Price:        trade.Price,
+		Fee:          trade.Fee,
+		BlockNumber:  trade.BlockNumber,
+		Timestamp:    trade.Timestamp.UTC(),
+	}
+}
+
+// TradeHistoryResponse represents a page of trades, newest first. NextCursor
+// is empty on the last page.
+type TradeHistoryResponse struct {
+	Trades     []*TradeEntry `json:"trades"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// TradeExport streams a trade history export, oldest trade first
+type TradeExport struct {
+	Filename    string
+	ContentType string
+
+	svc    *TradeHistoryService
+	filter *repository.TradeFilter
+	format string
+}
+
+// GetUserTrades retrieves a page of a wallet's trades
+func (s *TradeHistoryService) GetUserTrades(ctx context.Context, address string, query *TradeHistoryQuery) (*TradeHistoryResponse, error) {
+	filter, err := s.userFilter(ctx, address, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.page(ctx, filter, query)
+}
+
+// GetCircleTrades retrieves a page of a circle's trades
+func (s *TradeHistoryService) GetCircleTrades(ctx context.Context, circleID uint64, query *TradeHistoryQuery) (*TradeHistoryResponse, error) {
+	filter, err := s.circleFilter(ctx, circleID, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.page(ctx, filter, query)
+}
+
+// ExportUserTrades prepares an export of every wallet trade matching the
+// query, as "csv" or "json". The cursor and limit are ignored.
+func (s *TradeHistoryService) ExportUserTrades(ctx context.Context, address, format string, query *TradeHistoryQuery) (*TradeExport, error) {
+	filter, err := s.userFilter(ctx, address, query)
+	if err != nil {
+		return nil, err
+	}
+	name := "trades-" + strings.ToLower(common.HexToAddress(address).Hex())
+	return s.export(filter, format, name)
+}
+
+// ExportCircleTrades prepares an export of every circle trade matching the
+// query, as "csv" or "json". The cursor and limit are ignored.
+func (s *TradeHistoryService) ExportCircleTrades(ctx context.Context, circleID uint64, format string, query *TradeHistoryQuery) (*TradeExport, error) {
+	filter, err := s.circleFilter(ctx, circleID, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.export(filter, format, fmt.Sprintf("trades-circle-%d", circleID))
+}
+
+// Write streams the export to w
+func (e *TradeExport) Write(ctx context.Context, w io.Writer) error {
+	if e.format == "json" {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		count := 0
+		err := e.each(ctx, func(entry *TradeEntry) error {
+			raw, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			count++
+			_, err = w.Write(raw)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, "]\n")
+		return err
+	}
+
+	csvWriter := csv.NewWriter(w)
+	err := csvWriter.Write([]string{
+		"timestamp", "trade_id", "tx_hash", "log_index", "circle_id", "circle_symbol", "trader",
+		"trade_type", "token_amount", "eth_amount", "price", "fee", "block_number",
+	})
+	if err != nil {
+		return err
+	}
+	err = e.each(ctx, func(entry *TradeEntry) error {
+		return csvWriter.Write([]string{
+			entry.Timestamp.Format(time.RFC3339),
+			strconv.FormatUint(entry.TradeID, 10),
+			entry.TxHash,
+			strconv.FormatUint(uint64(entry.LogIndex), 10),
+			strconv.FormatUint(entry.CircleID, 10),
+			entry.CircleSymbol,
+			entry.Trader,
+			entry.TradeType,
+			entry.TokenAmount,
+			entry.ETHAmount,
+			entry.Price,
+			entry.Fee,
+			strconv.FormatUint(entry.BlockNumber, 10),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// each passes every exported trade to fn, reading them in batches
+func (e *TradeExport) each(ctx context.Context, fn func(*TradeEntry) error) error {
+	if e.filter == nil {
+		return nil
+	}
+
+	var after *repository.TradeCursor
+	for {
+		trades, err := e.svc.tradeRepo.List(ctx, e.filter, after, tradeExportBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get trades: %w", err)
+		}
+		for _, trade := range trades {
+			if err := fn(newTradeEntry(trade)); err != nil {
+				return err
+			}
+		}
+		if len(trades) < tradeExportBatchSize {
+			return nil
+		}
+		last := trades[len(trades)-1]
+		after = &repository.TradeCursor{Timestamp: last.Timestamp, TradeID: last.TradeID}
+	}
+}
+
+// page reads one page of history. A nil filter is a wallet with no trades.
+func (s *TradeHistoryService) page(ctx context.Context, filter *repository.TradeFilter, query *TradeHistoryQuery) (*TradeHistoryResponse, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultTradePageSize
+	}
+	if limit > maxTradePageSize {
+		limit = maxTradePageSize
+	}
+
+	var after *repository.TradeCursor
+	if query.Cursor != "" {
+		cursor, err := decodeTradeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	resp := &TradeHistoryResponse{Trades: []*TradeEntry{}}
+	if filter == nil {
+		return resp, nil
+	}
+
+	// One extra row tells whether another page follows
+	trades, err := s.tradeRepo.List(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+	if len(trades) > limit {
+		trades = trades[:limit]
+		last := trades[limit-1]
+		resp.NextCursor = encodeTradeCursor(&repository.TradeCursor{Timestamp: last.Timestamp, TradeID: last.TradeID})
+	}
+	for _, trade := range trades {
+		resp.Trades = append(resp.Trades, newTradeEntry(trade))
+	}
+	return resp, nil
+}
+
+func (s *TradeHistoryService) export(filter *repository.TradeFilter, format, name string) (*TradeExport, error) {
+	export := &TradeExport{svc: s, format: format}
+	switch format {
+	case "csv":
+		export.ContentType = "text/csv"
+	case "json":
+		export.ContentType = "application/json"
+	default:
+		return nil, fmt.Errorf("%w: unsupported export format %q", ErrInvalidTradeQuery, format)
+	}
+	export.Filename = name + "." + format
+
+	if filter != nil {
+		exported := *filter
+		exported.Ascending = true
+		export.filter = &exported
+	}
+	return export, nil
+}
+
+// userFilter builds the filter for a wallet's trades, or nil if the wallet
+// has never signed in or traded
+func (s *TradeHistoryService) userFilter(ctx context.Context, address string, query *TradeHistoryQuery) (*repository.TradeFilter, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidTradeQuery, address)
+	}
+	filter, err := tradeFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByAddress(ctx, common.HexToAddress(address).Hex())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	filter.TraderID = user.UserID
+	return filter, nil
+}
+
+// circleFilter builds the filter for a circle's trades
+func (s *TradeHistoryService) circleFilter(ctx context.Context, circleID uint64, query *TradeHistoryQuery) (*repository.TradeFilter, error) {
+	filter, err := tradeFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	circle, err := s.circleRepo.GetByID(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCircleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+	filter.CircleID = circle.ID
+	return filter, nil
+}
+
+// tradeFilter validates a query's filters
+func tradeFilter(query *TradeHistoryQuery) (*repository.TradeFilter, error) {
+	filter := &repository.TradeFilter{}
+
+	switch tradeType := strings.ToUpper(query.TradeType); tradeType {
+	case "":
+	case "BUY", "SELL":
+		filter.TradeType = tradeType
+	default:
+		return nil, fmt.Errorf("%w: trade type must be BUY or SELL", ErrInvalidTradeQuery)
+	}
+
+	if query.From != 0 {
+		filter.From = time.Unix(query.From, 0).UTC()
+	}
+	if query.To != 0 {
+		filter.To = time.Unix(query.To, 0).UTC()
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidTradeQuery)
+	}
+
+	if query.MinSize != "" {
+		size, err := web3.ParseUnits(query.MinSize, 18)
+		if err != nil || size.Sign() < 0 {
+			return nil, fmt.Errorf("%w: min size must be a non-negative ETH amount", ErrInvalidTradeQuery)
+		}
+		filter.MinETHAmount = web3.FormatUnits(size, 18)
+	}
+	return filter, nil
+}
+
+// encodeTradeCursor renders a history position as an opaque page token
+func encodeTradeCursor(cursor *repository.TradeCursor) string {
+	raw := strconv.FormatInt(cursor.Timestamp.UnixNano(), 10) + ":" + strconv.FormatUint(cursor.TradeID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeTradeCursor parses a page token from encodeTradeCursor
+func decodeTradeCursor(token string) (*repository.TradeCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidTradeQuery)
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidTradeQuery)
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidTradeQuery)
+	}
+	tradeID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidTradeQuery)
+	}
+	return &repository.TradeCursor{Timestamp: time.Unix(0, unixNano).UTC(), TradeID: tradeID}, nil
+}
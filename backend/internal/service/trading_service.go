@@ -6,13 +6,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fast-socialfi/backend/internal/models"
 	"github.com/fast-socialfi/backend/internal/repository"
 	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // TradingService handles token trading logic
@@ -21,8 +26,13 @@ type TradingService struct {
 	userRepo   *repository.UserRepository
 	txRepo     *repository.TransactionRepository
 	web3Svc    *web3.Web3Service
+	quotes     QuoteStore
+	quoteTTL   time.Duration
 }
 
+// defaultQuoteTTL is how long a quote's bounds can be redeemed
+const defaultQuoteTTL = 30 * time.Second
+
 // NewTradingService creates a new trading service
 func NewTradingService(
 	circleRepo *repository.CircleRepository,
@@ -35,115 +45,341 @@ func NewTradingService(
 		userRepo:   userRepo,
 		txRepo:     txRepo,
 		web3Svc:    web3Svc,
+		quotes:     NewMemoryQuoteStore(),
+		quoteTTL:   defaultQuoteTTL,
 	}
 }
 
-// BuyTokensRequest represents a buy tokens request
+// SetQuoteStore replaces the in-process quote store, e.g. with a
+// RedisQuoteStore when several replicas serve the trading API
+func (s *TradingService) SetQuoteStore(store QuoteStore) {
+	s.quotes = store
+}
+
+// BuyTokensRequest represents a request to prepare a buy transaction.
+// With a quote ID the amount and max cost are taken from the quote. The
+// curve spends the whole max cost and mints at least the amount for it.
 type BuyTokensRequest struct {
-	CircleID   uint64   `json:"circle_id" binding:"required"`
-	Amount     *big.Int `json:"amount" binding:"required"`
-	MaxCost    *big.Int `json:"max_cost" binding:"required"`
-	PrivateKey string   `json:"private_key" binding:"required"`
+	CircleID    uint64   `json:"circle_id" binding:"required"`
+	QuoteID     string   `json:"quote_id"`
+	Amount      *big.Int `json:"amount" binding:"required_without=QuoteID"`
+	MaxCost     *big.Int `json:"max_cost" binding:"required_without=QuoteID"`
+	FromAddress string   `json:"from_address"`
 }
 
-// SellTokensRequest represents a sell tokens request
+// SellTokensRequest represents a request to prepare a sell transaction.
+// With a quote ID the amount and min refund are taken from the quote.
 type SellTokensRequest struct {
-	CircleID   uint64   `json:"circle_id" binding:"required"`
-	Amount     *big.Int `json:"amount" binding:"required"`
-	MinRefund  *big.Int `json:"min_refund" binding:"required"`
-	PrivateKey string   `json:"private_key" binding:"required"`
+	CircleID    uint64   `json:"circle_id" binding:"required"`
+	QuoteID     string   `json:"quote_id"`
+	Amount      *big.Int `json:"amount" binding:"required_without=QuoteID"`
+	MinRefund   *big.Int `json:"min_refund" binding:"required_without=QuoteID"`
+	FromAddress string   `json:"from_address"`
+}
+
+// QuoteRequest represents a request to quote a buy or sell
+type QuoteRequest struct {
+	CircleID    uint64   `json:"circle_id" binding:"required"`
+	Side        string   `json:"side" binding:"required,oneof=buy sell"`
+	Amount      *big.Int `json:"amount" binding:"required"`
+	SlippageBps uint64   `json:"slippage_bps" binding:"max=5000"`
+}
+
+// TradeQuote is a priced trade and the bounds to trade it with. For a sell
+// MinRefund bounds the refund before fees, which is what the contract checks.
+type TradeQuote struct {
+	QuoteID        string    `json:"quote_id"`
+	CircleID       uint64    `json:"circle_id"`
+	Side           string    `json:"side"`
+	Amount         *big.Int  `json:"amount"`
+	Supply         *big.Int  `json:"supply"`
+	Price          *big.Int  `json:"price"`
+	NewPrice       *big.Int  `json:"new_price"`
+	AveragePrice   *big.Int  `json:"average_price"`
+	PriceImpactBps *big.Int  `json:"price_impact_bps"`
+	Cost           *big.Int  `json:"cost,omitempty"`
+	Refund         *big.Int  `json:"refund,omitempty"`
+	NetRefund      *big.Int  `json:"net_refund,omitempty"`
+	Fee            *big.Int  `json:"fee"`
+	FeeBps         *big.Int  `json:"fee_bps"`
+	SlippageBps    uint64    `json:"slippage_bps"`
+	MaxCost        *big.Int  `json:"max_cost,omitempty"`
+	MinRefund      *big.Int  `json:"min_refund,omitempty"`
+	BlockNumber    uint64    `json:"block_number"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// SubmitTradeRequest represents a wallet-signed buy or sell transaction
+type SubmitTradeRequest struct {
+	CircleID    uint64 `json:"circle_id" binding:"required"`
+	SignedTx    string `json:"signed_tx" binding:"required"`
+	FromAddress string `json:"from_address"`
+}
+
+// PreparedTxResponse represents an unsigned transaction for the wallet to sign
+type PreparedTxResponse struct {
+	Transaction *web3.UnsignedTx `json:"transaction"`
+	Message     string           `json:"message"`
 }
 
 // TradeResponse represents a trading response
 type TradeResponse struct {
 	TxHash  string `json:"tx_hash"`
 	Message string `json:"message"`
+	Warning string `json:"warning,omitempty"`
 }
 
-// BuyTokens purchases circle tokens
-func (s *TradingService) BuyTokens(ctx context.Context, req *BuyTokensRequest) (*TradeResponse, error) {
-	// Get circle information
-	circle, err := s.circleRepo.GetByID(ctx, req.CircleID)
+// Quote prices a buy or sell on the bonding curve and derives the max cost or
+// min refund for the caller's slippage tolerance. The quote can be redeemed
+// by ID on the prepare endpoints until it expires.
+func (s *TradingService) Quote(ctx context.Context, req *QuoteRequest) (*TradeQuote, error) {
+	if req.Amount == nil || req.Amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidQuote)
+	}
+	if req.SlippageBps > 10000 {
+		return nil, fmt.Errorf("%w: slippage must not exceed 10000 bps", ErrInvalidQuote)
+	}
+
+	circle, err := s.getTradableCircle(ctx, req.CircleID)
 	if err != nil {
-		return nil, fmt.Errorf("circle not found: %w", err)
+		return nil, err
+	}
+	if req.Side == "buy" && !circle.Active {
+		return nil, fmt.Errorf("%w: circle is not active", ErrCircleStateConflict)
 	}
 
-	if circle.Status != "confirmed" {
-		return nil, fmt.Errorf("circle is not confirmed yet")
+	tokenAddr := common.HexToAddress(circle.TokenAddress)
+	quote := s.web3Svc.QuoteBuy
+	if req.Side == "sell" {
+		quote = s.web3Svc.QuoteSell
+	}
+	q, err := quote(ctx, tokenAddr, req.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote %s: %w", req.Side, err)
 	}
 
-	if !circle.Active {
-		return nil, fmt.Errorf("circle is not active")
+	result := &TradeQuote{
+		QuoteID:        uuid.NewString(),
+		CircleID:       circle.ID,
+		Side:           req.Side,
+		Amount:         q.Amount,
+		Supply:         q.Supply,
+		Price:          q.Price,
+		NewPrice:       q.NewPrice,
+		AveragePrice:   q.AvgPrice,
+		PriceImpactBps: q.ImpactBps,
+		Fee:            q.Fee,
+		FeeBps:         q.FeeBps,
+		SlippageBps:    req.SlippageBps,
+		BlockNumber:    q.BlockNumber,
+		ExpiresAt:      time.Now().Add(s.quoteTTL).UTC(),
 	}
 
-	tokenAddr := common.HexToAddress(circle.TokenAddress)
+	bps := big.NewInt(10000)
+	if req.Side == "buy" {
+		// Round the bound up so a zero tolerance still admits the exact cost
+		maxCost := new(big.Int).Mul(q.Value, new(big.Int).Add(bps, new(big.Int).SetUint64(req.SlippageBps)))
+		maxCost.Add(maxCost, big.NewInt(9999)).Quo(maxCost, bps)
+		result.Cost = q.Value
+		result.MaxCost = maxCost
+	} else {
+		minRefund := new(big.Int).Mul(q.Value, new(big.Int).Sub(bps, new(big.Int).SetUint64(req.SlippageBps)))
+		minRefund.Quo(minRefund, bps)
+		result.Refund = q.Value
+		result.NetRefund = new(big.Int).Sub(q.Value, q.Fee)
+		result.MinRefund = minRefund
+	}
 
-	// Execute buy on blockchain
-	txHash, err := s.web3Svc.BuyTokens(ctx, req.PrivateKey, tokenAddr, req.Amount, req.MaxCost)
+	if err := s.quotes.Save(ctx, result); err != nil {
+		return nil, fmt.Errorf("failed to store quote: %w", err)
+	}
+
+	return result, nil
+}
+
+// redeemQuote loads an unexpired quote for the given circle and side
+func (s *TradingService) redeemQuote(ctx context.Context, id string, circleID uint64, side string, amount *big.Int) (*TradeQuote, error) {
+	quote, err := s.quotes.Get(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to buy tokens: %w", err)
+		return nil, err
+	}
+	if time.Now().After(quote.ExpiresAt) {
+		return nil, ErrQuoteExpired
+	}
+	if quote.CircleID != circleID || quote.Side != side {
+		return nil, fmt.Errorf("%w: quote is for a %s of circle %d", ErrQuoteMismatch, quote.Side, quote.CircleID)
 	}
+	if amount != nil && amount.Cmp(quote.Amount) != 0 {
+		return nil, fmt.Errorf("%w: quote is for %s tokens", ErrQuoteMismatch, quote.Amount)
+	}
+	return quote, nil
+}
 
-	// Record transaction
-	tx := &models.Transaction{
-		CircleID:    circle.ID,
-		TxHash:      txHash,
-		TxType:      "buy",
-		Amount:      req.Amount.String(),
-		Status:      "pending",
-		TokenAddress: circle.TokenAddress,
+// PrepareBuyTokens builds an unsigned buy transaction for the user's wallet
+func (s *TradingService) PrepareBuyTokens(ctx context.Context, req *BuyTokensRequest) (*PreparedTxResponse, error) {
+	if !common.IsHexAddress(req.FromAddress) {
+		return nil, fmt.Errorf("invalid sender address: %s", req.FromAddress)
 	}
 
-	if err := s.txRepo.Create(ctx, tx); err != nil {
-		// Log error but don't fail
-		fmt.Printf("failed to record transaction: %v\n", err)
+	if req.QuoteID != "" {
+		quote, err := s.redeemQuote(ctx, req.QuoteID, req.CircleID, "buy", req.Amount)
+		if err != nil {
+			return nil, err
+		}
+		req.Amount, req.MaxCost = quote.Amount, quote.MaxCost
+	}
+	if req.Amount == nil || req.MaxCost == nil {
+		return nil, fmt.Errorf("amount and max_cost are required without a quote")
+	}
+
+	circle, err := s.getTradableCircle(ctx, req.CircleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !circle.Active {
+		return nil, fmt.Errorf("%w: circle is not active", ErrCircleStateConflict)
+	}
+
+	unsignedTx, err := s.web3Svc.PrepareBuyTokens(
+		ctx,
+		common.HexToAddress(req.FromAddress),
+		common.HexToAddress(circle.TokenAddress),
+		req.Amount,
+		req.MaxCost,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare buy transaction: %w", err)
 	}
 
-	return &TradeResponse{
-		TxHash:  txHash,
-		Message: "Buy transaction submitted successfully",
+	return &PreparedTxResponse{
+		Transaction: unsignedTx,
+		Message: "Sign the transaction with your wallet and submit it. The whole max cost is spent and nothing is refunded; " +
+			"it mints at least the requested amount, more if the price has not moved.",
 	}, nil
 }
 
-// SellTokens sells circle tokens
-func (s *TradingService) SellTokens(ctx context.Context, req *SellTokensRequest) (*TradeResponse, error) {
-	// Get circle information
-	circle, err := s.circleRepo.GetByID(ctx, req.CircleID)
+// PrepareSellTokens builds an unsigned sell transaction for the user's wallet
+func (s *TradingService) PrepareSellTokens(ctx context.Context, req *SellTokensRequest) (*PreparedTxResponse, error) {
+	if !common.IsHexAddress(req.FromAddress) {
+		return nil, fmt.Errorf("invalid sender address: %s", req.FromAddress)
+	}
+
+	if req.QuoteID != "" {
+		quote, err := s.redeemQuote(ctx, req.QuoteID, req.CircleID, "sell", req.Amount)
+		if err != nil {
+			return nil, err
+		}
+		req.Amount, req.MinRefund = quote.Amount, quote.MinRefund
+	}
+	if req.Amount == nil || req.MinRefund == nil {
+		return nil, fmt.Errorf("amount and min_refund are required without a quote")
+	}
+
+	circle, err := s.getTradableCircle(ctx, req.CircleID)
 	if err != nil {
-		return nil, fmt.Errorf("circle not found: %w", err)
+		return nil, err
 	}
 
-	if circle.Status != "confirmed" {
-		return nil, fmt.Errorf("circle is not confirmed yet")
+	unsignedTx, err := s.web3Svc.PrepareSellTokens(
+		ctx,
+		common.HexToAddress(req.FromAddress),
+		common.HexToAddress(circle.TokenAddress),
+		req.Amount,
+		req.MinRefund,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare sell transaction: %w", err)
 	}
 
-	tokenAddr := common.HexToAddress(circle.TokenAddress)
+	return &PreparedTxResponse{
+		Transaction: unsignedTx,
+		Message:     "Sign the transaction with your wallet and submit it",
+	}, nil
+}
+
+// SubmitTrade verifies and broadcasts a wallet-signed buy or sell transaction
+func (s *TradingService) SubmitTrade(ctx context.Context, req *SubmitTradeRequest) (*TradeResponse, error) {
+	if !common.IsHexAddress(req.FromAddress) {
+		return nil, fmt.Errorf("invalid sender address: %s", req.FromAddress)
+	}
+
+	circle, err := s.getTradableCircle(ctx, req.CircleID)
+	if err != nil {
+		return nil, err
+	}
 
-	// Execute sell on blockchain
-	txHash, err := s.web3Svc.SellTokens(ctx, req.PrivateKey, tokenAddr, req.Amount, req.MinRefund)
+	from := common.HexToAddress(req.FromAddress)
+	signedTx, call, err := s.web3Svc.VerifyBondingCurveTx(req.SignedTx, from, web3.MethodBuyTokens, web3.MethodSellTokens)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sell tokens: %w", err)
+		return nil, err
+	}
+
+	// The calldata must trade this circle's token, not another one
+	tokenArg, ok := call.Args[0].(common.Address)
+	if !ok || tokenArg != common.HexToAddress(circle.TokenAddress) {
+		return nil, fmt.Errorf("%w: transaction does not trade circle %d", web3.ErrInvalidTransaction, circle.ID)
+	}
+
+	if call.Method == web3.MethodBuyTokens && !circle.Active {
+		return nil, fmt.Errorf("%w: circle is not active", ErrCircleStateConflict)
+	}
+
+	if err := s.web3Svc.SendSignedTx(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	// A buy only names the least it mints, so its amount is left for the
+	// indexer to fill in from the purchase event
+	txType, message, amount := "buy", "Buy transaction submitted successfully", big.NewInt(0)
+	if call.Method == web3.MethodSellTokens {
+		txType, message = "sell", "Sell transaction submitted successfully"
+		amount, _ = call.Args[1].(*big.Int)
 	}
 
 	// Record transaction
 	tx := &models.Transaction{
-		CircleID:    circle.ID,
-		TxHash:      txHash,
-		TxType:      "sell",
-		Amount:      req.Amount.String(),
-		Status:      "pending",
+		CircleID:     circle.ID,
+		TxHash:       signedTx.Hash().Hex(),
+		TxType:       txType,
+		FromAddress:  from.Hex(),
+		ToAddress:    signedTx.To().Hex(),
+		Amount:       amount.String(),
+		Status:       "pending",
 		TokenAddress: circle.TokenAddress,
+		Timestamp:    time.Now(),
 	}
 
+	resp := &TradeResponse{
+		TxHash:  tx.TxHash,
+		Message: message,
+	}
+
+	// The trade is already broadcast, so failing the request would only
+	// invite a second one; the indexer records it if it mines
 	if err := s.txRepo.Create(ctx, tx); err != nil {
-		// Log error but don't fail
-		fmt.Printf("failed to record transaction: %v\n", err)
+		logger.Error("Failed to record trade", "circle_id", circle.ID, "tx_hash", tx.TxHash, "error", err)
+		resp.Warning = "Transaction was broadcast but not recorded: " + err.Error()
 	}
 
-	return &TradeResponse{
-		TxHash:  txHash,
-		Message: "Sell transaction submitted successfully",
-	}, nil
+	return resp, nil
+}
+
+// getTradableCircle loads a circle and checks it is confirmed on-chain
+func (s *TradingService) getTradableCircle(ctx context.Context, circleID uint64) (*models.Circle, error) {
+	circle, err := s.circleRepo.GetByID(ctx, circleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCircleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+
+	if circle.Status != "confirmed" {
+		return nil, fmt.Errorf("%w: circle is not confirmed yet", ErrCircleStateConflict)
+	}
+
+	return circle, nil
 }
 
 // GetTokenBalance retrieves token balance for a user
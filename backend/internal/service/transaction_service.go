@@ -0,0 +1,174 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ErrTransactionNotFound is returned when no recorded transaction has the hash
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// TransactionService handles speeding up and cancelling stuck transactions
+type TransactionService struct {
+	txRepo  *repository.TransactionRepository
+	web3Svc *web3.Web3Service
+}
+
+// NewTransactionService creates a new transaction service
+func NewTransactionService(txRepo *repository.TransactionRepository, web3Svc *web3.Web3Service) *TransactionService {
+	return &TransactionService{
+		txRepo:  txRepo,
+		web3Svc: web3Svc,
+	}
+}
+
+// PrepareReplacementRequest represents a request to speed up or cancel a transaction
+type PrepareReplacementRequest struct {
+	FromAddress string `json:"from_address"`
+}
+
+// SubmitReplacementRequest represents a wallet-signed replacement transaction
+type SubmitReplacementRequest struct {
+	SignedTx    string `json:"signed_tx" binding:"required"`
+	FromAddress string `json:"from_address"`
+}
+
+// ReplacementResponse represents a broadcast replacement
+type ReplacementResponse struct {
+	TxHash       string `json:"tx_hash"`
+	ReplacedHash string `json:"replaced_hash"`
+	Message      string `json:"message"`
+	Warning      string `json:"warning,omitempty"`
+}
+
+// PrepareReplacement builds an unsigned transaction that reuses the nonce of
+// a stuck transaction with higher fees. With cancel set it replaces the call
+// with an empty transfer to the sender instead of repeating it.
+func (s *TransactionService) PrepareReplacement(ctx context.Context, txHash string, req *PrepareReplacementRequest, cancel bool) (*PreparedTxResponse, error) {
+	if !common.IsHexAddress(req.FromAddress) {
+		return nil, fmt.Errorf("invalid sender address: %s", req.FromAddress)
+	}
+
+	row, err := s.getPendingTransaction(ctx, txHash, req.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedTx, err := s.web3Svc.PrepareReplacement(ctx, row.TxHash, common.HexToAddress(req.FromAddress), cancel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare replacement: %w", err)
+	}
+
+	message := "Sign this transaction to speed up " + row.TxHash
+	if cancel {
+		message = "Sign this transaction to cancel " + row.TxHash
+	}
+
+	return &PreparedTxResponse{
+		Transaction: unsignedTx,
+		Message:     message,
+	}, nil
+}
+
+// SubmitReplacement verifies and broadcasts a wallet-signed replacement and
+// points the recorded transaction at the replacement hash
+func (s *TransactionService) SubmitReplacement(ctx context.Context, txHash string, req *SubmitReplacementRequest) (*ReplacementResponse, error) {
+	if !common.IsHexAddress(req.FromAddress) {
+		return nil, fmt.Errorf("invalid sender address: %s", req.FromAddress)
+	}
+
+	row, err := s.getPendingTransaction(ctx, txHash, req.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	original, _, err := s.web3Svc.PendingTransaction(ctx, row.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	from := common.HexToAddress(req.FromAddress)
+	signedTx, cancel, err := s.web3Svc.VerifyReplacementTx(req.SignedTx, from, original)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.web3Svc.SendSignedTx(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	resp := &ReplacementResponse{
+		TxHash:       signedTx.Hash().Hex(),
+		ReplacedHash: row.TxHash,
+		Message:      "Speed-up transaction submitted successfully",
+	}
+	if cancel {
+		resp.Message = "Cancel transaction submitted successfully"
+	}
+
+	// The replacement is already broadcast, so failing the request would only
+	// invite a second one; the indexer records it if it mines
+	if err := s.recordReplacement(ctx, row, resp.TxHash, cancel); err != nil {
+		logger.Error("Failed to record replacement", "tx_hash", resp.ReplacedHash, "replacement", resp.TxHash, "error", err)
+		resp.Warning = "Replacement was broadcast but not recorded: " + err.Error()
+	}
+
+	return resp, nil
+}
+
+// getPendingTransaction loads a recorded transaction that can still be replaced
+func (s *TransactionService) getPendingTransaction(ctx context.Context, txHash, fromAddress string) (*models.Transaction, error) {
+	row, err := s.txRepo.GetByHash(ctx, txHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if !strings.EqualFold(row.FromAddress, fromAddress) {
+		return nil, fmt.Errorf("%w: transaction was not sent by %s", web3.ErrNotReplaceable, fromAddress)
+	}
+	if row.Status != "pending" {
+		return nil, fmt.Errorf("%w: transaction is %s", web3.ErrNotReplaceable, row.Status)
+	}
+
+	return row, nil
+}
+
+// recordReplacement moves the transaction row, and the circle it creates if
+// any, over to the replacement hash. A cancelled row becomes a "cancel" so
+// the reconciler fails the circle instead of reporting the original action.
+// The original hash is kept, so the row still settles if the original is
+// mined after all.
+func (s *TransactionService) recordReplacement(ctx context.Context, row *models.Transaction, newHash string, cancel bool) error {
+	superseded := &models.TransactionReplacement{
+		TxHash:    row.TxHash,
+		TxType:    row.TxType,
+		ToAddress: row.ToAddress,
+		Amount:    row.Amount,
+	}
+
+	row.TxHash = newHash
+	row.ReplacedHash = superseded.TxHash
+	if cancel {
+		row.TxType = "cancel"
+		row.ToAddress = row.FromAddress
+		row.Amount = "0"
+	}
+
+	return s.txRepo.Replace(ctx, row, superseded)
+}
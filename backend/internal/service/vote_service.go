@@ -0,0 +1,258 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/votes"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// Vote directions
+const (
+	VoteUp   = "up"
+	VoteDown = "down"
+	VoteNone = "none"
+)
+
+// ErrInvalidVote is returned for an unknown direction, a downvote on a
+// comment or a malformed wallet address
+var ErrInvalidVote = errors.New("invalid vote")
+
+// VoteService manages the vote ledger of posts and comments
+type VoteService struct {
+	voteRepo    *repository.VoteRepository
+	postRepo    *repository.PostRepository
+	commentRepo *repository.CommentRepository
+	userRepo    *repository.UserRepository
+	holderRepo  *repository.HolderRepository
+	cfg         config.VoteConfig
+}
+
+// NewVoteService creates a new vote service
+func NewVoteService(
+	voteRepo *repository.VoteRepository,
+	postRepo *repository.PostRepository,
+	commentRepo *repository.CommentRepository,
+	userRepo *repository.UserRepository,
+	holderRepo *repository.HolderRepository,
+	cfg config.VoteConfig,
+) *VoteService {
+	return &VoteService{
+		voteRepo:    voteRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		userRepo:    userRepo,
+		holderRepo:  holderRepo,
+		cfg:         cfg,
+	}
+}
+
+// VoteRequest represents a vote on a post or comment. A direction of none
+// retracts the vote.
+type VoteRequest struct {
+	Direction string `json:"direction" binding:"required"`
+}
+
+// VoteResponse represents a target's counters after a vote
+type VoteResponse struct {
+	TargetType string `json:"target_type"`
+	TargetID   uint64 `json:"target_id"`
+	Direction  string `json:"direction"`
+	Changed    bool   `json:"changed"`
+	Upvotes    uint   `json:"upvotes"`
+	Downvotes  uint   `json:"downvotes"`
+}
+
+// VotePost upvotes, downvotes or retracts a user's vote on a post. Voting
+// the way the user already voted changes nothing.
+func (s *VoteService) VotePost(ctx context.Context, postID uint64, userAddress, direction string) (*VoteResponse, error) {
+	value, err := voteValue(direction)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsHexAddress(userAddress) {
+		return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidVote, userAddress)
+	}
+
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return s.cast(ctx, models.VoteTargetPost, post.PostID, post.CircleID, userAddress, value)
+}
+
+// VoteComment upvotes or retracts a user's vote on a comment. Comments
+// cannot be downvoted.
+func (s *VoteService) VoteComment(ctx context.Context, commentID uint64, userAddress, direction string) (*VoteResponse, error) {
+	value, err := voteValue(direction)
+	if err != nil {
+		return nil, err
+	}
+	if value < 0 {
+		return nil, fmt.Errorf("%w: comments cannot be downvoted", ErrInvalidVote)
+	}
+	if !common.IsHexAddress(userAddress) {
+		return nil, fmt.Errorf("%w: invalid wallet address %s", ErrInvalidVote, userAddress)
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment.IsDeleted {
+		return nil, ErrCommentNotFound
+	}
+	post, err := s.getPost(ctx, comment.PostID)
+	if errors.Is(err, ErrPostNotFound) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.cast(ctx, models.VoteTargetComment, comment.CommentID, post.CircleID, userAddress, value)
+}
+
+// Reconcile recomputes every post's and comment's counters from the ledger
+// and returns how many had drifted
+func (s *VoteService) Reconcile(ctx context.Context) (int64, error) {
+	fixed, err := s.voteRepo.Reconcile(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reconcile votes: %w", err)
+	}
+	return fixed, nil
+}
+
+// RunReconciliation reconciles vote counters with the ledger every interval
+// until the context is cancelled
+func (s *VoteService) RunReconciliation(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fixed, err := s.Reconcile(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Error("Vote reconciliation failed", "error", err)
+		} else if fixed > 0 {
+			logger.Info("Vote counters reconciled", "fixed", fixed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// cast records a vote on a target in a post, optionally in a circle, and
+// reports the target's counters
+func (s *VoteService) cast(ctx context.Context, targetType string, targetID uint64, circleID *uint64, userAddress string, value int8) (*VoteResponse, error) {
+	address := common.HexToAddress(userAddress).Hex()
+	user, err := s.userRepo.GetOrCreateByAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var weight uint = 1
+	if value != 0 && circleID != nil && s.cfg.WeightByBalance {
+		weight, err = s.weight(ctx, *circleID, address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tally, changed, err := s.voteRepo.Cast(ctx, user.UserID, targetType, targetID, value, weight)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if targetType == models.VoteTargetComment {
+			return nil, ErrCommentNotFound
+		}
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record vote: %w", err)
+	}
+
+	resp := &VoteResponse{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Direction:  VoteNone,
+		Changed:    changed,
+		Upvotes:    tally.Upvotes,
+		Downvotes:  tally.Downvotes,
+	}
+	switch {
+	case value > 0:
+		resp.Direction = VoteUp
+	case value < 0:
+		resp.Direction = VoteDown
+	}
+	return resp, nil
+}
+
+// weight is how much a vote in a circle counts given the voter's current
+// balance of its token
+func (s *VoteService) weight(ctx context.Context, circleID uint64, address string) (uint, error) {
+	holder, err := s.holderRepo.Get(ctx, circleID, address)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return votes.Weight(nil, s.cfg.MaxWeight), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance: %w", err)
+	}
+	balance, err := web3.ParseUnits(holder.Balance, 18)
+	if err != nil {
+		return 0, fmt.Errorf("invalid balance in circle %d: %w", circleID, err)
+	}
+	return votes.Weight(balance, s.cfg.MaxWeight), nil
+}
+
+// getPost loads an undeleted post
+func (s *VoteService) getPost(ctx context.Context, postID uint64) (*models.Post, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post.IsDeleted {
+		return nil, ErrPostNotFound
+	}
+	return post, nil
+}
+
+// voteValue is the ledger value of a vote direction
+func voteValue(direction string) (int8, error) {
+	switch strings.ToLower(direction) {
+	case VoteUp:
+		return 1, nil
+	case VoteDown:
+		return -1, nil
+	case VoteNone:
+		return 0, nil
+	}
+	return 0, fmt.Errorf("%w: direction must be up, down or none", ErrInvalidVote)
+}
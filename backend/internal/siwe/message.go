@@ -0,0 +1,266 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+// Package siwe parses and verifies Sign-In With Ethereum (EIP-4361) messages
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrInvalidMessage is returned for text that is not an EIP-4361 message
+	ErrInvalidMessage = errors.New("invalid sign-in message")
+	// ErrInvalidSignature is returned when a signature cannot be recovered
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+const (
+	headerSuffix = " wants you to sign in with your Ethereum account:"
+	// Version is the only message version defined by EIP-4361
+	Version = "1"
+)
+
+// nonceFormat is the EIP-4361 nonce grammar: at least 8 alphanumerics
+var nonceFormat = regexp.MustCompile(`^[a-zA-Z0-9]{8,}$`)
+
+// Message represents a parsed EIP-4361 message
+type Message struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// Parse reads an EIP-4361 message. The address must be EIP-55 checksummed
+// as the standard requires.
+func Parse(text string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	p := &parser{lines: lines}
+	msg := &Message{}
+
+	header, ok := p.next()
+	if !ok || !strings.HasSuffix(header, headerSuffix) {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidMessage)
+	}
+	msg.Domain = strings.TrimSuffix(header, headerSuffix)
+	if msg.Domain == "" || strings.ContainsAny(msg.Domain, " /") {
+		return nil, fmt.Errorf("%w: invalid domain %q", ErrInvalidMessage, msg.Domain)
+	}
+
+	address, _ := p.next()
+	if !common.IsHexAddress(address) || common.HexToAddress(address).Hex() != address {
+		return nil, fmt.Errorf("%w: address %q is not EIP-55 checksummed", ErrInvalidMessage, address)
+	}
+	msg.Address = common.HexToAddress(address)
+
+	if line, _ := p.next(); line != "" {
+		return nil, fmt.Errorf("%w: expected blank line after address", ErrInvalidMessage)
+	}
+
+	// The statement is optional; both the current grammar, which keeps the
+	// blank line without one, and older wallets that drop it are accepted
+	if line, _ := p.peek(); line != "" && !strings.HasPrefix(line, "URI: ") {
+		msg.Statement, _ = p.next()
+		if line, _ := p.next(); line != "" {
+			return nil, fmt.Errorf("%w: expected blank line after statement", ErrInvalidMessage)
+		}
+	} else if line == "" {
+		p.next()
+	}
+
+	var err error
+	if msg.URI, err = p.field("URI", true); err != nil {
+		return nil, err
+	}
+	if msg.Version, err = p.field("Version", true); err != nil {
+		return nil, err
+	}
+	if msg.Version != Version {
+		return nil, fmt.Errorf("%w: unsupported version %q", ErrInvalidMessage, msg.Version)
+	}
+
+	chainID, err := p.field("Chain ID", true)
+	if err != nil {
+		return nil, err
+	}
+	if msg.ChainID, err = strconv.ParseInt(chainID, 10, 64); err != nil || msg.ChainID <= 0 {
+		return nil, fmt.Errorf("%w: invalid chain ID %q", ErrInvalidMessage, chainID)
+	}
+
+	if msg.Nonce, err = p.field("Nonce", true); err != nil {
+		return nil, err
+	}
+	if !nonceFormat.MatchString(msg.Nonce) {
+		return nil, fmt.Errorf("%w: invalid nonce %q", ErrInvalidMessage, msg.Nonce)
+	}
+
+	issuedAt, err := p.field("Issued At", true)
+	if err != nil {
+		return nil, err
+	}
+	if msg.IssuedAt, err = parseTime("Issued At", issuedAt); err != nil {
+		return nil, err
+	}
+
+	if expiration, _ := p.field("Expiration Time", false); expiration != "" {
+		t, err := parseTime("Expiration Time", expiration)
+		if err != nil {
+			return nil, err
+		}
+		msg.ExpirationTime = &t
+	}
+	if notBefore, _ := p.field("Not Before", false); notBefore != "" {
+		t, err := parseTime("Not Before", notBefore)
+		if err != nil {
+			return nil, err
+		}
+		msg.NotBefore = &t
+	}
+	msg.RequestID, _ = p.field("Request ID", false)
+
+	if line, ok := p.peek(); ok && line == "Resources:" {
+		p.next()
+		for {
+			line, ok := p.peek()
+			if !ok || !strings.HasPrefix(line, "- ") {
+				break
+			}
+			p.next()
+			msg.Resources = append(msg.Resources, strings.TrimPrefix(line, "- "))
+		}
+	}
+
+	// Anything left over, other than a trailing newline, is not part of the grammar
+	for {
+		line, ok := p.next()
+		if !ok {
+			break
+		}
+		if line != "" {
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidMessage, line)
+		}
+	}
+
+	return msg, nil
+}
+
+// String renders the message in EIP-4361 form, the exact text a wallet signs
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address.Hex() + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + strconv.FormatInt(m.ChainID, 10) + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.UTC().Format(time.RFC3339) + "\n")
+	if m.ExpirationTime != nil {
+		b.WriteString("Expiration Time: " + m.ExpirationTime.UTC().Format(time.RFC3339) + "\n")
+	}
+	if m.NotBefore != nil {
+		b.WriteString("Not Before: " + m.NotBefore.UTC().Format(time.RFC3339) + "\n")
+	}
+	if m.RequestID != "" {
+		b.WriteString("Request ID: " + m.RequestID + "\n")
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("Resources:\n")
+		for _, resource := range m.Resources {
+			b.WriteString("- " + resource + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// RecoverSigner returns the account that produced a personal_sign (EIP-191)
+// signature over the message text
+func RecoverSigner(text string, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidSignature, crypto.SignatureLength, len(signature))
+	}
+
+	// Wallets return V as 27/28 while SigToPub expects the recovery ID
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(text)), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+func parseTime(name, value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s %q", ErrInvalidMessage, name, value)
+	}
+	return t, nil
+}
+
+// parser walks the message line by line
+type parser struct {
+	lines []string
+	pos   int
+}
+
+func (p *parser) peek() (string, bool) {
+	if p.pos >= len(p.lines) {
+		return "", false
+	}
+	return p.lines[p.pos], true
+}
+
+func (p *parser) next() (string, bool) {
+	line, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return line, ok
+}
+
+// field consumes a "Name: value" line. Optional fields that are absent
+// return an empty value without consuming anything.
+func (p *parser) field(name string, required bool) (string, error) {
+	line, _ := p.peek()
+	prefix := name + ": "
+	if !strings.HasPrefix(line, prefix) {
+		if required {
+			return "", fmt.Errorf("%w: missing %s", ErrInvalidMessage, name)
+		}
+		return "", nil
+	}
+	p.next()
+
+	value := strings.TrimPrefix(line, prefix)
+	if value == "" {
+		return "", fmt.Errorf("%w: empty %s", ErrInvalidMessage, name)
+	}
+	return value, nil
+}
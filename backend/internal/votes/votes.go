@@ -0,0 +1,33 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package votes
+
+import (
+	"math/big"
+)
+
+// Weight is the weight of a vote cast while holding the given balance of
+// the circle token, in base units. It grows with the square root of the
+// whole tokens held, so influence rises with stake but large holders cannot
+// simply outvote everyone, and is capped at maxWeight when that is not zero.
+// Every voter counts at least once.
+func Weight(balance *big.Int, maxWeight uint) uint {
+	if balance == nil || balance.Sign() <= 0 {
+		return 1
+	}
+
+	whole := new(big.Int).Quo(balance, big.NewInt(1e18))
+	root := whole.Sqrt(whole)
+	if maxWeight > 0 && root.Cmp(new(big.Int).SetUint64(uint64(maxWeight))) >= 0 {
+		return maxWeight
+	}
+	if !root.IsUint64() || root.Uint64() > uint64(^uint32(0)) {
+		return uint(^uint32(0))
+	}
+	if root.Sign() == 0 {
+		return 1
+	}
+	return uint(root.Uint64())
+}
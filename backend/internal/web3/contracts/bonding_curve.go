@@ -0,0 +1,1373 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BondingCurveMetaData contains all meta data concerning the BondingCurve contract.
+var BondingCurveMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"name\":\"_factory\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"receive\",\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"MAX_SLIPPAGE\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"MIN_PURCHASE\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"buyTokens\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"minTokens\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"calculateBuyCost\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"supply\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"calculateEthForTokens\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenAmount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"currentSupply\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"calculateSellRefund\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"supply\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"calculateTokensForEth\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"ethAmount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"currentSupply\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"curveParameters\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"curveType\",\"type\":\"uint8\",\"internalType\":\"enumBondingCurve.CurveType\"},{\"name\":\"basePrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"slope\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"growthRate\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"maxPrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"inflectionPoint\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"factory\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getBuyPriceImpact\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"avgPrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"priceImpact\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getCurrentPrice\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getSellPriceImpact\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"avgPrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"priceImpact\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"initializeCurve\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"curveType\",\"type\":\"uint8\",\"internalType\":\"enumBondingCurve.CurveType\"},{\"name\":\"basePrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"param1\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"param2\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"param3\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"renounceOwnership\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"sellTokens\",\"inputs\":[{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"minEth\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"CurveInitialized\",\"inputs\":[{\"name\":\"token\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"curveType\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"enumBondingCurve.CurveType\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"newOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"TokensPurchased\",\"inputs\":[{\"name\":\"token\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"buyer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"cost\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"newPrice\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"TokensSold\",\"inputs\":[{\"name\":\"token\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"seller\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"refund\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"newPrice\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false}]",
+	Bin: "0x6080346200012357601f6200199538819003918201601f19168301916001600160401b0383118484101762000128578084926020946040528339810103126200012357516001600160a01b038082168083036200012357600160005562000066336200013e565b8015620000ec57600380546001600160a01b03191691909117905560015433911603620000a85762000098906200013e565b60405161180d9081620001888239f35b606460405162461bcd60e51b815260206004820152602060248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b60405162461bcd60e51b815260206004820152600f60248201526e496e76616c696420666163746f727960881b6044820152606490fd5b600080fd5b634e487b7160e01b600052604160045260246000fd5b600180546001600160a01b039283166001600160a01b0319821681179092559091167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0600080a356fe60406080815260049081361015610020575b5050361561001e57600080fd5b005b600090813560e01c9283630752881a14610b365783630eee3755146100ed5783632f8d8d3a14610a785783633830c89f146108e0578363571fd619146107df578363715018a61461077e57836374c9b78f1461070857836384cc315b146106e35783638da5cb5b146106bb578363c45a015514610693578363d36747dc14610678578363d439390c14610657578363dde663cb14610635578363e4e57b9e146101e0578363f2fde38b1461011157508263f9759518146100f257505063fcc319df146100ed573880610011565b610eec565b3461010d578160031936011261010d57602090516101f48152f35b5080fd5b346101dc5760203660031901126101dc5761012a610eac565b90610133611729565b6001600160a01b0391821692831561018a575050600154826bffffffffffffffffffffffff60a01b821617600155167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0600080a380f35b906020608492519162461bcd60e51b8352820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201526564647265737360d01b6064820152fd5b8280fd5b92503461010d576101f036610ec2565b93916101fa611781565b8115610601576001600160a01b03811690610216821515610f30565b84516370a0823160e01b81523388820152602097908881602481875afa90811561055d5790859187916105d0575b501061059657828552600288526102a88887872060a060058a519261026884610f6c565b61027660ff82541685610fd4565b6001810154809585015260028101548c8501526003810154606085015286810154608085015201549101521515610fe0565b85516318160ddd60e01b815288818381875afa90811561055d578691610567575b506102d86102e1918685611376565b97881015611024565b8551620647af60e91b815288818381875afa801561055d57869061052e575b61030b915088611064565b865163d73792a960e01b81529089828481885afa9081156104b85787916104f8575b610337925061108d565b9661034288826110ad565b97843b156104c2578751632770a7eb60e21b815233848201908152602081018890528890829081906040010381838a5af180156104da579088916104e4575b5050843b156104c25787516395cb1fa560e01b81528381018a90523360248201528781604481838a5af180156104da579088916104c6575b50508061046d575b50833b1561046957865163b70c924f60e01b815291820152848160248183875af1801561045f57610447575b50907fa0fe9740856690637d999c103293d3c823fc3b81443c34c6004bb582ab4b616661043d6104206001969594611487565b875194855260208501899052604085015233939081906060820190565b0390a35551908152f35b6104518591610f9e565b61045b57386103ed565b8380fd5b86513d87823e3d90fd5b8580fd5b843b156104c2578660249189519283809263b17acdcd60e01b82528088830152895af180156104b8579087916104a4575b506103c1565b6104ad90610f9e565b61046957853861049e565b88513d89823e3d90fd5b8680fd5b6104cf90610f9e565b6104c25786386103b9565b89513d8a823e3d90fd5b6104ed90610f9e565b6104c2578638610381565b90508982813d8311610527575b61050f8183610fb2565b810103126105225761033791519061032d565b600080fd5b503d610505565b508881813d8311610556575b6105448183610fb2565b810103126105225761030b9051610300565b503d61053a565b87513d88823e3d90fd5b90508881813d831161058f575b61057e8183610fb2565b8101031261052257516102d86102c9565b503d610574565b855162461bcd60e51b81529081018890526014602482015273496e73756666696369656e742062616c616e636560601b6044820152606490fd5b8092508a8092503d83116105fa575b6105e98183610fb2565b810103126105225784905138610244565b503d6105df565b835162461bcd60e51b8152602081880152600e60248201526d125b9d985b1a5908185b5bdd5b9d60921b6044820152606490fd5b503461010d5760209061065061064a36610ec2565b916111f1565b9051908152f35b503461010d578160031936011261010d576020905166038d7ea4c680008152f35b503461010d5760209061065061068d36610ec2565b916110c7565b503461010d578160031936011261010d5760035490516001600160a01b039091168152602090f35b503461010d578160031936011261010d5760015490516001600160a01b039091168152602090f35b503461010d57602036600319011261010d57602090610650610703610eac565b611487565b90346101dc5760203660031901126101dc5760c09281906001600160a01b0361072f610eac565b16815260026020522060ff8154169260018201549260028301549060056003850154938501549401549461076582518098610f0d565b60208701528501526060840152608083015260a0820152f35b82346107dc57806003193601126107dc57610797611729565b600180546001600160a01b031981169091556000906001600160a01b03167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e08280a380f35b80fd5b82903461010d578260031936011261010d576107f9610eac565b83516318160ddd60e01b81528392919060243560208285816001600160a01b0387165afa91821561055d5786926108ab575b50610843610848928261083d86611487565b956111f1565b61108d565b938161085e575b50505082519182526020820152f35b90919493925061086e82846110ad565b906127109182810292818404149015171561089857506108909293945061108d565b83808061084f565b634e487b7160e01b815260118652602490fd5b91506020823d82116108d8575b816108c560209383610fb2565b810103126105225790519061084361082b565b3d91506108b8565b92503461010d5760c036600319011261010d576108fb610eac565b9060243590600382101561045b5760035460a435936001600160a01b039182163303610a4657169261092e841515610f30565b838552600260205260018286200154610a0d5781519061094d82610f6c565b6109578483610fd4565b602082016044358152838301606435815260608401906084358252608085019284845260a08601948552888a526002602052868a20955160038110156109fa57865460ff191660ff91909116178655516001860155516002850155516003840155518783015551600590910155517ff07226072cbf1591a0c0324a692d7c1a4668d2623937bc7279e36f996b916af491602091906109f6908290610f0d565ba280f35b634e487b7160e01b8b5260218c5260248bfd5b815162461bcd60e51b81526020818801526013602482015272105b1c9958591e481a5b9a5d1a585b1a5e9959606a1b6044820152606490fd5b825162461bcd60e51b8152602081890152600c60248201526b4f6e6c7920666163746f727960a01b6044820152606490fd5b82903461010d578260031936011261010d57610a92610eac565b83516318160ddd60e01b81528392919060243560208285816001600160a01b0387165afa91821561055d578692610b01575b50610843610adc9282610ad686611487565b95611376565b9381610af15750505082519182526020820152f35b90919493925061086e83836110ad565b91506020823d8211610b2e575b81610b1b60209383610fb2565b8101031261052257905190610843610ac4565b3d9150610b0e565b90806003193601126101dc57610b4a610eac565b92610b53611781565b66038d7ea4c680003410610e79576001600160a01b038416610b76811515610f30565b80825260209460028652610bd68484208551610b9181610f6c565b610b9f60ff83541682610fd4565b60a06005600184015493848c85015260028101548a850152600381015460608501528a810154608085015201549101521515610fe0565b83516318160ddd60e01b815286818781865afa8015610da3578490610e4a575b610c02915034836110c7565b94610c11602435871015611024565b8451620647af60e91b815287818381875afa801561045f578590610e1b575b610c3b915034611064565b855163d73792a960e01b815288818481885afa90811561055d578691610dec575b50610c669161108d565b610c7081346110ad565b843b156104695786516340c10f1960e01b815233848201908152602081018a90528790829081906040010381838a5af180156104b857610dd9575b50843b15610469578560249188519283809263b1107d6560e01b82528088830152895af1801561055d57908691610dc5575b5050833b15610dc1578460249187519283809263b17acdcd60e01b82528087830152885af1801561045f57908591610dad575b5050823b1561045b5784519063b70c924f60e01b82523490820152838160248183875af18015610da357610d8f575b5090610d4d60019392611487565b845186815234602082015260408101919091523391907f377aadedb6b2a771959584d10a6a36eccb5f56b4eb3a48525f76108d2660d8d490806060810161043d565b610d998491610f9e565b6101dc5786610d3f565b85513d86823e3d90fd5b610db690610f9e565b61045b578388610d10565b8480fd5b610dce90610f9e565b610dc1578489610cdd565b610de590969196610f9e565b9489610cab565b90508881813d8311610e14575b610e038183610fb2565b810103126104695751610c66610c5c565b503d610df9565b508781813d8311610e43575b610e318183610fb2565b81010312610dc157610c3b9051610c30565b503d610e27565b508681813d8311610e72575b610e608183610fb2565b8101031261045b57610c029051610bf6565b503d610e56565b815162461bcd60e51b8152602081850152600d60248201526c42656c6f77206d696e696d756d60981b6044820152606490fd5b600435906001600160a01b038216820361052257565b6060906003190112610522576004356001600160a01b038116810361052257906024359060443590565b34610522576020610f05610eff36610ec2565b91611376565b604051908152f35b906003821015610f1a5752565b634e487b7160e01b600052602160045260246000fd5b15610f3757565b60405162461bcd60e51b815260206004820152600d60248201526c24b73b30b634b2103a37b5b2b760991b6044820152606490fd5b60c0810190811067ffffffffffffffff821117610f8857604052565b634e487b7160e01b600052604160045260246000fd5b67ffffffffffffffff8111610f8857604052565b90601f8019910116810190811067ffffffffffffffff821117610f8857604052565b6003821015610f1a5752565b15610fe757565b60405162461bcd60e51b815260206004820152601560248201527410dd5c9d99481b9bdd081a5b9a5d1a585b1a5e9959605a1b6044820152606490fd5b1561102b57565b60405162461bcd60e51b81526020600482015260116024820152700a6d8d2e0e0c2ceca40e8dede40d0d2ced607b1b6044820152606490fd5b8181029291811591840414171561107757565b634e487b7160e01b600052601160045260246000fd5b8115611097570490565b634e487b7160e01b600052601260045260246000fd5b9190820391821161107757565b9190820180921161107757565b9060009060018060a01b038316825260026020526040822090604051906110ed82610f6c565b6110fb60ff84541683610fd4565b600191828401546020820152600284015460408201526003840154606082015260a06005600495868101546080850152015491015283956103e88083029083820414831517156111de579695949392919084975b8088111561116257505050505050505090565b9091929394959697611174828a6110ba565b851c98611182848b8b6111f1565b808603611196575050505050505050505090565b8511156111c95750508388018089116111b6575b9695949392919061114f565b634e487b7160e01b875260118652602487fd5b91509760001981019081116111b657906111aa565b634e487b7160e01b865260118552602486fd5b6001600160a01b0316600090815260026020526040808220905191939192919061121a84610f6c565b61122860ff82541685610fd4565b600181015490602085019182526002810154946040810195865260056003830154926060830193845260048101546080840152015460a082015280516003811015611362576112e9575050826112819151945194611064565b936001600160ff1b03821682036112d557506112d293926112c5836112bf6112b8671bc16d674ec80000966112cb9660011b611064565b9180611064565b906110ba565b90611064565b04906110ba565b90565b634e487b7160e01b81526011600452602490fd5b909293945051600381101561134e57600114611306575050505090565b9291925190519084935b83851061131f57505050505090565b909192939461133f611345916112bf868661133a8b886110ba565b6116b3565b9561171a565b93929190611310565b634e487b7160e01b86526021600452602486fd5b634e487b7160e01b88526021600452602488fd5b6001600160a01b03166000908152600260205260408082209051919392919061139e82610f6c565b6113ac60ff82541683610fd4565b600181015490602083019182526002810154926040810193845260056003830154926060830193845260048101546080840152015460a0820152805160038110156113625785939291906114205750509061141a81611281935194519661141582821015611671565b6110ad565b92611064565b919250925051600381101561134e5760011461143d575050505090565b519051909361144e83851015611671565b936001935b8385111561146357505050505090565b909192939461133f61147e916112bf868661133a8b886110ad565b93929190611453565b6040516318160ddd60e01b8152602091600491906001600160a01b031683828481845afa91821561166557600092611636575b5060005260028352604060002092604051926114d584610f6c565b6114e360ff86541685610fd4565b60018501549184019182526002850154936040810194855260038601549160608201928352600581880154976080840198895201549160a08101928352805160038110156116215761154f57505050506112d29350670de0b6b3a7640000916112cb9151935190611064565b8091929395965051600381101561162157600103611578575050506112d29350519051916116b3565b90929194935051600381101561162157600214611599575050505050600090565b826115ad6115b292518097519651966110ad565b611064565b91670de0b6b3a7640000938484029380850486149015171561160c57906115d8916110ba565b8381029381850414901517156115f757506112d292916112bf9161108d565b601190634e487b7160e01b6000525260246000fd5b601183634e487b7160e01b6000525260246000fd5b602183634e487b7160e01b6000525260246000fd5b90918482813d831161165e575b61164d8183610fb2565b810103126107dc57505190386114ba565b503d611643565b6040513d6000823e3d90fd5b1561167857565b60405162461bcd60e51b8152602060048201526013602482015272496e73756666696369656e7420737570706c7960681b6044820152606490fd5b90811561171457620f42406116c88484611064565b0492670de0b6b3a764000093840180851161107757600019840193808511611077576112cb836115ad6112c5956115ad611710996c193e5939a08ce9dbd48000000096611064565b0490565b91505090565b60001981146110775760010190565b6001546001600160a01b0316330361173d57565b606460405162461bcd60e51b815260206004820152602060248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b600260005414611792576002600055565b60405162461bcd60e51b815260206004820152601f60248201527f5265656e7472616e637947756172643a207265656e7472616e742063616c6c006044820152606490fdfea2646970667358221220f4a259dd0635f0cd3ffd66c4564fbe593a51562b8c2199f2b087f112b0f9256964736f6c63430008140033",
+}
+
+// BondingCurveABI is the input ABI used to generate the binding from.
+// Deprecated: Use BondingCurveMetaData.ABI instead.
+var BondingCurveABI = BondingCurveMetaData.ABI
+
+// BondingCurveBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use BondingCurveMetaData.Bin instead.
+var BondingCurveBin = BondingCurveMetaData.Bin
+
+// DeployBondingCurve deploys a new Ethereum contract, binding an instance of BondingCurve to it.
+func DeployBondingCurve(auth *bind.TransactOpts, backend bind.ContractBackend, _factory common.Address) (common.Address, *types.Transaction, *BondingCurve, error) {
+	parsed, err := BondingCurveMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(BondingCurveBin), backend, _factory)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &BondingCurve{BondingCurveCaller: BondingCurveCaller{contract: contract}, BondingCurveTransactor: BondingCurveTransactor{contract: contract}, BondingCurveFilterer: BondingCurveFilterer{contract: contract}}, nil
+}
+
+// BondingCurve is an auto generated Go binding around an Ethereum contract.
+type BondingCurve struct {
+	BondingCurveCaller     // Read-only binding to the contract
+	BondingCurveTransactor // Write-only binding to the contract
+	BondingCurveFilterer   // Log filterer for contract events
+}
+
+// BondingCurveCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BondingCurveCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BondingCurveTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BondingCurveTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BondingCurveFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BondingCurveFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BondingCurveSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BondingCurveSession struct {
+	Contract     *BondingCurve     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BondingCurveCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BondingCurveCallerSession struct {
+	Contract *BondingCurveCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// BondingCurveTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BondingCurveTransactorSession struct {
+	Contract     *BondingCurveTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// BondingCurveRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BondingCurveRaw struct {
+	Contract *BondingCurve // Generic contract binding to access the raw methods on
+}
+
+// BondingCurveCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BondingCurveCallerRaw struct {
+	Contract *BondingCurveCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BondingCurveTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BondingCurveTransactorRaw struct {
+	Contract *BondingCurveTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBondingCurve creates a new instance of BondingCurve, bound to a specific deployed contract.
+func NewBondingCurve(address common.Address, backend bind.ContractBackend) (*BondingCurve, error) {
+	contract, err := bindBondingCurve(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &BondingCurve{BondingCurveCaller: BondingCurveCaller{contract: contract}, BondingCurveTransactor: BondingCurveTransactor{contract: contract}, BondingCurveFilterer: BondingCurveFilterer{contract: contract}}, nil
+}
+
+// NewBondingCurveCaller creates a new read-only instance of BondingCurve, bound to a specific deployed contract.
+func NewBondingCurveCaller(address common.Address, caller bind.ContractCaller) (*BondingCurveCaller, error) {
+	contract, err := bindBondingCurve(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BondingCurveCaller{contract: contract}, nil
+}
+
+// NewBondingCurveTransactor creates a new write-only instance of BondingCurve, bound to a specific deployed contract.
+func NewBondingCurveTransactor(address common.Address, transactor bind.ContractTransactor) (*BondingCurveTransactor, error) {
+	contract, err := bindBondingCurve(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BondingCurveTransactor{contract: contract}, nil
+}
+
+// NewBondingCurveFilterer creates a new log filterer instance of BondingCurve, bound to a specific deployed contract.
+func NewBondingCurveFilterer(address common.Address, filterer bind.ContractFilterer) (*BondingCurveFilterer, error) {
+	contract, err := bindBondingCurve(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BondingCurveFilterer{contract: contract}, nil
+}
+
+// bindBondingCurve binds a generic wrapper to an already deployed contract.
+func bindBondingCurve(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BondingCurveMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BondingCurve *BondingCurveRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BondingCurve.Contract.BondingCurveCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BondingCurve *BondingCurveRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BondingCurve.Contract.BondingCurveTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BondingCurve *BondingCurveRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BondingCurve.Contract.BondingCurveTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BondingCurve *BondingCurveCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BondingCurve.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BondingCurve *BondingCurveTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BondingCurve.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BondingCurve *BondingCurveTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BondingCurve.Contract.contract.Transact(opts, method, params...)
+}
+
+// MAXSLIPPAGE is a free data retrieval call binding the contract method 0xf9759518.
+//
+// Solidity: function MAX_SLIPPAGE() view returns(uint256)
+func (_BondingCurve *BondingCurveCaller) MAXSLIPPAGE(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "MAX_SLIPPAGE")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXSLIPPAGE is a free data retrieval call binding the contract method 0xf9759518.
+//
+// Solidity: function MAX_SLIPPAGE() view returns(uint256)
+func (_BondingCurve *BondingCurveSession) MAXSLIPPAGE() (*big.Int, error) {
+	return _BondingCurve.Contract.MAXSLIPPAGE(&_BondingCurve.CallOpts)
+}
+
+// MAXSLIPPAGE is a free data retrieval call binding the contract method 0xf9759518.
+//
+// Solidity: function MAX_SLIPPAGE() view returns(uint256)
+func (_BondingCurve *BondingCurveCallerSession) MAXSLIPPAGE() (*big.Int, error) {
+	return _BondingCurve.Contract.MAXSLIPPAGE(&_BondingCurve.CallOpts)
+}
+
+// MINPURCHASE is a free data retrieval call binding the contract method 0xd439390c.
+//
+// Solidity: function MIN_PURCHASE() view returns(uint256)
+func (_BondingCurve *BondingCurveCaller) MINPURCHASE(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "MIN_PURCHASE")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINPURCHASE is a free data retrieval call binding the contract method 0xd439390c.
+//
+// Solidity: function MIN_PURCHASE() view returns(uint256)
+func (_BondingCurve *BondingCurveSession) MINPURCHASE() (*big.Int, error) {
+	return _BondingCurve.Contract.MINPURCHASE(&_BondingCurve.CallOpts)
+}
+
+// MINPURCHASE is a free data retrieval call binding the contract method 0xd439390c.
+//
+// Solidity: function MIN_PURCHASE() view returns(uint256)
+func (_BondingCurve *BondingCurveCallerSession) MINPURCHASE() (*big.Int, error) {
+	return _BondingCurve.Contract.MINPURCHASE(&_BondingCurve.CallOpts)
+}
+
+// CalculateBuyCost is a free data retrieval call binding the contract method 0xdde663cb.
+//
+// Solidity: function calculateBuyCost(address tokenAddress, uint256 amount, uint256 supply) view returns(uint256)
+func (_BondingCurve *BondingCurveCaller) CalculateBuyCost(opts *bind.CallOpts, tokenAddress common.Address, amount *big.Int, supply *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "calculateBuyCost", tokenAddress, amount, supply)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CalculateBuyCost is a free data retrieval call binding the contract method 0xdde663cb.
+//
+// Solidity: function calculateBuyCost(address tokenAddress, uint256 amount, uint256 supply) view returns(uint256)
+func (_BondingCurve *BondingCurveSession) CalculateBuyCost(tokenAddress common.Address, amount *big.Int, supply *big.Int) (*big.Int, error) {
+	return _BondingCurve.Contract.CalculateBuyCost(&_BondingCurve.CallOpts, tokenAddress, amount, supply)
+}
+
+// CalculateBuyCost is a free data retrieval call binding the contract method 0xdde663cb.
+//
+// Solidity: function calculateBuyCost(address tokenAddress, uint256 amount, uint256 supply) view returns(uint256)
+func (_BondingCurve *BondingCurveCallerSession) CalculateBuyCost(tokenAddress common.Address, amount *big.Int, supply *big.Int) (*big.Int, error) {
+	return _BondingCurve.Contract.CalculateBuyCost(&_BondingCurve.CallOpts, tokenAddress, amount, supply)
+}
+
+// CalculateEthForTokens is a free data retrieval call binding the contract method 0x0eee3755.
+//
+// Solidity: function calculateEthForTokens(address tokenAddress, uint256 tokenAmount, uint256 currentSupply) view returns(uint256)
+func (_BondingCurve *BondingCurveCaller) CalculateEthForTokens(opts *bind.CallOpts, tokenAddress common.Address, tokenAmount *big.Int, currentSupply *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "calculateEthForTokens", tokenAddress, tokenAmount, currentSupply)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CalculateEthForTokens is a free data retrieval call binding the contract method 0x0eee3755.
+//
+// Solidity: function calculateEthForTokens(address tokenAddress, uint256 tokenAmount, uint256 currentSupply) view returns(uint256)
+func (_BondingCurve *BondingCurveSession) CalculateEthForTokens(tokenAddress common.Address, tokenAmount *big.Int, currentSupply *big.Int) (*big.Int, error) {
+	return _BondingCurve.Contract.CalculateEthForTokens(&_BondingCurve.CallOpts, tokenAddress, tokenAmount, currentSupply)
+}
+
+// CalculateEthForTokens is a free data retrieval call binding the contract method 0x0eee3755.
+//
+// Solidity: function calculateEthForTokens(address tokenAddress, uint256 tokenAmount, uint256 currentSupply) view returns(uint256)
+func (_BondingCurve *BondingCurveCallerSession) CalculateEthForTokens(tokenAddress common.Address, tokenAmount *big.Int, currentSupply *big.Int) (*big.Int, error) {
+	return _BondingCurve.Contract.CalculateEthForTokens(&_BondingCurve.CallOpts, tokenAddress, tokenAmount, currentSupply)
+}
+
+// CalculateSellRefund is a free data retrieval call binding the contract method 0xfcc319df.
+//
+// Solidity: function calculateSellRefund(address tokenAddress, uint256 amount, uint256 supply) view returns(uint256)
+func (_BondingCurve *BondingCurveCaller) CalculateSellRefund(opts *bind.CallOpts, tokenAddress common.Address, amount *big.Int, supply *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "calculateSellRefund", tokenAddress, amount, supply)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CalculateSellRefund is a free data retrieval call binding the contract method 0xfcc319df.
+//
+// Solidity: function calculateSellRefund(address tokenAddress, uint256 amount, uint256 supply) view returns(uint256)
+func (_BondingCurve *BondingCurveSession) CalculateSellRefund(tokenAddress common.Address, amount *big.Int, supply *big.Int) (*big.Int, error) {
+	return _BondingCurve.Contract.CalculateSellRefund(&_BondingCurve.CallOpts, tokenAddress, amount, supply)
+}
+
+// CalculateSellRefund is a free data retrieval call binding the contract method 0xfcc319df.
+//
+// Solidity: function calculateSellRefund(address tokenAddress, uint256 amount, uint256 supply) view returns(uint256)
+func (_BondingCurve *BondingCurveCallerSession) CalculateSellRefund(tokenAddress common.Address, amount *big.Int, supply *big.Int) (*big.Int, error) {
+	return _BondingCurve.Contract.CalculateSellRefund(&_BondingCurve.CallOpts, tokenAddress, amount, supply)
+}
+
+// CalculateTokensForEth is a free data retrieval call binding the contract method 0xd36747dc.
+//
+// Solidity: function calculateTokensForEth(address tokenAddress, uint256 ethAmount, uint256 currentSupply) view returns(uint256)
+func (_BondingCurve *BondingCurveCaller) CalculateTokensForEth(opts *bind.CallOpts, tokenAddress common.Address, ethAmount *big.Int, currentSupply *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "calculateTokensForEth", tokenAddress, ethAmount, currentSupply)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CalculateTokensForEth is a free data retrieval call binding the contract method 0xd36747dc.
+//
+// Solidity: function calculateTokensForEth(address tokenAddress, uint256 ethAmount, uint256 currentSupply) view returns(uint256)
+func (_BondingCurve *BondingCurveSession) CalculateTokensForEth(tokenAddress common.Address, ethAmount *big.Int, currentSupply *big.Int) (*big.Int, error) {
+	return _BondingCurve.Contract.CalculateTokensForEth(&_BondingCurve.CallOpts, tokenAddress, ethAmount, currentSupply)
+}
+
+// CalculateTokensForEth is a free data retrieval call binding the contract method 0xd36747dc.
+//
+// Solidity: function calculateTokensForEth(address tokenAddress, uint256 ethAmount, uint256 currentSupply) view returns(uint256)
+func (_BondingCurve *BondingCurveCallerSession) CalculateTokensForEth(tokenAddress common.Address, ethAmount *big.Int, currentSupply *big.Int) (*big.Int, error) {
+	return _BondingCurve.Contract.CalculateTokensForEth(&_BondingCurve.CallOpts, tokenAddress, ethAmount, currentSupply)
+}
+
+// CurveParameters is a free data retrieval call binding the contract method 0x74c9b78f.
+//
+// Solidity: function curveParameters(address ) view returns(uint8 curveType, uint256 basePrice, uint256 slope, uint256 growthRate, uint256 maxPrice, uint256 inflectionPoint)
+func (_BondingCurve *BondingCurveCaller) CurveParameters(opts *bind.CallOpts, arg0 common.Address) (struct {
+	CurveType       uint8
+	BasePrice       *big.Int
+	Slope           *big.Int
+	GrowthRate      *big.Int
+	MaxPrice        *big.Int
+	InflectionPoint *big.Int
+}, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "curveParameters", arg0)
+
+	outstruct := new(struct {
+		CurveType       uint8
+		BasePrice       *big.Int
+		Slope           *big.Int
+		GrowthRate      *big.Int
+		MaxPrice        *big.Int
+		InflectionPoint *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.CurveType = *abi.ConvertType(out[0], new(uint8)).(*uint8)
+	outstruct.BasePrice = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Slope = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.GrowthRate = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.MaxPrice = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.InflectionPoint = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// CurveParameters is a free data retrieval call binding the contract method 0x74c9b78f.
+//
+// Solidity: function curveParameters(address ) view returns(uint8 curveType, uint256 basePrice, uint256 slope, uint256 growthRate, uint256 maxPrice, uint256 inflectionPoint)
+func (_BondingCurve *BondingCurveSession) CurveParameters(arg0 common.Address) (struct {
+	CurveType       uint8
+	BasePrice       *big.Int
+	Slope           *big.Int
+	GrowthRate      *big.Int
+	MaxPrice        *big.Int
+	InflectionPoint *big.Int
+}, error) {
+	return _BondingCurve.Contract.CurveParameters(&_BondingCurve.CallOpts, arg0)
+}
+
+// CurveParameters is a free data retrieval call binding the contract method 0x74c9b78f.
+//
+// Solidity: function curveParameters(address ) view returns(uint8 curveType, uint256 basePrice, uint256 slope, uint256 growthRate, uint256 maxPrice, uint256 inflectionPoint)
+func (_BondingCurve *BondingCurveCallerSession) CurveParameters(arg0 common.Address) (struct {
+	CurveType       uint8
+	BasePrice       *big.Int
+	Slope           *big.Int
+	GrowthRate      *big.Int
+	MaxPrice        *big.Int
+	InflectionPoint *big.Int
+}, error) {
+	return _BondingCurve.Contract.CurveParameters(&_BondingCurve.CallOpts, arg0)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_BondingCurve *BondingCurveCaller) Factory(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "factory")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_BondingCurve *BondingCurveSession) Factory() (common.Address, error) {
+	return _BondingCurve.Contract.Factory(&_BondingCurve.CallOpts)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_BondingCurve *BondingCurveCallerSession) Factory() (common.Address, error) {
+	return _BondingCurve.Contract.Factory(&_BondingCurve.CallOpts)
+}
+
+// GetBuyPriceImpact is a free data retrieval call binding the contract method 0x571fd619.
+//
+// Solidity: function getBuyPriceImpact(address tokenAddress, uint256 amount) view returns(uint256 avgPrice, uint256 priceImpact)
+func (_BondingCurve *BondingCurveCaller) GetBuyPriceImpact(opts *bind.CallOpts, tokenAddress common.Address, amount *big.Int) (struct {
+	AvgPrice    *big.Int
+	PriceImpact *big.Int
+}, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "getBuyPriceImpact", tokenAddress, amount)
+
+	outstruct := new(struct {
+		AvgPrice    *big.Int
+		PriceImpact *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.AvgPrice = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.PriceImpact = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetBuyPriceImpact is a free data retrieval call binding the contract method 0x571fd619.
+//
+// Solidity: function getBuyPriceImpact(address tokenAddress, uint256 amount) view returns(uint256 avgPrice, uint256 priceImpact)
+func (_BondingCurve *BondingCurveSession) GetBuyPriceImpact(tokenAddress common.Address, amount *big.Int) (struct {
+	AvgPrice    *big.Int
+	PriceImpact *big.Int
+}, error) {
+	return _BondingCurve.Contract.GetBuyPriceImpact(&_BondingCurve.CallOpts, tokenAddress, amount)
+}
+
+// GetBuyPriceImpact is a free data retrieval call binding the contract method 0x571fd619.
+//
+// Solidity: function getBuyPriceImpact(address tokenAddress, uint256 amount) view returns(uint256 avgPrice, uint256 priceImpact)
+func (_BondingCurve *BondingCurveCallerSession) GetBuyPriceImpact(tokenAddress common.Address, amount *big.Int) (struct {
+	AvgPrice    *big.Int
+	PriceImpact *big.Int
+}, error) {
+	return _BondingCurve.Contract.GetBuyPriceImpact(&_BondingCurve.CallOpts, tokenAddress, amount)
+}
+
+// GetCurrentPrice is a free data retrieval call binding the contract method 0x84cc315b.
+//
+// Solidity: function getCurrentPrice(address tokenAddress) view returns(uint256)
+func (_BondingCurve *BondingCurveCaller) GetCurrentPrice(opts *bind.CallOpts, tokenAddress common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "getCurrentPrice", tokenAddress)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetCurrentPrice is a free data retrieval call binding the contract method 0x84cc315b.
+//
+// Solidity: function getCurrentPrice(address tokenAddress) view returns(uint256)
+func (_BondingCurve *BondingCurveSession) GetCurrentPrice(tokenAddress common.Address) (*big.Int, error) {
+	return _BondingCurve.Contract.GetCurrentPrice(&_BondingCurve.CallOpts, tokenAddress)
+}
+
+// GetCurrentPrice is a free data retrieval call binding the contract method 0x84cc315b.
+//
+// Solidity: function getCurrentPrice(address tokenAddress) view returns(uint256)
+func (_BondingCurve *BondingCurveCallerSession) GetCurrentPrice(tokenAddress common.Address) (*big.Int, error) {
+	return _BondingCurve.Contract.GetCurrentPrice(&_BondingCurve.CallOpts, tokenAddress)
+}
+
+// GetSellPriceImpact is a free data retrieval call binding the contract method 0x2f8d8d3a.
+//
+// Solidity: function getSellPriceImpact(address tokenAddress, uint256 amount) view returns(uint256 avgPrice, uint256 priceImpact)
+func (_BondingCurve *BondingCurveCaller) GetSellPriceImpact(opts *bind.CallOpts, tokenAddress common.Address, amount *big.Int) (struct {
+	AvgPrice    *big.Int
+	PriceImpact *big.Int
+}, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "getSellPriceImpact", tokenAddress, amount)
+
+	outstruct := new(struct {
+		AvgPrice    *big.Int
+		PriceImpact *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.AvgPrice = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.PriceImpact = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetSellPriceImpact is a free data retrieval call binding the contract method 0x2f8d8d3a.
+//
+// Solidity: function getSellPriceImpact(address tokenAddress, uint256 amount) view returns(uint256 avgPrice, uint256 priceImpact)
+func (_BondingCurve *BondingCurveSession) GetSellPriceImpact(tokenAddress common.Address, amount *big.Int) (struct {
+	AvgPrice    *big.Int
+	PriceImpact *big.Int
+}, error) {
+	return _BondingCurve.Contract.GetSellPriceImpact(&_BondingCurve.CallOpts, tokenAddress, amount)
+}
+
+// GetSellPriceImpact is a free data retrieval call binding the contract method 0x2f8d8d3a.
+//
+// Solidity: function getSellPriceImpact(address tokenAddress, uint256 amount) view returns(uint256 avgPrice, uint256 priceImpact)
+func (_BondingCurve *BondingCurveCallerSession) GetSellPriceImpact(tokenAddress common.Address, amount *big.Int) (struct {
+	AvgPrice    *big.Int
+	PriceImpact *big.Int
+}, error) {
+	return _BondingCurve.Contract.GetSellPriceImpact(&_BondingCurve.CallOpts, tokenAddress, amount)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BondingCurve *BondingCurveCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _BondingCurve.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BondingCurve *BondingCurveSession) Owner() (common.Address, error) {
+	return _BondingCurve.Contract.Owner(&_BondingCurve.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BondingCurve *BondingCurveCallerSession) Owner() (common.Address, error) {
+	return _BondingCurve.Contract.Owner(&_BondingCurve.CallOpts)
+}
+
+// BuyTokens is a paid mutator transaction binding the contract method 0x0752881a.
+//
+// Solidity: function buyTokens(address tokenAddress, uint256 minTokens) payable returns(uint256)
+func (_BondingCurve *BondingCurveTransactor) BuyTokens(opts *bind.TransactOpts, tokenAddress common.Address, minTokens *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.contract.Transact(opts, "buyTokens", tokenAddress, minTokens)
+}
+
+// BuyTokens is a paid mutator transaction binding the contract method 0x0752881a.
+//
+// Solidity: function buyTokens(address tokenAddress, uint256 minTokens) payable returns(uint256)
+func (_BondingCurve *BondingCurveSession) BuyTokens(tokenAddress common.Address, minTokens *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.Contract.BuyTokens(&_BondingCurve.TransactOpts, tokenAddress, minTokens)
+}
+
+// BuyTokens is a paid mutator transaction binding the contract method 0x0752881a.
+//
+// Solidity: function buyTokens(address tokenAddress, uint256 minTokens) payable returns(uint256)
+func (_BondingCurve *BondingCurveTransactorSession) BuyTokens(tokenAddress common.Address, minTokens *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.Contract.BuyTokens(&_BondingCurve.TransactOpts, tokenAddress, minTokens)
+}
+
+// InitializeCurve is a paid mutator transaction binding the contract method 0x3830c89f.
+//
+// Solidity: function initializeCurve(address tokenAddress, uint8 curveType, uint256 basePrice, uint256 param1, uint256 param2, uint256 param3) returns()
+func (_BondingCurve *BondingCurveTransactor) InitializeCurve(opts *bind.TransactOpts, tokenAddress common.Address, curveType uint8, basePrice *big.Int, param1 *big.Int, param2 *big.Int, param3 *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.contract.Transact(opts, "initializeCurve", tokenAddress, curveType, basePrice, param1, param2, param3)
+}
+
+// InitializeCurve is a paid mutator transaction binding the contract method 0x3830c89f.
+//
+// Solidity: function initializeCurve(address tokenAddress, uint8 curveType, uint256 basePrice, uint256 param1, uint256 param2, uint256 param3) returns()
+func (_BondingCurve *BondingCurveSession) InitializeCurve(tokenAddress common.Address, curveType uint8, basePrice *big.Int, param1 *big.Int, param2 *big.Int, param3 *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.Contract.InitializeCurve(&_BondingCurve.TransactOpts, tokenAddress, curveType, basePrice, param1, param2, param3)
+}
+
+// InitializeCurve is a paid mutator transaction binding the contract method 0x3830c89f.
+//
+// Solidity: function initializeCurve(address tokenAddress, uint8 curveType, uint256 basePrice, uint256 param1, uint256 param2, uint256 param3) returns()
+func (_BondingCurve *BondingCurveTransactorSession) InitializeCurve(tokenAddress common.Address, curveType uint8, basePrice *big.Int, param1 *big.Int, param2 *big.Int, param3 *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.Contract.InitializeCurve(&_BondingCurve.TransactOpts, tokenAddress, curveType, basePrice, param1, param2, param3)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_BondingCurve *BondingCurveTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BondingCurve.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_BondingCurve *BondingCurveSession) RenounceOwnership() (*types.Transaction, error) {
+	return _BondingCurve.Contract.RenounceOwnership(&_BondingCurve.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_BondingCurve *BondingCurveTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _BondingCurve.Contract.RenounceOwnership(&_BondingCurve.TransactOpts)
+}
+
+// SellTokens is a paid mutator transaction binding the contract method 0xe4e57b9e.
+//
+// Solidity: function sellTokens(address tokenAddress, uint256 amount, uint256 minEth) returns(uint256)
+func (_BondingCurve *BondingCurveTransactor) SellTokens(opts *bind.TransactOpts, tokenAddress common.Address, amount *big.Int, minEth *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.contract.Transact(opts, "sellTokens", tokenAddress, amount, minEth)
+}
+
+// SellTokens is a paid mutator transaction binding the contract method 0xe4e57b9e.
+//
+// Solidity: function sellTokens(address tokenAddress, uint256 amount, uint256 minEth) returns(uint256)
+func (_BondingCurve *BondingCurveSession) SellTokens(tokenAddress common.Address, amount *big.Int, minEth *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.Contract.SellTokens(&_BondingCurve.TransactOpts, tokenAddress, amount, minEth)
+}
+
+// SellTokens is a paid mutator transaction binding the contract method 0xe4e57b9e.
+//
+// Solidity: function sellTokens(address tokenAddress, uint256 amount, uint256 minEth) returns(uint256)
+func (_BondingCurve *BondingCurveTransactorSession) SellTokens(tokenAddress common.Address, amount *big.Int, minEth *big.Int) (*types.Transaction, error) {
+	return _BondingCurve.Contract.SellTokens(&_BondingCurve.TransactOpts, tokenAddress, amount, minEth)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_BondingCurve *BondingCurveTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _BondingCurve.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_BondingCurve *BondingCurveSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _BondingCurve.Contract.TransferOwnership(&_BondingCurve.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_BondingCurve *BondingCurveTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _BondingCurve.Contract.TransferOwnership(&_BondingCurve.TransactOpts, newOwner)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_BondingCurve *BondingCurveTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BondingCurve.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_BondingCurve *BondingCurveSession) Receive() (*types.Transaction, error) {
+	return _BondingCurve.Contract.Receive(&_BondingCurve.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_BondingCurve *BondingCurveTransactorSession) Receive() (*types.Transaction, error) {
+	return _BondingCurve.Contract.Receive(&_BondingCurve.TransactOpts)
+}
+
+// BondingCurveCurveInitializedIterator is returned from FilterCurveInitialized and is used to iterate over the raw logs and unpacked data for CurveInitialized events raised by the BondingCurve contract.
+type BondingCurveCurveInitializedIterator struct {
+	Event *BondingCurveCurveInitialized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BondingCurveCurveInitializedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BondingCurveCurveInitialized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BondingCurveCurveInitialized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BondingCurveCurveInitializedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BondingCurveCurveInitializedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BondingCurveCurveInitialized represents a CurveInitialized event raised by the BondingCurve contract.
+type BondingCurveCurveInitialized struct {
+	Token     common.Address
+	CurveType uint8
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterCurveInitialized is a free log retrieval operation binding the contract event 0xf07226072cbf1591a0c0324a692d7c1a4668d2623937bc7279e36f996b916af4.
+//
+// Solidity: event CurveInitialized(address indexed token, uint8 curveType)
+func (_BondingCurve *BondingCurveFilterer) FilterCurveInitialized(opts *bind.FilterOpts, token []common.Address) (*BondingCurveCurveInitializedIterator, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+
+	logs, sub, err := _BondingCurve.contract.FilterLogs(opts, "CurveInitialized", tokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BondingCurveCurveInitializedIterator{contract: _BondingCurve.contract, event: "CurveInitialized", logs: logs, sub: sub}, nil
+}
+
+// WatchCurveInitialized is a free log subscription operation binding the contract event 0xf07226072cbf1591a0c0324a692d7c1a4668d2623937bc7279e36f996b916af4.
+//
+// Solidity: event CurveInitialized(address indexed token, uint8 curveType)
+func (_BondingCurve *BondingCurveFilterer) WatchCurveInitialized(opts *bind.WatchOpts, sink chan<- *BondingCurveCurveInitialized, token []common.Address) (event.Subscription, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+
+	logs, sub, err := _BondingCurve.contract.WatchLogs(opts, "CurveInitialized", tokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BondingCurveCurveInitialized)
+				if err := _BondingCurve.contract.UnpackLog(event, "CurveInitialized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCurveInitialized is a log parse operation binding the contract event 0xf07226072cbf1591a0c0324a692d7c1a4668d2623937bc7279e36f996b916af4.
+//
+// Solidity: event CurveInitialized(address indexed token, uint8 curveType)
+func (_BondingCurve *BondingCurveFilterer) ParseCurveInitialized(log types.Log) (*BondingCurveCurveInitialized, error) {
+	event := new(BondingCurveCurveInitialized)
+	if err := _BondingCurve.contract.UnpackLog(event, "CurveInitialized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BondingCurveOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the BondingCurve contract.
+type BondingCurveOwnershipTransferredIterator struct {
+	Event *BondingCurveOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BondingCurveOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BondingCurveOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BondingCurveOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BondingCurveOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BondingCurveOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BondingCurveOwnershipTransferred represents a OwnershipTransferred event raised by the BondingCurve contract.
+type BondingCurveOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_BondingCurve *BondingCurveFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*BondingCurveOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _BondingCurve.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BondingCurveOwnershipTransferredIterator{contract: _BondingCurve.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_BondingCurve *BondingCurveFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *BondingCurveOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _BondingCurve.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BondingCurveOwnershipTransferred)
+				if err := _BondingCurve.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_BondingCurve *BondingCurveFilterer) ParseOwnershipTransferred(log types.Log) (*BondingCurveOwnershipTransferred, error) {
+	event := new(BondingCurveOwnershipTransferred)
+	if err := _BondingCurve.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BondingCurveTokensPurchasedIterator is returned from FilterTokensPurchased and is used to iterate over the raw logs and unpacked data for TokensPurchased events raised by the BondingCurve contract.
+type BondingCurveTokensPurchasedIterator struct {
+	Event *BondingCurveTokensPurchased // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BondingCurveTokensPurchasedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BondingCurveTokensPurchased)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BondingCurveTokensPurchased)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BondingCurveTokensPurchasedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BondingCurveTokensPurchasedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BondingCurveTokensPurchased represents a TokensPurchased event raised by the BondingCurve contract.
+type BondingCurveTokensPurchased struct {
+	Token    common.Address
+	Buyer    common.Address
+	Amount   *big.Int
+	Cost     *big.Int
+	NewPrice *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokensPurchased is a free log retrieval operation binding the contract event 0x377aadedb6b2a771959584d10a6a36eccb5f56b4eb3a48525f76108d2660d8d4.
+//
+// Solidity: event TokensPurchased(address indexed token, address indexed buyer, uint256 amount, uint256 cost, uint256 newPrice)
+func (_BondingCurve *BondingCurveFilterer) FilterTokensPurchased(opts *bind.FilterOpts, token []common.Address, buyer []common.Address) (*BondingCurveTokensPurchasedIterator, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _BondingCurve.contract.FilterLogs(opts, "TokensPurchased", tokenRule, buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BondingCurveTokensPurchasedIterator{contract: _BondingCurve.contract, event: "TokensPurchased", logs: logs, sub: sub}, nil
+}
+
+// WatchTokensPurchased is a free log subscription operation binding the contract event 0x377aadedb6b2a771959584d10a6a36eccb5f56b4eb3a48525f76108d2660d8d4.
+//
+// Solidity: event TokensPurchased(address indexed token, address indexed buyer, uint256 amount, uint256 cost, uint256 newPrice)
+func (_BondingCurve *BondingCurveFilterer) WatchTokensPurchased(opts *bind.WatchOpts, sink chan<- *BondingCurveTokensPurchased, token []common.Address, buyer []common.Address) (event.Subscription, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _BondingCurve.contract.WatchLogs(opts, "TokensPurchased", tokenRule, buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BondingCurveTokensPurchased)
+				if err := _BondingCurve.contract.UnpackLog(event, "TokensPurchased", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokensPurchased is a log parse operation binding the contract event 0x377aadedb6b2a771959584d10a6a36eccb5f56b4eb3a48525f76108d2660d8d4.
+//
+// Solidity: event TokensPurchased(address indexed token, address indexed buyer, uint256 amount, uint256 cost, uint256 newPrice)
+func (_BondingCurve *BondingCurveFilterer) ParseTokensPurchased(log types.Log) (*BondingCurveTokensPurchased, error) {
+	event := new(BondingCurveTokensPurchased)
+	if err := _BondingCurve.contract.UnpackLog(event, "TokensPurchased", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BondingCurveTokensSoldIterator is returned from FilterTokensSold and is used to iterate over the raw logs and unpacked data for TokensSold events raised by the BondingCurve contract.
+type BondingCurveTokensSoldIterator struct {
+	Event *BondingCurveTokensSold // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BondingCurveTokensSoldIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BondingCurveTokensSold)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BondingCurveTokensSold)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BondingCurveTokensSoldIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BondingCurveTokensSoldIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BondingCurveTokensSold represents a TokensSold event raised by the BondingCurve contract.
+type BondingCurveTokensSold struct {
+	Token    common.Address
+	Seller   common.Address
+	Amount   *big.Int
+	Refund   *big.Int
+	NewPrice *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokensSold is a free log retrieval operation binding the contract event 0xa0fe9740856690637d999c103293d3c823fc3b81443c34c6004bb582ab4b6166.
+//
+// Solidity: event TokensSold(address indexed token, address indexed seller, uint256 amount, uint256 refund, uint256 newPrice)
+func (_BondingCurve *BondingCurveFilterer) FilterTokensSold(opts *bind.FilterOpts, token []common.Address, seller []common.Address) (*BondingCurveTokensSoldIterator, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+
+	logs, sub, err := _BondingCurve.contract.FilterLogs(opts, "TokensSold", tokenRule, sellerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BondingCurveTokensSoldIterator{contract: _BondingCurve.contract, event: "TokensSold", logs: logs, sub: sub}, nil
+}
+
+// WatchTokensSold is a free log subscription operation binding the contract event 0xa0fe9740856690637d999c103293d3c823fc3b81443c34c6004bb582ab4b6166.
+//
+// Solidity: event TokensSold(address indexed token, address indexed seller, uint256 amount, uint256 refund, uint256 newPrice)
+func (_BondingCurve *BondingCurveFilterer) WatchTokensSold(opts *bind.WatchOpts, sink chan<- *BondingCurveTokensSold, token []common.Address, seller []common.Address) (event.Subscription, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+
+	logs, sub, err := _BondingCurve.contract.WatchLogs(opts, "TokensSold", tokenRule, sellerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BondingCurveTokensSold)
+				if err := _BondingCurve.contract.UnpackLog(event, "TokensSold", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokensSold is a log parse operation binding the contract event 0xa0fe9740856690637d999c103293d3c823fc3b81443c34c6004bb582ab4b6166.
+//
+// Solidity: event TokensSold(address indexed token, address indexed seller, uint256 amount, uint256 refund, uint256 newPrice)
+func (_BondingCurve *BondingCurveFilterer) ParseTokensSold(log types.Log) (*BondingCurveTokensSold, error) {
+	event := new(BondingCurveTokensSold)
+	if err := _BondingCurve.contract.UnpackLog(event, "TokensSold", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
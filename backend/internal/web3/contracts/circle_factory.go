@@ -0,0 +1,2140 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// CircleFactoryCircle is an auto generated low-level Go binding around an user-defined struct.
+type CircleFactoryCircle struct {
+	CircleId            *big.Int
+	Owner               common.Address
+	TokenAddress        common.Address
+	BondingCurveAddress common.Address
+	Name                string
+	Symbol              string
+	CreatedAt           *big.Int
+	Active              bool
+	CurveType           uint8
+}
+
+// CircleFactoryMetaData contains all meta data concerning the CircleFactory contract.
+var CircleFactoryMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"name\":\"_platformTreasury\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"receive\",\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"MAX_CIRCLES_PER_USER\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"bondingCurveImpl\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"circleCount\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"circleCreationFee\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"circles\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"bondingCurveAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"name\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"symbol\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"createdAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"curveType\",\"type\":\"uint8\",\"internalType\":\"enumBondingCurve.CurveType\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"createCircle\",\"inputs\":[{\"name\":\"name\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"symbol\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"description\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"curveType\",\"type\":\"uint8\",\"internalType\":\"enumBondingCurve.CurveType\"},{\"name\":\"basePrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"param1\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"param2\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"param3\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"deactivateCircle\",\"inputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"emergencyWithdraw\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"getActiveCircles\",\"inputs\":[{\"name\":\"offset\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"limit\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"tuple[]\",\"internalType\":\"structCircleFactory.Circle[]\",\"components\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"bondingCurveAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"name\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"symbol\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"createdAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"curveType\",\"type\":\"uint8\",\"internalType\":\"enumBondingCurve.CurveType\"}]}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getCircle\",\"inputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"tuple\",\"internalType\":\"structCircleFactory.Circle\",\"components\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"bondingCurveAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"name\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"symbol\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"createdAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"curveType\",\"type\":\"uint8\",\"internalType\":\"enumBondingCurve.CurveType\"}]}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getOwnerCircles\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getStatistics\",\"inputs\":[],\"outputs\":[{\"name\":\"totalCircles\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"activeCircles\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"totalValue\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"isCircleToken\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"ownerCircles\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"pause\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"paused\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"platformTreasury\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"reactivateCircle\",\"inputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"renounceOwnership\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferCircleOwnership\",\"inputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"unpause\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"updateCircleCreationFee\",\"inputs\":[{\"name\":\"newFee\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"updatePlatformTreasury\",\"inputs\":[{\"name\":\"newTreasury\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"CircleCreated\",\"inputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"tokenAddress\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"name\",\"type\":\"string\",\"indexed\":false,\"internalType\":\"string\"},{\"name\":\"symbol\",\"type\":\"string\",\"indexed\":false,\"internalType\":\"string\"},{\"name\":\"curveType\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"enumBondingCurve.CurveType\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CircleCreationFeeUpdated\",\"inputs\":[{\"name\":\"newFee\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CircleDeactivated\",\"inputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CircleOwnershipTransferred\",\"inputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"oldOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"newOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CircleReactivated\",\"inputs\":[{\"name\":\"circleId\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"newOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Paused\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Unpaused\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"}],\"anonymous\":false}]",
+	Bin: "0x6080346200016e576001600160401b0390601f62005dba38819003918201601f19168301918483118484101762000173578084926020946040528339810103126200016e57516001600160a01b038082169290918390036200016e57600090815460405190338582167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e08680a36001600160a81b0319163360ff60a01b191617835560018055662386f26fc100006008558415620001395750600780546001600160a01b0319908116909517905560405190611995808301918211838310176200012557602091839162004425833930815203019082f09081156200011957501690600654161760065560405161429b90816200018a8239f35b604051903d90823e3d90fd5b634e487b7160e01b84526041600452602484fd5b62461bcd60e51b815260206004820152601060248201526f496e76616c696420747265617375727960801b6044820152606490fd5b600080fd5b634e487b7160e01b600052604160045260246000fdfe608060405260043610156200001d575b36156200001b57600080fd5b005b6000803560e01c80630c1bd1e5146200107d5780630ff61ae0146200101f5780632a4fb36a1462000ff4578063372d6b271462000ecb5780633f4ba83a1462000e2b5780633fd28fc41462000d7b5780635c975abb1462000d5457806364ccecde1462000ca6578063709f56101462000bea578063715018a61462000b8c5780638456cb591462000b255780638da5cb5b1462000afc578063943d7cae1462000a76578063a8667a331462000775578063a9ccd11e14620006c5578063bc5e0f3014620005f0578063c33c65a11462000403578063cf0b111714620003e3578063d8864a4e14620003c5578063db2e21bc146200034a578063dc34751e14620002b3578063e138818c1462000288578063f2fde38b14620001bb578063f5b8088814620001785763ffd22913146200015657506200000f565b3462000175578060031936011262000175576020600854604051908152f35b80fd5b503462000175576020366003190112620001755760209060ff906040906001600160a01b03620001a7620019bd565b168152600484522054166040519015158152f35b5034620001755760203660031901126200017557620001d9620019bd565b620001e362001e03565b6001600160a01b039081169081156200023457600054826001600160601b0360a01b821617600055167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0600080a380f35b60405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201526564647265737360d01b6064820152608490fd5b503462000175578060031936011262000175576007546040516001600160a01b039091168152602090f35b5034620001755760203660031901126200017557600435620002d462001e03565b670de0b6b3a7640000811162000316576020817f296cdb8153118137fc4e81216134782ce26386ea221f0b61b75e94263256f38c92600855604051908152a180f35b60405162461bcd60e51b815260206004820152600c60248201526b08ccaca40e8dede40d0d2ced60a31b6044820152606490fd5b503462000175578060031936011262000175576200036762001e03565b808080804760018060a01b038254165af16200038262001d17565b50156200038c5780f35b60405162461bcd60e51b815260206004820152601160248201527015da5d1a191c985dd85b0819985a5b1959607a1b6044820152606490fd5b503462000175578060031936011262000175576020604051600a8152f35b503462000175578060031936011262000175576020600554604051908152f35b5034620001755760403660031901126200017557602480356001600160a01b038181169160043590839003620005eb578215620005b3578085526002602052600193846040872001848154948516946200045f33871462001d95565b6001600160601b0360a01b1617905582865260039081602052604087208688905b620004c8575b505050859450838552602052620004a1816040862062001ceb565b7f47562d741428c467148a697035defd09a4f65a52abfc41156086f56f4b8ef0f58480a480f35b815480821015620005ac5785620004e08385620019d4565b905490871b1c14620004ff5750620004f89062001c54565b8762000480565b939495969750600092919219938481019081116200059957906200053a6200052c620005539386620019d4565b905490881b1c9185620019d4565b90919082549060031b91821b91600019901b1916179055565b81549081156200058757508201916200056d8383620019d4565b9091825491861b1b19169055559083929138808062000486565b634e487b7160e01b8952603160045288fd5b634e487b7160e01b8a526011600452828afd5b5062000486565b60405162461bcd60e51b8152602060048201526011818601527024b73b30b634b2103732bb9037bbb732b960791b6044820152606490fd5b600080fd5b50346200017557602036600319011262000175576040906004358152600260205220805460018060a01b0391620006c183600183015416936200069e81600285015416916003850154166200068f6200064c6004870162001ba2565b6200065a6005880162001ba2565b9260076006890154980154956040519a8b9a8b5260208b015260408a015260608901526101208060808a015288019062001a03565b9086820360a088015262001a03565b9260c085015260ff8116151560e085015260ff61010085019160081c1662001a45565b0390f35b503462000175576020366003190112620001755760043580825260026020526007604083206200070360018060a01b03600183015416331462001d95565b01805460ff8116156200073d5760ff191690557f320ff379b7d4234faa6fee23dfa531c1a96cab59b64d93658440dd9f069ccf388280a280f35b60405162461bcd60e51b815260206004820152601060248201526f416c726561647920696e61637469766560801b6044820152606490fd5b5034620001755760403660031901126200017557600435906024356064811162000a405781926001926005948554945b85811115620009fd575082811115620009f457828103908111620009de57925b808411620009d5575b50620007da8362001dd5565b92620007ea604051948562001b7f565b808452601f19620007fb8262001dd5565b01825b818110620009bb5750508192829160015b8781111580620009b1575b156200095157808552600260209080825260ff90600790828260408b2001541662000855575b505050506200084f9062001c54565b6200080f565b9398938c8789106200093457926200092894926200090892620008de6200084f989660408f8f908152848952209260405197620008928962001b61565b8454895260018501546001600160a01b03908116918a019190915290840154811660408901526003840154166060880152620008d16004840162001ba2565b6080880152820162001ba2565b60a0860152600681015460c08601520154818116151560e085015260081c16610100830162001cde565b62000914828b62001dee565b5262000921818a62001dee565b5062001c54565b955b9038808062000840565b505050505094926200094a6200084f9162001c54565b936200092a565b60408051602080825289518183018190528893818e1b8401810192808d01929185015b828710620009825785850386f35b909192938280620009a0600193603f198a8203018652885162001a69565b960192019601959291909262000974565b508186106200081a565b602090620009c862001d4c565b82828901015201620007fe565b925038620007ce565b634e487b7160e01b600052601160045260246000fd5b508092620007c5565b808352600260205260ff60076040852001541662000a27575b62000a219062001c54565b620007a5565b9062000a3762000a219162001c54565b91905062000a16565b60405162461bcd60e51b815260206004820152600e60248201526d098d2dad2e840e8dede40d0d2ced60931b6044820152606490fd5b503462000175576020366003190112620001755762000a94620019bd565b62000a9e62001e03565b6001600160a01b0316801562000ac4576001600160601b0360a01b600754161760075580f35b60405162461bcd60e51b815260206004820152601060248201526f496e76616c696420747265617375727960801b6044820152606490fd5b50346200017557806003193601126200017557546040516001600160a01b039091168152602090f35b5034620001755780600319360112620001755762000b4262001e03565b62000b4c62001e5c565b805460ff60a01b1916600160a01b1781556040513381527f62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a25890602090a180f35b5034620001755780600319360112620001755762000ba962001e03565b600080546001600160a01b0319811682556001600160a01b03167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e08280a380f35b5034620001755760208060031936011262000ca2576001600160a01b0362000c11620019bd565b168252600381526040822060405192838383549182815201908193835284832090835b81811062000c8d575050508462000c4d91038562001b7f565b60405193838594850191818652518092526040850193925b82811062000c7557505050500390f35b83518552869550938101939281019260010162000c65565b82548452928601926001928301920162000c34565b5080fd5b50346200017557602036600319011262000175576040620006c19162000ccb62001d4c565b50600435815260026020522062000d3f60ff60076040519362000cee8562001b61565b8054855260018101546001600160a01b039081166020870152600282015481166040870152600382015416606086015262000d2c6004820162001ba2565b6080860152620008de6005820162001ba2565b60405191829160208352602083019062001a69565b5034620001755780600319360112620001755760ff6020915460a01c166040519015158152f35b5034620001755760203660031901126200017557600435808252600260205260076040832062000db960018060a01b03600183015416331462001d95565b01805460ff811662000df55760ff191660011790557f4663177e89735761eda7dceaa156f929cbc86ad8e603ccef53d2c3c99ea13f148280a280f35b60405162461bcd60e51b815260206004820152600e60248201526d416c72656164792061637469766560901b6044820152606490fd5b5034620001755780600319360112620001755762000e4862001e03565b805460ff8160a01c161562000e8f5760ff60a01b191681556040513381527f5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa90602090a180f35b60405162461bcd60e51b815260206004820152601460248201527314185d5cd8589b194e881b9bdd081c185d5cd95960621b6044820152606490fd5b50346200017557806003193601126200017557600554818060015b8381111562000f0657506060935060405192835260208301526040820152f35b8085526002602081815260ff60076040892001541662000f34575b505062000f2e9062001c54565b62000ee6565b90928162000f4760049296949662001c54565b8689528582526040808a20909601549551635084aa7f60e11b815290959092839182906001600160a01b03165afa91821562000fe957879262000fb1575b5050810180911162000f9d579162000f2e3862000f21565b634e487b7160e01b85526011600452602485fd5b90809250813d831162000fe1575b62000fcb818362001b7f565b8101031262000fdd5751388062000f85565b8580fd5b503d62000fbf565b6040513d89823e3d90fd5b503462000175578060031936011262000175576006546040516001600160a01b039091168152602090f35b50346200017557604036600319011262000175576200103d620019bd565b6001600160a01b0316815260036020526040812080546024359290831015620001755760206200106e8484620019d4565b90549060031b1c604051908152f35b50610100366003190112620001755760043567ffffffffffffffff811162000ca257620010af9036906004016200198c565b60249291923567ffffffffffffffff81116200160357620010d59036906004016200198c565b919060443567ffffffffffffffff81116200198857620010fa9036906004016200198c565b9390946003606435101562000175576200111362001e5c565b6002600154146200194357600260015560085434106200190b5783151580620018ff575b15620018cb5781151580620018bf575b1562001889573381526003602052600a604082205410156200184e576084351562001814576200117960055462001c54565b6005819055600654600754604051929892916001600160a01b03908116911667ffffffffffffffff6123c184019081119084111762001800579082916123c162001ea5843960e06123c18401818152620011f391620011db91018b8f62001c64565b6123c18501810360206123c187010152888a62001c64565b913360406123c1860101523060606123c18601015260806123c18501015260a06123c1840101528960c06123c184010152039083f08015620015f8576006546001600160a01b03918216979116803b15620017fc5783809160c460405180948193633830c89f60e01b83528d6004840152620012756024840160643562001a45565b608435604484015260a43560648401528335608484015260e43560a48401525af18015620017f157620017db575b50600654604051906001600160a01b0316620012bf8262001b61565b8982523360208301528860408301526060820152620012e036888c62001ca2565b6080820152620012f236868862001ca2565b60a08201524260c0820152600160e082015262001316606435610100830162001cde565b88845260026020526040842090805182556001820160018060a01b03602083015116906001600160601b0360a01b91828254161790556002830160018060a01b0360408401511682825416179055600383019060018060a01b0360608401511690825416179055608081015180519067ffffffffffffffff8211620016f1578190620013a6600486015462001af9565b601f811162001785575b50602090601f83116001146200171157889262001705575b50508160011b916000199060031b1c19161760048301555b60a081015180519067ffffffffffffffff8211620016f157819062001409600586015462001af9565b601f81116200169b575b50602090601f8311600114620016275788926200161b575b50508160011b916000199060031b1c19161760058301555b60c0810151600683015560e08101511515906101006007840154910151600381101562001607579060ff61ff006007949360081b1692169061ffff1916171791015533835260036020526200149c886040852062001ceb565b868352600460205260408320805460ff19166001179055863b15620016035782906020620014e56040519485936315b3839b60e21b855260406004860152604485019162001c64565b838382039160031983016024860152520181838a5af18015620015f857908291620015e0575b506007548190819034906001600160a01b03165af16200152a62001d17565b5015620015a5576200158185927f06909894b67dbde90a608cf9f999243b5797ccb0a9d39c5942289fc0692313ab92620015736020996040519760608952606089019162001c64565b918683038a88015262001c64565b92620015936040820160643562001a45565b8033940390a460018055604051908152f35b60405162461bcd60e51b8152602060048201526013602482015272119959481d1c985b9cd9995c8819985a5b1959606a1b6044820152606490fd5b620015eb9062001b36565b620001755780386200150b565b6040513d84823e3d90fd5b8280fd5b634e487b7160e01b87526021600452602487fd5b0151905038806200142b565b9250600585018852602088209088935b601f19841685106200167f576001945083601f1981161062001665575b505050811b01600583015562001443565b015160001960f88460031b161c1916905538808062001654565b8181015183556020948501946001909301929091019062001637565b90915060058501885260208820601f840160051c810160208510620016e9575b90849392915b601f830160051c82018110620016d957505062001413565b60008155859450600101620016c1565b5080620016bb565b634e487b7160e01b87526041600452602487fd5b015190503880620013c8565b9250600485018852602088209088935b601f198416851062001769576001945083601f198116106200174f575b505050811b016004830155620013e0565b015160001960f88460031b161c191690553880806200173e565b8181015183556020948501946001909301929091019062001721565b90915060048501885260208820601f840160051c810160208510620017d3575b90849392915b601f830160051c82018110620017c3575050620013b0565b60008155859450600101620017ab565b5080620017a5565b620017e99093919362001b36565b9138620012a3565b6040513d86823e3d90fd5b8380fd5b634e487b7160e01b85526041600452602485fd5b60405162461bcd60e51b8152602060048201526012602482015271496e76616c6964206261736520707269636560701b6044820152606490fd5b60405162461bcd60e51b815260206004820152601360248201527213585e0818da5c98db195cc81c995858da1959606a1b6044820152606490fd5b60405162461bcd60e51b815260206004820152600e60248201526d125b9d985b1a59081cde5b589bdb60921b6044820152606490fd5b50600a82111562001147565b60405162461bcd60e51b815260206004820152600c60248201526b496e76616c6964206e616d6560a01b6044820152606490fd5b50603284111562001137565b60405162461bcd60e51b815260206004820152601060248201526f496e73756666696369656e742066656560801b6044820152606490fd5b60405162461bcd60e51b815260206004820152601f60248201527f5265656e7472616e637947756172643a207265656e7472616e742063616c6c006044820152606490fd5b8480fd5b9181601f84011215620005eb5782359167ffffffffffffffff8311620005eb5760208381860195010111620005eb57565b600435906001600160a01b0382168203620005eb57565b8054821015620019ed5760005260206000200190600090565b634e487b7160e01b600052603260045260246000fd5b919082519283825260005b84811062001a30575050826000602080949584010152601f8019910116010190565b60208183018101518483018201520162001a0e565b90600382101562001a535752565b634e487b7160e01b600052602160045260246000fd5b9062001af6908251815260018060a01b03806020850151166020830152806040850151166040830152606084015116606082015262001acf62001abc608085015161012080608086015284019062001a03565b60a085015183820360a085015262001a03565b9260c081015160c083015260e0810151151560e08301526101008091015191019062001a45565b90565b90600182811c9216801562001b2b575b602083101462001b1557565b634e487b7160e01b600052602260045260246000fd5b91607f169162001b09565b67ffffffffffffffff811162001b4b57604052565b634e487b7160e01b600052604160045260246000fd5b610120810190811067ffffffffffffffff82111762001b4b57604052565b90601f8019910116810190811067ffffffffffffffff82111762001b4b57604052565b906040519182600082549262001bb88462001af9565b90818452600194858116908160001462001c2f575060011462001be8575b505062001be69250038362001b7f565b565b9093915060005260209081600020936000915b81831062001c1657505062001be69350820101388062001bd6565b8554888401850152948501948794509183019162001bfb565b91505062001be694506020925060ff191682840152151560051b820101388062001bd6565b6000198114620009de5760010190565b908060209392818452848401376000828201840152601f01601f1916010190565b67ffffffffffffffff811162001b4b57601f01601f191660200190565b92919262001cb08262001c85565b9162001cc0604051938462001b7f565b829481845281830111620005eb578281602093846000960137010152565b600382101562001a535752565b908154916801000000000000000083101562001b4b57826200053a91600162001be695018155620019d4565b3d1562001d47573d9062001d2b8262001c85565b9162001d3b604051938462001b7f565b82523d6000602084013e565b606090565b6040519062001d5b8262001b61565b8161010060009182815282602082015282604082015282606082015260606080820152606060a08201528260c08201528260e08201520152565b1562001d9d57565b60405162461bcd60e51b815260206004820152601060248201526f2737ba1031b4b931b6329037bbb732b960811b6044820152606490fd5b67ffffffffffffffff811162001b4b5760051b60200190565b8051821015620019ed5760209160051b010190565b6000546001600160a01b0316330362001e1857565b606460405162461bcd60e51b815260206004820152602060248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b60ff60005460a01c1662001e6c57565b60405162461bcd60e51b815260206004820152601060248201526f14185d5cd8589b194e881c185d5cd95960821b6044820152606490fdfe60406080815234620005fb57620023c1803803806200001e8162000600565b928339810160e082820312620005fb5781516001600160401b0390818111620005fb57826200004f91850162000626565b906020928385015190828211620005fb576200006d91860162000626565b916200007b86860162000698565b620000896060870162000698565b92620000986080880162000698565b9660c0620000a960a0830162000698565b910151918451818111620004ec576003908154906001978883811c93168015620005f0575b8c841014620005da5781908c601f9485811162000581575b50508c908483116001146200050e5760009262000502575b505060001982851b1c191690881b1782555b8851928311620004ec5760049889548881811c91168015620004e1575b8c821014620004cc57908b828487969594116200046f575b50508b9184116001146200040457600093620003f8575b505082871b92600019911b1c19161786555b6200017933620006ad565b6005805460ff60a01b19811690915560069490945560fa600e55611770600f556107d060108190556011556001600160a01b03928316978815620003c557838616908115620003905784169182156200034d578416918215620003175760018060a01b0319918a8360095416176009558260085416176008558160075416176007556012541617601255600c5533911603620002d6576200021a90620006ad565b60ff60055460a01c16620002a257600254683635c9adc5dea00000918282018092116200028d575060025560008381528083528481208054830190558451918252917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef91a351611cca9081620006f78239f35b601190634e487b7160e01b6000525260246000fd5b60649184519162461bcd60e51b8352820152601060248201526f14185d5cd8589b194e881c185d5cd95960821b6044820152fd5b508160649285519262461bcd60e51b845283015260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b8a5162461bcd60e51b81528089018a9052601060248201526f496e76616c696420747265617375727960801b6044820152606490fd5b8a5162461bcd60e51b81528089018a9052601560248201527f496e76616c696420626f6e64696e6720637572766500000000000000000000006044820152606490fd5b8a5162461bcd60e51b81528089018a9052600f60248201526e496e76616c696420666163746f727960881b6044820152606490fd5b895162461bcd60e51b8152808801899052600d60248201526c24b73b30b634b21037bbb732b960991b6044820152606490fd5b0151915038806200015c565b60008b81528c81208a965093929190601f198616908e5b8282106200045757505085116200043c575b50505050811b0186556200016e565b01519060f884600019921b161c19169055388080806200042d565b8385015187558c989096019593840193018e6200041b565b9091929394508b60005283826000209181880160051c8301938810620004c2575b918b918897969594930160051c01915b828110620004b257508d915062000145565b600081558796508b9101620004a0565b9250819262000490565b60228b634e487b7160e01b6000525260246000fd5b90607f16906200012d565b634e487b7160e01b600052604160045260246000fd5b015190503880620000fe565b8a9350908d91601f1984168760005283600020936000905b8282106200055f575050841162000546575b505050811b01825562000110565b015160001983871b60f8161c1916905538808062000538565b91929395968291958786015181550195019301908f918d969594939262000526565b909192508560005284826000209181860160051c8301938610620005d0575b918c91869594930160051c01915b828110620005c057508e9150620000e6565b600081558594508c9101620005ae565b92508192620005a0565b634e487b7160e01b600052602260045260246000fd5b92607f1692620000ce565b600080fd5b6040519190601f01601f191682016001600160401b03811183821017620004ec57604052565b919080601f84011215620005fb5782516001600160401b038111620004ec576020906200065c601f8201601f1916830162000600565b92818452828287010111620005fb5760005b8181106200068457508260009394955001015290565b85810183015184820184015282016200066e565b51906001600160a01b0382168203620005fb57565b600580546001600160a01b039283166001600160a01b0319821681179092559091167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0600080a356fe60806040818152600480361015610021575b505050361561001f57600080fd5b005b600092833560e01c908163045e695c1461163e5750806306fdde031461159457806308c9d1e41461156b578063095ea7b3146115415780630c8f5e00146115225780630ee976961461148b57806318160ddd1461146c57806323b872dd146113a2578063313ce56714611386578063372d6b271461134d57806339509351146112fd5780633f4ba83a1461125557806340c10f191461117157806356ce0e6c14610da05780635c975abb14610d795780635f81a57c14610d5a5780636998de3514610d3b5780636e2310b214610d1c57806370a0823114610ce5578063715018a614610c885780637bc1680714610bb55780638456cb5914610b475780638c639a8514610b285780638da5cb5b14610aff57806395cb1fa514610a0d57806395d89b411461092b5780639dc29fac146107e4578063a10954fe146107c5578063a457c2d714610721578063a9059cbb146106f0578063b1107d651461067d578063b17acdcd146104e7578063b70c924f14610484578063b9a6003814610465578063c30db74614610446578063c45a01551461041d578063d73792a914610400578063db2e21bc1461038b578063dd62ed3e14610342578063e138818c14610319578063eb91d37e146102fe578063eff1d50e146102d15763f2fde38b0361001157346102cd5760203660031901126102cd5761021c6117a1565b90610225611927565b6001600160a01b0391821692831561027b575050600554826bffffffffffffffffffffffff60a01b821617600555167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e08380a380f35b906020608492519162461bcd60e51b8352820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201526564647265737360d01b6064820152fd5b8280fd5b5050346102fa57816003193601126102fa5760075490516001600160a01b039091168152602090f35b5080fd5b5050346102fa57816003193601126102fa5751908152602090f35b5050346102fa57816003193601126102fa5760125490516001600160a01b039091168152602090f35b5050346102fa57806003193601126102fa57806020926103606117a1565b6103686117bc565b6001600160a01b0391821683526001865283832091168252845220549051908152f35b5090346102cd57826003193601126102cd576103a5611927565b828080804760018060a01b03600554165af16103bf611888565b50156103c9578280f35b906020606492519162461bcd60e51b8352820152601160248201527015da5d1a191c985dd85b0819985a5b1959607a1b6044820152fd5b5050346102fa57816003193601126102fa57602090516127108152f35b5050346102fa57816003193601126102fa5760085490516001600160a01b039091168152602090f35b5050346102fa57816003193601126102fa57602090600c549051908152f35b5050346102fa57816003193601126102fa576020906014549051908152f35b5082346104e45760203660031901126104e4576104ac60018060a01b03600854163314611821565b6104b98235601354611865565b60135560145460001981146104d15760010160145580f35b506011602492634e487b7160e01b835252fd5b80fd5b509060203660031901126102cd576008546001600160a01b0391908335906105129084163314611821565b80340361064b57612710938461052a600f54846118c8565b04946105478161053c601054866118c8565b0493601154906118c8565b0493868080808985600954165af161055d611888565b501561060857868080858194601254165af1610577611888565b50156105c55750917fa54e6c2fc0861aa9c991b26891d71059d517983b9e84b91020f42609c452eb9d93916060936105b183600d54611865565b600d5581519384526020840152820152a180f35b606490602084519162461bcd60e51b8352820152601c60248201527f506c6174666f726d20666565207472616e73666572206661696c6564000000006044820152fd5b835162461bcd60e51b8152602081840152601960248201527f4f776e657220666565207472616e73666572206661696c6564000000000000006044820152606490fd5b815162461bcd60e51b8152602081860152600c60248201526b08ccaca40dad2e6dac2e8c6d60a31b6044820152606490fd5b5060203660031901126102cd578035916106a260018060a01b03600854163314611821565b8234036106bc57836106b684600d54611865565b600d5580f35b906020606492519162461bcd60e51b8352820152600e60248201526d0acc2d8eaca40dad2e6dac2e8c6d60931b6044820152fd5b5050346102fa57806003193601126102fa5760209061071a6107106117a1565b6024359033611a1c565b5160018152f35b5082346104e457826003193601126104e45761073b6117a1565b918360243592338152600160205281812060018060a01b03861682526020522054908282106107745760208561071a8585038733611b92565b608490602086519162461bcd60e51b8352820152602560248201527f45524332303a2064656372656173656420616c6c6f77616e63652062656c6f77604482015264207a65726f60d81b6064820152fd5b5050346102fa57816003193601126102fa57602090600d549051908152f35b508290346102fa57826003193601126102fa576107ff6117a1565b600854602435916001600160a01b039161081c9083163314611821565b6108246119c6565b169182156108de5761083461197f565b82845283602052848420549082821061089057508184957fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef936020938688528785520381872055816002540360025551908152a3600160065580f35b608490602087519162461bcd60e51b8352820152602260248201527f45524332303a206275726e20616d6f756e7420657863656564732062616c616e604482015261636560f01b6064820152fd5b608490602086519162461bcd60e51b8352820152602160248201527f45524332303a206275726e2066726f6d20746865207a65726f206164647265736044820152607360f81b6064820152fd5b5090346102cd57826003193601126102cd578051918381549061094d826116e6565b808652926001928084169081156109e25750600114610986575b6109828686610978828b0383611720565b5191829182611758565b0390f35b815294507f8a35acfbc15ff81a39ae7d344fd709f28e8600b4aa8c65c6b64bfe7fe36bd19b5b8286106109ca57505050610978826020610982958201019438610967565b805460208787018101919091529095019481016109ac565b905061098297508693506020925061097894915060ff191682840152151560051b8201019438610967565b5090346102cd57806003193601126102cd578135610a296117bc565b610a3e60018060a01b03600854163314611821565b610a466119c6565b600d54828110610ac557828103908111610ab25785809381938293600d555af1610a6e611888565b5015610a7d5782600160065580f35b906020606492519162461bcd60e51b8352820152600f60248201526e151c985b9cd9995c8819985a5b1959608a1b6044820152fd5b634e487b7160e01b865260118552602486fd5b835162461bcd60e51b81526020818701526014602482015273496e73756666696369656e74207265736572766560601b6044820152606490fd5b5050346102fa57816003193601126102fa5760055490516001600160a01b039091168152602090f35b5050346102fa57816003193601126102fa576020906010549051908152f35b5050346102fa57816003193601126102fa5760207f62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a25891610b9260018060a01b036009541633146118db565b610b9a61197f565b6005805460ff60a01b1916600160a01b17905551338152a180f35b5050346102fa57816003193601126102fa5780519082600b54610bd7816116e6565b80855291600191808316908115610c605750600114610c03575b50505061097882610982940383611720565b9450600b85527f0175b7a638427703f0dbe7bb9bbf987a2551717b34e79f33b5b1008d1fa01db95b828610610c48575050506109788260206109829582010194610bf1565b80546020878701810191909152909501948101610c2b565b61098297508693506020925061097894915060ff191682840152151560051b82010194610bf1565b83346104e457806003193601126104e457610ca1611927565b600580546001600160a01b0319811690915581906001600160a01b03167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e08280a380f35b5050346102fa5760203660031901126102fa5760209181906001600160a01b03610d0d6117a1565b16815280845220549051908152f35b5050346102fa57816003193601126102fa576020906011549051908152f35b5050346102fa57816003193601126102fa57602090600f549051908152f35b5050346102fa57816003193601126102fa576020906013549051908152f35b5050346102fa57816003193601126102fa5760209060ff60055460a01c1690519015158152f35b508290346102fa57826003193601126102fa5767ffffffffffffffff92813584811161116d57610dd390369084016117d2565b929060243586811161116957610dec90369084016117d2565b6009549193909291336001600160a01b039182161490811561115b575b50156110ff57878611610fff57610e21600a546116e6565b97601f9889811161109f575b5086888a821160011461101d578991611012575b508760011b906000198960031b1c191617600a555b8311610fff5750610e68600b546116e6565b878111610f9f575b5085968211600114610ef45790610ed5610ee393928288997f30f5c4b652f95e2a697bda3258896c421eee4f29adce8fe38060f47f7aed91ad9991610ee9575b508360011b906000198560031b1c191617600b555b8551968688978852870191611800565b918483036020860152611800565b0390a180f35b90508401358a610eb0565b600b86527f0175b7a638427703f0dbe7bb9bbf987a2551717b34e79f33b5b1008d1fa01db9601f198316875b818110610f87575091610ed5917f30f5c4b652f95e2a697bda3258896c421eee4f29adce8fe38060f47f7aed91ad989985610ee397969510610f6d575b5050600183811b01600b55610ec5565b850135600019600386901b60f8161c191690558980610f5d565b858a013583556020998a019960019093019201610f20565b600b87527f0175b7a638427703f0dbe7bb9bbf987a2551717b34e79f33b5b1008d1fa01db98880850160051c82019260208610610ff6575b0160051c01905b818110610feb5750610e70565b878155600101610fde565b92508192610fd7565b634e487b7160e01b875260419052602486fd5b90508301358a610e41565b600a8a527fc65a7bb8d6351c1cf70c95a316cc6a92839c986682d98bc35f958f4883f9d2a8915088601f1981168b5b81811061108457501061106a575b5050600187811b01600a55610e56565b84013560001960038a901b60f8161c19169055898061105a565b8784013585556001909401936020938401938c93500161104c565b600a89527fc65a7bb8d6351c1cf70c95a316cc6a92839c986682d98bc35f958f4883f9d2a88a808a0160051c82019260208b106110f6575b0160051c01905b8181106110eb5750610e2d565b8981556001016110de565b925081926110d7565b608490602086519162461bcd60e51b8352820152603060248201527f4f6e6c7920636972636c65206f776e6572206f7220666163746f72792063616e60448201526f20757064617465206d6574616461746160801b6064820152fd5b905060085416331489610e09565b8580fd5b8380fd5b5090346102cd57806003193601126102cd5761118b6117a1565b60085460243592916001600160a01b03916111a99083163314611821565b6111b16119c6565b169283156112135750602084927fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef926111e861197f565b6111f482600254611865565b60025585855284835280852082815401905551908152a3600160065580f35b6020606492519162461bcd60e51b8352820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f2061646472657373006044820152fd5b50346102cd57826003193601126102cd5761127b60018060a01b036009541633146118db565b6005549060ff8260a01c16156112c3575060ff60a01b1916600555513381527f5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa90602090a180f35b606490602084519162461bcd60e51b8352820152601460248201527314185d5cd8589b194e881b9bdd081c185d5cd95960621b6044820152fd5b5050346102fa57806003193601126102fa5761071a6020926113466113206117a1565b338352600186528483206001600160a01b03821684528652918490205460243590611865565b9033611b92565b5050346102fa57816003193601126102fa57608090600254906013549060145490600d5492815194855260208501528301526060820152f35b5050346102fa57816003193601126102fa576020905160128152f35b508290346102fa5760603660031901126102fa576113be6117a1565b6113c66117bc565b91846044359460018060a01b038416815260016020528181203382526020522054906000198203611400575b60208661071a878787611a1c565b848210611429575091839161141e6020969561071a95033383611b92565b9193948193506113f2565b606490602087519162461bcd60e51b8352820152601d60248201527f45524332303a20696e73756666696369656e7420616c6c6f77616e63650000006044820152fd5b5050346102fa57816003193601126102fa576020906002549051908152f35b5090346102cd5760203660031901126102cd578135916114b660018060a01b03600854163314611821565b6101f483116114f15750816020917f21ea14061974d1886e13e854a8a738e5177a587170a7f767a2b182a3f8b8dcdf93600e5551908152a180f35b6020606492519162461bcd60e51b8352820152600c60248201526b08ccaca40e8dede40d0d2ced60a31b6044820152fd5b5050346102fa57816003193601126102fa57602090600e549051908152f35b5050346102fa57806003193601126102fa5760209061071a6115616117a1565b6024359033611b92565b5050346102fa57816003193601126102fa5760095490516001600160a01b039091168152602090f35b5050346102fa57816003193601126102fa57805190826003546115b6816116e6565b80855291600191808316908115610c6057506001146115e15750505061097882610982940383611720565b9450600385527fc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85b5b828610611626575050506109788260206109829582010194610bf1565b80546020878701810191909152909501948101611609565b849084346102cd57826003193601126102cd5782600a5461165e816116e6565b80855291600191808316908115610c6057506001146116895750505061097882610982940383611720565b9450600a85527fc65a7bb8d6351c1cf70c95a316cc6a92839c986682d98bc35f958f4883f9d2a85b8286106116ce575050506109788260206109829582010194610bf1565b805460208787018101919091529095019481016116b1565b90600182811c92168015611716575b602083101461170057565b634e487b7160e01b600052602260045260246000fd5b91607f16916116f5565b90601f8019910116810190811067ffffffffffffffff82111761174257604052565b634e487b7160e01b600052604160045260246000fd5b6020808252825181830181905290939260005b82811061178d57505060409293506000838284010152601f8019910116010190565b81810186015184820160400152850161176b565b600435906001600160a01b03821682036117b757565b600080fd5b602435906001600160a01b03821682036117b757565b9181601f840112156117b75782359167ffffffffffffffff83116117b757602083818601950101116117b757565b908060209392818452848401376000828201840152601f01601f1916010190565b1561182857565b60405162461bcd60e51b815260206004820152601560248201527413db9b1e48199858dd1bdc9e4818d85b8818d85b1b605a1b6044820152606490fd5b9190820180921161187257565b634e487b7160e01b600052601160045260246000fd5b3d156118c3573d9067ffffffffffffffff821161174257604051916118b7601f8201601f191660200184611720565b82523d6000602084013e565b606090565b8181029291811591840414171561187257565b156118e257565b60405162461bcd60e51b815260206004820152601a60248201527f4f6e6c7920636972636c65206f776e65722063616e2063616c6c0000000000006044820152606490fd5b6005546001600160a01b0316330361193b57565b606460405162461bcd60e51b815260206004820152602060248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b60ff60055460a01c1661198e57565b60405162461bcd60e51b815260206004820152601060248201526f14185d5cd8589b194e881c185d5cd95960821b6044820152606490fd5b6002600654146119d7576002600655565b60405162461bcd60e51b815260206004820152601f60248201527f5265656e7472616e637947756172643a207265656e7472616e742063616c6c006044820152606490fd5b6001600160a01b03908116918215611b3f5716918215611aee57611a3e61197f565b600082815280602052604081205491808310611a9a57604082827fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef958760209652828652038282205586815220818154019055604051908152a3565b60405162461bcd60e51b815260206004820152602660248201527f45524332303a207472616e7366657220616d6f756e7420657863656564732062604482015265616c616e636560d01b6064820152608490fd5b60405162461bcd60e51b815260206004820152602360248201527f45524332303a207472616e7366657220746f20746865207a65726f206164647260448201526265737360e81b6064820152608490fd5b60405162461bcd60e51b815260206004820152602560248201527f45524332303a207472616e736665722066726f6d20746865207a65726f206164604482015264647265737360d81b6064820152608490fd5b6001600160a01b03908116918215611c435716918215611bf35760207f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925918360005260018252604060002085600052825280604060002055604051908152a3565b60405162461bcd60e51b815260206004820152602260248201527f45524332303a20617070726f766520746f20746865207a65726f206164647265604482015261737360f01b6064820152608490fd5b60405162461bcd60e51b8152602060048201526024808201527f45524332303a20617070726f76652066726f6d20746865207a65726f206164646044820152637265737360e01b6064820152608490fdfea26469706673582212209bbd9cfcea8ec7ca9467c06482da2ebba49360543f461ae7b18c6fa267755f6a64736f6c63430008140033a26469706673582212203d30e3ff565ac1feed815ccb370ee5bb5f3b51c9bd2839aee2f5110d7ce13b3364736f6c634300081400336080346200012357601f6200199538819003918201601f19168301916001600160401b0383118484101762000128578084926020946040528339810103126200012357516001600160a01b038082168083036200012357600160005562000066336200013e565b8015620000ec57600380546001600160a01b03191691909117905560015433911603620000a85762000098906200013e565b60405161180d9081620001888239f35b606460405162461bcd60e51b815260206004820152602060248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b60405162461bcd60e51b815260206004820152600f60248201526e496e76616c696420666163746f727960881b6044820152606490fd5b600080fd5b634e487b7160e01b600052604160045260246000fd5b600180546001600160a01b039283166001600160a01b0319821681179092559091167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0600080a356fe60406080815260049081361015610020575b5050361561001e57600080fd5b005b600090813560e01c9283630752881a14610b365783630eee3755146100ed5783632f8d8d3a14610a785783633830c89f146108e0578363571fd619146107df578363715018a61461077e57836374c9b78f1461070857836384cc315b146106e35783638da5cb5b146106bb578363c45a015514610693578363d36747dc14610678578363d439390c14610657578363dde663cb14610635578363e4e57b9e146101e0578363f2fde38b1461011157508263f9759518146100f257505063fcc319df146100ed573880610011565b610eec565b3461010d578160031936011261010d57602090516101f48152f35b5080fd5b346101dc5760203660031901126101dc5761012a610eac565b90610133611729565b6001600160a01b0391821692831561018a575050600154826bffffffffffffffffffffffff60a01b821617600155167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0600080a380f35b906020608492519162461bcd60e51b8352820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201526564647265737360d01b6064820152fd5b8280fd5b92503461010d576101f036610ec2565b93916101fa611781565b8115610601576001600160a01b03811690610216821515610f30565b84516370a0823160e01b81523388820152602097908881602481875afa90811561055d5790859187916105d0575b501061059657828552600288526102a88887872060a060058a519261026884610f6c565b61027660ff82541685610fd4565b6001810154809585015260028101548c8501526003810154606085015286810154608085015201549101521515610fe0565b85516318160ddd60e01b815288818381875afa90811561055d578691610567575b506102d86102e1918685611376565b97881015611024565b8551620647af60e91b815288818381875afa801561055d57869061052e575b61030b915088611064565b865163d73792a960e01b81529089828481885afa9081156104b85787916104f8575b610337925061108d565b9661034288826110ad565b97843b156104c2578751632770a7eb60e21b815233848201908152602081018890528890829081906040010381838a5af180156104da579088916104e4575b5050843b156104c25787516395cb1fa560e01b81528381018a90523360248201528781604481838a5af180156104da579088916104c6575b50508061046d575b50833b1561046957865163b70c924f60e01b815291820152848160248183875af1801561045f57610447575b50907fa0fe9740856690637d999c103293d3c823fc3b81443c34c6004bb582ab4b616661043d6104206001969594611487565b875194855260208501899052604085015233939081906060820190565b0390a35551908152f35b6104518591610f9e565b61045b57386103ed565b8380fd5b86513d87823e3d90fd5b8580fd5b843b156104c2578660249189519283809263b17acdcd60e01b82528088830152895af180156104b8579087916104a4575b506103c1565b6104ad90610f9e565b61046957853861049e565b88513d89823e3d90fd5b8680fd5b6104cf90610f9e565b6104c25786386103b9565b89513d8a823e3d90fd5b6104ed90610f9e565b6104c2578638610381565b90508982813d8311610527575b61050f8183610fb2565b810103126105225761033791519061032d565b600080fd5b503d610505565b508881813d8311610556575b6105448183610fb2565b810103126105225761030b9051610300565b503d61053a565b87513d88823e3d90fd5b90508881813d831161058f575b61057e8183610fb2565b8101031261052257516102d86102c9565b503d610574565b855162461bcd60e51b81529081018890526014602482015273496e73756666696369656e742062616c616e636560601b6044820152606490fd5b8092508a8092503d83116105fa575b6105e98183610fb2565b810103126105225784905138610244565b503d6105df565b835162461bcd60e51b8152602081880152600e60248201526d125b9d985b1a5908185b5bdd5b9d60921b6044820152606490fd5b503461010d5760209061065061064a36610ec2565b916111f1565b9051908152f35b503461010d578160031936011261010d576020905166038d7ea4c680008152f35b503461010d5760209061065061068d36610ec2565b916110c7565b503461010d578160031936011261010d5760035490516001600160a01b039091168152602090f35b503461010d578160031936011261010d5760015490516001600160a01b039091168152602090f35b503461010d57602036600319011261010d57602090610650610703610eac565b611487565b90346101dc5760203660031901126101dc5760c09281906001600160a01b0361072f610eac565b16815260026020522060ff8154169260018201549260028301549060056003850154938501549401549461076582518098610f0d565b60208701528501526060840152608083015260a0820152f35b82346107dc57806003193601126107dc57610797611729565b600180546001600160a01b031981169091556000906001600160a01b03167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e08280a380f35b80fd5b82903461010d578260031936011261010d576107f9610eac565b83516318160ddd60e01b81528392919060243560208285816001600160a01b0387165afa91821561055d5786926108ab575b50610843610848928261083d86611487565b956111f1565b61108d565b938161085e575b50505082519182526020820152f35b90919493925061086e82846110ad565b906127109182810292818404149015171561089857506108909293945061108d565b83808061084f565b634e487b7160e01b815260118652602490fd5b91506020823d82116108d8575b816108c560209383610fb2565b810103126105225790519061084361082b565b3d91506108b8565b92503461010d5760c036600319011261010d576108fb610eac565b9060243590600382101561045b5760035460a435936001600160a01b039182163303610a4657169261092e841515610f30565b838552600260205260018286200154610a0d5781519061094d82610f6c565b6109578483610fd4565b602082016044358152838301606435815260608401906084358252608085019284845260a08601948552888a526002602052868a20955160038110156109fa57865460ff191660ff91909116178655516001860155516002850155516003840155518783015551600590910155517ff07226072cbf1591a0c0324a692d7c1a4668d2623937bc7279e36f996b916af491602091906109f6908290610f0d565ba280f35b634e487b7160e01b8b5260218c5260248bfd5b815162461bcd60e51b81526020818801526013602482015272105b1c9958591e481a5b9a5d1a585b1a5e9959606a1b6044820152606490fd5b825162461bcd60e51b8152602081890152600c60248201526b4f6e6c7920666163746f727960a01b6044820152606490fd5b82903461010d578260031936011261010d57610a92610eac565b83516318160ddd60e01b81528392919060243560208285816001600160a01b0387165afa91821561055d578692610b01575b50610843610adc9282610ad686611487565b95611376565b9381610af15750505082519182526020820152f35b90919493925061086e83836110ad565b91506020823d8211610b2e575b81610b1b60209383610fb2565b8101031261052257905190610843610ac4565b3d9150610b0e565b90806003193601126101dc57610b4a610eac565b92610b53611781565b66038d7ea4c680003410610e79576001600160a01b038416610b76811515610f30565b80825260209460028652610bd68484208551610b9181610f6c565b610b9f60ff83541682610fd4565b60a06005600184015493848c85015260028101548a850152600381015460608501528a810154608085015201549101521515610fe0565b83516318160ddd60e01b815286818781865afa8015610da3578490610e4a575b610c02915034836110c7565b94610c11602435871015611024565b8451620647af60e91b815287818381875afa801561045f578590610e1b575b610c3b915034611064565b855163d73792a960e01b815288818481885afa90811561055d578691610dec575b50610c669161108d565b610c7081346110ad565b843b156104695786516340c10f1960e01b815233848201908152602081018a90528790829081906040010381838a5af180156104b857610dd9575b50843b15610469578560249188519283809263b1107d6560e01b82528088830152895af1801561055d57908691610dc5575b5050833b15610dc1578460249187519283809263b17acdcd60e01b82528087830152885af1801561045f57908591610dad575b5050823b1561045b5784519063b70c924f60e01b82523490820152838160248183875af18015610da357610d8f575b5090610d4d60019392611487565b845186815234602082015260408101919091523391907f377aadedb6b2a771959584d10a6a36eccb5f56b4eb3a48525f76108d2660d8d490806060810161043d565b610d998491610f9e565b6101dc5786610d3f565b85513d86823e3d90fd5b610db690610f9e565b61045b578388610d10565b8480fd5b610dce90610f9e565b610dc1578489610cdd565b610de590969196610f9e565b9489610cab565b90508881813d8311610e14575b610e038183610fb2565b810103126104695751610c66610c5c565b503d610df9565b508781813d8311610e43575b610e318183610fb2565b81010312610dc157610c3b9051610c30565b503d610e27565b508681813d8311610e72575b610e608183610fb2565b8101031261045b57610c029051610bf6565b503d610e56565b815162461bcd60e51b8152602081850152600d60248201526c42656c6f77206d696e696d756d60981b6044820152606490fd5b600435906001600160a01b038216820361052257565b6060906003190112610522576004356001600160a01b038116810361052257906024359060443590565b34610522576020610f05610eff36610ec2565b91611376565b604051908152f35b906003821015610f1a5752565b634e487b7160e01b600052602160045260246000fd5b15610f3757565b60405162461bcd60e51b815260206004820152600d60248201526c24b73b30b634b2103a37b5b2b760991b6044820152606490fd5b60c0810190811067ffffffffffffffff821117610f8857604052565b634e487b7160e01b600052604160045260246000fd5b67ffffffffffffffff8111610f8857604052565b90601f8019910116810190811067ffffffffffffffff821117610f8857604052565b6003821015610f1a5752565b15610fe757565b60405162461bcd60e51b815260206004820152601560248201527410dd5c9d99481b9bdd081a5b9a5d1a585b1a5e9959605a1b6044820152606490fd5b1561102b57565b60405162461bcd60e51b81526020600482015260116024820152700a6d8d2e0e0c2ceca40e8dede40d0d2ced607b1b6044820152606490fd5b8181029291811591840414171561107757565b634e487b7160e01b600052601160045260246000fd5b8115611097570490565b634e487b7160e01b600052601260045260246000fd5b9190820391821161107757565b9190820180921161107757565b9060009060018060a01b038316825260026020526040822090604051906110ed82610f6c565b6110fb60ff84541683610fd4565b600191828401546020820152600284015460408201526003840154606082015260a06005600495868101546080850152015491015283956103e88083029083820414831517156111de579695949392919084975b8088111561116257505050505050505090565b9091929394959697611174828a6110ba565b851c98611182848b8b6111f1565b808603611196575050505050505050505090565b8511156111c95750508388018089116111b6575b9695949392919061114f565b634e487b7160e01b875260118652602487fd5b91509760001981019081116111b657906111aa565b634e487b7160e01b865260118552602486fd5b6001600160a01b0316600090815260026020526040808220905191939192919061121a84610f6c565b61122860ff82541685610fd4565b600181015490602085019182526002810154946040810195865260056003830154926060830193845260048101546080840152015460a082015280516003811015611362576112e9575050826112819151945194611064565b936001600160ff1b03821682036112d557506112d293926112c5836112bf6112b8671bc16d674ec80000966112cb9660011b611064565b9180611064565b906110ba565b90611064565b04906110ba565b90565b634e487b7160e01b81526011600452602490fd5b909293945051600381101561134e57600114611306575050505090565b9291925190519084935b83851061131f57505050505090565b909192939461133f611345916112bf868661133a8b886110ba565b6116b3565b9561171a565b93929190611310565b634e487b7160e01b86526021600452602486fd5b634e487b7160e01b88526021600452602488fd5b6001600160a01b03166000908152600260205260408082209051919392919061139e82610f6c565b6113ac60ff82541683610fd4565b600181015490602083019182526002810154926040810193845260056003830154926060830193845260048101546080840152015460a0820152805160038110156113625785939291906114205750509061141a81611281935194519661141582821015611671565b6110ad565b92611064565b919250925051600381101561134e5760011461143d575050505090565b519051909361144e83851015611671565b936001935b8385111561146357505050505090565b909192939461133f61147e916112bf868661133a8b886110ad565b93929190611453565b6040516318160ddd60e01b8152602091600491906001600160a01b031683828481845afa91821561166557600092611636575b5060005260028352604060002092604051926114d584610f6c565b6114e360ff86541685610fd4565b60018501549184019182526002850154936040810194855260038601549160608201928352600581880154976080840198895201549160a08101928352805160038110156116215761154f57505050506112d29350670de0b6b3a7640000916112cb9151935190611064565b8091929395965051600381101561162157600103611578575050506112d29350519051916116b3565b90929194935051600381101561162157600214611599575050505050600090565b826115ad6115b292518097519651966110ad565b611064565b91670de0b6b3a7640000938484029380850486149015171561160c57906115d8916110ba565b8381029381850414901517156115f757506112d292916112bf9161108d565b601190634e487b7160e01b6000525260246000fd5b601183634e487b7160e01b6000525260246000fd5b602183634e487b7160e01b6000525260246000fd5b90918482813d831161165e575b61164d8183610fb2565b810103126107dc57505190386114ba565b503d611643565b6040513d6000823e3d90fd5b1561167857565b60405162461bcd60e51b8152602060048201526013602482015272496e73756666696369656e7420737570706c7960681b6044820152606490fd5b90811561171457620f42406116c88484611064565b0492670de0b6b3a764000093840180851161107757600019840193808511611077576112cb836115ad6112c5956115ad611710996c193e5939a08ce9dbd48000000096611064565b0490565b91505090565b60001981146110775760010190565b6001546001600160a01b0316330361173d57565b606460405162461bcd60e51b815260206004820152602060248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b600260005414611792576002600055565b60405162461bcd60e51b815260206004820152601f60248201527f5265656e7472616e637947756172643a207265656e7472616e742063616c6c006044820152606490fdfea2646970667358221220f4a259dd0635f0cd3ffd66c4564fbe593a51562b8c2199f2b087f112b0f9256964736f6c63430008140033",
+}
+
+// CircleFactoryABI is the input ABI used to generate the binding from.
+// Deprecated: Use CircleFactoryMetaData.ABI instead.
+var CircleFactoryABI = CircleFactoryMetaData.ABI
+
+// CircleFactoryBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use CircleFactoryMetaData.Bin instead.
+var CircleFactoryBin = CircleFactoryMetaData.Bin
+
+// DeployCircleFactory deploys a new Ethereum contract, binding an instance of CircleFactory to it.
+func DeployCircleFactory(auth *bind.TransactOpts, backend bind.ContractBackend, _platformTreasury common.Address) (common.Address, *types.Transaction, *CircleFactory, error) {
+	parsed, err := CircleFactoryMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(CircleFactoryBin), backend, _platformTreasury)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &CircleFactory{CircleFactoryCaller: CircleFactoryCaller{contract: contract}, CircleFactoryTransactor: CircleFactoryTransactor{contract: contract}, CircleFactoryFilterer: CircleFactoryFilterer{contract: contract}}, nil
+}
+
+// CircleFactory is an auto generated Go binding around an Ethereum contract.
+type CircleFactory struct {
+	CircleFactoryCaller     // Read-only binding to the contract
+	CircleFactoryTransactor // Write-only binding to the contract
+	CircleFactoryFilterer   // Log filterer for contract events
+}
+
+// CircleFactoryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type CircleFactoryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CircleFactoryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type CircleFactoryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CircleFactoryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type CircleFactoryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CircleFactorySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type CircleFactorySession struct {
+	Contract     *CircleFactory    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// CircleFactoryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type CircleFactoryCallerSession struct {
+	Contract *CircleFactoryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// CircleFactoryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type CircleFactoryTransactorSession struct {
+	Contract     *CircleFactoryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// CircleFactoryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type CircleFactoryRaw struct {
+	Contract *CircleFactory // Generic contract binding to access the raw methods on
+}
+
+// CircleFactoryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type CircleFactoryCallerRaw struct {
+	Contract *CircleFactoryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// CircleFactoryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type CircleFactoryTransactorRaw struct {
+	Contract *CircleFactoryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewCircleFactory creates a new instance of CircleFactory, bound to a specific deployed contract.
+func NewCircleFactory(address common.Address, backend bind.ContractBackend) (*CircleFactory, error) {
+	contract, err := bindCircleFactory(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactory{CircleFactoryCaller: CircleFactoryCaller{contract: contract}, CircleFactoryTransactor: CircleFactoryTransactor{contract: contract}, CircleFactoryFilterer: CircleFactoryFilterer{contract: contract}}, nil
+}
+
+// NewCircleFactoryCaller creates a new read-only instance of CircleFactory, bound to a specific deployed contract.
+func NewCircleFactoryCaller(address common.Address, caller bind.ContractCaller) (*CircleFactoryCaller, error) {
+	contract, err := bindCircleFactory(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryCaller{contract: contract}, nil
+}
+
+// NewCircleFactoryTransactor creates a new write-only instance of CircleFactory, bound to a specific deployed contract.
+func NewCircleFactoryTransactor(address common.Address, transactor bind.ContractTransactor) (*CircleFactoryTransactor, error) {
+	contract, err := bindCircleFactory(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryTransactor{contract: contract}, nil
+}
+
+// NewCircleFactoryFilterer creates a new log filterer instance of CircleFactory, bound to a specific deployed contract.
+func NewCircleFactoryFilterer(address common.Address, filterer bind.ContractFilterer) (*CircleFactoryFilterer, error) {
+	contract, err := bindCircleFactory(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryFilterer{contract: contract}, nil
+}
+
+// bindCircleFactory binds a generic wrapper to an already deployed contract.
+func bindCircleFactory(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := CircleFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_CircleFactory *CircleFactoryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _CircleFactory.Contract.CircleFactoryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_CircleFactory *CircleFactoryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleFactory.Contract.CircleFactoryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_CircleFactory *CircleFactoryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _CircleFactory.Contract.CircleFactoryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_CircleFactory *CircleFactoryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _CircleFactory.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_CircleFactory *CircleFactoryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleFactory.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_CircleFactory *CircleFactoryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _CircleFactory.Contract.contract.Transact(opts, method, params...)
+}
+
+// MAXCIRCLESPERUSER is a free data retrieval call binding the contract method 0xd8864a4e.
+//
+// Solidity: function MAX_CIRCLES_PER_USER() view returns(uint256)
+func (_CircleFactory *CircleFactoryCaller) MAXCIRCLESPERUSER(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "MAX_CIRCLES_PER_USER")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXCIRCLESPERUSER is a free data retrieval call binding the contract method 0xd8864a4e.
+//
+// Solidity: function MAX_CIRCLES_PER_USER() view returns(uint256)
+func (_CircleFactory *CircleFactorySession) MAXCIRCLESPERUSER() (*big.Int, error) {
+	return _CircleFactory.Contract.MAXCIRCLESPERUSER(&_CircleFactory.CallOpts)
+}
+
+// MAXCIRCLESPERUSER is a free data retrieval call binding the contract method 0xd8864a4e.
+//
+// Solidity: function MAX_CIRCLES_PER_USER() view returns(uint256)
+func (_CircleFactory *CircleFactoryCallerSession) MAXCIRCLESPERUSER() (*big.Int, error) {
+	return _CircleFactory.Contract.MAXCIRCLESPERUSER(&_CircleFactory.CallOpts)
+}
+
+// BondingCurveImpl is a free data retrieval call binding the contract method 0x2a4fb36a.
+//
+// Solidity: function bondingCurveImpl() view returns(address)
+func (_CircleFactory *CircleFactoryCaller) BondingCurveImpl(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "bondingCurveImpl")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BondingCurveImpl is a free data retrieval call binding the contract method 0x2a4fb36a.
+//
+// Solidity: function bondingCurveImpl() view returns(address)
+func (_CircleFactory *CircleFactorySession) BondingCurveImpl() (common.Address, error) {
+	return _CircleFactory.Contract.BondingCurveImpl(&_CircleFactory.CallOpts)
+}
+
+// BondingCurveImpl is a free data retrieval call binding the contract method 0x2a4fb36a.
+//
+// Solidity: function bondingCurveImpl() view returns(address)
+func (_CircleFactory *CircleFactoryCallerSession) BondingCurveImpl() (common.Address, error) {
+	return _CircleFactory.Contract.BondingCurveImpl(&_CircleFactory.CallOpts)
+}
+
+// CircleCount is a free data retrieval call binding the contract method 0xcf0b1117.
+//
+// Solidity: function circleCount() view returns(uint256)
+func (_CircleFactory *CircleFactoryCaller) CircleCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "circleCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CircleCount is a free data retrieval call binding the contract method 0xcf0b1117.
+//
+// Solidity: function circleCount() view returns(uint256)
+func (_CircleFactory *CircleFactorySession) CircleCount() (*big.Int, error) {
+	return _CircleFactory.Contract.CircleCount(&_CircleFactory.CallOpts)
+}
+
+// CircleCount is a free data retrieval call binding the contract method 0xcf0b1117.
+//
+// Solidity: function circleCount() view returns(uint256)
+func (_CircleFactory *CircleFactoryCallerSession) CircleCount() (*big.Int, error) {
+	return _CircleFactory.Contract.CircleCount(&_CircleFactory.CallOpts)
+}
+
+// CircleCreationFee is a free data retrieval call binding the contract method 0xffd22913.
+//
+// Solidity: function circleCreationFee() view returns(uint256)
+func (_CircleFactory *CircleFactoryCaller) CircleCreationFee(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "circleCreationFee")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CircleCreationFee is a free data retrieval call binding the contract method 0xffd22913.
+//
+// Solidity: function circleCreationFee() view returns(uint256)
+func (_CircleFactory *CircleFactorySession) CircleCreationFee() (*big.Int, error) {
+	return _CircleFactory.Contract.CircleCreationFee(&_CircleFactory.CallOpts)
+}
+
+// CircleCreationFee is a free data retrieval call binding the contract method 0xffd22913.
+//
+// Solidity: function circleCreationFee() view returns(uint256)
+func (_CircleFactory *CircleFactoryCallerSession) CircleCreationFee() (*big.Int, error) {
+	return _CircleFactory.Contract.CircleCreationFee(&_CircleFactory.CallOpts)
+}
+
+// Circles is a free data retrieval call binding the contract method 0xbc5e0f30.
+//
+// Solidity: function circles(uint256 ) view returns(uint256 circleId, address owner, address tokenAddress, address bondingCurveAddress, string name, string symbol, uint256 createdAt, bool active, uint8 curveType)
+func (_CircleFactory *CircleFactoryCaller) Circles(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	CircleId            *big.Int
+	Owner               common.Address
+	TokenAddress        common.Address
+	BondingCurveAddress common.Address
+	Name                string
+	Symbol              string
+	CreatedAt           *big.Int
+	Active              bool
+	CurveType           uint8
+}, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "circles", arg0)
+
+	outstruct := new(struct {
+		CircleId            *big.Int
+		Owner               common.Address
+		TokenAddress        common.Address
+		BondingCurveAddress common.Address
+		Name                string
+		Symbol              string
+		CreatedAt           *big.Int
+		Active              bool
+		CurveType           uint8
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.CircleId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Owner = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.TokenAddress = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.BondingCurveAddress = *abi.ConvertType(out[3], new(common.Address)).(*common.Address)
+	outstruct.Name = *abi.ConvertType(out[4], new(string)).(*string)
+	outstruct.Symbol = *abi.ConvertType(out[5], new(string)).(*string)
+	outstruct.CreatedAt = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+	outstruct.Active = *abi.ConvertType(out[7], new(bool)).(*bool)
+	outstruct.CurveType = *abi.ConvertType(out[8], new(uint8)).(*uint8)
+
+	return *outstruct, err
+
+}
+
+// Circles is a free data retrieval call binding the contract method 0xbc5e0f30.
+//
+// Solidity: function circles(uint256 ) view returns(uint256 circleId, address owner, address tokenAddress, address bondingCurveAddress, string name, string symbol, uint256 createdAt, bool active, uint8 curveType)
+func (_CircleFactory *CircleFactorySession) Circles(arg0 *big.Int) (struct {
+	CircleId            *big.Int
+	Owner               common.Address
+	TokenAddress        common.Address
+	BondingCurveAddress common.Address
+	Name                string
+	Symbol              string
+	CreatedAt           *big.Int
+	Active              bool
+	CurveType           uint8
+}, error) {
+	return _CircleFactory.Contract.Circles(&_CircleFactory.CallOpts, arg0)
+}
+
+// Circles is a free data retrieval call binding the contract method 0xbc5e0f30.
+//
+// Solidity: function circles(uint256 ) view returns(uint256 circleId, address owner, address tokenAddress, address bondingCurveAddress, string name, string symbol, uint256 createdAt, bool active, uint8 curveType)
+func (_CircleFactory *CircleFactoryCallerSession) Circles(arg0 *big.Int) (struct {
+	CircleId            *big.Int
+	Owner               common.Address
+	TokenAddress        common.Address
+	BondingCurveAddress common.Address
+	Name                string
+	Symbol              string
+	CreatedAt           *big.Int
+	Active              bool
+	CurveType           uint8
+}, error) {
+	return _CircleFactory.Contract.Circles(&_CircleFactory.CallOpts, arg0)
+}
+
+// GetActiveCircles is a free data retrieval call binding the contract method 0xa8667a33.
+//
+// Solidity: function getActiveCircles(uint256 offset, uint256 limit) view returns((uint256,address,address,address,string,string,uint256,bool,uint8)[])
+func (_CircleFactory *CircleFactoryCaller) GetActiveCircles(opts *bind.CallOpts, offset *big.Int, limit *big.Int) ([]CircleFactoryCircle, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "getActiveCircles", offset, limit)
+
+	if err != nil {
+		return *new([]CircleFactoryCircle), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]CircleFactoryCircle)).(*[]CircleFactoryCircle)
+
+	return out0, err
+
+}
+
+// GetActiveCircles is a free data retrieval call binding the contract method 0xa8667a33.
+//
+// Solidity: function getActiveCircles(uint256 offset, uint256 limit) view returns((uint256,address,address,address,string,string,uint256,bool,uint8)[])
+func (_CircleFactory *CircleFactorySession) GetActiveCircles(offset *big.Int, limit *big.Int) ([]CircleFactoryCircle, error) {
+	return _CircleFactory.Contract.GetActiveCircles(&_CircleFactory.CallOpts, offset, limit)
+}
+
+// GetActiveCircles is a free data retrieval call binding the contract method 0xa8667a33.
+//
+// Solidity: function getActiveCircles(uint256 offset, uint256 limit) view returns((uint256,address,address,address,string,string,uint256,bool,uint8)[])
+func (_CircleFactory *CircleFactoryCallerSession) GetActiveCircles(offset *big.Int, limit *big.Int) ([]CircleFactoryCircle, error) {
+	return _CircleFactory.Contract.GetActiveCircles(&_CircleFactory.CallOpts, offset, limit)
+}
+
+// GetCircle is a free data retrieval call binding the contract method 0x64ccecde.
+//
+// Solidity: function getCircle(uint256 circleId) view returns((uint256,address,address,address,string,string,uint256,bool,uint8))
+func (_CircleFactory *CircleFactoryCaller) GetCircle(opts *bind.CallOpts, circleId *big.Int) (CircleFactoryCircle, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "getCircle", circleId)
+
+	if err != nil {
+		return *new(CircleFactoryCircle), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(CircleFactoryCircle)).(*CircleFactoryCircle)
+
+	return out0, err
+
+}
+
+// GetCircle is a free data retrieval call binding the contract method 0x64ccecde.
+//
+// Solidity: function getCircle(uint256 circleId) view returns((uint256,address,address,address,string,string,uint256,bool,uint8))
+func (_CircleFactory *CircleFactorySession) GetCircle(circleId *big.Int) (CircleFactoryCircle, error) {
+	return _CircleFactory.Contract.GetCircle(&_CircleFactory.CallOpts, circleId)
+}
+
+// GetCircle is a free data retrieval call binding the contract method 0x64ccecde.
+//
+// Solidity: function getCircle(uint256 circleId) view returns((uint256,address,address,address,string,string,uint256,bool,uint8))
+func (_CircleFactory *CircleFactoryCallerSession) GetCircle(circleId *big.Int) (CircleFactoryCircle, error) {
+	return _CircleFactory.Contract.GetCircle(&_CircleFactory.CallOpts, circleId)
+}
+
+// GetOwnerCircles is a free data retrieval call binding the contract method 0x709f5610.
+//
+// Solidity: function getOwnerCircles(address owner) view returns(uint256[])
+func (_CircleFactory *CircleFactoryCaller) GetOwnerCircles(opts *bind.CallOpts, owner common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "getOwnerCircles", owner)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetOwnerCircles is a free data retrieval call binding the contract method 0x709f5610.
+//
+// Solidity: function getOwnerCircles(address owner) view returns(uint256[])
+func (_CircleFactory *CircleFactorySession) GetOwnerCircles(owner common.Address) ([]*big.Int, error) {
+	return _CircleFactory.Contract.GetOwnerCircles(&_CircleFactory.CallOpts, owner)
+}
+
+// GetOwnerCircles is a free data retrieval call binding the contract method 0x709f5610.
+//
+// Solidity: function getOwnerCircles(address owner) view returns(uint256[])
+func (_CircleFactory *CircleFactoryCallerSession) GetOwnerCircles(owner common.Address) ([]*big.Int, error) {
+	return _CircleFactory.Contract.GetOwnerCircles(&_CircleFactory.CallOpts, owner)
+}
+
+// GetStatistics is a free data retrieval call binding the contract method 0x372d6b27.
+//
+// Solidity: function getStatistics() view returns(uint256 totalCircles, uint256 activeCircles, uint256 totalValue)
+func (_CircleFactory *CircleFactoryCaller) GetStatistics(opts *bind.CallOpts) (struct {
+	TotalCircles  *big.Int
+	ActiveCircles *big.Int
+	TotalValue    *big.Int
+}, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "getStatistics")
+
+	outstruct := new(struct {
+		TotalCircles  *big.Int
+		ActiveCircles *big.Int
+		TotalValue    *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.TotalCircles = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.ActiveCircles = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.TotalValue = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetStatistics is a free data retrieval call binding the contract method 0x372d6b27.
+//
+// Solidity: function getStatistics() view returns(uint256 totalCircles, uint256 activeCircles, uint256 totalValue)
+func (_CircleFactory *CircleFactorySession) GetStatistics() (struct {
+	TotalCircles  *big.Int
+	ActiveCircles *big.Int
+	TotalValue    *big.Int
+}, error) {
+	return _CircleFactory.Contract.GetStatistics(&_CircleFactory.CallOpts)
+}
+
+// GetStatistics is a free data retrieval call binding the contract method 0x372d6b27.
+//
+// Solidity: function getStatistics() view returns(uint256 totalCircles, uint256 activeCircles, uint256 totalValue)
+func (_CircleFactory *CircleFactoryCallerSession) GetStatistics() (struct {
+	TotalCircles  *big.Int
+	ActiveCircles *big.Int
+	TotalValue    *big.Int
+}, error) {
+	return _CircleFactory.Contract.GetStatistics(&_CircleFactory.CallOpts)
+}
+
+// IsCircleToken is a free data retrieval call binding the contract method 0xf5b80888.
+//
+// Solidity: function isCircleToken(address ) view returns(bool)
+func (_CircleFactory *CircleFactoryCaller) IsCircleToken(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "isCircleToken", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsCircleToken is a free data retrieval call binding the contract method 0xf5b80888.
+//
+// Solidity: function isCircleToken(address ) view returns(bool)
+func (_CircleFactory *CircleFactorySession) IsCircleToken(arg0 common.Address) (bool, error) {
+	return _CircleFactory.Contract.IsCircleToken(&_CircleFactory.CallOpts, arg0)
+}
+
+// IsCircleToken is a free data retrieval call binding the contract method 0xf5b80888.
+//
+// Solidity: function isCircleToken(address ) view returns(bool)
+func (_CircleFactory *CircleFactoryCallerSession) IsCircleToken(arg0 common.Address) (bool, error) {
+	return _CircleFactory.Contract.IsCircleToken(&_CircleFactory.CallOpts, arg0)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CircleFactory *CircleFactoryCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CircleFactory *CircleFactorySession) Owner() (common.Address, error) {
+	return _CircleFactory.Contract.Owner(&_CircleFactory.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CircleFactory *CircleFactoryCallerSession) Owner() (common.Address, error) {
+	return _CircleFactory.Contract.Owner(&_CircleFactory.CallOpts)
+}
+
+// OwnerCircles is a free data retrieval call binding the contract method 0x0ff61ae0.
+//
+// Solidity: function ownerCircles(address , uint256 ) view returns(uint256)
+func (_CircleFactory *CircleFactoryCaller) OwnerCircles(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "ownerCircles", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// OwnerCircles is a free data retrieval call binding the contract method 0x0ff61ae0.
+//
+// Solidity: function ownerCircles(address , uint256 ) view returns(uint256)
+func (_CircleFactory *CircleFactorySession) OwnerCircles(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _CircleFactory.Contract.OwnerCircles(&_CircleFactory.CallOpts, arg0, arg1)
+}
+
+// OwnerCircles is a free data retrieval call binding the contract method 0x0ff61ae0.
+//
+// Solidity: function ownerCircles(address , uint256 ) view returns(uint256)
+func (_CircleFactory *CircleFactoryCallerSession) OwnerCircles(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _CircleFactory.Contract.OwnerCircles(&_CircleFactory.CallOpts, arg0, arg1)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_CircleFactory *CircleFactoryCaller) Paused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "paused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_CircleFactory *CircleFactorySession) Paused() (bool, error) {
+	return _CircleFactory.Contract.Paused(&_CircleFactory.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_CircleFactory *CircleFactoryCallerSession) Paused() (bool, error) {
+	return _CircleFactory.Contract.Paused(&_CircleFactory.CallOpts)
+}
+
+// PlatformTreasury is a free data retrieval call binding the contract method 0xe138818c.
+//
+// Solidity: function platformTreasury() view returns(address)
+func (_CircleFactory *CircleFactoryCaller) PlatformTreasury(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CircleFactory.contract.Call(opts, &out, "platformTreasury")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PlatformTreasury is a free data retrieval call binding the contract method 0xe138818c.
+//
+// Solidity: function platformTreasury() view returns(address)
+func (_CircleFactory *CircleFactorySession) PlatformTreasury() (common.Address, error) {
+	return _CircleFactory.Contract.PlatformTreasury(&_CircleFactory.CallOpts)
+}
+
+// PlatformTreasury is a free data retrieval call binding the contract method 0xe138818c.
+//
+// Solidity: function platformTreasury() view returns(address)
+func (_CircleFactory *CircleFactoryCallerSession) PlatformTreasury() (common.Address, error) {
+	return _CircleFactory.Contract.PlatformTreasury(&_CircleFactory.CallOpts)
+}
+
+// CreateCircle is a paid mutator transaction binding the contract method 0x0c1bd1e5.
+//
+// Solidity: function createCircle(string name, string symbol, string description, uint8 curveType, uint256 basePrice, uint256 param1, uint256 param2, uint256 param3) payable returns(uint256)
+func (_CircleFactory *CircleFactoryTransactor) CreateCircle(opts *bind.TransactOpts, name string, symbol string, description string, curveType uint8, basePrice *big.Int, param1 *big.Int, param2 *big.Int, param3 *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "createCircle", name, symbol, description, curveType, basePrice, param1, param2, param3)
+}
+
+// CreateCircle is a paid mutator transaction binding the contract method 0x0c1bd1e5.
+//
+// Solidity: function createCircle(string name, string symbol, string description, uint8 curveType, uint256 basePrice, uint256 param1, uint256 param2, uint256 param3) payable returns(uint256)
+func (_CircleFactory *CircleFactorySession) CreateCircle(name string, symbol string, description string, curveType uint8, basePrice *big.Int, param1 *big.Int, param2 *big.Int, param3 *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.Contract.CreateCircle(&_CircleFactory.TransactOpts, name, symbol, description, curveType, basePrice, param1, param2, param3)
+}
+
+// CreateCircle is a paid mutator transaction binding the contract method 0x0c1bd1e5.
+//
+// Solidity: function createCircle(string name, string symbol, string description, uint8 curveType, uint256 basePrice, uint256 param1, uint256 param2, uint256 param3) payable returns(uint256)
+func (_CircleFactory *CircleFactoryTransactorSession) CreateCircle(name string, symbol string, description string, curveType uint8, basePrice *big.Int, param1 *big.Int, param2 *big.Int, param3 *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.Contract.CreateCircle(&_CircleFactory.TransactOpts, name, symbol, description, curveType, basePrice, param1, param2, param3)
+}
+
+// DeactivateCircle is a paid mutator transaction binding the contract method 0xa9ccd11e.
+//
+// Solidity: function deactivateCircle(uint256 circleId) returns()
+func (_CircleFactory *CircleFactoryTransactor) DeactivateCircle(opts *bind.TransactOpts, circleId *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "deactivateCircle", circleId)
+}
+
+// DeactivateCircle is a paid mutator transaction binding the contract method 0xa9ccd11e.
+//
+// Solidity: function deactivateCircle(uint256 circleId) returns()
+func (_CircleFactory *CircleFactorySession) DeactivateCircle(circleId *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.Contract.DeactivateCircle(&_CircleFactory.TransactOpts, circleId)
+}
+
+// DeactivateCircle is a paid mutator transaction binding the contract method 0xa9ccd11e.
+//
+// Solidity: function deactivateCircle(uint256 circleId) returns()
+func (_CircleFactory *CircleFactoryTransactorSession) DeactivateCircle(circleId *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.Contract.DeactivateCircle(&_CircleFactory.TransactOpts, circleId)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xdb2e21bc.
+//
+// Solidity: function emergencyWithdraw() returns()
+func (_CircleFactory *CircleFactoryTransactor) EmergencyWithdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "emergencyWithdraw")
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xdb2e21bc.
+//
+// Solidity: function emergencyWithdraw() returns()
+func (_CircleFactory *CircleFactorySession) EmergencyWithdraw() (*types.Transaction, error) {
+	return _CircleFactory.Contract.EmergencyWithdraw(&_CircleFactory.TransactOpts)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xdb2e21bc.
+//
+// Solidity: function emergencyWithdraw() returns()
+func (_CircleFactory *CircleFactoryTransactorSession) EmergencyWithdraw() (*types.Transaction, error) {
+	return _CircleFactory.Contract.EmergencyWithdraw(&_CircleFactory.TransactOpts)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_CircleFactory *CircleFactoryTransactor) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "pause")
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_CircleFactory *CircleFactorySession) Pause() (*types.Transaction, error) {
+	return _CircleFactory.Contract.Pause(&_CircleFactory.TransactOpts)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_CircleFactory *CircleFactoryTransactorSession) Pause() (*types.Transaction, error) {
+	return _CircleFactory.Contract.Pause(&_CircleFactory.TransactOpts)
+}
+
+// ReactivateCircle is a paid mutator transaction binding the contract method 0x3fd28fc4.
+//
+// Solidity: function reactivateCircle(uint256 circleId) returns()
+func (_CircleFactory *CircleFactoryTransactor) ReactivateCircle(opts *bind.TransactOpts, circleId *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "reactivateCircle", circleId)
+}
+
+// ReactivateCircle is a paid mutator transaction binding the contract method 0x3fd28fc4.
+//
+// Solidity: function reactivateCircle(uint256 circleId) returns()
+func (_CircleFactory *CircleFactorySession) ReactivateCircle(circleId *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.Contract.ReactivateCircle(&_CircleFactory.TransactOpts, circleId)
+}
+
+// ReactivateCircle is a paid mutator transaction binding the contract method 0x3fd28fc4.
+//
+// Solidity: function reactivateCircle(uint256 circleId) returns()
+func (_CircleFactory *CircleFactoryTransactorSession) ReactivateCircle(circleId *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.Contract.ReactivateCircle(&_CircleFactory.TransactOpts, circleId)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CircleFactory *CircleFactoryTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CircleFactory *CircleFactorySession) RenounceOwnership() (*types.Transaction, error) {
+	return _CircleFactory.Contract.RenounceOwnership(&_CircleFactory.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CircleFactory *CircleFactoryTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _CircleFactory.Contract.RenounceOwnership(&_CircleFactory.TransactOpts)
+}
+
+// TransferCircleOwnership is a paid mutator transaction binding the contract method 0xc33c65a1.
+//
+// Solidity: function transferCircleOwnership(uint256 circleId, address newOwner) returns()
+func (_CircleFactory *CircleFactoryTransactor) TransferCircleOwnership(opts *bind.TransactOpts, circleId *big.Int, newOwner common.Address) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "transferCircleOwnership", circleId, newOwner)
+}
+
+// TransferCircleOwnership is a paid mutator transaction binding the contract method 0xc33c65a1.
+//
+// Solidity: function transferCircleOwnership(uint256 circleId, address newOwner) returns()
+func (_CircleFactory *CircleFactorySession) TransferCircleOwnership(circleId *big.Int, newOwner common.Address) (*types.Transaction, error) {
+	return _CircleFactory.Contract.TransferCircleOwnership(&_CircleFactory.TransactOpts, circleId, newOwner)
+}
+
+// TransferCircleOwnership is a paid mutator transaction binding the contract method 0xc33c65a1.
+//
+// Solidity: function transferCircleOwnership(uint256 circleId, address newOwner) returns()
+func (_CircleFactory *CircleFactoryTransactorSession) TransferCircleOwnership(circleId *big.Int, newOwner common.Address) (*types.Transaction, error) {
+	return _CircleFactory.Contract.TransferCircleOwnership(&_CircleFactory.TransactOpts, circleId, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CircleFactory *CircleFactoryTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CircleFactory *CircleFactorySession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _CircleFactory.Contract.TransferOwnership(&_CircleFactory.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CircleFactory *CircleFactoryTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _CircleFactory.Contract.TransferOwnership(&_CircleFactory.TransactOpts, newOwner)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_CircleFactory *CircleFactoryTransactor) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "unpause")
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_CircleFactory *CircleFactorySession) Unpause() (*types.Transaction, error) {
+	return _CircleFactory.Contract.Unpause(&_CircleFactory.TransactOpts)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_CircleFactory *CircleFactoryTransactorSession) Unpause() (*types.Transaction, error) {
+	return _CircleFactory.Contract.Unpause(&_CircleFactory.TransactOpts)
+}
+
+// UpdateCircleCreationFee is a paid mutator transaction binding the contract method 0xdc34751e.
+//
+// Solidity: function updateCircleCreationFee(uint256 newFee) returns()
+func (_CircleFactory *CircleFactoryTransactor) UpdateCircleCreationFee(opts *bind.TransactOpts, newFee *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "updateCircleCreationFee", newFee)
+}
+
+// UpdateCircleCreationFee is a paid mutator transaction binding the contract method 0xdc34751e.
+//
+// Solidity: function updateCircleCreationFee(uint256 newFee) returns()
+func (_CircleFactory *CircleFactorySession) UpdateCircleCreationFee(newFee *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.Contract.UpdateCircleCreationFee(&_CircleFactory.TransactOpts, newFee)
+}
+
+// UpdateCircleCreationFee is a paid mutator transaction binding the contract method 0xdc34751e.
+//
+// Solidity: function updateCircleCreationFee(uint256 newFee) returns()
+func (_CircleFactory *CircleFactoryTransactorSession) UpdateCircleCreationFee(newFee *big.Int) (*types.Transaction, error) {
+	return _CircleFactory.Contract.UpdateCircleCreationFee(&_CircleFactory.TransactOpts, newFee)
+}
+
+// UpdatePlatformTreasury is a paid mutator transaction binding the contract method 0x943d7cae.
+//
+// Solidity: function updatePlatformTreasury(address newTreasury) returns()
+func (_CircleFactory *CircleFactoryTransactor) UpdatePlatformTreasury(opts *bind.TransactOpts, newTreasury common.Address) (*types.Transaction, error) {
+	return _CircleFactory.contract.Transact(opts, "updatePlatformTreasury", newTreasury)
+}
+
+// UpdatePlatformTreasury is a paid mutator transaction binding the contract method 0x943d7cae.
+//
+// Solidity: function updatePlatformTreasury(address newTreasury) returns()
+func (_CircleFactory *CircleFactorySession) UpdatePlatformTreasury(newTreasury common.Address) (*types.Transaction, error) {
+	return _CircleFactory.Contract.UpdatePlatformTreasury(&_CircleFactory.TransactOpts, newTreasury)
+}
+
+// UpdatePlatformTreasury is a paid mutator transaction binding the contract method 0x943d7cae.
+//
+// Solidity: function updatePlatformTreasury(address newTreasury) returns()
+func (_CircleFactory *CircleFactoryTransactorSession) UpdatePlatformTreasury(newTreasury common.Address) (*types.Transaction, error) {
+	return _CircleFactory.Contract.UpdatePlatformTreasury(&_CircleFactory.TransactOpts, newTreasury)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_CircleFactory *CircleFactoryTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleFactory.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_CircleFactory *CircleFactorySession) Receive() (*types.Transaction, error) {
+	return _CircleFactory.Contract.Receive(&_CircleFactory.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_CircleFactory *CircleFactoryTransactorSession) Receive() (*types.Transaction, error) {
+	return _CircleFactory.Contract.Receive(&_CircleFactory.TransactOpts)
+}
+
+// CircleFactoryCircleCreatedIterator is returned from FilterCircleCreated and is used to iterate over the raw logs and unpacked data for CircleCreated events raised by the CircleFactory contract.
+type CircleFactoryCircleCreatedIterator struct {
+	Event *CircleFactoryCircleCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleFactoryCircleCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleFactoryCircleCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleFactoryCircleCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleFactoryCircleCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleFactoryCircleCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleFactoryCircleCreated represents a CircleCreated event raised by the CircleFactory contract.
+type CircleFactoryCircleCreated struct {
+	CircleId     *big.Int
+	Owner        common.Address
+	TokenAddress common.Address
+	Name         string
+	Symbol       string
+	CurveType    uint8
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterCircleCreated is a free log retrieval operation binding the contract event 0x06909894b67dbde90a608cf9f999243b5797ccb0a9d39c5942289fc0692313ab.
+//
+// Solidity: event CircleCreated(uint256 indexed circleId, address indexed owner, address indexed tokenAddress, string name, string symbol, uint8 curveType)
+func (_CircleFactory *CircleFactoryFilterer) FilterCircleCreated(opts *bind.FilterOpts, circleId []*big.Int, owner []common.Address, tokenAddress []common.Address) (*CircleFactoryCircleCreatedIterator, error) {
+
+	var circleIdRule []interface{}
+	for _, circleIdItem := range circleId {
+		circleIdRule = append(circleIdRule, circleIdItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var tokenAddressRule []interface{}
+	for _, tokenAddressItem := range tokenAddress {
+		tokenAddressRule = append(tokenAddressRule, tokenAddressItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.FilterLogs(opts, "CircleCreated", circleIdRule, ownerRule, tokenAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryCircleCreatedIterator{contract: _CircleFactory.contract, event: "CircleCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchCircleCreated is a free log subscription operation binding the contract event 0x06909894b67dbde90a608cf9f999243b5797ccb0a9d39c5942289fc0692313ab.
+//
+// Solidity: event CircleCreated(uint256 indexed circleId, address indexed owner, address indexed tokenAddress, string name, string symbol, uint8 curveType)
+func (_CircleFactory *CircleFactoryFilterer) WatchCircleCreated(opts *bind.WatchOpts, sink chan<- *CircleFactoryCircleCreated, circleId []*big.Int, owner []common.Address, tokenAddress []common.Address) (event.Subscription, error) {
+
+	var circleIdRule []interface{}
+	for _, circleIdItem := range circleId {
+		circleIdRule = append(circleIdRule, circleIdItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var tokenAddressRule []interface{}
+	for _, tokenAddressItem := range tokenAddress {
+		tokenAddressRule = append(tokenAddressRule, tokenAddressItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.WatchLogs(opts, "CircleCreated", circleIdRule, ownerRule, tokenAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleFactoryCircleCreated)
+				if err := _CircleFactory.contract.UnpackLog(event, "CircleCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCircleCreated is a log parse operation binding the contract event 0x06909894b67dbde90a608cf9f999243b5797ccb0a9d39c5942289fc0692313ab.
+//
+// Solidity: event CircleCreated(uint256 indexed circleId, address indexed owner, address indexed tokenAddress, string name, string symbol, uint8 curveType)
+func (_CircleFactory *CircleFactoryFilterer) ParseCircleCreated(log types.Log) (*CircleFactoryCircleCreated, error) {
+	event := new(CircleFactoryCircleCreated)
+	if err := _CircleFactory.contract.UnpackLog(event, "CircleCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleFactoryCircleCreationFeeUpdatedIterator is returned from FilterCircleCreationFeeUpdated and is used to iterate over the raw logs and unpacked data for CircleCreationFeeUpdated events raised by the CircleFactory contract.
+type CircleFactoryCircleCreationFeeUpdatedIterator struct {
+	Event *CircleFactoryCircleCreationFeeUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleFactoryCircleCreationFeeUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleFactoryCircleCreationFeeUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleFactoryCircleCreationFeeUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleFactoryCircleCreationFeeUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleFactoryCircleCreationFeeUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleFactoryCircleCreationFeeUpdated represents a CircleCreationFeeUpdated event raised by the CircleFactory contract.
+type CircleFactoryCircleCreationFeeUpdated struct {
+	NewFee *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterCircleCreationFeeUpdated is a free log retrieval operation binding the contract event 0x296cdb8153118137fc4e81216134782ce26386ea221f0b61b75e94263256f38c.
+//
+// Solidity: event CircleCreationFeeUpdated(uint256 newFee)
+func (_CircleFactory *CircleFactoryFilterer) FilterCircleCreationFeeUpdated(opts *bind.FilterOpts) (*CircleFactoryCircleCreationFeeUpdatedIterator, error) {
+
+	logs, sub, err := _CircleFactory.contract.FilterLogs(opts, "CircleCreationFeeUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryCircleCreationFeeUpdatedIterator{contract: _CircleFactory.contract, event: "CircleCreationFeeUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchCircleCreationFeeUpdated is a free log subscription operation binding the contract event 0x296cdb8153118137fc4e81216134782ce26386ea221f0b61b75e94263256f38c.
+//
+// Solidity: event CircleCreationFeeUpdated(uint256 newFee)
+func (_CircleFactory *CircleFactoryFilterer) WatchCircleCreationFeeUpdated(opts *bind.WatchOpts, sink chan<- *CircleFactoryCircleCreationFeeUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _CircleFactory.contract.WatchLogs(opts, "CircleCreationFeeUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleFactoryCircleCreationFeeUpdated)
+				if err := _CircleFactory.contract.UnpackLog(event, "CircleCreationFeeUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCircleCreationFeeUpdated is a log parse operation binding the contract event 0x296cdb8153118137fc4e81216134782ce26386ea221f0b61b75e94263256f38c.
+//
+// Solidity: event CircleCreationFeeUpdated(uint256 newFee)
+func (_CircleFactory *CircleFactoryFilterer) ParseCircleCreationFeeUpdated(log types.Log) (*CircleFactoryCircleCreationFeeUpdated, error) {
+	event := new(CircleFactoryCircleCreationFeeUpdated)
+	if err := _CircleFactory.contract.UnpackLog(event, "CircleCreationFeeUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleFactoryCircleDeactivatedIterator is returned from FilterCircleDeactivated and is used to iterate over the raw logs and unpacked data for CircleDeactivated events raised by the CircleFactory contract.
+type CircleFactoryCircleDeactivatedIterator struct {
+	Event *CircleFactoryCircleDeactivated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleFactoryCircleDeactivatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleFactoryCircleDeactivated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleFactoryCircleDeactivated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleFactoryCircleDeactivatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleFactoryCircleDeactivatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleFactoryCircleDeactivated represents a CircleDeactivated event raised by the CircleFactory contract.
+type CircleFactoryCircleDeactivated struct {
+	CircleId *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterCircleDeactivated is a free log retrieval operation binding the contract event 0x320ff379b7d4234faa6fee23dfa531c1a96cab59b64d93658440dd9f069ccf38.
+//
+// Solidity: event CircleDeactivated(uint256 indexed circleId)
+func (_CircleFactory *CircleFactoryFilterer) FilterCircleDeactivated(opts *bind.FilterOpts, circleId []*big.Int) (*CircleFactoryCircleDeactivatedIterator, error) {
+
+	var circleIdRule []interface{}
+	for _, circleIdItem := range circleId {
+		circleIdRule = append(circleIdRule, circleIdItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.FilterLogs(opts, "CircleDeactivated", circleIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryCircleDeactivatedIterator{contract: _CircleFactory.contract, event: "CircleDeactivated", logs: logs, sub: sub}, nil
+}
+
+// WatchCircleDeactivated is a free log subscription operation binding the contract event 0x320ff379b7d4234faa6fee23dfa531c1a96cab59b64d93658440dd9f069ccf38.
+//
+// Solidity: event CircleDeactivated(uint256 indexed circleId)
+func (_CircleFactory *CircleFactoryFilterer) WatchCircleDeactivated(opts *bind.WatchOpts, sink chan<- *CircleFactoryCircleDeactivated, circleId []*big.Int) (event.Subscription, error) {
+
+	var circleIdRule []interface{}
+	for _, circleIdItem := range circleId {
+		circleIdRule = append(circleIdRule, circleIdItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.WatchLogs(opts, "CircleDeactivated", circleIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleFactoryCircleDeactivated)
+				if err := _CircleFactory.contract.UnpackLog(event, "CircleDeactivated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCircleDeactivated is a log parse operation binding the contract event 0x320ff379b7d4234faa6fee23dfa531c1a96cab59b64d93658440dd9f069ccf38.
+//
+// Solidity: event CircleDeactivated(uint256 indexed circleId)
+func (_CircleFactory *CircleFactoryFilterer) ParseCircleDeactivated(log types.Log) (*CircleFactoryCircleDeactivated, error) {
+	event := new(CircleFactoryCircleDeactivated)
+	if err := _CircleFactory.contract.UnpackLog(event, "CircleDeactivated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleFactoryCircleOwnershipTransferredIterator is returned from FilterCircleOwnershipTransferred and is used to iterate over the raw logs and unpacked data for CircleOwnershipTransferred events raised by the CircleFactory contract.
+type CircleFactoryCircleOwnershipTransferredIterator struct {
+	Event *CircleFactoryCircleOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleFactoryCircleOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleFactoryCircleOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleFactoryCircleOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleFactoryCircleOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleFactoryCircleOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleFactoryCircleOwnershipTransferred represents a CircleOwnershipTransferred event raised by the CircleFactory contract.
+type CircleFactoryCircleOwnershipTransferred struct {
+	CircleId *big.Int
+	OldOwner common.Address
+	NewOwner common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterCircleOwnershipTransferred is a free log retrieval operation binding the contract event 0x47562d741428c467148a697035defd09a4f65a52abfc41156086f56f4b8ef0f5.
+//
+// Solidity: event CircleOwnershipTransferred(uint256 indexed circleId, address indexed oldOwner, address indexed newOwner)
+func (_CircleFactory *CircleFactoryFilterer) FilterCircleOwnershipTransferred(opts *bind.FilterOpts, circleId []*big.Int, oldOwner []common.Address, newOwner []common.Address) (*CircleFactoryCircleOwnershipTransferredIterator, error) {
+
+	var circleIdRule []interface{}
+	for _, circleIdItem := range circleId {
+		circleIdRule = append(circleIdRule, circleIdItem)
+	}
+	var oldOwnerRule []interface{}
+	for _, oldOwnerItem := range oldOwner {
+		oldOwnerRule = append(oldOwnerRule, oldOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.FilterLogs(opts, "CircleOwnershipTransferred", circleIdRule, oldOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryCircleOwnershipTransferredIterator{contract: _CircleFactory.contract, event: "CircleOwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchCircleOwnershipTransferred is a free log subscription operation binding the contract event 0x47562d741428c467148a697035defd09a4f65a52abfc41156086f56f4b8ef0f5.
+//
+// Solidity: event CircleOwnershipTransferred(uint256 indexed circleId, address indexed oldOwner, address indexed newOwner)
+func (_CircleFactory *CircleFactoryFilterer) WatchCircleOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *CircleFactoryCircleOwnershipTransferred, circleId []*big.Int, oldOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var circleIdRule []interface{}
+	for _, circleIdItem := range circleId {
+		circleIdRule = append(circleIdRule, circleIdItem)
+	}
+	var oldOwnerRule []interface{}
+	for _, oldOwnerItem := range oldOwner {
+		oldOwnerRule = append(oldOwnerRule, oldOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.WatchLogs(opts, "CircleOwnershipTransferred", circleIdRule, oldOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleFactoryCircleOwnershipTransferred)
+				if err := _CircleFactory.contract.UnpackLog(event, "CircleOwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCircleOwnershipTransferred is a log parse operation binding the contract event 0x47562d741428c467148a697035defd09a4f65a52abfc41156086f56f4b8ef0f5.
+//
+// Solidity: event CircleOwnershipTransferred(uint256 indexed circleId, address indexed oldOwner, address indexed newOwner)
+func (_CircleFactory *CircleFactoryFilterer) ParseCircleOwnershipTransferred(log types.Log) (*CircleFactoryCircleOwnershipTransferred, error) {
+	event := new(CircleFactoryCircleOwnershipTransferred)
+	if err := _CircleFactory.contract.UnpackLog(event, "CircleOwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleFactoryCircleReactivatedIterator is returned from FilterCircleReactivated and is used to iterate over the raw logs and unpacked data for CircleReactivated events raised by the CircleFactory contract.
+type CircleFactoryCircleReactivatedIterator struct {
+	Event *CircleFactoryCircleReactivated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleFactoryCircleReactivatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleFactoryCircleReactivated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleFactoryCircleReactivated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleFactoryCircleReactivatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleFactoryCircleReactivatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleFactoryCircleReactivated represents a CircleReactivated event raised by the CircleFactory contract.
+type CircleFactoryCircleReactivated struct {
+	CircleId *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterCircleReactivated is a free log retrieval operation binding the contract event 0x4663177e89735761eda7dceaa156f929cbc86ad8e603ccef53d2c3c99ea13f14.
+//
+// Solidity: event CircleReactivated(uint256 indexed circleId)
+func (_CircleFactory *CircleFactoryFilterer) FilterCircleReactivated(opts *bind.FilterOpts, circleId []*big.Int) (*CircleFactoryCircleReactivatedIterator, error) {
+
+	var circleIdRule []interface{}
+	for _, circleIdItem := range circleId {
+		circleIdRule = append(circleIdRule, circleIdItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.FilterLogs(opts, "CircleReactivated", circleIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryCircleReactivatedIterator{contract: _CircleFactory.contract, event: "CircleReactivated", logs: logs, sub: sub}, nil
+}
+
+// WatchCircleReactivated is a free log subscription operation binding the contract event 0x4663177e89735761eda7dceaa156f929cbc86ad8e603ccef53d2c3c99ea13f14.
+//
+// Solidity: event CircleReactivated(uint256 indexed circleId)
+func (_CircleFactory *CircleFactoryFilterer) WatchCircleReactivated(opts *bind.WatchOpts, sink chan<- *CircleFactoryCircleReactivated, circleId []*big.Int) (event.Subscription, error) {
+
+	var circleIdRule []interface{}
+	for _, circleIdItem := range circleId {
+		circleIdRule = append(circleIdRule, circleIdItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.WatchLogs(opts, "CircleReactivated", circleIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleFactoryCircleReactivated)
+				if err := _CircleFactory.contract.UnpackLog(event, "CircleReactivated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCircleReactivated is a log parse operation binding the contract event 0x4663177e89735761eda7dceaa156f929cbc86ad8e603ccef53d2c3c99ea13f14.
+//
+// Solidity: event CircleReactivated(uint256 indexed circleId)
+func (_CircleFactory *CircleFactoryFilterer) ParseCircleReactivated(log types.Log) (*CircleFactoryCircleReactivated, error) {
+	event := new(CircleFactoryCircleReactivated)
+	if err := _CircleFactory.contract.UnpackLog(event, "CircleReactivated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleFactoryOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the CircleFactory contract.
+type CircleFactoryOwnershipTransferredIterator struct {
+	Event *CircleFactoryOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleFactoryOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleFactoryOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleFactoryOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleFactoryOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleFactoryOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleFactoryOwnershipTransferred represents a OwnershipTransferred event raised by the CircleFactory contract.
+type CircleFactoryOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CircleFactory *CircleFactoryFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*CircleFactoryOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryOwnershipTransferredIterator{contract: _CircleFactory.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CircleFactory *CircleFactoryFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *CircleFactoryOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _CircleFactory.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleFactoryOwnershipTransferred)
+				if err := _CircleFactory.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CircleFactory *CircleFactoryFilterer) ParseOwnershipTransferred(log types.Log) (*CircleFactoryOwnershipTransferred, error) {
+	event := new(CircleFactoryOwnershipTransferred)
+	if err := _CircleFactory.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleFactoryPausedIterator is returned from FilterPaused and is used to iterate over the raw logs and unpacked data for Paused events raised by the CircleFactory contract.
+type CircleFactoryPausedIterator struct {
+	Event *CircleFactoryPaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleFactoryPausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleFactoryPaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleFactoryPaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleFactoryPausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleFactoryPausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleFactoryPaused represents a Paused event raised by the CircleFactory contract.
+type CircleFactoryPaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaused is a free log retrieval operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_CircleFactory *CircleFactoryFilterer) FilterPaused(opts *bind.FilterOpts) (*CircleFactoryPausedIterator, error) {
+
+	logs, sub, err := _CircleFactory.contract.FilterLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryPausedIterator{contract: _CircleFactory.contract, event: "Paused", logs: logs, sub: sub}, nil
+}
+
+// WatchPaused is a free log subscription operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_CircleFactory *CircleFactoryFilterer) WatchPaused(opts *bind.WatchOpts, sink chan<- *CircleFactoryPaused) (event.Subscription, error) {
+
+	logs, sub, err := _CircleFactory.contract.WatchLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleFactoryPaused)
+				if err := _CircleFactory.contract.UnpackLog(event, "Paused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaused is a log parse operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_CircleFactory *CircleFactoryFilterer) ParsePaused(log types.Log) (*CircleFactoryPaused, error) {
+	event := new(CircleFactoryPaused)
+	if err := _CircleFactory.contract.UnpackLog(event, "Paused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleFactoryUnpausedIterator is returned from FilterUnpaused and is used to iterate over the raw logs and unpacked data for Unpaused events raised by the CircleFactory contract.
+type CircleFactoryUnpausedIterator struct {
+	Event *CircleFactoryUnpaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleFactoryUnpausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleFactoryUnpaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleFactoryUnpaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleFactoryUnpausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleFactoryUnpausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleFactoryUnpaused represents a Unpaused event raised by the CircleFactory contract.
+type CircleFactoryUnpaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpaused is a free log retrieval operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_CircleFactory *CircleFactoryFilterer) FilterUnpaused(opts *bind.FilterOpts) (*CircleFactoryUnpausedIterator, error) {
+
+	logs, sub, err := _CircleFactory.contract.FilterLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return &CircleFactoryUnpausedIterator{contract: _CircleFactory.contract, event: "Unpaused", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpaused is a free log subscription operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_CircleFactory *CircleFactoryFilterer) WatchUnpaused(opts *bind.WatchOpts, sink chan<- *CircleFactoryUnpaused) (event.Subscription, error) {
+
+	logs, sub, err := _CircleFactory.contract.WatchLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleFactoryUnpaused)
+				if err := _CircleFactory.contract.UnpackLog(event, "Unpaused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpaused is a log parse operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_CircleFactory *CircleFactoryFilterer) ParseUnpaused(log types.Log) (*CircleFactoryUnpaused, error) {
+	event := new(CircleFactoryUnpaused)
+	if err := _CircleFactory.contract.UnpackLog(event, "Unpaused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,2810 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// CircleTokenMetaData contains all meta data concerning the CircleToken contract.
+var CircleTokenMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"name\":\"name\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"symbol\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"_circleOwner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_factory\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_bondingCurve\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_platformTreasury\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_circleId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"receive\",\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"FEE_DENOMINATOR\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"addToReserve\",\"inputs\":[{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"allowance\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"approve\",\"inputs\":[{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"balanceOf\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"bondingCurve\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"burn\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"circleDescription\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"circleId\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"circleImage\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"circleOwner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"collectFees\",\"inputs\":[{\"name\":\"totalFee\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"decimals\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"decreaseAllowance\",\"inputs\":[{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"subtractedValue\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"emergencyWithdraw\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"factory\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getCurrentPrice\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getStatistics\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"increaseAllowance\",\"inputs\":[{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"addedValue\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"liquidityFeePercent\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"mint\",\"inputs\":[{\"name\":\"to\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"name\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"ownerFeePercent\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"pause\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"paused\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"platformFeePercent\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"platformTreasury\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"recordTransaction\",\"inputs\":[{\"name\":\"volume\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"removeFromReserve\",\"inputs\":[{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"to\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"renounceOwnership\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"reserveBalance\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"symbol\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"totalSupply\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"totalTransactions\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"totalVolume\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"transactionFeePercent\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"transfer\",\"inputs\":[{\"name\":\"to\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferFrom\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"to\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"unpause\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"updateMetadata\",\"inputs\":[{\"name\":\"description\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"image\",\"type\":\"string\",\"internalType\":\"string\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"updateTransactionFee\",\"inputs\":[{\"name\":\"newFee\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"Approval\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"spender\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"FeesCollected\",\"inputs\":[{\"name\":\"ownerFee\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"platformFee\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"liquidityFee\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"MetadataUpdated\",\"inputs\":[{\"name\":\"description\",\"type\":\"string\",\"indexed\":false,\"internalType\":\"string\"},{\"name\":\"image\",\"type\":\"string\",\"indexed\":false,\"internalType\":\"string\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"newOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Paused\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"TokensPurchased\",\"inputs\":[{\"name\":\"buyer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"cost\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"fee\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"newSupply\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"TokensSold\",\"inputs\":[{\"name\":\"seller\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"refund\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"fee\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"newSupply\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"TransactionFeeUpdated\",\"inputs\":[{\"name\":\"newFee\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Transfer\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"to\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Unpaused\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"}],\"anonymous\":false}]",
+	Bin: "0x60406080815234620005fb57620023c1803803806200001e8162000600565b928339810160e082820312620005fb5781516001600160401b0390818111620005fb57826200004f91850162000626565b906020928385015190828211620005fb576200006d91860162000626565b916200007b86860162000698565b620000896060870162000698565b92620000986080880162000698565b9660c0620000a960a0830162000698565b910151918451818111620004ec576003908154906001978883811c93168015620005f0575b8c841014620005da5781908c601f9485811162000581575b50508c908483116001146200050e5760009262000502575b505060001982851b1c191690881b1782555b8851928311620004ec5760049889548881811c91168015620004e1575b8c821014620004cc57908b828487969594116200046f575b50508b9184116001146200040457600093620003f8575b505082871b92600019911b1c19161786555b6200017933620006ad565b6005805460ff60a01b19811690915560069490945560fa600e55611770600f556107d060108190556011556001600160a01b03928316978815620003c557838616908115620003905784169182156200034d578416918215620003175760018060a01b0319918a8360095416176009558260085416176008558160075416176007556012541617601255600c5533911603620002d6576200021a90620006ad565b60ff60055460a01c16620002a257600254683635c9adc5dea00000918282018092116200028d575060025560008381528083528481208054830190558451918252917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef91a351611cca9081620006f78239f35b601190634e487b7160e01b6000525260246000fd5b60649184519162461bcd60e51b8352820152601060248201526f14185d5cd8589b194e881c185d5cd95960821b6044820152fd5b508160649285519262461bcd60e51b845283015260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b8a5162461bcd60e51b81528089018a9052601060248201526f496e76616c696420747265617375727960801b6044820152606490fd5b8a5162461bcd60e51b81528089018a9052601560248201527f496e76616c696420626f6e64696e6720637572766500000000000000000000006044820152606490fd5b8a5162461bcd60e51b81528089018a9052600f60248201526e496e76616c696420666163746f727960881b6044820152606490fd5b895162461bcd60e51b8152808801899052600d60248201526c24b73b30b634b21037bbb732b960991b6044820152606490fd5b0151915038806200015c565b60008b81528c81208a965093929190601f198616908e5b8282106200045757505085116200043c575b50505050811b0186556200016e565b01519060f884600019921b161c19169055388080806200042d565b8385015187558c989096019593840193018e6200041b565b9091929394508b60005283826000209181880160051c8301938810620004c2575b918b918897969594930160051c01915b828110620004b257508d915062000145565b600081558796508b9101620004a0565b9250819262000490565b60228b634e487b7160e01b6000525260246000fd5b90607f16906200012d565b634e487b7160e01b600052604160045260246000fd5b015190503880620000fe565b8a9350908d91601f1984168760005283600020936000905b8282106200055f575050841162000546575b505050811b01825562000110565b015160001983871b60f8161c1916905538808062000538565b91929395968291958786015181550195019301908f918d969594939262000526565b909192508560005284826000209181860160051c8301938610620005d0575b918c91869594930160051c01915b828110620005c057508e9150620000e6565b600081558594508c9101620005ae565b92508192620005a0565b634e487b7160e01b600052602260045260246000fd5b92607f1692620000ce565b600080fd5b6040519190601f01601f191682016001600160401b03811183821017620004ec57604052565b919080601f84011215620005fb5782516001600160401b038111620004ec576020906200065c601f8201601f1916830162000600565b92818452828287010111620005fb5760005b8181106200068457508260009394955001015290565b85810183015184820184015282016200066e565b51906001600160a01b0382168203620005fb57565b600580546001600160a01b039283166001600160a01b0319821681179092559091167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0600080a356fe60806040818152600480361015610021575b505050361561001f57600080fd5b005b600092833560e01c908163045e695c1461163e5750806306fdde031461159457806308c9d1e41461156b578063095ea7b3146115415780630c8f5e00146115225780630ee976961461148b57806318160ddd1461146c57806323b872dd146113a2578063313ce56714611386578063372d6b271461134d57806339509351146112fd5780633f4ba83a1461125557806340c10f191461117157806356ce0e6c14610da05780635c975abb14610d795780635f81a57c14610d5a5780636998de3514610d3b5780636e2310b214610d1c57806370a0823114610ce5578063715018a614610c885780637bc1680714610bb55780638456cb5914610b475780638c639a8514610b285780638da5cb5b14610aff57806395cb1fa514610a0d57806395d89b411461092b5780639dc29fac146107e4578063a10954fe146107c5578063a457c2d714610721578063a9059cbb146106f0578063b1107d651461067d578063b17acdcd146104e7578063b70c924f14610484578063b9a6003814610465578063c30db74614610446578063c45a01551461041d578063d73792a914610400578063db2e21bc1461038b578063dd62ed3e14610342578063e138818c14610319578063eb91d37e146102fe578063eff1d50e146102d15763f2fde38b0361001157346102cd5760203660031901126102cd5761021c6117a1565b90610225611927565b6001600160a01b0391821692831561027b575050600554826bffffffffffffffffffffffff60a01b821617600555167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e08380a380f35b906020608492519162461bcd60e51b8352820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201526564647265737360d01b6064820152fd5b8280fd5b5050346102fa57816003193601126102fa5760075490516001600160a01b039091168152602090f35b5080fd5b5050346102fa57816003193601126102fa5751908152602090f35b5050346102fa57816003193601126102fa5760125490516001600160a01b039091168152602090f35b5050346102fa57806003193601126102fa57806020926103606117a1565b6103686117bc565b6001600160a01b0391821683526001865283832091168252845220549051908152f35b5090346102cd57826003193601126102cd576103a5611927565b828080804760018060a01b03600554165af16103bf611888565b50156103c9578280f35b906020606492519162461bcd60e51b8352820152601160248201527015da5d1a191c985dd85b0819985a5b1959607a1b6044820152fd5b5050346102fa57816003193601126102fa57602090516127108152f35b5050346102fa57816003193601126102fa5760085490516001600160a01b039091168152602090f35b5050346102fa57816003193601126102fa57602090600c549051908152f35b5050346102fa57816003193601126102fa576020906014549051908152f35b5082346104e45760203660031901126104e4576104ac60018060a01b03600854163314611821565b6104b98235601354611865565b60135560145460001981146104d15760010160145580f35b506011602492634e487b7160e01b835252fd5b80fd5b509060203660031901126102cd576008546001600160a01b0391908335906105129084163314611821565b80340361064b57612710938461052a600f54846118c8565b04946105478161053c601054866118c8565b0493601154906118c8565b0493868080808985600954165af161055d611888565b501561060857868080858194601254165af1610577611888565b50156105c55750917fa54e6c2fc0861aa9c991b26891d71059d517983b9e84b91020f42609c452eb9d93916060936105b183600d54611865565b600d5581519384526020840152820152a180f35b606490602084519162461bcd60e51b8352820152601c60248201527f506c6174666f726d20666565207472616e73666572206661696c6564000000006044820152fd5b835162461bcd60e51b8152602081840152601960248201527f4f776e657220666565207472616e73666572206661696c6564000000000000006044820152606490fd5b815162461bcd60e51b8152602081860152600c60248201526b08ccaca40dad2e6dac2e8c6d60a31b6044820152606490fd5b5060203660031901126102cd578035916106a260018060a01b03600854163314611821565b8234036106bc57836106b684600d54611865565b600d5580f35b906020606492519162461bcd60e51b8352820152600e60248201526d0acc2d8eaca40dad2e6dac2e8c6d60931b6044820152fd5b5050346102fa57806003193601126102fa5760209061071a6107106117a1565b6024359033611a1c565b5160018152f35b5082346104e457826003193601126104e45761073b6117a1565b918360243592338152600160205281812060018060a01b03861682526020522054908282106107745760208561071a8585038733611b92565b608490602086519162461bcd60e51b8352820152602560248201527f45524332303a2064656372656173656420616c6c6f77616e63652062656c6f77604482015264207a65726f60d81b6064820152fd5b5050346102fa57816003193601126102fa57602090600d549051908152f35b508290346102fa57826003193601126102fa576107ff6117a1565b600854602435916001600160a01b039161081c9083163314611821565b6108246119c6565b169182156108de5761083461197f565b82845283602052848420549082821061089057508184957fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef936020938688528785520381872055816002540360025551908152a3600160065580f35b608490602087519162461bcd60e51b8352820152602260248201527f45524332303a206275726e20616d6f756e7420657863656564732062616c616e604482015261636560f01b6064820152fd5b608490602086519162461bcd60e51b8352820152602160248201527f45524332303a206275726e2066726f6d20746865207a65726f206164647265736044820152607360f81b6064820152fd5b5090346102cd57826003193601126102cd578051918381549061094d826116e6565b808652926001928084169081156109e25750600114610986575b6109828686610978828b0383611720565b5191829182611758565b0390f35b815294507f8a35acfbc15ff81a39ae7d344fd709f28e8600b4aa8c65c6b64bfe7fe36bd19b5b8286106109ca57505050610978826020610982958201019438610967565b805460208787018101919091529095019481016109ac565b905061098297508693506020925061097894915060ff191682840152151560051b8201019438610967565b5090346102cd57806003193601126102cd578135610a296117bc565b610a3e60018060a01b03600854163314611821565b610a466119c6565b600d54828110610ac557828103908111610ab25785809381938293600d555af1610a6e611888565b5015610a7d5782600160065580f35b906020606492519162461bcd60e51b8352820152600f60248201526e151c985b9cd9995c8819985a5b1959608a1b6044820152fd5b634e487b7160e01b865260118552602486fd5b835162461bcd60e51b81526020818701526014602482015273496e73756666696369656e74207265736572766560601b6044820152606490fd5b5050346102fa57816003193601126102fa5760055490516001600160a01b039091168152602090f35b5050346102fa57816003193601126102fa576020906010549051908152f35b5050346102fa57816003193601126102fa5760207f62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a25891610b9260018060a01b036009541633146118db565b610b9a61197f565b6005805460ff60a01b1916600160a01b17905551338152a180f35b5050346102fa57816003193601126102fa5780519082600b54610bd7816116e6565b80855291600191808316908115610c605750600114610c03575b50505061097882610982940383611720565b9450600b85527f0175b7a638427703f0dbe7bb9bbf987a2551717b34e79f33b5b1008d1fa01db95b828610610c48575050506109788260206109829582010194610bf1565b80546020878701810191909152909501948101610c2b565b61098297508693506020925061097894915060ff191682840152151560051b82010194610bf1565b83346104e457806003193601126104e457610ca1611927565b600580546001600160a01b0319811690915581906001600160a01b03167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e08280a380f35b5050346102fa5760203660031901126102fa5760209181906001600160a01b03610d0d6117a1565b16815280845220549051908152f35b5050346102fa57816003193601126102fa576020906011549051908152f35b5050346102fa57816003193601126102fa57602090600f549051908152f35b5050346102fa57816003193601126102fa576020906013549051908152f35b5050346102fa57816003193601126102fa5760209060ff60055460a01c1690519015158152f35b508290346102fa57826003193601126102fa5767ffffffffffffffff92813584811161116d57610dd390369084016117d2565b929060243586811161116957610dec90369084016117d2565b6009549193909291336001600160a01b039182161490811561115b575b50156110ff57878611610fff57610e21600a546116e6565b97601f9889811161109f575b5086888a821160011461101d578991611012575b508760011b906000198960031b1c191617600a555b8311610fff5750610e68600b546116e6565b878111610f9f575b5085968211600114610ef45790610ed5610ee393928288997f30f5c4b652f95e2a697bda3258896c421eee4f29adce8fe38060f47f7aed91ad9991610ee9575b508360011b906000198560031b1c191617600b555b8551968688978852870191611800565b918483036020860152611800565b0390a180f35b90508401358a610eb0565b600b86527f0175b7a638427703f0dbe7bb9bbf987a2551717b34e79f33b5b1008d1fa01db9601f198316875b818110610f87575091610ed5917f30f5c4b652f95e2a697bda3258896c421eee4f29adce8fe38060f47f7aed91ad989985610ee397969510610f6d575b5050600183811b01600b55610ec5565b850135600019600386901b60f8161c191690558980610f5d565b858a013583556020998a019960019093019201610f20565b600b87527f0175b7a638427703f0dbe7bb9bbf987a2551717b34e79f33b5b1008d1fa01db98880850160051c82019260208610610ff6575b0160051c01905b818110610feb5750610e70565b878155600101610fde565b92508192610fd7565b634e487b7160e01b875260419052602486fd5b90508301358a610e41565b600a8a527fc65a7bb8d6351c1cf70c95a316cc6a92839c986682d98bc35f958f4883f9d2a8915088601f1981168b5b81811061108457501061106a575b5050600187811b01600a55610e56565b84013560001960038a901b60f8161c19169055898061105a565b8784013585556001909401936020938401938c93500161104c565b600a89527fc65a7bb8d6351c1cf70c95a316cc6a92839c986682d98bc35f958f4883f9d2a88a808a0160051c82019260208b106110f6575b0160051c01905b8181106110eb5750610e2d565b8981556001016110de565b925081926110d7565b608490602086519162461bcd60e51b8352820152603060248201527f4f6e6c7920636972636c65206f776e6572206f7220666163746f72792063616e60448201526f20757064617465206d6574616461746160801b6064820152fd5b905060085416331489610e09565b8580fd5b8380fd5b5090346102cd57806003193601126102cd5761118b6117a1565b60085460243592916001600160a01b03916111a99083163314611821565b6111b16119c6565b169283156112135750602084927fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef926111e861197f565b6111f482600254611865565b60025585855284835280852082815401905551908152a3600160065580f35b6020606492519162461bcd60e51b8352820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f2061646472657373006044820152fd5b50346102cd57826003193601126102cd5761127b60018060a01b036009541633146118db565b6005549060ff8260a01c16156112c3575060ff60a01b1916600555513381527f5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa90602090a180f35b606490602084519162461bcd60e51b8352820152601460248201527314185d5cd8589b194e881b9bdd081c185d5cd95960621b6044820152fd5b5050346102fa57806003193601126102fa5761071a6020926113466113206117a1565b338352600186528483206001600160a01b03821684528652918490205460243590611865565b9033611b92565b5050346102fa57816003193601126102fa57608090600254906013549060145490600d5492815194855260208501528301526060820152f35b5050346102fa57816003193601126102fa576020905160128152f35b508290346102fa5760603660031901126102fa576113be6117a1565b6113c66117bc565b91846044359460018060a01b038416815260016020528181203382526020522054906000198203611400575b60208661071a878787611a1c565b848210611429575091839161141e6020969561071a95033383611b92565b9193948193506113f2565b606490602087519162461bcd60e51b8352820152601d60248201527f45524332303a20696e73756666696369656e7420616c6c6f77616e63650000006044820152fd5b5050346102fa57816003193601126102fa576020906002549051908152f35b5090346102cd5760203660031901126102cd578135916114b660018060a01b03600854163314611821565b6101f483116114f15750816020917f21ea14061974d1886e13e854a8a738e5177a587170a7f767a2b182a3f8b8dcdf93600e5551908152a180f35b6020606492519162461bcd60e51b8352820152600c60248201526b08ccaca40e8dede40d0d2ced60a31b6044820152fd5b5050346102fa57816003193601126102fa57602090600e549051908152f35b5050346102fa57806003193601126102fa5760209061071a6115616117a1565b6024359033611b92565b5050346102fa57816003193601126102fa5760095490516001600160a01b039091168152602090f35b5050346102fa57816003193601126102fa57805190826003546115b6816116e6565b80855291600191808316908115610c6057506001146115e15750505061097882610982940383611720565b9450600385527fc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85b5b828610611626575050506109788260206109829582010194610bf1565b80546020878701810191909152909501948101611609565b849084346102cd57826003193601126102cd5782600a5461165e816116e6565b80855291600191808316908115610c6057506001146116895750505061097882610982940383611720565b9450600a85527fc65a7bb8d6351c1cf70c95a316cc6a92839c986682d98bc35f958f4883f9d2a85b8286106116ce575050506109788260206109829582010194610bf1565b805460208787018101919091529095019481016116b1565b90600182811c92168015611716575b602083101461170057565b634e487b7160e01b600052602260045260246000fd5b91607f16916116f5565b90601f8019910116810190811067ffffffffffffffff82111761174257604052565b634e487b7160e01b600052604160045260246000fd5b6020808252825181830181905290939260005b82811061178d57505060409293506000838284010152601f8019910116010190565b81810186015184820160400152850161176b565b600435906001600160a01b03821682036117b757565b600080fd5b602435906001600160a01b03821682036117b757565b9181601f840112156117b75782359167ffffffffffffffff83116117b757602083818601950101116117b757565b908060209392818452848401376000828201840152601f01601f1916010190565b1561182857565b60405162461bcd60e51b815260206004820152601560248201527413db9b1e48199858dd1bdc9e4818d85b8818d85b1b605a1b6044820152606490fd5b9190820180921161187257565b634e487b7160e01b600052601160045260246000fd5b3d156118c3573d9067ffffffffffffffff821161174257604051916118b7601f8201601f191660200184611720565b82523d6000602084013e565b606090565b8181029291811591840414171561187257565b156118e257565b60405162461bcd60e51b815260206004820152601a60248201527f4f6e6c7920636972636c65206f776e65722063616e2063616c6c0000000000006044820152606490fd5b6005546001600160a01b0316330361193b57565b606460405162461bcd60e51b815260206004820152602060248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152fd5b60ff60055460a01c1661198e57565b60405162461bcd60e51b815260206004820152601060248201526f14185d5cd8589b194e881c185d5cd95960821b6044820152606490fd5b6002600654146119d7576002600655565b60405162461bcd60e51b815260206004820152601f60248201527f5265656e7472616e637947756172643a207265656e7472616e742063616c6c006044820152606490fd5b6001600160a01b03908116918215611b3f5716918215611aee57611a3e61197f565b600082815280602052604081205491808310611a9a57604082827fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef958760209652828652038282205586815220818154019055604051908152a3565b60405162461bcd60e51b815260206004820152602660248201527f45524332303a207472616e7366657220616d6f756e7420657863656564732062604482015265616c616e636560d01b6064820152608490fd5b60405162461bcd60e51b815260206004820152602360248201527f45524332303a207472616e7366657220746f20746865207a65726f206164647260448201526265737360e81b6064820152608490fd5b60405162461bcd60e51b815260206004820152602560248201527f45524332303a207472616e736665722066726f6d20746865207a65726f206164604482015264647265737360d81b6064820152608490fd5b6001600160a01b03908116918215611c435716918215611bf35760207f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925918360005260018252604060002085600052825280604060002055604051908152a3565b60405162461bcd60e51b815260206004820152602260248201527f45524332303a20617070726f766520746f20746865207a65726f206164647265604482015261737360f01b6064820152608490fd5b60405162461bcd60e51b8152602060048201526024808201527f45524332303a20617070726f76652066726f6d20746865207a65726f206164646044820152637265737360e01b6064820152608490fdfea26469706673582212209bbd9cfcea8ec7ca9467c06482da2ebba49360543f461ae7b18c6fa267755f6a64736f6c63430008140033",
+}
+
+// CircleTokenABI is the input ABI used to generate the binding from.
+// Deprecated: Use CircleTokenMetaData.ABI instead.
+var CircleTokenABI = CircleTokenMetaData.ABI
+
+// CircleTokenBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use CircleTokenMetaData.Bin instead.
+var CircleTokenBin = CircleTokenMetaData.Bin
+
+// DeployCircleToken deploys a new Ethereum contract, binding an instance of CircleToken to it.
+func DeployCircleToken(auth *bind.TransactOpts, backend bind.ContractBackend, name string, symbol string, _circleOwner common.Address, _factory common.Address, _bondingCurve common.Address, _platformTreasury common.Address, _circleId *big.Int) (common.Address, *types.Transaction, *CircleToken, error) {
+	parsed, err := CircleTokenMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(CircleTokenBin), backend, name, symbol, _circleOwner, _factory, _bondingCurve, _platformTreasury, _circleId)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &CircleToken{CircleTokenCaller: CircleTokenCaller{contract: contract}, CircleTokenTransactor: CircleTokenTransactor{contract: contract}, CircleTokenFilterer: CircleTokenFilterer{contract: contract}}, nil
+}
+
+// CircleToken is an auto generated Go binding around an Ethereum contract.
+type CircleToken struct {
+	CircleTokenCaller     // Read-only binding to the contract
+	CircleTokenTransactor // Write-only binding to the contract
+	CircleTokenFilterer   // Log filterer for contract events
+}
+
+// CircleTokenCaller is an auto generated read-only Go binding around an Ethereum contract.
+type CircleTokenCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CircleTokenTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type CircleTokenTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CircleTokenFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type CircleTokenFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CircleTokenSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type CircleTokenSession struct {
+	Contract     *CircleToken      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// CircleTokenCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type CircleTokenCallerSession struct {
+	Contract *CircleTokenCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// CircleTokenTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type CircleTokenTransactorSession struct {
+	Contract     *CircleTokenTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// CircleTokenRaw is an auto generated low-level Go binding around an Ethereum contract.
+type CircleTokenRaw struct {
+	Contract *CircleToken // Generic contract binding to access the raw methods on
+}
+
+// CircleTokenCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type CircleTokenCallerRaw struct {
+	Contract *CircleTokenCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// CircleTokenTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type CircleTokenTransactorRaw struct {
+	Contract *CircleTokenTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewCircleToken creates a new instance of CircleToken, bound to a specific deployed contract.
+func NewCircleToken(address common.Address, backend bind.ContractBackend) (*CircleToken, error) {
+	contract, err := bindCircleToken(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleToken{CircleTokenCaller: CircleTokenCaller{contract: contract}, CircleTokenTransactor: CircleTokenTransactor{contract: contract}, CircleTokenFilterer: CircleTokenFilterer{contract: contract}}, nil
+}
+
+// NewCircleTokenCaller creates a new read-only instance of CircleToken, bound to a specific deployed contract.
+func NewCircleTokenCaller(address common.Address, caller bind.ContractCaller) (*CircleTokenCaller, error) {
+	contract, err := bindCircleToken(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenCaller{contract: contract}, nil
+}
+
+// NewCircleTokenTransactor creates a new write-only instance of CircleToken, bound to a specific deployed contract.
+func NewCircleTokenTransactor(address common.Address, transactor bind.ContractTransactor) (*CircleTokenTransactor, error) {
+	contract, err := bindCircleToken(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenTransactor{contract: contract}, nil
+}
+
+// NewCircleTokenFilterer creates a new log filterer instance of CircleToken, bound to a specific deployed contract.
+func NewCircleTokenFilterer(address common.Address, filterer bind.ContractFilterer) (*CircleTokenFilterer, error) {
+	contract, err := bindCircleToken(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenFilterer{contract: contract}, nil
+}
+
+// bindCircleToken binds a generic wrapper to an already deployed contract.
+func bindCircleToken(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := CircleTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_CircleToken *CircleTokenRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _CircleToken.Contract.CircleTokenCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_CircleToken *CircleTokenRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleToken.Contract.CircleTokenTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_CircleToken *CircleTokenRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _CircleToken.Contract.CircleTokenTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_CircleToken *CircleTokenCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _CircleToken.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_CircleToken *CircleTokenTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleToken.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_CircleToken *CircleTokenTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _CircleToken.Contract.contract.Transact(opts, method, params...)
+}
+
+// FEEDENOMINATOR is a free data retrieval call binding the contract method 0xd73792a9.
+//
+// Solidity: function FEE_DENOMINATOR() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) FEEDENOMINATOR(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "FEE_DENOMINATOR")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// FEEDENOMINATOR is a free data retrieval call binding the contract method 0xd73792a9.
+//
+// Solidity: function FEE_DENOMINATOR() view returns(uint256)
+func (_CircleToken *CircleTokenSession) FEEDENOMINATOR() (*big.Int, error) {
+	return _CircleToken.Contract.FEEDENOMINATOR(&_CircleToken.CallOpts)
+}
+
+// FEEDENOMINATOR is a free data retrieval call binding the contract method 0xd73792a9.
+//
+// Solidity: function FEE_DENOMINATOR() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) FEEDENOMINATOR() (*big.Int, error) {
+	return _CircleToken.Contract.FEEDENOMINATOR(&_CircleToken.CallOpts)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_CircleToken *CircleTokenCaller) Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "allowance", owner, spender)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_CircleToken *CircleTokenSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _CircleToken.Contract.Allowance(&_CircleToken.CallOpts, owner, spender)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _CircleToken.Contract.Allowance(&_CircleToken.CallOpts, owner, spender)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_CircleToken *CircleTokenCaller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "balanceOf", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_CircleToken *CircleTokenSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _CircleToken.Contract.BalanceOf(&_CircleToken.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _CircleToken.Contract.BalanceOf(&_CircleToken.CallOpts, account)
+}
+
+// BondingCurve is a free data retrieval call binding the contract method 0xeff1d50e.
+//
+// Solidity: function bondingCurve() view returns(address)
+func (_CircleToken *CircleTokenCaller) BondingCurve(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "bondingCurve")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BondingCurve is a free data retrieval call binding the contract method 0xeff1d50e.
+//
+// Solidity: function bondingCurve() view returns(address)
+func (_CircleToken *CircleTokenSession) BondingCurve() (common.Address, error) {
+	return _CircleToken.Contract.BondingCurve(&_CircleToken.CallOpts)
+}
+
+// BondingCurve is a free data retrieval call binding the contract method 0xeff1d50e.
+//
+// Solidity: function bondingCurve() view returns(address)
+func (_CircleToken *CircleTokenCallerSession) BondingCurve() (common.Address, error) {
+	return _CircleToken.Contract.BondingCurve(&_CircleToken.CallOpts)
+}
+
+// CircleDescription is a free data retrieval call binding the contract method 0x045e695c.
+//
+// Solidity: function circleDescription() view returns(string)
+func (_CircleToken *CircleTokenCaller) CircleDescription(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "circleDescription")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// CircleDescription is a free data retrieval call binding the contract method 0x045e695c.
+//
+// Solidity: function circleDescription() view returns(string)
+func (_CircleToken *CircleTokenSession) CircleDescription() (string, error) {
+	return _CircleToken.Contract.CircleDescription(&_CircleToken.CallOpts)
+}
+
+// CircleDescription is a free data retrieval call binding the contract method 0x045e695c.
+//
+// Solidity: function circleDescription() view returns(string)
+func (_CircleToken *CircleTokenCallerSession) CircleDescription() (string, error) {
+	return _CircleToken.Contract.CircleDescription(&_CircleToken.CallOpts)
+}
+
+// CircleId is a free data retrieval call binding the contract method 0xc30db746.
+//
+// Solidity: function circleId() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) CircleId(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "circleId")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CircleId is a free data retrieval call binding the contract method 0xc30db746.
+//
+// Solidity: function circleId() view returns(uint256)
+func (_CircleToken *CircleTokenSession) CircleId() (*big.Int, error) {
+	return _CircleToken.Contract.CircleId(&_CircleToken.CallOpts)
+}
+
+// CircleId is a free data retrieval call binding the contract method 0xc30db746.
+//
+// Solidity: function circleId() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) CircleId() (*big.Int, error) {
+	return _CircleToken.Contract.CircleId(&_CircleToken.CallOpts)
+}
+
+// CircleImage is a free data retrieval call binding the contract method 0x7bc16807.
+//
+// Solidity: function circleImage() view returns(string)
+func (_CircleToken *CircleTokenCaller) CircleImage(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "circleImage")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// CircleImage is a free data retrieval call binding the contract method 0x7bc16807.
+//
+// Solidity: function circleImage() view returns(string)
+func (_CircleToken *CircleTokenSession) CircleImage() (string, error) {
+	return _CircleToken.Contract.CircleImage(&_CircleToken.CallOpts)
+}
+
+// CircleImage is a free data retrieval call binding the contract method 0x7bc16807.
+//
+// Solidity: function circleImage() view returns(string)
+func (_CircleToken *CircleTokenCallerSession) CircleImage() (string, error) {
+	return _CircleToken.Contract.CircleImage(&_CircleToken.CallOpts)
+}
+
+// CircleOwner is a free data retrieval call binding the contract method 0x08c9d1e4.
+//
+// Solidity: function circleOwner() view returns(address)
+func (_CircleToken *CircleTokenCaller) CircleOwner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "circleOwner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// CircleOwner is a free data retrieval call binding the contract method 0x08c9d1e4.
+//
+// Solidity: function circleOwner() view returns(address)
+func (_CircleToken *CircleTokenSession) CircleOwner() (common.Address, error) {
+	return _CircleToken.Contract.CircleOwner(&_CircleToken.CallOpts)
+}
+
+// CircleOwner is a free data retrieval call binding the contract method 0x08c9d1e4.
+//
+// Solidity: function circleOwner() view returns(address)
+func (_CircleToken *CircleTokenCallerSession) CircleOwner() (common.Address, error) {
+	return _CircleToken.Contract.CircleOwner(&_CircleToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_CircleToken *CircleTokenCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_CircleToken *CircleTokenSession) Decimals() (uint8, error) {
+	return _CircleToken.Contract.Decimals(&_CircleToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_CircleToken *CircleTokenCallerSession) Decimals() (uint8, error) {
+	return _CircleToken.Contract.Decimals(&_CircleToken.CallOpts)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_CircleToken *CircleTokenCaller) Factory(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "factory")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_CircleToken *CircleTokenSession) Factory() (common.Address, error) {
+	return _CircleToken.Contract.Factory(&_CircleToken.CallOpts)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_CircleToken *CircleTokenCallerSession) Factory() (common.Address, error) {
+	return _CircleToken.Contract.Factory(&_CircleToken.CallOpts)
+}
+
+// GetCurrentPrice is a free data retrieval call binding the contract method 0xeb91d37e.
+//
+// Solidity: function getCurrentPrice() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) GetCurrentPrice(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "getCurrentPrice")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetCurrentPrice is a free data retrieval call binding the contract method 0xeb91d37e.
+//
+// Solidity: function getCurrentPrice() view returns(uint256)
+func (_CircleToken *CircleTokenSession) GetCurrentPrice() (*big.Int, error) {
+	return _CircleToken.Contract.GetCurrentPrice(&_CircleToken.CallOpts)
+}
+
+// GetCurrentPrice is a free data retrieval call binding the contract method 0xeb91d37e.
+//
+// Solidity: function getCurrentPrice() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) GetCurrentPrice() (*big.Int, error) {
+	return _CircleToken.Contract.GetCurrentPrice(&_CircleToken.CallOpts)
+}
+
+// GetStatistics is a free data retrieval call binding the contract method 0x372d6b27.
+//
+// Solidity: function getStatistics() view returns(uint256, uint256, uint256, uint256)
+func (_CircleToken *CircleTokenCaller) GetStatistics(opts *bind.CallOpts) (*big.Int, *big.Int, *big.Int, *big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "getStatistics")
+
+	if err != nil {
+		return *new(*big.Int), *new(*big.Int), *new(*big.Int), *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	out1 := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	out2 := *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	out3 := *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return out0, out1, out2, out3, err
+
+}
+
+// GetStatistics is a free data retrieval call binding the contract method 0x372d6b27.
+//
+// Solidity: function getStatistics() view returns(uint256, uint256, uint256, uint256)
+func (_CircleToken *CircleTokenSession) GetStatistics() (*big.Int, *big.Int, *big.Int, *big.Int, error) {
+	return _CircleToken.Contract.GetStatistics(&_CircleToken.CallOpts)
+}
+
+// GetStatistics is a free data retrieval call binding the contract method 0x372d6b27.
+//
+// Solidity: function getStatistics() view returns(uint256, uint256, uint256, uint256)
+func (_CircleToken *CircleTokenCallerSession) GetStatistics() (*big.Int, *big.Int, *big.Int, *big.Int, error) {
+	return _CircleToken.Contract.GetStatistics(&_CircleToken.CallOpts)
+}
+
+// LiquidityFeePercent is a free data retrieval call binding the contract method 0x6e2310b2.
+//
+// Solidity: function liquidityFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) LiquidityFeePercent(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "liquidityFeePercent")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LiquidityFeePercent is a free data retrieval call binding the contract method 0x6e2310b2.
+//
+// Solidity: function liquidityFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenSession) LiquidityFeePercent() (*big.Int, error) {
+	return _CircleToken.Contract.LiquidityFeePercent(&_CircleToken.CallOpts)
+}
+
+// LiquidityFeePercent is a free data retrieval call binding the contract method 0x6e2310b2.
+//
+// Solidity: function liquidityFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) LiquidityFeePercent() (*big.Int, error) {
+	return _CircleToken.Contract.LiquidityFeePercent(&_CircleToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_CircleToken *CircleTokenCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_CircleToken *CircleTokenSession) Name() (string, error) {
+	return _CircleToken.Contract.Name(&_CircleToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_CircleToken *CircleTokenCallerSession) Name() (string, error) {
+	return _CircleToken.Contract.Name(&_CircleToken.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CircleToken *CircleTokenCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CircleToken *CircleTokenSession) Owner() (common.Address, error) {
+	return _CircleToken.Contract.Owner(&_CircleToken.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CircleToken *CircleTokenCallerSession) Owner() (common.Address, error) {
+	return _CircleToken.Contract.Owner(&_CircleToken.CallOpts)
+}
+
+// OwnerFeePercent is a free data retrieval call binding the contract method 0x6998de35.
+//
+// Solidity: function ownerFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) OwnerFeePercent(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "ownerFeePercent")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// OwnerFeePercent is a free data retrieval call binding the contract method 0x6998de35.
+//
+// Solidity: function ownerFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenSession) OwnerFeePercent() (*big.Int, error) {
+	return _CircleToken.Contract.OwnerFeePercent(&_CircleToken.CallOpts)
+}
+
+// OwnerFeePercent is a free data retrieval call binding the contract method 0x6998de35.
+//
+// Solidity: function ownerFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) OwnerFeePercent() (*big.Int, error) {
+	return _CircleToken.Contract.OwnerFeePercent(&_CircleToken.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_CircleToken *CircleTokenCaller) Paused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "paused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_CircleToken *CircleTokenSession) Paused() (bool, error) {
+	return _CircleToken.Contract.Paused(&_CircleToken.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_CircleToken *CircleTokenCallerSession) Paused() (bool, error) {
+	return _CircleToken.Contract.Paused(&_CircleToken.CallOpts)
+}
+
+// PlatformFeePercent is a free data retrieval call binding the contract method 0x8c639a85.
+//
+// Solidity: function platformFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) PlatformFeePercent(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "platformFeePercent")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PlatformFeePercent is a free data retrieval call binding the contract method 0x8c639a85.
+//
+// Solidity: function platformFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenSession) PlatformFeePercent() (*big.Int, error) {
+	return _CircleToken.Contract.PlatformFeePercent(&_CircleToken.CallOpts)
+}
+
+// PlatformFeePercent is a free data retrieval call binding the contract method 0x8c639a85.
+//
+// Solidity: function platformFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) PlatformFeePercent() (*big.Int, error) {
+	return _CircleToken.Contract.PlatformFeePercent(&_CircleToken.CallOpts)
+}
+
+// PlatformTreasury is a free data retrieval call binding the contract method 0xe138818c.
+//
+// Solidity: function platformTreasury() view returns(address)
+func (_CircleToken *CircleTokenCaller) PlatformTreasury(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "platformTreasury")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PlatformTreasury is a free data retrieval call binding the contract method 0xe138818c.
+//
+// Solidity: function platformTreasury() view returns(address)
+func (_CircleToken *CircleTokenSession) PlatformTreasury() (common.Address, error) {
+	return _CircleToken.Contract.PlatformTreasury(&_CircleToken.CallOpts)
+}
+
+// PlatformTreasury is a free data retrieval call binding the contract method 0xe138818c.
+//
+// Solidity: function platformTreasury() view returns(address)
+func (_CircleToken *CircleTokenCallerSession) PlatformTreasury() (common.Address, error) {
+	return _CircleToken.Contract.PlatformTreasury(&_CircleToken.CallOpts)
+}
+
+// ReserveBalance is a free data retrieval call binding the contract method 0xa10954fe.
+//
+// Solidity: function reserveBalance() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) ReserveBalance(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "reserveBalance")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReserveBalance is a free data retrieval call binding the contract method 0xa10954fe.
+//
+// Solidity: function reserveBalance() view returns(uint256)
+func (_CircleToken *CircleTokenSession) ReserveBalance() (*big.Int, error) {
+	return _CircleToken.Contract.ReserveBalance(&_CircleToken.CallOpts)
+}
+
+// ReserveBalance is a free data retrieval call binding the contract method 0xa10954fe.
+//
+// Solidity: function reserveBalance() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) ReserveBalance() (*big.Int, error) {
+	return _CircleToken.Contract.ReserveBalance(&_CircleToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_CircleToken *CircleTokenCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_CircleToken *CircleTokenSession) Symbol() (string, error) {
+	return _CircleToken.Contract.Symbol(&_CircleToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_CircleToken *CircleTokenCallerSession) Symbol() (string, error) {
+	return _CircleToken.Contract.Symbol(&_CircleToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_CircleToken *CircleTokenSession) TotalSupply() (*big.Int, error) {
+	return _CircleToken.Contract.TotalSupply(&_CircleToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _CircleToken.Contract.TotalSupply(&_CircleToken.CallOpts)
+}
+
+// TotalTransactions is a free data retrieval call binding the contract method 0xb9a60038.
+//
+// Solidity: function totalTransactions() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) TotalTransactions(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "totalTransactions")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalTransactions is a free data retrieval call binding the contract method 0xb9a60038.
+//
+// Solidity: function totalTransactions() view returns(uint256)
+func (_CircleToken *CircleTokenSession) TotalTransactions() (*big.Int, error) {
+	return _CircleToken.Contract.TotalTransactions(&_CircleToken.CallOpts)
+}
+
+// TotalTransactions is a free data retrieval call binding the contract method 0xb9a60038.
+//
+// Solidity: function totalTransactions() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) TotalTransactions() (*big.Int, error) {
+	return _CircleToken.Contract.TotalTransactions(&_CircleToken.CallOpts)
+}
+
+// TotalVolume is a free data retrieval call binding the contract method 0x5f81a57c.
+//
+// Solidity: function totalVolume() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) TotalVolume(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "totalVolume")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalVolume is a free data retrieval call binding the contract method 0x5f81a57c.
+//
+// Solidity: function totalVolume() view returns(uint256)
+func (_CircleToken *CircleTokenSession) TotalVolume() (*big.Int, error) {
+	return _CircleToken.Contract.TotalVolume(&_CircleToken.CallOpts)
+}
+
+// TotalVolume is a free data retrieval call binding the contract method 0x5f81a57c.
+//
+// Solidity: function totalVolume() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) TotalVolume() (*big.Int, error) {
+	return _CircleToken.Contract.TotalVolume(&_CircleToken.CallOpts)
+}
+
+// TransactionFeePercent is a free data retrieval call binding the contract method 0x0c8f5e00.
+//
+// Solidity: function transactionFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenCaller) TransactionFeePercent(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CircleToken.contract.Call(opts, &out, "transactionFeePercent")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TransactionFeePercent is a free data retrieval call binding the contract method 0x0c8f5e00.
+//
+// Solidity: function transactionFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenSession) TransactionFeePercent() (*big.Int, error) {
+	return _CircleToken.Contract.TransactionFeePercent(&_CircleToken.CallOpts)
+}
+
+// TransactionFeePercent is a free data retrieval call binding the contract method 0x0c8f5e00.
+//
+// Solidity: function transactionFeePercent() view returns(uint256)
+func (_CircleToken *CircleTokenCallerSession) TransactionFeePercent() (*big.Int, error) {
+	return _CircleToken.Contract.TransactionFeePercent(&_CircleToken.CallOpts)
+}
+
+// AddToReserve is a paid mutator transaction binding the contract method 0xb1107d65.
+//
+// Solidity: function addToReserve(uint256 amount) payable returns()
+func (_CircleToken *CircleTokenTransactor) AddToReserve(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "addToReserve", amount)
+}
+
+// AddToReserve is a paid mutator transaction binding the contract method 0xb1107d65.
+//
+// Solidity: function addToReserve(uint256 amount) payable returns()
+func (_CircleToken *CircleTokenSession) AddToReserve(amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.AddToReserve(&_CircleToken.TransactOpts, amount)
+}
+
+// AddToReserve is a paid mutator transaction binding the contract method 0xb1107d65.
+//
+// Solidity: function addToReserve(uint256 amount) payable returns()
+func (_CircleToken *CircleTokenTransactorSession) AddToReserve(amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.AddToReserve(&_CircleToken.TransactOpts, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "approve", spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.Approve(&_CircleToken.TransactOpts, spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenTransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.Approve(&_CircleToken.TransactOpts, spender, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address from, uint256 amount) returns()
+func (_CircleToken *CircleTokenTransactor) Burn(opts *bind.TransactOpts, from common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "burn", from, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address from, uint256 amount) returns()
+func (_CircleToken *CircleTokenSession) Burn(from common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.Burn(&_CircleToken.TransactOpts, from, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address from, uint256 amount) returns()
+func (_CircleToken *CircleTokenTransactorSession) Burn(from common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.Burn(&_CircleToken.TransactOpts, from, amount)
+}
+
+// CollectFees is a paid mutator transaction binding the contract method 0xb17acdcd.
+//
+// Solidity: function collectFees(uint256 totalFee) payable returns()
+func (_CircleToken *CircleTokenTransactor) CollectFees(opts *bind.TransactOpts, totalFee *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "collectFees", totalFee)
+}
+
+// CollectFees is a paid mutator transaction binding the contract method 0xb17acdcd.
+//
+// Solidity: function collectFees(uint256 totalFee) payable returns()
+func (_CircleToken *CircleTokenSession) CollectFees(totalFee *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.CollectFees(&_CircleToken.TransactOpts, totalFee)
+}
+
+// CollectFees is a paid mutator transaction binding the contract method 0xb17acdcd.
+//
+// Solidity: function collectFees(uint256 totalFee) payable returns()
+func (_CircleToken *CircleTokenTransactorSession) CollectFees(totalFee *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.CollectFees(&_CircleToken.TransactOpts, totalFee)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_CircleToken *CircleTokenTransactor) DecreaseAllowance(opts *bind.TransactOpts, spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "decreaseAllowance", spender, subtractedValue)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_CircleToken *CircleTokenSession) DecreaseAllowance(spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.DecreaseAllowance(&_CircleToken.TransactOpts, spender, subtractedValue)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_CircleToken *CircleTokenTransactorSession) DecreaseAllowance(spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.DecreaseAllowance(&_CircleToken.TransactOpts, spender, subtractedValue)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xdb2e21bc.
+//
+// Solidity: function emergencyWithdraw() returns()
+func (_CircleToken *CircleTokenTransactor) EmergencyWithdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "emergencyWithdraw")
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xdb2e21bc.
+//
+// Solidity: function emergencyWithdraw() returns()
+func (_CircleToken *CircleTokenSession) EmergencyWithdraw() (*types.Transaction, error) {
+	return _CircleToken.Contract.EmergencyWithdraw(&_CircleToken.TransactOpts)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xdb2e21bc.
+//
+// Solidity: function emergencyWithdraw() returns()
+func (_CircleToken *CircleTokenTransactorSession) EmergencyWithdraw() (*types.Transaction, error) {
+	return _CircleToken.Contract.EmergencyWithdraw(&_CircleToken.TransactOpts)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_CircleToken *CircleTokenTransactor) IncreaseAllowance(opts *bind.TransactOpts, spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "increaseAllowance", spender, addedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_CircleToken *CircleTokenSession) IncreaseAllowance(spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.IncreaseAllowance(&_CircleToken.TransactOpts, spender, addedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_CircleToken *CircleTokenTransactorSession) IncreaseAllowance(spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.IncreaseAllowance(&_CircleToken.TransactOpts, spender, addedValue)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_CircleToken *CircleTokenTransactor) Mint(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "mint", to, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_CircleToken *CircleTokenSession) Mint(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.Mint(&_CircleToken.TransactOpts, to, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_CircleToken *CircleTokenTransactorSession) Mint(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.Mint(&_CircleToken.TransactOpts, to, amount)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_CircleToken *CircleTokenTransactor) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "pause")
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_CircleToken *CircleTokenSession) Pause() (*types.Transaction, error) {
+	return _CircleToken.Contract.Pause(&_CircleToken.TransactOpts)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_CircleToken *CircleTokenTransactorSession) Pause() (*types.Transaction, error) {
+	return _CircleToken.Contract.Pause(&_CircleToken.TransactOpts)
+}
+
+// RecordTransaction is a paid mutator transaction binding the contract method 0xb70c924f.
+//
+// Solidity: function recordTransaction(uint256 volume) returns()
+func (_CircleToken *CircleTokenTransactor) RecordTransaction(opts *bind.TransactOpts, volume *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "recordTransaction", volume)
+}
+
+// RecordTransaction is a paid mutator transaction binding the contract method 0xb70c924f.
+//
+// Solidity: function recordTransaction(uint256 volume) returns()
+func (_CircleToken *CircleTokenSession) RecordTransaction(volume *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.RecordTransaction(&_CircleToken.TransactOpts, volume)
+}
+
+// RecordTransaction is a paid mutator transaction binding the contract method 0xb70c924f.
+//
+// Solidity: function recordTransaction(uint256 volume) returns()
+func (_CircleToken *CircleTokenTransactorSession) RecordTransaction(volume *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.RecordTransaction(&_CircleToken.TransactOpts, volume)
+}
+
+// RemoveFromReserve is a paid mutator transaction binding the contract method 0x95cb1fa5.
+//
+// Solidity: function removeFromReserve(uint256 amount, address to) returns()
+func (_CircleToken *CircleTokenTransactor) RemoveFromReserve(opts *bind.TransactOpts, amount *big.Int, to common.Address) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "removeFromReserve", amount, to)
+}
+
+// RemoveFromReserve is a paid mutator transaction binding the contract method 0x95cb1fa5.
+//
+// Solidity: function removeFromReserve(uint256 amount, address to) returns()
+func (_CircleToken *CircleTokenSession) RemoveFromReserve(amount *big.Int, to common.Address) (*types.Transaction, error) {
+	return _CircleToken.Contract.RemoveFromReserve(&_CircleToken.TransactOpts, amount, to)
+}
+
+// RemoveFromReserve is a paid mutator transaction binding the contract method 0x95cb1fa5.
+//
+// Solidity: function removeFromReserve(uint256 amount, address to) returns()
+func (_CircleToken *CircleTokenTransactorSession) RemoveFromReserve(amount *big.Int, to common.Address) (*types.Transaction, error) {
+	return _CircleToken.Contract.RemoveFromReserve(&_CircleToken.TransactOpts, amount, to)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CircleToken *CircleTokenTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CircleToken *CircleTokenSession) RenounceOwnership() (*types.Transaction, error) {
+	return _CircleToken.Contract.RenounceOwnership(&_CircleToken.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CircleToken *CircleTokenTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _CircleToken.Contract.RenounceOwnership(&_CircleToken.TransactOpts)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenTransactor) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "transfer", to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.Transfer(&_CircleToken.TransactOpts, to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenTransactorSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.Transfer(&_CircleToken.TransactOpts, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "transferFrom", from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.TransferFrom(&_CircleToken.TransactOpts, from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_CircleToken *CircleTokenTransactorSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.TransferFrom(&_CircleToken.TransactOpts, from, to, amount)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CircleToken *CircleTokenTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CircleToken *CircleTokenSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _CircleToken.Contract.TransferOwnership(&_CircleToken.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CircleToken *CircleTokenTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _CircleToken.Contract.TransferOwnership(&_CircleToken.TransactOpts, newOwner)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_CircleToken *CircleTokenTransactor) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "unpause")
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_CircleToken *CircleTokenSession) Unpause() (*types.Transaction, error) {
+	return _CircleToken.Contract.Unpause(&_CircleToken.TransactOpts)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_CircleToken *CircleTokenTransactorSession) Unpause() (*types.Transaction, error) {
+	return _CircleToken.Contract.Unpause(&_CircleToken.TransactOpts)
+}
+
+// UpdateMetadata is a paid mutator transaction binding the contract method 0x56ce0e6c.
+//
+// Solidity: function updateMetadata(string description, string image) returns()
+func (_CircleToken *CircleTokenTransactor) UpdateMetadata(opts *bind.TransactOpts, description string, image string) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "updateMetadata", description, image)
+}
+
+// UpdateMetadata is a paid mutator transaction binding the contract method 0x56ce0e6c.
+//
+// Solidity: function updateMetadata(string description, string image) returns()
+func (_CircleToken *CircleTokenSession) UpdateMetadata(description string, image string) (*types.Transaction, error) {
+	return _CircleToken.Contract.UpdateMetadata(&_CircleToken.TransactOpts, description, image)
+}
+
+// UpdateMetadata is a paid mutator transaction binding the contract method 0x56ce0e6c.
+//
+// Solidity: function updateMetadata(string description, string image) returns()
+func (_CircleToken *CircleTokenTransactorSession) UpdateMetadata(description string, image string) (*types.Transaction, error) {
+	return _CircleToken.Contract.UpdateMetadata(&_CircleToken.TransactOpts, description, image)
+}
+
+// UpdateTransactionFee is a paid mutator transaction binding the contract method 0x0ee97696.
+//
+// Solidity: function updateTransactionFee(uint256 newFee) returns()
+func (_CircleToken *CircleTokenTransactor) UpdateTransactionFee(opts *bind.TransactOpts, newFee *big.Int) (*types.Transaction, error) {
+	return _CircleToken.contract.Transact(opts, "updateTransactionFee", newFee)
+}
+
+// UpdateTransactionFee is a paid mutator transaction binding the contract method 0x0ee97696.
+//
+// Solidity: function updateTransactionFee(uint256 newFee) returns()
+func (_CircleToken *CircleTokenSession) UpdateTransactionFee(newFee *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.UpdateTransactionFee(&_CircleToken.TransactOpts, newFee)
+}
+
+// UpdateTransactionFee is a paid mutator transaction binding the contract method 0x0ee97696.
+//
+// Solidity: function updateTransactionFee(uint256 newFee) returns()
+func (_CircleToken *CircleTokenTransactorSession) UpdateTransactionFee(newFee *big.Int) (*types.Transaction, error) {
+	return _CircleToken.Contract.UpdateTransactionFee(&_CircleToken.TransactOpts, newFee)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_CircleToken *CircleTokenTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CircleToken.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_CircleToken *CircleTokenSession) Receive() (*types.Transaction, error) {
+	return _CircleToken.Contract.Receive(&_CircleToken.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_CircleToken *CircleTokenTransactorSession) Receive() (*types.Transaction, error) {
+	return _CircleToken.Contract.Receive(&_CircleToken.TransactOpts)
+}
+
+// CircleTokenApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the CircleToken contract.
+type CircleTokenApprovalIterator struct {
+	Event *CircleTokenApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenApproval represents a Approval event raised by the CircleToken contract.
+type CircleTokenApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_CircleToken *CircleTokenFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*CircleTokenApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenApprovalIterator{contract: _CircleToken.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_CircleToken *CircleTokenFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *CircleTokenApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenApproval)
+				if err := _CircleToken.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_CircleToken *CircleTokenFilterer) ParseApproval(log types.Log) (*CircleTokenApproval, error) {
+	event := new(CircleTokenApproval)
+	if err := _CircleToken.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenFeesCollectedIterator is returned from FilterFeesCollected and is used to iterate over the raw logs and unpacked data for FeesCollected events raised by the CircleToken contract.
+type CircleTokenFeesCollectedIterator struct {
+	Event *CircleTokenFeesCollected // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenFeesCollectedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenFeesCollected)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenFeesCollected)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenFeesCollectedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenFeesCollectedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenFeesCollected represents a FeesCollected event raised by the CircleToken contract.
+type CircleTokenFeesCollected struct {
+	OwnerFee     *big.Int
+	PlatformFee  *big.Int
+	LiquidityFee *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterFeesCollected is a free log retrieval operation binding the contract event 0xa54e6c2fc0861aa9c991b26891d71059d517983b9e84b91020f42609c452eb9d.
+//
+// Solidity: event FeesCollected(uint256 ownerFee, uint256 platformFee, uint256 liquidityFee)
+func (_CircleToken *CircleTokenFilterer) FilterFeesCollected(opts *bind.FilterOpts) (*CircleTokenFeesCollectedIterator, error) {
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "FeesCollected")
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenFeesCollectedIterator{contract: _CircleToken.contract, event: "FeesCollected", logs: logs, sub: sub}, nil
+}
+
+// WatchFeesCollected is a free log subscription operation binding the contract event 0xa54e6c2fc0861aa9c991b26891d71059d517983b9e84b91020f42609c452eb9d.
+//
+// Solidity: event FeesCollected(uint256 ownerFee, uint256 platformFee, uint256 liquidityFee)
+func (_CircleToken *CircleTokenFilterer) WatchFeesCollected(opts *bind.WatchOpts, sink chan<- *CircleTokenFeesCollected) (event.Subscription, error) {
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "FeesCollected")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenFeesCollected)
+				if err := _CircleToken.contract.UnpackLog(event, "FeesCollected", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFeesCollected is a log parse operation binding the contract event 0xa54e6c2fc0861aa9c991b26891d71059d517983b9e84b91020f42609c452eb9d.
+//
+// Solidity: event FeesCollected(uint256 ownerFee, uint256 platformFee, uint256 liquidityFee)
+func (_CircleToken *CircleTokenFilterer) ParseFeesCollected(log types.Log) (*CircleTokenFeesCollected, error) {
+	event := new(CircleTokenFeesCollected)
+	if err := _CircleToken.contract.UnpackLog(event, "FeesCollected", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenMetadataUpdatedIterator is returned from FilterMetadataUpdated and is used to iterate over the raw logs and unpacked data for MetadataUpdated events raised by the CircleToken contract.
+type CircleTokenMetadataUpdatedIterator struct {
+	Event *CircleTokenMetadataUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenMetadataUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenMetadataUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenMetadataUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenMetadataUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenMetadataUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenMetadataUpdated represents a MetadataUpdated event raised by the CircleToken contract.
+type CircleTokenMetadataUpdated struct {
+	Description string
+	Image       string
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterMetadataUpdated is a free log retrieval operation binding the contract event 0x30f5c4b652f95e2a697bda3258896c421eee4f29adce8fe38060f47f7aed91ad.
+//
+// Solidity: event MetadataUpdated(string description, string image)
+func (_CircleToken *CircleTokenFilterer) FilterMetadataUpdated(opts *bind.FilterOpts) (*CircleTokenMetadataUpdatedIterator, error) {
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "MetadataUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenMetadataUpdatedIterator{contract: _CircleToken.contract, event: "MetadataUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchMetadataUpdated is a free log subscription operation binding the contract event 0x30f5c4b652f95e2a697bda3258896c421eee4f29adce8fe38060f47f7aed91ad.
+//
+// Solidity: event MetadataUpdated(string description, string image)
+func (_CircleToken *CircleTokenFilterer) WatchMetadataUpdated(opts *bind.WatchOpts, sink chan<- *CircleTokenMetadataUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "MetadataUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenMetadataUpdated)
+				if err := _CircleToken.contract.UnpackLog(event, "MetadataUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMetadataUpdated is a log parse operation binding the contract event 0x30f5c4b652f95e2a697bda3258896c421eee4f29adce8fe38060f47f7aed91ad.
+//
+// Solidity: event MetadataUpdated(string description, string image)
+func (_CircleToken *CircleTokenFilterer) ParseMetadataUpdated(log types.Log) (*CircleTokenMetadataUpdated, error) {
+	event := new(CircleTokenMetadataUpdated)
+	if err := _CircleToken.contract.UnpackLog(event, "MetadataUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the CircleToken contract.
+type CircleTokenOwnershipTransferredIterator struct {
+	Event *CircleTokenOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenOwnershipTransferred represents a OwnershipTransferred event raised by the CircleToken contract.
+type CircleTokenOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CircleToken *CircleTokenFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*CircleTokenOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenOwnershipTransferredIterator{contract: _CircleToken.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CircleToken *CircleTokenFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *CircleTokenOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenOwnershipTransferred)
+				if err := _CircleToken.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CircleToken *CircleTokenFilterer) ParseOwnershipTransferred(log types.Log) (*CircleTokenOwnershipTransferred, error) {
+	event := new(CircleTokenOwnershipTransferred)
+	if err := _CircleToken.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenPausedIterator is returned from FilterPaused and is used to iterate over the raw logs and unpacked data for Paused events raised by the CircleToken contract.
+type CircleTokenPausedIterator struct {
+	Event *CircleTokenPaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenPausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenPaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenPaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenPausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenPausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenPaused represents a Paused event raised by the CircleToken contract.
+type CircleTokenPaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaused is a free log retrieval operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_CircleToken *CircleTokenFilterer) FilterPaused(opts *bind.FilterOpts) (*CircleTokenPausedIterator, error) {
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenPausedIterator{contract: _CircleToken.contract, event: "Paused", logs: logs, sub: sub}, nil
+}
+
+// WatchPaused is a free log subscription operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_CircleToken *CircleTokenFilterer) WatchPaused(opts *bind.WatchOpts, sink chan<- *CircleTokenPaused) (event.Subscription, error) {
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenPaused)
+				if err := _CircleToken.contract.UnpackLog(event, "Paused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaused is a log parse operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_CircleToken *CircleTokenFilterer) ParsePaused(log types.Log) (*CircleTokenPaused, error) {
+	event := new(CircleTokenPaused)
+	if err := _CircleToken.contract.UnpackLog(event, "Paused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenTokensPurchasedIterator is returned from FilterTokensPurchased and is used to iterate over the raw logs and unpacked data for TokensPurchased events raised by the CircleToken contract.
+type CircleTokenTokensPurchasedIterator struct {
+	Event *CircleTokenTokensPurchased // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenTokensPurchasedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenTokensPurchased)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenTokensPurchased)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenTokensPurchasedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenTokensPurchasedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenTokensPurchased represents a TokensPurchased event raised by the CircleToken contract.
+type CircleTokenTokensPurchased struct {
+	Buyer     common.Address
+	Amount    *big.Int
+	Cost      *big.Int
+	Fee       *big.Int
+	NewSupply *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokensPurchased is a free log retrieval operation binding the contract event 0x34987d73948b60cfba9beeb35760c345a3be430f3540605113911ead78a0830e.
+//
+// Solidity: event TokensPurchased(address indexed buyer, uint256 amount, uint256 cost, uint256 fee, uint256 newSupply)
+func (_CircleToken *CircleTokenFilterer) FilterTokensPurchased(opts *bind.FilterOpts, buyer []common.Address) (*CircleTokenTokensPurchasedIterator, error) {
+
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "TokensPurchased", buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenTokensPurchasedIterator{contract: _CircleToken.contract, event: "TokensPurchased", logs: logs, sub: sub}, nil
+}
+
+// WatchTokensPurchased is a free log subscription operation binding the contract event 0x34987d73948b60cfba9beeb35760c345a3be430f3540605113911ead78a0830e.
+//
+// Solidity: event TokensPurchased(address indexed buyer, uint256 amount, uint256 cost, uint256 fee, uint256 newSupply)
+func (_CircleToken *CircleTokenFilterer) WatchTokensPurchased(opts *bind.WatchOpts, sink chan<- *CircleTokenTokensPurchased, buyer []common.Address) (event.Subscription, error) {
+
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "TokensPurchased", buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenTokensPurchased)
+				if err := _CircleToken.contract.UnpackLog(event, "TokensPurchased", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokensPurchased is a log parse operation binding the contract event 0x34987d73948b60cfba9beeb35760c345a3be430f3540605113911ead78a0830e.
+//
+// Solidity: event TokensPurchased(address indexed buyer, uint256 amount, uint256 cost, uint256 fee, uint256 newSupply)
+func (_CircleToken *CircleTokenFilterer) ParseTokensPurchased(log types.Log) (*CircleTokenTokensPurchased, error) {
+	event := new(CircleTokenTokensPurchased)
+	if err := _CircleToken.contract.UnpackLog(event, "TokensPurchased", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenTokensSoldIterator is returned from FilterTokensSold and is used to iterate over the raw logs and unpacked data for TokensSold events raised by the CircleToken contract.
+type CircleTokenTokensSoldIterator struct {
+	Event *CircleTokenTokensSold // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenTokensSoldIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenTokensSold)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenTokensSold)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenTokensSoldIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenTokensSoldIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenTokensSold represents a TokensSold event raised by the CircleToken contract.
+type CircleTokenTokensSold struct {
+	Seller    common.Address
+	Amount    *big.Int
+	Refund    *big.Int
+	Fee       *big.Int
+	NewSupply *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokensSold is a free log retrieval operation binding the contract event 0x6b17016c67d28f4cc3a653d895e3b1db09320d6852a46f95aa225059190f7dcd.
+//
+// Solidity: event TokensSold(address indexed seller, uint256 amount, uint256 refund, uint256 fee, uint256 newSupply)
+func (_CircleToken *CircleTokenFilterer) FilterTokensSold(opts *bind.FilterOpts, seller []common.Address) (*CircleTokenTokensSoldIterator, error) {
+
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "TokensSold", sellerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenTokensSoldIterator{contract: _CircleToken.contract, event: "TokensSold", logs: logs, sub: sub}, nil
+}
+
+// WatchTokensSold is a free log subscription operation binding the contract event 0x6b17016c67d28f4cc3a653d895e3b1db09320d6852a46f95aa225059190f7dcd.
+//
+// Solidity: event TokensSold(address indexed seller, uint256 amount, uint256 refund, uint256 fee, uint256 newSupply)
+func (_CircleToken *CircleTokenFilterer) WatchTokensSold(opts *bind.WatchOpts, sink chan<- *CircleTokenTokensSold, seller []common.Address) (event.Subscription, error) {
+
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "TokensSold", sellerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenTokensSold)
+				if err := _CircleToken.contract.UnpackLog(event, "TokensSold", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokensSold is a log parse operation binding the contract event 0x6b17016c67d28f4cc3a653d895e3b1db09320d6852a46f95aa225059190f7dcd.
+//
+// Solidity: event TokensSold(address indexed seller, uint256 amount, uint256 refund, uint256 fee, uint256 newSupply)
+func (_CircleToken *CircleTokenFilterer) ParseTokensSold(log types.Log) (*CircleTokenTokensSold, error) {
+	event := new(CircleTokenTokensSold)
+	if err := _CircleToken.contract.UnpackLog(event, "TokensSold", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenTransactionFeeUpdatedIterator is returned from FilterTransactionFeeUpdated and is used to iterate over the raw logs and unpacked data for TransactionFeeUpdated events raised by the CircleToken contract.
+type CircleTokenTransactionFeeUpdatedIterator struct {
+	Event *CircleTokenTransactionFeeUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenTransactionFeeUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenTransactionFeeUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenTransactionFeeUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenTransactionFeeUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenTransactionFeeUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenTransactionFeeUpdated represents a TransactionFeeUpdated event raised by the CircleToken contract.
+type CircleTokenTransactionFeeUpdated struct {
+	NewFee *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransactionFeeUpdated is a free log retrieval operation binding the contract event 0x21ea14061974d1886e13e854a8a738e5177a587170a7f767a2b182a3f8b8dcdf.
+//
+// Solidity: event TransactionFeeUpdated(uint256 newFee)
+func (_CircleToken *CircleTokenFilterer) FilterTransactionFeeUpdated(opts *bind.FilterOpts) (*CircleTokenTransactionFeeUpdatedIterator, error) {
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "TransactionFeeUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenTransactionFeeUpdatedIterator{contract: _CircleToken.contract, event: "TransactionFeeUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchTransactionFeeUpdated is a free log subscription operation binding the contract event 0x21ea14061974d1886e13e854a8a738e5177a587170a7f767a2b182a3f8b8dcdf.
+//
+// Solidity: event TransactionFeeUpdated(uint256 newFee)
+func (_CircleToken *CircleTokenFilterer) WatchTransactionFeeUpdated(opts *bind.WatchOpts, sink chan<- *CircleTokenTransactionFeeUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "TransactionFeeUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenTransactionFeeUpdated)
+				if err := _CircleToken.contract.UnpackLog(event, "TransactionFeeUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransactionFeeUpdated is a log parse operation binding the contract event 0x21ea14061974d1886e13e854a8a738e5177a587170a7f767a2b182a3f8b8dcdf.
+//
+// Solidity: event TransactionFeeUpdated(uint256 newFee)
+func (_CircleToken *CircleTokenFilterer) ParseTransactionFeeUpdated(log types.Log) (*CircleTokenTransactionFeeUpdated, error) {
+	event := new(CircleTokenTransactionFeeUpdated)
+	if err := _CircleToken.contract.UnpackLog(event, "TransactionFeeUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the CircleToken contract.
+type CircleTokenTransferIterator struct {
+	Event *CircleTokenTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenTransfer represents a Transfer event raised by the CircleToken contract.
+type CircleTokenTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_CircleToken *CircleTokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*CircleTokenTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenTransferIterator{contract: _CircleToken.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_CircleToken *CircleTokenFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *CircleTokenTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenTransfer)
+				if err := _CircleToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_CircleToken *CircleTokenFilterer) ParseTransfer(log types.Log) (*CircleTokenTransfer, error) {
+	event := new(CircleTokenTransfer)
+	if err := _CircleToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CircleTokenUnpausedIterator is returned from FilterUnpaused and is used to iterate over the raw logs and unpacked data for Unpaused events raised by the CircleToken contract.
+type CircleTokenUnpausedIterator struct {
+	Event *CircleTokenUnpaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CircleTokenUnpausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CircleTokenUnpaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CircleTokenUnpaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CircleTokenUnpausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CircleTokenUnpausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CircleTokenUnpaused represents a Unpaused event raised by the CircleToken contract.
+type CircleTokenUnpaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpaused is a free log retrieval operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_CircleToken *CircleTokenFilterer) FilterUnpaused(opts *bind.FilterOpts) (*CircleTokenUnpausedIterator, error) {
+
+	logs, sub, err := _CircleToken.contract.FilterLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return &CircleTokenUnpausedIterator{contract: _CircleToken.contract, event: "Unpaused", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpaused is a free log subscription operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_CircleToken *CircleTokenFilterer) WatchUnpaused(opts *bind.WatchOpts, sink chan<- *CircleTokenUnpaused) (event.Subscription, error) {
+
+	logs, sub, err := _CircleToken.contract.WatchLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CircleTokenUnpaused)
+				if err := _CircleToken.contract.UnpackLog(event, "Unpaused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpaused is a log parse operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_CircleToken *CircleTokenFilterer) ParseUnpaused(log types.Log) (*CircleTokenUnpaused, error) {
+	event := new(CircleTokenUnpaused)
+	if err := _CircleToken.contract.UnpackLog(event, "Unpaused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,11 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+// Package contracts holds the Go bindings of the deployed contracts,
+// generated from the Foundry artifacts in out/. After changing a contract run
+// `forge build` and then `go generate ./internal/web3/contracts` so any
+// method or event drift shows up as a compile error in the backend.
+package contracts
+
+//go:generate go run ../../../cmd/forgebind -artifacts ../../../../out -pkg contracts -out . CircleFactory BondingCurve CircleToken
@@ -0,0 +1,60 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatUnits renders an integer amount with the given number of decimals,
+// e.g. wei as ether, matching the DECIMAL(30,18) columns used for amounts
+func FormatUnits(amount *big.Int, decimals int) string {
+	if amount == nil {
+		return "0"
+	}
+
+	negative := amount.Sign() < 0
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole, frac := digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+	result := whole
+	if decimals > 0 {
+		result += "." + frac
+	}
+	if negative {
+		result = "-" + result
+	}
+	return result
+}
+
+// ParseUnits is the inverse of FormatUnits, e.g. turning "1.5" ether into wei.
+// Fractional digits beyond decimals are rejected rather than rounded.
+func ParseUnits(value string, decimals int) (*big.Int, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	whole, frac, _ := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+	if whole == "" && frac == "" {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", value, decimals)
+	}
+
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount.Neg(amount)
+	}
+	return amount, nil
+}
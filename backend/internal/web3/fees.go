@@ -0,0 +1,298 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fast-socialfi/backend/internal/config"
+)
+
+const (
+	// feeHistoryBlocks is how many recent blocks the priority fee is sampled from
+	feeHistoryBlocks = 20
+	// feeHistoryPercentile is the reward percentile used as the priority fee
+	feeHistoryPercentile = 50
+	// minFeeBumpPercent is the smallest bump nodes accept for a replacement
+	minFeeBumpPercent = 10
+	// defaultFeeBumpPercent leaves some margin above the node minimum
+	defaultFeeBumpPercent = 12
+)
+
+var (
+	// ErrNotReplaceable is returned when a transaction is no longer pending
+	// and so cannot be sped up or cancelled
+	ErrNotReplaceable = errors.New("transaction cannot be replaced")
+	// ErrFeeCapExceeded is returned when the fees a transaction needs are above
+	// the configured ceiling
+	ErrFeeCapExceeded = errors.New("fee exceeds configured cap")
+)
+
+// FeeHistoryReader is implemented by clients that expose eth_feeHistory.
+// The simulated backend does not, so fees fall back to the node's suggestion.
+type FeeHistoryReader interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// feeLimits are the configured ceilings applied to server-built transactions
+type feeLimits struct {
+	gasLimit    uint64
+	maxFee      *big.Int
+	maxTip      *big.Int
+	bumpPercent int64
+}
+
+// SetFeeLimits applies the gas and fee ceilings from the blockchain config
+func (s *Web3Service) SetFeeLimits(cfg config.BlockchainConfig) {
+	limits := feeLimits{gasLimit: cfg.GasLimit, bumpPercent: cfg.FeeBumpPercent}
+	if cfg.GasPrice > 0 {
+		limits.maxFee = big.NewInt(cfg.GasPrice)
+	}
+	if cfg.MaxPriorityFee > 0 {
+		limits.maxTip = big.NewInt(cfg.MaxPriorityFee)
+	}
+	if limits.bumpPercent < minFeeBumpPercent {
+		limits.bumpPercent = minFeeBumpPercent
+	}
+	s.fees = limits
+}
+
+// suggestFees returns the priority fee and fee cap for a new transaction,
+// clamped to the configured ceilings
+func (s *Web3Service) suggestFees(ctx context.Context) (tipCap, feeCap *big.Int, err error) {
+	tipCap, baseFee, err := s.feeHistory(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.fees.maxTip != nil && tipCap.Cmp(s.fees.maxTip) > 0 {
+		tipCap = new(big.Int).Set(s.fees.maxTip)
+	}
+
+	// Leave room for the base fee to double before the transaction is mined
+	feeCap = new(big.Int).Add(tipCap, new(big.Int).Mul(baseFee, big.NewInt(2)))
+	if s.fees.maxFee != nil && feeCap.Cmp(s.fees.maxFee) > 0 {
+		if s.fees.maxFee.Cmp(baseFee) < 0 {
+			return nil, nil, fmt.Errorf("%w: base fee %s is above %s", ErrFeeCapExceeded, baseFee, s.fees.maxFee)
+		}
+		feeCap = new(big.Int).Set(s.fees.maxFee)
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		tipCap = new(big.Int).Set(feeCap)
+	}
+
+	return tipCap, feeCap, nil
+}
+
+// feeHistory returns the median priority fee paid over recent blocks and the
+// base fee of the next block
+func (s *Web3Service) feeHistory(ctx context.Context) (tipCap, baseFee *big.Int, err error) {
+	if reader, ok := s.client.(FeeHistoryReader); ok {
+		history, err := reader.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{feeHistoryPercentile})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get fee history: %w", err)
+		}
+		if len(history.BaseFee) == 0 || history.BaseFee[len(history.BaseFee)-1] == nil {
+			return nil, nil, fmt.Errorf("chain does not support EIP-1559 transactions")
+		}
+
+		// The last entry is the base fee of the block after the newest one
+		baseFee = history.BaseFee[len(history.BaseFee)-1]
+		if tipCap = medianReward(history.Reward); tipCap != nil {
+			return tipCap, baseFee, nil
+		}
+	}
+
+	head, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		return nil, nil, fmt.Errorf("chain does not support EIP-1559 transactions")
+	}
+	if baseFee == nil {
+		baseFee = head.BaseFee
+	}
+
+	tipCap, err = s.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to suggest gas tip: %w", err)
+	}
+	return tipCap, baseFee, nil
+}
+
+// medianReward picks the median of the sampled rewards, skipping empty blocks
+// which report a zero reward. It returns nil when no block had transactions.
+func medianReward(rewards [][]*big.Int) *big.Int {
+	var samples []*big.Int
+	for _, block := range rewards {
+		if len(block) > 0 && block[0] != nil && block[0].Sign() > 0 {
+			samples = append(samples, block[0])
+		}
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Cmp(samples[j]) < 0 })
+	return new(big.Int).Set(samples[len(samples)/2])
+}
+
+// estimateGas estimates a call and adds headroom, rejecting calls that need
+// more than the configured gas limit
+func (s *Web3Service) estimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	estimate, err := s.client.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	gas := estimate + 50000 // Add buffer
+	if s.fees.gasLimit > 0 && gas > s.fees.gasLimit {
+		if estimate > s.fees.gasLimit {
+			return 0, fmt.Errorf("estimated gas %d exceeds the configured limit of %d", estimate, s.fees.gasLimit)
+		}
+		gas = s.fees.gasLimit
+	}
+	return gas, nil
+}
+
+// PendingTransaction looks up a transaction that is still waiting in the
+// mempool and returns it together with its sender
+func (s *Web3Service) PendingTransaction(ctx context.Context, txHash string) (*types.Transaction, common.Address, error) {
+	tx, isPending, err := s.client.TransactionByHash(ctx, common.HexToHash(txHash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, common.Address{}, fmt.Errorf("%w: %s is not known to the node", ErrNotReplaceable, txHash)
+	}
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if !isPending {
+		return nil, common.Address{}, fmt.Errorf("%w: %s is already mined", ErrNotReplaceable, txHash)
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to recover sender: %w", err)
+	}
+	return tx, from, nil
+}
+
+// replacementFees returns fees high enough for the node to accept a
+// replacement of the original transaction: the original fees bumped by the
+// configured percentage, or the current suggestion if that is higher
+func (s *Web3Service) replacementFees(ctx context.Context, original *types.Transaction) (tipCap, feeCap *big.Int, err error) {
+	tipCap = bumpFee(original.GasTipCap(), s.fees.bumpPercent)
+	feeCap = bumpFee(original.GasFeeCap(), s.fees.bumpPercent)
+
+	suggestedTip, suggestedCap, err := s.suggestFees(ctx)
+	if err != nil && !errors.Is(err, ErrFeeCapExceeded) {
+		return nil, nil, err
+	}
+	if err == nil {
+		if suggestedTip.Cmp(tipCap) > 0 {
+			tipCap = suggestedTip
+		}
+		if suggestedCap.Cmp(feeCap) > 0 {
+			feeCap = suggestedCap
+		}
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		feeCap = new(big.Int).Set(tipCap)
+	}
+
+	if s.fees.maxFee != nil && feeCap.Cmp(s.fees.maxFee) > 0 {
+		return nil, nil, fmt.Errorf("%w: replacement needs a fee cap of %s, above %s", ErrFeeCapExceeded, feeCap, s.fees.maxFee)
+	}
+	return tipCap, feeCap, nil
+}
+
+// replacementCall returns the target, value, data and gas of the replacement.
+// A speed-up repeats the original call; a cancel is an empty self-transfer.
+func replacementCall(original *types.Transaction, from common.Address, cancel bool) (common.Address, *big.Int, []byte, uint64) {
+	if cancel {
+		return from, big.NewInt(0), nil, 21000
+	}
+	return *original.To(), original.Value(), original.Data(), original.Gas()
+}
+
+// PrepareReplacement builds an unsigned transaction that reuses the nonce of a
+// stuck wallet transaction with bumped fees, either repeating it (speed-up)
+// or replacing it with an empty self-transfer (cancel)
+func (s *Web3Service) PrepareReplacement(ctx context.Context, txHash string, from common.Address, cancel bool) (*UnsignedTx, error) {
+	original, sender, err := s.PendingTransaction(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if sender != from {
+		return nil, fmt.Errorf("%w: %s was not sent by %s", ErrNotReplaceable, txHash, from.Hex())
+	}
+	if original.To() == nil {
+		return nil, fmt.Errorf("%w: contract deployments are not replaced", ErrNotReplaceable)
+	}
+
+	tipCap, feeCap, err := s.replacementFees(ctx, original)
+	if err != nil {
+		return nil, err
+	}
+
+	to, value, data, gas := replacementCall(original, from, cancel)
+	return &UnsignedTx{
+		Type:                 types.DynamicFeeTxType,
+		ChainID:              s.chainID.String(),
+		From:                 from.Hex(),
+		To:                   to.Hex(),
+		Nonce:                original.Nonce(),
+		Gas:                  gas,
+		MaxPriorityFeePerGas: tipCap.String(),
+		MaxFeePerGas:         feeCap.String(),
+		Value:                value.String(),
+		Data:                 hexutil.Encode(data),
+	}, nil
+}
+
+// VerifyReplacementTx decodes a wallet-signed replacement and checks that it
+// reuses the original nonce with high enough fees and either repeats the
+// original call or cancels it. It reports whether it is a cancellation.
+func (s *Web3Service) VerifyReplacementTx(rawTx string, from common.Address, original *types.Transaction) (*types.Transaction, bool, error) {
+	tx, err := s.decodeSignedTx(rawTx, from)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if tx.Nonce() != original.Nonce() {
+		return nil, false, fmt.Errorf("%w: nonce %d does not replace nonce %d", ErrInvalidTransaction, tx.Nonce(), original.Nonce())
+	}
+
+	minTip := bumpFee(original.GasTipCap(), minFeeBumpPercent)
+	minCap := bumpFee(original.GasFeeCap(), minFeeBumpPercent)
+	if tx.GasTipCap().Cmp(minTip) < 0 || tx.GasFeeCap().Cmp(minCap) < 0 {
+		return nil, false, fmt.Errorf("%w: replacement fees must be at least %d%% higher", ErrInvalidTransaction, minFeeBumpPercent)
+	}
+
+	cancel := tx.To() != nil && *tx.To() == from && tx.Value().Sign() == 0 && len(tx.Data()) == 0
+	sameCall := tx.To() != nil && original.To() != nil && *tx.To() == *original.To() &&
+		tx.Value().Cmp(original.Value()) == 0 && bytes.Equal(tx.Data(), original.Data())
+	if !cancel && !sameCall {
+		return nil, false, fmt.Errorf("%w: replacement must repeat the original call or cancel it", ErrInvalidTransaction)
+	}
+
+	return tx, cancel && !sameCall, nil
+}
+
+// bumpFee raises a fee by the given percentage, rounding up
+func bumpFee(fee *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
@@ -0,0 +1,98 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+)
+
+// topHolderCount is how many of the largest holders the concentration share
+// is measured over
+const topHolderCount = 10
+
+// CreditHolder adds tokens, and the ETH paid for them, to a holder's position
+func CreditHolder(holder *models.CircleHolder, amount, cost *big.Int, at time.Time) error {
+	balance, err := ParseUnits(holder.Balance, 18)
+	if err != nil {
+		return fmt.Errorf("invalid holder balance: %w", err)
+	}
+	costBasis, err := ParseUnits(holder.CostBasis, 18)
+	if err != nil {
+		return fmt.Errorf("invalid holder cost basis: %w", err)
+	}
+
+	if balance.Sign() == 0 {
+		holder.FirstAcquiredAt = at
+	}
+	holder.Balance = FormatUnits(balance.Add(balance, amount), 18)
+	holder.CostBasis = FormatUnits(costBasis.Add(costBasis, cost), 18)
+	return nil
+}
+
+// DebitHolder removes tokens from a holder's position together with their
+// share of the cost basis, so the average cost of what remains is unchanged
+func DebitHolder(holder *models.CircleHolder, amount *big.Int) error {
+	balance, err := ParseUnits(holder.Balance, 18)
+	if err != nil {
+		return fmt.Errorf("invalid holder balance: %w", err)
+	}
+	costBasis, err := ParseUnits(holder.CostBasis, 18)
+	if err != nil {
+		return fmt.Errorf("invalid holder cost basis: %w", err)
+	}
+	if amount.Cmp(balance) > 0 {
+		return fmt.Errorf("transfer of %s exceeds balance of %s", FormatUnits(amount, 18), holder.Balance)
+	}
+
+	remaining := new(big.Int).Sub(balance, amount)
+	if remaining.Sign() > 0 {
+		costBasis.Mul(costBasis, remaining).Quo(costBasis, balance)
+	} else {
+		costBasis.SetInt64(0)
+	}
+	holder.Balance = FormatUnits(remaining, 18)
+	holder.CostBasis = FormatUnits(costBasis, 18)
+	return nil
+}
+
+// HolderConcentration returns the share of the supply held by the ten largest
+// holders and the Gini coefficient of the balances, both between 0 and 1
+func HolderConcentration(balances []*big.Int) (top10Share, gini float64) {
+	sorted := make([]*big.Float, 0, len(balances))
+	total := new(big.Float)
+	for _, balance := range balances {
+		if balance.Sign() <= 0 {
+			continue
+		}
+		value := new(big.Float).SetInt(balance)
+		sorted = append(sorted, value)
+		total.Add(total, value)
+	}
+	if total.Sign() == 0 {
+		return 0, 0
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+
+	// Gini = Σ (2i - n - 1) x_i / (n Σ x) over balances sorted ascending
+	n := len(sorted)
+	top := new(big.Float)
+	weighted := new(big.Float)
+	for i, value := range sorted {
+		if i >= n-topHolderCount {
+			top.Add(top, value)
+		}
+		weight := new(big.Float).SetInt64(int64(2*(i+1) - n - 1))
+		weighted.Add(weighted, weight.Mul(weight, value))
+	}
+
+	top10Share, _ = new(big.Float).Quo(top, total).Float64()
+	gini, _ = weighted.Quo(weighted, total.Mul(total, new(big.Float).SetInt64(int64(n)))).Float64()
+	return top10Share, gini
+}
@@ -0,0 +1,150 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/bondingcurve"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+)
+
+// CurveQuote is the bonding curve's answer for trading an amount of tokens,
+// read from a single block so the figures are consistent with each other
+type CurveQuote struct {
+	BlockNumber uint64
+	Supply      *big.Int
+	Amount      *big.Int
+	Price       *big.Int // price per token before the trade
+	NewPrice    *big.Int // price per token after the trade
+	Value       *big.Int // buy cost or gross sell refund
+	Fee         *big.Int // part of Value kept as the transaction fee
+	FeeBps      *big.Int
+	AvgPrice    *big.Int
+	ImpactBps   *big.Int
+}
+
+// QuoteBuy asks the bonding curve what buying amount tokens costs
+func (s *Web3Service) QuoteBuy(ctx context.Context, tokenAddress common.Address, amount *big.Int) (*CurveQuote, error) {
+	return s.quote(ctx, tokenAddress, amount, true)
+}
+
+// QuoteSell asks the bonding curve what selling amount tokens refunds
+func (s *Web3Service) QuoteSell(ctx context.Context, tokenAddress common.Address, amount *big.Int) (*CurveQuote, error) {
+	return s.quote(ctx, tokenAddress, amount, false)
+}
+
+func (s *Web3Service) quote(ctx context.Context, tokenAddress common.Address, amount *big.Int, buy bool) (*CurveQuote, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	head, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+
+	token, err := contracts.NewCircleToken(tokenAddress, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind token: %w", err)
+	}
+
+	supply, err := token.TotalSupply(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call totalSupply: %w", err)
+	}
+	feeBps, err := token.TransactionFeePercent(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call transactionFeePercent: %w", err)
+	}
+	denominator, err := token.FEEDENOMINATOR(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call FEE_DENOMINATOR: %w", err)
+	}
+	price, err := s.bondingCurve.GetCurrentPrice(opts, tokenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call getCurrentPrice: %w", err)
+	}
+	params, err := s.curveParams(opts, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		value     *big.Int
+		impact    priceImpact
+		newSupply *big.Int
+	)
+	if buy {
+		newSupply = new(big.Int).Add(supply, amount)
+		if value, err = s.bondingCurve.CalculateBuyCost(opts, tokenAddress, amount, supply); err != nil {
+			return nil, fmt.Errorf("failed to call calculateBuyCost: %w", err)
+		}
+		if impact, err = s.bondingCurve.GetBuyPriceImpact(opts, tokenAddress, amount); err != nil {
+			return nil, fmt.Errorf("failed to call getBuyPriceImpact: %w", err)
+		}
+	} else {
+		newSupply = new(big.Int).Sub(supply, amount)
+		if value, err = s.bondingCurve.CalculateSellRefund(opts, tokenAddress, amount, supply); err != nil {
+			return nil, fmt.Errorf("failed to call calculateSellRefund: %w", err)
+		}
+		if impact, err = s.bondingCurve.GetSellPriceImpact(opts, tokenAddress, amount); err != nil {
+			return nil, fmt.Errorf("failed to call getSellPriceImpact: %w", err)
+		}
+	}
+
+	// The contract has no view for the price at another supply, so the
+	// post-trade price comes from the same curve reimplemented off-chain
+	newPrice, err := params.Price(newSupply)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute new price: %w", err)
+	}
+
+	fee := new(big.Int)
+	if denominator.Sign() > 0 {
+		fee.Mul(value, feeBps).Quo(fee, denominator)
+	}
+
+	return &CurveQuote{
+		BlockNumber: head.Number.Uint64(),
+		Supply:      supply,
+		Amount:      amount,
+		Price:       price,
+		NewPrice:    newPrice,
+		Value:       value,
+		Fee:         fee,
+		FeeBps:      feeBps,
+		AvgPrice:    impact.AvgPrice,
+		ImpactBps:   impact.PriceImpact,
+	}, nil
+}
+
+// priceImpact is the result of getBuyPriceImpact and getSellPriceImpact
+type priceImpact = struct {
+	AvgPrice    *big.Int
+	PriceImpact *big.Int
+}
+
+// curveParams reads the curve parameters the contract stored for a token
+func (s *Web3Service) curveParams(opts *bind.CallOpts, tokenAddress common.Address) (bondingcurve.Params, error) {
+	out, err := s.bondingCurve.CurveParameters(opts, tokenAddress)
+	if err != nil {
+		return bondingcurve.Params{}, fmt.Errorf("failed to call curveParameters: %w", err)
+	}
+
+	return bondingcurve.Params{
+		CurveType:       bondingcurve.CurveType(out.CurveType),
+		BasePrice:       out.BasePrice,
+		Slope:           out.Slope,
+		GrowthRate:      out.GrowthRate,
+		MaxPrice:        out.MaxPrice,
+		InflectionPoint: out.InflectionPoint,
+	}, nil
+}
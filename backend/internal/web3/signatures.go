@@ -0,0 +1,141 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// erc1271MagicValue is returned by isValidSignature(bytes32,bytes) for a
+// valid signature
+var erc1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// erc6492Suffix marks a signature wrapped for a wallet that may not be
+// deployed yet
+var erc6492Suffix = common.FromHex("0x6492649264926492649264926492649264926492649264926492649264926492")
+
+var erc1271ABI = mustParseABI(`[{"type":"function","name":"isValidSignature","stateMutability":"view",
+	"inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],
+	"outputs":[{"name":"magicValue","type":"bytes4"}]}]`)
+
+// erc6492Wrapper is the ABI encoding in front of erc6492Suffix
+var erc6492Wrapper = abi.Arguments{
+	{Type: mustNewType("address")}, // factory
+	{Type: mustNewType("bytes")},   // factory calldata
+	{Type: mustNewType("bytes")},   // signature for the deployed wallet
+}
+
+// sigValidatorCode is init code that, run through eth_call as a contract
+// creation, deploys a counterfactual wallet and asks it about a signature
+// in the same call, returning 0x01 if it is valid and 0x00 otherwise. The
+// arguments follow the code as 32-byte words: signer, hash, factory,
+// calldata length and signature length, then the factory calldata and the
+// signature.
+//
+//	codecopy(0x80, L, codesize-L)           args to memory, L = 0xa6
+//	mstore(0x00, 0x80+codesize-L)           F, the free memory after them
+//	if extcodesize(signer) == 0 && factory != 0:
+//	    call(gas, factory, 0, 0x120, calldataLen, 0, 0)
+//	mstore(F, 0x1626ba7e << 224)            isValidSignature(hash, sig)
+//	mstore(F+4, hash); mstore(F+36, 0x40); mstore(F+68, sigLen)
+//	codecopy(F+100, L+0xa0+calldataLen, sigLen)
+//	ok := staticcall(gas, signer, F, 100+sigLen, 0, 32)
+//	mstore8(0, ok && returndatasize >= 32 && mload(0)>>224 == 0x1626ba7e)
+//	return(0, 1)
+var sigValidatorCode = common.FromHex("0x" +
+	"6100a638036100a66080396100a638036080016000526080513b6100385760c0" +
+	"5115610038576000600060e051610120600060c0515af1505b631626ba7e60e0" +
+	"1b6000515260a051600051600401526040600051602401526101005160005160" +
+	"4401526101005160e0516100a660a00101600051606401396020600061010051" +
+	"6064016000516080515afa60203d10151660005160e01c631626ba7e14166000" +
+	"5360016000f3")
+
+// IsERC6492Signature reports whether a signature is wrapped for a wallet
+// that may not be deployed yet
+func IsERC6492Signature(signature []byte) bool {
+	return len(signature) > len(erc6492Suffix) && bytes.HasSuffix(signature, erc6492Suffix)
+}
+
+// IsValidSignature asks a smart contract wallet whether it accepts a
+// signature over hash (EIP-1271). ERC-6492 wrapped signatures are checked
+// with the wallet's deployment simulated first, so wallets that only exist
+// counterfactually are supported too. An account without code and with an
+// unwrapped signature is not a contract wallet and yields false.
+func (s *Web3Service) IsValidSignature(ctx context.Context, account common.Address, hash common.Hash, signature []byte) (bool, error) {
+	if IsERC6492Signature(signature) {
+		return s.validateERC6492(ctx, account, hash, signature)
+	}
+
+	code, err := s.client.CodeAt(ctx, account, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code: %w", err)
+	}
+	if len(code) == 0 {
+		return false, nil
+	}
+
+	data, err := erc1271ABI.Pack("isValidSignature", hash, signature)
+	if err != nil {
+		return false, err
+	}
+	out, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &account, Data: data}, nil)
+	if err != nil {
+		// A wallet that rejects a signature may revert instead of returning
+		return false, nil
+	}
+	return len(out) >= 4 && bytes.Equal(out[:4], erc1271MagicValue[:]), nil
+}
+
+// validateERC6492 runs sigValidatorCode against the unwrapped signature
+func (s *Web3Service) validateERC6492(ctx context.Context, account common.Address, hash common.Hash, signature []byte) (bool, error) {
+	values, err := erc6492Wrapper.Unpack(signature[:len(signature)-len(erc6492Suffix)])
+	if err != nil {
+		return false, fmt.Errorf("invalid ERC-6492 signature: %w", err)
+	}
+	factory := values[0].(common.Address)
+	factoryCalldata := values[1].([]byte)
+	inner := values[2].([]byte)
+
+	data := make([]byte, 0, len(sigValidatorCode)+5*32+len(factoryCalldata)+len(inner))
+	data = append(data, sigValidatorCode...)
+	data = append(data, common.LeftPadBytes(account.Bytes(), 32)...)
+	data = append(data, hash.Bytes()...)
+	data = append(data, common.LeftPadBytes(factory.Bytes(), 32)...)
+	data = append(data, math.U256Bytes(big.NewInt(int64(len(factoryCalldata))))...)
+	data = append(data, math.U256Bytes(big.NewInt(int64(len(inner))))...)
+	data = append(data, factoryCalldata...)
+	data = append(data, inner...)
+
+	out, err := s.client.CallContract(ctx, ethereum.CallMsg{Data: data}, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to validate ERC-6492 signature: %w", err)
+	}
+	return len(out) == 1 && out[0] == 1, nil
+}
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func mustNewType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -0,0 +1,141 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+)
+
+var (
+	// ErrTipNotSettled is returned when a tip transaction is not mined yet or
+	// not buried under enough blocks to be counted
+	ErrTipNotSettled = errors.New("tip transaction is not settled")
+	// ErrTipMismatch is returned when a mined transaction is not the transfer
+	// it was claimed to be
+	ErrTipMismatch = errors.New("transaction is not a matching tip")
+)
+
+// Tip is a verified transfer from a tipper to a post author. Token is the
+// zero address for ETH. Nonce is the tipper's nonce the transaction was sent
+// with.
+type Tip struct {
+	TxHash      common.Hash
+	From        common.Address
+	To          common.Address
+	Token       common.Address
+	Amount      *big.Int
+	Nonce       uint64
+	BlockNumber uint64
+}
+
+// PrepareTip builds an unsigned transfer of amount from the tipper to the
+// author, in ETH when token is the zero address and in that circle token
+// otherwise
+func (s *Web3Service) PrepareTip(ctx context.Context, from, to, token common.Address, amount *big.Int) (*UnsignedTx, error) {
+	if token == (common.Address{}) {
+		return s.prepareTx(ctx, from, to, amount, nil)
+	}
+
+	data, err := s.transferData(token, to, amount)
+	if err != nil {
+		return nil, err
+	}
+	return s.prepareTx(ctx, from, token, big.NewInt(0), data)
+}
+
+// VerifyTip checks that a mined transaction sent by the tipper moved ETH, or
+// the given circle token, to the author and returns how much. The
+// transaction must have succeeded and be buried under confirmations blocks.
+// Token tips are read from the token's Transfer logs, so transfers made
+// through another contract count too; several transfers in one transaction
+// are summed.
+func (s *Web3Service) VerifyTip(ctx context.Context, txHash common.Hash, from, to, token common.Address, confirmations uint64) (*Tip, error) {
+	receipt, err := s.client.TransactionReceipt(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("%w: %s is not mined", ErrTipNotSettled, txHash.Hex())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%w: transaction reverted", ErrTipMismatch)
+	}
+
+	head, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain head: %w", err)
+	}
+	block := receipt.BlockNumber.Uint64()
+	if head.Number.Uint64() < block+confirmations {
+		return nil, fmt.Errorf("%w: %d of %d confirmations", ErrTipNotSettled, head.Number.Uint64()-block, confirmations)
+	}
+
+	tx, _, err := s.client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(s.chainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to recover sender: %v", ErrTipMismatch, err)
+	}
+	if sender != from {
+		return nil, fmt.Errorf("%w: sent by %s, expected %s", ErrTipMismatch, sender.Hex(), from.Hex())
+	}
+
+	tip := &Tip{
+		TxHash:      txHash,
+		From:        from,
+		To:          to,
+		Token:       token,
+		Amount:      new(big.Int),
+		Nonce:       tx.Nonce(),
+		BlockNumber: block,
+	}
+
+	if token == (common.Address{}) {
+		if tx.To() == nil || *tx.To() != to {
+			return nil, fmt.Errorf("%w: ETH was not sent to %s", ErrTipMismatch, to.Hex())
+		}
+		tip.Amount.Set(tx.Value())
+	} else {
+		for _, lg := range receipt.Logs {
+			if lg.Address != token || len(lg.Topics) == 0 || lg.Topics[0] != s.events.Transfer {
+				continue
+			}
+			ev, err := s.circleToken.ParseTransfer(*lg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse transfer: %w", err)
+			}
+			if ev.From == from && ev.To == to {
+				tip.Amount.Add(tip.Amount, ev.Value)
+			}
+		}
+	}
+
+	if tip.Amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: nothing was transferred from %s to %s", ErrTipMismatch, from.Hex(), to.Hex())
+	}
+	return tip, nil
+}
+
+// transferData packs an ERC-20 transfer of a circle token
+func (s *Web3Service) transferData(token, to common.Address, amount *big.Int) ([]byte, error) {
+	transactor, err := contracts.NewCircleTokenTransactor(token, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind token: %w", err)
+	}
+	return calldata(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return transactor.Transfer(opts, to, amount)
+	})
+}
@@ -0,0 +1,312 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrInvalidTransaction is returned when a signed transaction submitted by a
+// client does not match what the backend expects to broadcast
+var ErrInvalidTransaction = errors.New("invalid signed transaction")
+
+// UnsignedTx represents an EIP-1559 transaction prepared for a wallet to sign.
+// Numeric fields are encoded as decimal strings so they survive JSON clients.
+type UnsignedTx struct {
+	Type                 uint8  `json:"type"`
+	ChainID              string `json:"chain_id"`
+	From                 string `json:"from"`
+	To                   string `json:"to"`
+	Nonce                uint64 `json:"nonce"`
+	Gas                  uint64 `json:"gas"`
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas"`
+	MaxFeePerGas         string `json:"max_fee_per_gas"`
+	Value                string `json:"value"`
+	Data                 string `json:"data"`
+}
+
+// Contract methods a wallet-signed transaction may call. The service checks
+// at construction that the ABIs define each of them.
+const (
+	MethodCreateCircle            = "createCircle"
+	MethodDeactivateCircle        = "deactivateCircle"
+	MethodReactivateCircle        = "reactivateCircle"
+	MethodTransferCircleOwnership = "transferCircleOwnership"
+	MethodBuyTokens               = "buyTokens"
+	MethodSellTokens              = "sellTokens"
+)
+
+// DecodedCall represents a contract call recovered from transaction calldata
+type DecodedCall struct {
+	Method string
+	Args   []interface{}
+}
+
+// PrepareCreateCircle builds an unsigned createCircle transaction for the
+// given sender, carrying the factory's creation fee as value
+func (s *Web3Service) PrepareCreateCircle(ctx context.Context, from common.Address, params CreateCircleParams) (*UnsignedTx, error) {
+	fee, err := s.CircleCreationFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.createCircleData(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.prepareTx(ctx, from, s.factoryAddress, fee, data)
+}
+
+// PrepareBuyTokens builds an unsigned buyTokens transaction for the given
+// sender. The curve mints as many tokens as the sent value buys, so maxCost
+// is sent as value and amount becomes the minimum the trade must return.
+func (s *Web3Service) PrepareBuyTokens(ctx context.Context, from, tokenAddress common.Address, amount *big.Int, maxCost *big.Int) (*UnsignedTx, error) {
+	data, err := s.buyTokensData(tokenAddress, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.prepareTx(ctx, from, s.bondingCurveAddress, maxCost, data)
+}
+
+// PrepareSellTokens builds an unsigned sellTokens transaction for the given sender
+func (s *Web3Service) PrepareSellTokens(ctx context.Context, from, tokenAddress common.Address, amount *big.Int, minRefund *big.Int) (*UnsignedTx, error) {
+	data, err := s.sellTokensData(tokenAddress, amount, minRefund)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.prepareTx(ctx, from, s.bondingCurveAddress, big.NewInt(0), data)
+}
+
+// PrepareDeactivateCircle builds an unsigned deactivateCircle transaction for the circle owner
+func (s *Web3Service) PrepareDeactivateCircle(ctx context.Context, from common.Address, circleID *big.Int) (*UnsignedTx, error) {
+	data, err := calldata(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.factory.DeactivateCircle(opts, circleID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.prepareTx(ctx, from, s.factoryAddress, big.NewInt(0), data)
+}
+
+// PrepareReactivateCircle builds an unsigned reactivateCircle transaction for the circle owner
+func (s *Web3Service) PrepareReactivateCircle(ctx context.Context, from common.Address, circleID *big.Int) (*UnsignedTx, error) {
+	data, err := calldata(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.factory.ReactivateCircle(opts, circleID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.prepareTx(ctx, from, s.factoryAddress, big.NewInt(0), data)
+}
+
+// PrepareTransferCircleOwnership builds an unsigned transferCircleOwnership
+// transaction for the circle owner
+func (s *Web3Service) PrepareTransferCircleOwnership(ctx context.Context, from common.Address, circleID *big.Int, newOwner common.Address) (*UnsignedTx, error) {
+	data, err := calldata(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.factory.TransferCircleOwnership(opts, circleID, newOwner)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.prepareTx(ctx, from, s.factoryAddress, big.NewInt(0), data)
+}
+
+// VerifyFactoryTx decodes a signed transaction and checks it is a call to one
+// of the allowed CircleFactory methods sent by the expected address
+func (s *Web3Service) VerifyFactoryTx(rawTx string, from common.Address, methods ...string) (*types.Transaction, *DecodedCall, error) {
+	return s.verifySignedTx(rawTx, from, s.factoryAddress, s.factoryABI, methods)
+}
+
+// VerifyBondingCurveTx decodes a signed transaction and checks it is a call to
+// one of the allowed BondingCurve methods sent by the expected address
+func (s *Web3Service) VerifyBondingCurveTx(rawTx string, from common.Address, methods ...string) (*types.Transaction, *DecodedCall, error) {
+	return s.verifySignedTx(rawTx, from, s.bondingCurveAddress, s.bondingCurveABI, methods)
+}
+
+// SendSignedTx broadcasts a transaction that was signed by the user's wallet
+func (s *Web3Service) SendSignedTx(ctx context.Context, tx *types.Transaction) error {
+	if err := s.client.SendTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *Web3Service) createCircleData(params CreateCircleParams) ([]byte, error) {
+	return calldata(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.factory.CreateCircle(opts,
+			params.Name,
+			params.Symbol,
+			params.Description,
+			params.CurveType,
+			params.BasePrice,
+			params.K,
+			params.M,
+			params.N,
+		)
+	})
+}
+
+func (s *Web3Service) buyTokensData(tokenAddress common.Address, minTokens *big.Int) ([]byte, error) {
+	return calldata(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.bondingCurve.BuyTokens(opts, tokenAddress, minTokens)
+	})
+}
+
+func (s *Web3Service) sellTokensData(tokenAddress common.Address, amount, minRefund *big.Int) ([]byte, error) {
+	return calldata(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.bondingCurve.SellTokens(opts, tokenAddress, amount, minRefund)
+	})
+}
+
+// calldata packs a call through a generated transactor method, so the
+// arguments are checked against the contract ABI at compile time. The
+// options are filled in so the binding neither queries the node nor signs.
+func calldata(call func(opts *bind.TransactOpts) (*types.Transaction, error)) ([]byte, error) {
+	tx, err := call(&bind.TransactOpts{
+		Nonce:    new(big.Int),
+		GasPrice: new(big.Int),
+		GasLimit: 1,
+		NoSend:   true,
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack transaction: %w", err)
+	}
+	return tx.Data(), nil
+}
+
+// prepareTx fills in nonce, gas and fee fields for a call from the sender
+func (s *Web3Service) prepareTx(ctx context.Context, from, to common.Address, value *big.Int, data []byte) (*UnsignedTx, error) {
+	nonce, err := s.client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	gasLimit, err := s.estimateGas(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tipCap, feeCap, err := s.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnsignedTx{
+		Type:                 types.DynamicFeeTxType,
+		ChainID:              s.chainID.String(),
+		From:                 from.Hex(),
+		To:                   to.Hex(),
+		Nonce:                nonce,
+		Gas:                  gasLimit,
+		MaxPriorityFeePerGas: tipCap.String(),
+		MaxFeePerGas:         feeCap.String(),
+		Value:                value.String(),
+		Data:                 hexutil.Encode(data),
+	}, nil
+}
+
+// verifySignedTx decodes a raw transaction and validates chain, sender, target and method
+func (s *Web3Service) verifySignedTx(rawTx string, from, target common.Address, contractABI *abi.ABI, methods []string) (*types.Transaction, *DecodedCall, error) {
+	tx, err := s.decodeSignedTx(rawTx, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tx.To() == nil || *tx.To() != target {
+		return nil, nil, fmt.Errorf("%w: transaction does not target %s", ErrInvalidTransaction, target.Hex())
+	}
+
+	if len(tx.Data()) < 4 {
+		return nil, nil, fmt.Errorf("%w: missing method selector", ErrInvalidTransaction)
+	}
+
+	method, err := contractABI.MethodById(tx.Data()[:4])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: unknown method selector", ErrInvalidTransaction)
+	}
+
+	allowed := false
+	for _, name := range methods {
+		if _, ok := contractABI.Methods[name]; !ok {
+			return nil, nil, fmt.Errorf("method %s is not defined by the contract ABI", name)
+		}
+		if method.Name == name {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, nil, fmt.Errorf("%w: method %s is not allowed, expected %s", ErrInvalidTransaction, method.Name, strings.Join(methods, " or "))
+	}
+
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to decode arguments: %v", ErrInvalidTransaction, err)
+	}
+
+	return tx, &DecodedCall{Method: method.Name, Args: args}, nil
+}
+
+// decodeSignedTx decodes a raw transaction and checks its chain and sender
+func (s *Web3Service) decodeSignedTx(rawTx string, from common.Address) (*types.Transaction, error) {
+	raw, err := hexutil.Decode(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed hex: %v", ErrInvalidTransaction, err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	if tx.ChainId().Cmp(s.chainID) != 0 {
+		return nil, fmt.Errorf("%w: chain ID %s, expected %s", ErrInvalidTransaction, tx.ChainId(), s.chainID)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(s.chainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to recover sender: %v", ErrInvalidTransaction, err)
+	}
+	if sender != from {
+		return nil, fmt.Errorf("%w: signed by %s, expected %s", ErrInvalidTransaction, sender.Hex(), from.Hex())
+	}
+
+	return tx, nil
+}
+
+// requireMethods checks the ABI defines every named method, so a method
+// renamed in the contracts fails at startup instead of rejecting every call
+func requireMethods(contractABI *abi.ABI, names ...string) error {
+	for _, name := range names {
+		if _, ok := contractABI.Methods[name]; !ok {
+			return fmt.Errorf("contract ABI has no method %s", name)
+		}
+	}
+	return nil
+}
@@ -6,29 +6,78 @@ package web3
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
 )
 
+// Backend is the subset of the Ethereum client API the service relies on. It
+// is satisfied by *ethclient.Client and by the simulated backend in tests.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
+}
+
 // Web3Service handles blockchain interactions
 type Web3Service struct {
-	client              *ethclient.Client
+	client              Backend
 	chainID             *big.Int
 	factoryAddress      common.Address
 	bondingCurveAddress common.Address
-	factoryABI          abi.ABI
-	bondingCurveABI     abi.ABI
-	tokenABI            abi.ABI
+	factory             *contracts.CircleFactory
+	bondingCurve        *contracts.BondingCurve
+	// The parsed ABIs decode calldata of wallet-signed transactions
+	factoryABI      *abi.ABI
+	bondingCurveABI *abi.ABI
+	// circleToken parses logs of every circle's token, whatever its address
+	circleToken *contracts.CircleTokenFilterer
+	events      ContractEvents
+	fees        feeLimits
+}
+
+// ContractEvents holds the topic IDs of the events the service and the
+// indexer read logs of. They are resolved once at construction, so an event
+// renamed in the contracts fails startup instead of silently matching no logs.
+type ContractEvents struct {
+	CircleCreated              common.Hash
+	CircleDeactivated          common.Hash
+	CircleReactivated          common.Hash
+	CircleOwnershipTransferred common.Hash
+	TokensPurchased            common.Hash
+	TokensSold                 common.Hash
+	Transfer                   common.Hash
+}
+
+func resolveEvents(factoryABI, bondingCurveABI, circleTokenABI *abi.ABI) (ContractEvents, error) {
+	var events ContractEvents
+	for _, lookup := range []struct {
+		contractABI *abi.ABI
+		name        string
+		id          *common.Hash
+	}{
+		{factoryABI, "CircleCreated", &events.CircleCreated},
+		{factoryABI, "CircleDeactivated", &events.CircleDeactivated},
+		{factoryABI, "CircleReactivated", &events.CircleReactivated},
+		{factoryABI, "CircleOwnershipTransferred", &events.CircleOwnershipTransferred},
+		{bondingCurveABI, "TokensPurchased", &events.TokensPurchased},
+		{bondingCurveABI, "TokensSold", &events.TokensSold},
+		{circleTokenABI, "Transfer", &events.Transfer},
+	} {
+		event, ok := lookup.contractABI.Events[lookup.name]
+		if !ok {
+			return ContractEvents{}, fmt.Errorf("contract ABI has no event %s", lookup.name)
+		}
+		*lookup.id = event.ID
+	}
+	return events, nil
 }
 
 // NewWeb3Service creates a new Web3 service instance
@@ -43,281 +92,181 @@ func NewWeb3Service(rpcURL string, factoryAddr, bondingCurveAddr string) (*Web3S
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 
-	// Parse ABIs
-	factoryABI, err := abi.JSON(strings.NewReader(CircleFactoryABI))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse factory ABI: %w", err)
-	}
-
-	bondingCurveABI, err := abi.JSON(strings.NewReader(BondingCurveABI))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse bonding curve ABI: %w", err)
-	}
-
-	tokenABI, err := abi.JSON(strings.NewReader(CircleTokenABI))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token ABI: %w", err)
-	}
-
-	return &Web3Service{
-		client:              client,
-		chainID:             chainID,
-		factoryAddress:      common.HexToAddress(factoryAddr),
-		bondingCurveAddress: common.HexToAddress(bondingCurveAddr),
-		factoryABI:          factoryABI,
-		bondingCurveABI:     bondingCurveABI,
-		tokenABI:            tokenABI,
-	}, nil
+	return NewWeb3ServiceWithBackend(client, chainID, factoryAddr, bondingCurveAddr)
 }
 
-// CreateCircleParams represents parameters for creating a circle
-type CreateCircleParams struct {
-	Name        string
-	Symbol      string
-	Description string
-	CurveType   uint8
-	BasePrice   *big.Int
-	K           *big.Int
-	M           *big.Int
-	N           *big.Int
-}
+// NewWeb3ServiceWithBackend creates a Web3 service on top of an existing backend
+func NewWeb3ServiceWithBackend(client Backend, chainID *big.Int, factoryAddr, bondingCurveAddr string) (*Web3Service, error) {
+	factoryAddress := common.HexToAddress(factoryAddr)
+	bondingCurveAddress := common.HexToAddress(bondingCurveAddr)
 
-// CreateCircle creates a new circle on-chain
-func (s *Web3Service) CreateCircle(ctx context.Context, privateKey string, params CreateCircleParams) (string, error) {
-	key, err := crypto.HexToECDSA(privateKey)
+	factory, err := contracts.NewCircleFactory(factoryAddress, client)
 	if err != nil {
-		return "", fmt.Errorf("invalid private key: %w", err)
+		return nil, fmt.Errorf("failed to bind factory: %w", err)
 	}
 
-	auth, err := s.newTransactor(key)
+	bondingCurve, err := contracts.NewBondingCurve(bondingCurveAddress, client)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to bind bonding curve: %w", err)
 	}
 
-	// Pack the transaction data
-	data, err := s.factoryABI.Pack(
-		"createCircle",
-		params.Name,
-		params.Symbol,
-		params.Description,
-		params.CurveType,
-		params.BasePrice,
-		params.K,
-		params.M,
-		params.N,
-	)
+	factoryABI, err := contracts.CircleFactoryMetaData.GetAbi()
 	if err != nil {
-		return "", fmt.Errorf("failed to pack transaction: %w", err)
+		return nil, fmt.Errorf("failed to parse factory ABI: %w", err)
 	}
 
-	// Estimate gas
-	gasLimit, err := s.client.EstimateGas(ctx, ethereum.CallMsg{
-		From:  auth.From,
-		To:    &s.factoryAddress,
-		Value: auth.Value,
-		Data:  data,
-	})
+	bondingCurveABI, err := contracts.BondingCurveMetaData.GetAbi()
 	if err != nil {
-		return "", fmt.Errorf("failed to estimate gas: %w", err)
+		return nil, fmt.Errorf("failed to parse bonding curve ABI: %w", err)
 	}
 
-	auth.GasLimit = gasLimit + 50000 // Add buffer
-
-	// Send transaction
-	tx := types.NewTransaction(
-		auth.Nonce.Uint64(),
-		s.factoryAddress,
-		auth.Value,
-		auth.GasLimit,
-		auth.GasPrice,
-		data,
-	)
-
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(s.chainID), key)
+	circleToken, err := contracts.NewCircleTokenFilterer(common.Address{}, client)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
+		return nil, fmt.Errorf("failed to bind circle token: %w", err)
 	}
 
-	err = s.client.SendTransaction(ctx, signedTx)
+	circleTokenABI, err := contracts.CircleTokenMetaData.GetAbi()
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		return nil, fmt.Errorf("failed to parse circle token ABI: %w", err)
 	}
 
-	return signedTx.Hash().Hex(), nil
-}
-
-// GetCircle retrieves circle information
-func (s *Web3Service) GetCircle(ctx context.Context, circleID *big.Int) (*CircleInfo, error) {
-	data, err := s.factoryABI.Pack("circles", circleID)
+	events, err := resolveEvents(factoryABI, bondingCurveABI, circleTokenABI)
 	if err != nil {
-		return nil, fmt.Errorf("failed to pack call: %w", err)
+		return nil, err
 	}
 
-	result, err := s.client.CallContract(ctx, ethereum.CallMsg{
-		To:   &s.factoryAddress,
-		Data: data,
-	}, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call contract: %w", err)
+	if err := requireMethods(factoryABI, MethodCreateCircle, MethodDeactivateCircle, MethodReactivateCircle, MethodTransferCircleOwnership); err != nil {
+		return nil, err
 	}
-
-	var circle CircleInfo
-	err = s.factoryABI.UnpackIntoInterface(&circle, "circles", result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unpack result: %w", err)
+	if err := requireMethods(bondingCurveABI, MethodBuyTokens, MethodSellTokens); err != nil {
+		return nil, err
 	}
 
-	return &circle, nil
+	return &Web3Service{
+		client:              client,
+		chainID:             chainID,
+		factoryAddress:      factoryAddress,
+		bondingCurveAddress: bondingCurveAddress,
+		factory:             factory,
+		bondingCurve:        bondingCurve,
+		factoryABI:          factoryABI,
+		bondingCurveABI:     bondingCurveABI,
+		circleToken:         circleToken,
+		events:              events,
+		fees:                feeLimits{bumpPercent: defaultFeeBumpPercent},
+	}, nil
 }
 
-// BuyTokens purchases circle tokens
-func (s *Web3Service) BuyTokens(ctx context.Context, privateKey string, tokenAddress common.Address, amount *big.Int, maxCost *big.Int) (string, error) {
-	key, err := crypto.HexToECDSA(privateKey)
-	if err != nil {
-		return "", fmt.Errorf("invalid private key: %w", err)
-	}
-
-	auth, err := s.newTransactor(key)
-	if err != nil {
-		return "", err
-	}
-
-	auth.Value = maxCost
-
-	data, err := s.bondingCurveABI.Pack("buyTokens", tokenAddress, amount, maxCost)
-	if err != nil {
-		return "", fmt.Errorf("failed to pack transaction: %w", err)
-	}
-
-	gasLimit, err := s.client.EstimateGas(ctx, ethereum.CallMsg{
-		From:  auth.From,
-		To:    &s.bondingCurveAddress,
-		Value: auth.Value,
-		Data:  data,
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to estimate gas: %w", err)
-	}
+// Client returns the Ethereum backend the service talks to
+func (s *Web3Service) Client() Backend {
+	return s.client
+}
 
-	auth.GasLimit = gasLimit + 50000
+// FactoryAddress returns the CircleFactory contract address
+func (s *Web3Service) FactoryAddress() common.Address {
+	return s.factoryAddress
+}
 
-	tx := types.NewTransaction(
-		auth.Nonce.Uint64(),
-		s.bondingCurveAddress,
-		auth.Value,
-		auth.GasLimit,
-		auth.GasPrice,
-		data,
-	)
+// BondingCurveAddress returns the BondingCurve contract address
+func (s *Web3Service) BondingCurveAddress() common.Address {
+	return s.bondingCurveAddress
+}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(s.chainID), key)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
-	}
+// Factory returns the CircleFactory binding
+func (s *Web3Service) Factory() *contracts.CircleFactory {
+	return s.factory
+}
 
-	err = s.client.SendTransaction(ctx, signedTx)
-	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
-	}
+// BondingCurve returns the BondingCurve binding
+func (s *Web3Service) BondingCurve() *contracts.BondingCurve {
+	return s.bondingCurve
+}
 
-	return signedTx.Hash().Hex(), nil
+// CircleToken returns a filterer that parses the logs of any circle token
+func (s *Web3Service) CircleToken() *contracts.CircleTokenFilterer {
+	return s.circleToken
 }
 
-// SellTokens sells circle tokens
-func (s *Web3Service) SellTokens(ctx context.Context, privateKey string, tokenAddress common.Address, amount *big.Int, minRefund *big.Int) (string, error) {
-	key, err := crypto.HexToECDSA(privateKey)
-	if err != nil {
-		return "", fmt.Errorf("invalid private key: %w", err)
-	}
+// Events returns the topic IDs of the contract events
+func (s *Web3Service) Events() ContractEvents {
+	return s.events
+}
 
-	auth, err := s.newTransactor(key)
-	if err != nil {
-		return "", err
-	}
+// CreateCircleParams represents parameters for creating a circle
+type CreateCircleParams struct {
+	Name        string
+	Symbol      string
+	Description string
+	CurveType   uint8
+	BasePrice   *big.Int
+	K           *big.Int
+	M           *big.Int
+	N           *big.Int
+}
 
-	data, err := s.bondingCurveABI.Pack("sellTokens", tokenAddress, amount, minRefund)
+// CircleCreationFee returns the value createCircle must be sent with
+func (s *Web3Service) CircleCreationFee(ctx context.Context) (*big.Int, error) {
+	fee, err := s.factory.CircleCreationFee(&bind.CallOpts{Context: ctx})
 	if err != nil {
-		return "", fmt.Errorf("failed to pack transaction: %w", err)
+		return nil, fmt.Errorf("failed to get circle creation fee: %w", err)
 	}
+	return fee, nil
+}
 
-	gasLimit, err := s.client.EstimateGas(ctx, ethereum.CallMsg{
-		From: auth.From,
-		To:   &s.bondingCurveAddress,
-		Data: data,
-	})
+// GetCircle retrieves circle information
+func (s *Web3Service) GetCircle(ctx context.Context, circleID *big.Int) (*contracts.CircleFactoryCircle, error) {
+	circle, err := s.factory.GetCircle(&bind.CallOpts{Context: ctx}, circleID)
 	if err != nil {
-		return "", fmt.Errorf("failed to estimate gas: %w", err)
+		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
-	auth.GasLimit = gasLimit + 50000
-
-	tx := types.NewTransaction(
-		auth.Nonce.Uint64(),
-		s.bondingCurveAddress,
-		big.NewInt(0),
-		auth.GasLimit,
-		auth.GasPrice,
-		data,
-	)
+	return &circle, nil
+}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(s.chainID), key)
+// GetTokenBalance retrieves token balance for an address
+func (s *Web3Service) GetTokenBalance(ctx context.Context, tokenAddress, userAddress common.Address) (*big.Int, error) {
+	token, err := contracts.NewCircleToken(tokenAddress, s.client)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
+		return nil, fmt.Errorf("failed to bind token: %w", err)
 	}
 
-	err = s.client.SendTransaction(ctx, signedTx)
+	balance, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, userAddress)
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
-	return signedTx.Hash().Hex(), nil
+	return balance, nil
 }
 
-// GetTokenBalance retrieves token balance for an address
-func (s *Web3Service) GetTokenBalance(ctx context.Context, tokenAddress, userAddress common.Address) (*big.Int, error) {
-	data, err := s.tokenABI.Pack("balanceOf", userAddress)
+// CurrentTokenBalance reads a token balance pinned to the current head block
+// and returns that block's header, so a cached balance can record which
+// transfers it already reflects
+func (s *Web3Service) CurrentTokenBalance(ctx context.Context, tokenAddress, userAddress common.Address) (*big.Int, *types.Header, error) {
+	head, err := s.client.HeaderByNumber(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to pack call: %w", err)
+		return nil, nil, fmt.Errorf("failed to get chain head: %w", err)
 	}
 
-	result, err := s.client.CallContract(ctx, ethereum.CallMsg{
-		To:   &tokenAddress,
-		Data: data,
-	}, nil)
+	token, err := contracts.NewCircleTokenCaller(tokenAddress, s.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call contract: %w", err)
+		return nil, nil, fmt.Errorf("failed to bind token: %w", err)
 	}
 
-	balance := new(big.Int)
-	err = s.tokenABI.UnpackIntoInterface(&balance, "balanceOf", result)
+	balance, err := token.BalanceOf(&bind.CallOpts{Context: ctx, BlockNumber: head.Number}, userAddress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unpack result: %w", err)
+		return nil, nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
-	return balance, nil
+	return balance, head, nil
 }
 
 // GetCurrentPrice retrieves current price for a token
 func (s *Web3Service) GetCurrentPrice(ctx context.Context, tokenAddress common.Address) (*big.Int, error) {
-	data, err := s.bondingCurveABI.Pack("getCurrentPrice", tokenAddress)
-	if err != nil {
-		return nil, fmt.Errorf("failed to pack call: %w", err)
-	}
-
-	result, err := s.client.CallContract(ctx, ethereum.CallMsg{
-		To:   &s.bondingCurveAddress,
-		Data: data,
-	}, nil)
+	price, err := s.bondingCurve.GetCurrentPrice(&bind.CallOpts{Context: ctx}, tokenAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
-	price := new(big.Int)
-	err = s.bondingCurveABI.UnpackIntoInterface(&price, "getCurrentPrice", result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unpack result: %w", err)
-	}
-
 	return price, nil
 }
 
@@ -325,52 +274,15 @@ func (s *Web3Service) GetCurrentPrice(ctx context.Context, tokenAddress common.A
 func (s *Web3Service) WaitForTransaction(ctx context.Context, txHash string) (*types.Receipt, error) {
 	hash := common.HexToHash(txHash)
 
-	receipt, err := bind.WaitMined(ctx, s.client, &types.Transaction{})
+	tx, _, err := s.client.TransactionByHash(ctx, hash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for transaction: %w", err)
-	}
-
-	return receipt, nil
-}
-
-// Helper function to create a transactor
-func (s *Web3Service) newTransactor(privateKey *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("error casting public key to ECDSA")
-	}
-
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	nonce, err := s.client.PendingNonceAt(context.Background(), fromAddress)
-	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
 
-	gasPrice, err := s.client.SuggestGasPrice(context.Background())
+	receipt, err := bind.WaitMined(ctx, s.client, tx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to wait for transaction: %w", err)
 	}
 
-	auth := bind.NewKeyedTransactor(privateKey)
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)
-	auth.GasLimit = uint64(3000000)
-	auth.GasPrice = gasPrice
-
-	return auth, nil
-}
-
-// CircleInfo represents circle information from blockchain
-type CircleInfo struct {
-	ID             *big.Int
-	Owner          common.Address
-	TokenAddress   common.Address
-	BondingCurve   common.Address
-	Name           string
-	Symbol         string
-	Description    string
-	Active         bool
-	CurveType      uint8
-	CreatedAt      *big.Int
+	return receipt, nil
 }
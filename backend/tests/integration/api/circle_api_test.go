@@ -11,7 +11,6 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/fast-socialfi/backend/internal/handler"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -70,13 +69,13 @@ func TestCreateCircle_API(t *testing.T) {
 
 	// Test valid request
 	validPayload := map[string]interface{}{
-		"name":        "Test Circle",
-		"symbol":      "TST",
-		"description": "Test Description",
-		"curve_type":  0,
-		"base_price":  "1000000000000000",
-		"k":           "1000000000000000",
-		"private_key": "0xtest",
+		"name":         "Test Circle",
+		"symbol":       "TST",
+		"description":  "Test Description",
+		"curve_type":   0,
+		"base_price":   "1000000000000000",
+		"k":            "1000000000000000",
+		"from_address": "0x1234567890123456789012345678901234567890",
 	}
 
 	jsonData, _ := json.Marshal(validPayload)
@@ -225,10 +224,10 @@ func TestBuyTokens_API(t *testing.T) {
 	})
 
 	payload := map[string]interface{}{
-		"circle_id":   1,
-		"amount":      "100",
-		"max_cost":    "1000000000000000000",
-		"private_key": "0xtest",
+		"circle_id":    1,
+		"amount":       "100",
+		"max_cost":     "1000000000000000000",
+		"from_address": "0x1234567890123456789012345678901234567890",
 	}
 
 	jsonData, _ := json.Marshal(payload)
@@ -269,10 +268,10 @@ func TestSellTokens_API(t *testing.T) {
 	})
 
 	payload := map[string]interface{}{
-		"circle_id":   1,
-		"amount":      "50",
-		"min_refund":  "500000000000000000",
-		"private_key": "0xtest",
+		"circle_id":    1,
+		"amount":       "50",
+		"min_refund":   "500000000000000000",
+		"from_address": "0x1234567890123456789012345678901234567890",
 	}
 
 	jsonData, _ := json.Marshal(payload)
@@ -0,0 +1,241 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/middleware"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/siwe"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// walletInitCode deploys a mock EIP-1271 wallet that accepts signatures
+// made by owner's key:
+//
+//	mstore(0x00, calldataload(0x04))                  hash
+//	mstore(0x20, byte(0, calldataload(0xa4)))         v
+//	mstore(0x40, calldataload(0x64))                  r
+//	mstore(0x60, calldataload(0x84))                  s
+//	pop(staticcall(gas, 1, 0, 0x80, 0x80, 0x20))      ecrecover
+//	mstore(0, (mload(0x80) == owner) * 0x1626ba7e << 224)
+//	return(0, 0x20)
+func walletInitCode(owner common.Address) []byte {
+	runtime := common.FromHex("0x" +
+		"60043560005260a43560001a602052606435604052608435606052602060806080" +
+		"600060015afa50608051" +
+		"73" + hexutil.Encode(owner.Bytes())[2:] +
+		"14631626ba7e0260e01b60005260206000f3")
+	// codecopy(0, 0x0c, len) and return it
+	constructor := []byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}
+	return append(constructor, runtime...)
+}
+
+// factoryInitCode deploys a CREATE2 factory that deploys its calldata as
+// init code with salt 0, standing in for a Safe proxy factory
+//
+//	calldatacopy(0, 0, calldatasize)
+//	mstore(0, create2(0, 0, calldatasize, 0))
+//	return(0, 0x20)
+var factoryInitCode = common.FromHex("0x6016600c60003960166000f3" + "36600060003760003660006000f560005260206000f3")
+
+type chainFixture struct {
+	backend *backends.SimulatedBackend
+	auth    *bind.TransactOpts
+	web3Svc *web3.Web3Service
+	svc     *service.AuthService
+}
+
+func setupChain(t *testing.T) *chainFixture {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		deployer: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))},
+	}, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+
+	chainID := backend.Blockchain().Config().ChainID
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+
+	web3Svc, err := web3.NewWeb3ServiceWithBackend(backend, chainID, common.Address{}.Hex(), common.Address{}.Hex())
+	require.NoError(t, err)
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.UserSession{}))
+
+	svc := service.NewAuthService(repository.NewUserRepository(db), repository.NewSessionRepository(db), web3Svc,
+		middleware.NewAuthMiddleware("test-secret"), siweCfg, config.JWTConfig{
+			AccessExpiration:  time.Hour,
+			RefreshExpiration: 24 * time.Hour,
+		})
+
+	return &chainFixture{backend: backend, auth: auth, web3Svc: web3Svc, svc: svc}
+}
+
+// send mines a transaction to the given address, or a contract creation
+// when to is nil
+func (c *chainFixture) send(t *testing.T, to *common.Address, data []byte) *types.Receipt {
+	ctx := context.Background()
+	nonce, err := c.backend.PendingNonceAt(ctx, c.auth.From)
+	require.NoError(t, err)
+	tx, err := c.auth.Signer(c.auth.From, types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       to,
+		Gas:      1_000_000,
+		GasPrice: big.NewInt(2e9),
+		Data:     data,
+	}))
+	require.NoError(t, err)
+	require.NoError(t, c.backend.SendTransaction(ctx, tx))
+	c.backend.Commit()
+
+	receipt, err := c.backend.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	return receipt
+}
+
+// deploy creates a contract from init code and returns its address
+func (c *chainFixture) deploy(t *testing.T, initCode []byte) common.Address {
+	return c.send(t, nil, initCode).ContractAddress
+}
+
+// loginMessage builds a sign-in message for account with a fresh nonce
+func (c *chainFixture) loginMessage(t *testing.T, account common.Address) string {
+	nonce, err := c.svc.IssueNonce(context.Background())
+	require.NoError(t, err)
+	msg := &siwe.Message{
+		Domain:   siweCfg.Domain,
+		Address:  account,
+		URI:      "https://" + siweCfg.Domain,
+		Version:  siwe.Version,
+		ChainID:  siweCfg.ChainID,
+		Nonce:    nonce.Nonce,
+		IssuedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	return msg.String()
+}
+
+func personalSign(t *testing.T, w *wallet, message string) []byte {
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), w.key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig
+}
+
+func wrapERC6492(t *testing.T, factory common.Address, factoryCalldata, signature []byte) []byte {
+	addressType, _ := abi.NewType("address", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	wrapped, err := abi.Arguments{{Type: addressType}, {Type: bytesType}, {Type: bytesType}}.
+		Pack(factory, factoryCalldata, signature)
+	require.NoError(t, err)
+	return append(wrapped, common.FromHex("0x6492649264926492649264926492649264926492649264926492649264926492")...)
+}
+
+func TestContractWalletLogin(t *testing.T) {
+	c := setupChain(t)
+	ctx := context.Background()
+	owner, stranger := newWallet(t), newWallet(t)
+	safe := c.deploy(t, walletInitCode(crypto.PubkeyToAddress(owner.key.PublicKey)))
+
+	// The owner's key signs on behalf of the wallet, which confirms it
+	message := c.loginMessage(t, safe)
+	resp, err := c.svc.Login(ctx, &service.LoginRequest{
+		Message:   message,
+		Signature: hexutil.Encode(personalSign(t, owner, message)),
+	}, service.ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, safe.Hex(), resp.User.WalletAddress)
+
+	message = c.loginMessage(t, safe)
+	_, err = c.svc.Login(ctx, &service.LoginRequest{
+		Message:   message,
+		Signature: hexutil.Encode(personalSign(t, stranger, message)),
+	}, service.ClientInfo{})
+	assert.ErrorIs(t, err, siwe.ErrInvalidSignature)
+
+	// Externally owned accounts still sign for themselves
+	message = c.loginMessage(t, crypto.PubkeyToAddress(stranger.key.PublicKey))
+	_, err = c.svc.Login(ctx, &service.LoginRequest{
+		Message:   message,
+		Signature: hexutil.Encode(personalSign(t, stranger, message)),
+	}, service.ClientInfo{})
+	assert.NoError(t, err)
+}
+
+func TestCounterfactualWalletLogin(t *testing.T) {
+	c := setupChain(t)
+	ctx := context.Background()
+	owner, stranger := newWallet(t), newWallet(t)
+
+	factory := c.deploy(t, factoryInitCode)
+	initCode := walletInitCode(crypto.PubkeyToAddress(owner.key.PublicKey))
+	safe := crypto.CreateAddress2(factory, common.Hash{}, crypto.Keccak256(initCode))
+
+	code, err := c.backend.CodeAt(ctx, safe, nil)
+	require.NoError(t, err)
+	require.Empty(t, code, "the wallet must not be deployed yet")
+
+	message := c.loginMessage(t, safe)
+	signature := wrapERC6492(t, factory, initCode, personalSign(t, owner, message))
+	resp, err := c.svc.Login(ctx, &service.LoginRequest{
+		Message:   message,
+		Signature: hexutil.Encode(signature),
+	}, service.ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, safe.Hex(), resp.User.WalletAddress)
+
+	// Verification only simulated the deployment
+	code, err = c.backend.CodeAt(ctx, safe, nil)
+	require.NoError(t, err)
+	assert.Empty(t, code)
+
+	message = c.loginMessage(t, safe)
+	signature = wrapERC6492(t, factory, initCode, personalSign(t, stranger, message))
+	_, err = c.svc.Login(ctx, &service.LoginRequest{
+		Message:   message,
+		Signature: hexutil.Encode(signature),
+	}, service.ClientInfo{})
+	assert.ErrorIs(t, err, siwe.ErrInvalidSignature)
+
+	// Once deployed, the wrapped signature still verifies
+	c.send(t, &factory, initCode)
+	code, err = c.backend.CodeAt(ctx, safe, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, code)
+	hash := common.BytesToHash(accounts.TextHash([]byte(message)))
+	valid, err := c.web3Svc.IsValidSignature(ctx, safe, hash, wrapERC6492(t, factory, initCode, personalSign(t, owner, message)))
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
@@ -0,0 +1,243 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/database"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/middleware"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/siwe"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var siweCfg = config.SIWEConfig{
+	Domain:   "app.socialfi.test",
+	ChainID:  11155111,
+	NonceTTL: 5 * time.Minute,
+}
+
+type fixture struct {
+	router *gin.Engine
+	svc    *service.AuthService
+	auth   *middleware.AuthMiddleware
+	db     *gorm.DB
+}
+
+func setup(t *testing.T) *fixture {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.UserSession{}))
+
+	auth := middleware.NewAuthMiddleware("test-secret")
+	svc := service.NewAuthService(repository.NewUserRepository(db), repository.NewSessionRepository(db), nil, auth, siweCfg, config.JWTConfig{
+		AccessExpiration:  time.Hour,
+		RefreshExpiration: 24 * time.Hour,
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	handler.NewAuthHandler(svc, auth).RegisterRoutes(v1)
+	v1.GET("/me", auth.Authenticate(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_address": c.GetString("user_address")})
+	})
+
+	return &fixture{router: router, svc: svc, auth: auth, db: db}
+}
+
+func (f *fixture) do(t *testing.T, method, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	var raw []byte
+	if body != nil {
+		var err error
+		raw, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+// nonce asks the API for a fresh nonce
+func (f *fixture) nonce(t *testing.T) string {
+	w := f.do(t, http.MethodGet, "/api/v1/auth/nonce", nil, "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp service.LoginNonceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, siweCfg.Domain, resp.Domain)
+	assert.Equal(t, siweCfg.ChainID, resp.ChainID)
+	return resp.Nonce
+}
+
+// wallet signs sign-in messages the way a browser wallet does
+type wallet struct {
+	key *ecdsa.PrivateKey
+}
+
+func newWallet(t *testing.T) *wallet {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &wallet{key: key}
+}
+
+func (w *wallet) message(nonce string) *siwe.Message {
+	return &siwe.Message{
+		Domain:    siweCfg.Domain,
+		Address:   crypto.PubkeyToAddress(w.key.PublicKey),
+		Statement: "Sign in to SocialFi",
+		URI:       "https://" + siweCfg.Domain,
+		Version:   siwe.Version,
+		ChainID:   siweCfg.ChainID,
+		Nonce:     nonce,
+		IssuedAt:  time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func (w *wallet) sign(t *testing.T, msg *siwe.Message) *service.LoginRequest {
+	text := msg.String()
+	sig, err := crypto.Sign(accounts.TextHash([]byte(text)), w.key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+	return &service.LoginRequest{Message: text, Signature: hexutil.Encode(sig)}
+}
+
+func TestLoginIssuesTokenAndCreatesUser(t *testing.T) {
+	f := setup(t)
+	w := newWallet(t)
+	address := crypto.PubkeyToAddress(w.key.PublicKey).Hex()
+
+	login := w.sign(t, w.message(f.nonce(t)))
+	resp := f.do(t, http.MethodPost, "/api/v1/auth/verify", login, "")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var body service.LoginResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, "Bearer", body.TokenType)
+	assert.Equal(t, address, body.User.WalletAddress)
+	assert.True(t, body.ExpiresAt.After(time.Now().Add(59*time.Minute)))
+
+	var users int64
+	require.NoError(t, f.db.Model(&models.User{}).Where("wallet_address = ?", address).Count(&users).Error)
+	assert.Equal(t, int64(1), users)
+
+	// The token authenticates the wallet
+	me := f.do(t, http.MethodGet, "/api/v1/me", nil, body.AccessToken)
+	require.Equal(t, http.StatusOK, me.Code)
+	assert.Contains(t, me.Body.String(), address)
+
+	// Nonces are single-use
+	resp = f.do(t, http.MethodPost, "/api/v1/auth/verify", login, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	// A second login finds the existing user
+	second, err := f.svc.Login(context.Background(), w.sign(t, w.message(f.nonce(t))), service.ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, body.User.UserID, second.User.UserID)
+}
+
+func TestLoginRejections(t *testing.T) {
+	f := setup(t)
+	w := newWallet(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		mutate func(msg *siwe.Message)
+		want   error
+	}{
+		{"other domain", func(msg *siwe.Message) { msg.Domain = "evil.test" }, service.ErrLoginRejected},
+		{"other chain", func(msg *siwe.Message) { msg.ChainID = 1 }, service.ErrLoginRejected},
+		{"expired", func(msg *siwe.Message) {
+			expired := time.Now().Add(-time.Second).UTC()
+			msg.ExpirationTime = &expired
+		}, service.ErrLoginRejected},
+		{"not yet valid", func(msg *siwe.Message) {
+			later := time.Now().Add(time.Hour).UTC()
+			msg.NotBefore = &later
+		}, service.ErrLoginRejected},
+		{"stale", func(msg *siwe.Message) { msg.IssuedAt = time.Now().Add(-time.Hour).UTC() }, service.ErrLoginRejected},
+		{"unknown nonce", func(msg *siwe.Message) { msg.Nonce = "neverissued1" }, service.ErrLoginNonce},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := w.message(f.nonce(t))
+			tt.mutate(msg)
+			_, err := f.svc.Login(ctx, w.sign(t, msg), service.ClientInfo{})
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	// A message signed by another key does not prove the address
+	msg := w.message(f.nonce(t))
+	forged := newWallet(t).sign(t, msg)
+	_, err := f.svc.Login(ctx, forged, service.ClientInfo{})
+	assert.ErrorIs(t, err, siwe.ErrInvalidSignature)
+
+	// and leaves the nonce for the real wallet
+	_, err = f.svc.Login(ctx, w.sign(t, msg), service.ClientInfo{})
+	assert.NoError(t, err)
+
+	resp := f.do(t, http.MethodPost, "/api/v1/auth/verify", &service.LoginRequest{Message: "hello", Signature: "0x00"}, "")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestRedisNonceStoreSharesNonces(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := &database.RedisClient{Client: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	t.Cleanup(func() { client.Close() })
+
+	// Two replicas share the store; a nonce issued by one is redeemable
+	// once on either
+	issuer, verifier := setup(t), setup(t)
+	issuer.svc.SetNonceStore(service.NewRedisLoginNonceStore(client))
+	verifier.svc.SetNonceStore(service.NewRedisLoginNonceStore(client))
+
+	w := newWallet(t)
+	login := w.sign(t, w.message(issuer.nonce(t)))
+	_, err := verifier.svc.Login(context.Background(), login, service.ClientInfo{})
+	require.NoError(t, err)
+	_, err = issuer.svc.Login(context.Background(), login, service.ClientInfo{})
+	assert.ErrorIs(t, err, service.ErrLoginNonce)
+
+	// Redis expires nonces that were never used
+	nonce := issuer.nonce(t)
+	mr.FastForward(siweCfg.NonceTTL + time.Second)
+	_, err = verifier.svc.Login(context.Background(), w.sign(t, w.message(nonce)), service.ClientInfo{})
+	assert.ErrorIs(t, err, service.ErrLoginNonce)
+}
@@ -0,0 +1,154 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/middleware"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrants(t *testing.T) {
+	member := &models.UserCircleRelationship{RelationshipType: "MEMBER", CanPost: true}
+	moderator := &models.UserCircleRelationship{RelationshipType: "MODERATOR", CanPost: true, CanModerate: true, CanInvite: true}
+	owns := &models.UserCircleRelationship{RelationshipType: "OWNS"}
+
+	tests := []struct {
+		name    string
+		rel     *models.UserCircleRelationship
+		isOwner bool
+		perm    middleware.Permission
+		want    bool
+	}{
+		{"member posts", member, false, middleware.PermissionPost, true},
+		{"member cannot moderate", member, false, middleware.PermissionModerate, false},
+		{"member cannot invite", member, false, middleware.PermissionInvite, false},
+		{"moderator moderates", moderator, false, middleware.PermissionModerate, true},
+		{"moderator invites", moderator, false, middleware.PermissionInvite, true},
+		{"moderator cannot manage", moderator, false, middleware.PermissionManage, false},
+		{"owner manages without a relationship", nil, true, middleware.PermissionManage, true},
+		{"OWNS relationship manages", owns, false, middleware.PermissionManage, true},
+		{"stranger cannot post", nil, false, middleware.PermissionPost, false},
+		{"unknown permission", moderator, false, middleware.Permission("delete_circle"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, middleware.Grants(tt.rel, tt.isOwner, tt.perm))
+		})
+	}
+}
+
+func TestCirclePolicyRoutes(t *testing.T) {
+	f := setup(t)
+	require.NoError(t, f.db.AutoMigrate(&models.Circle{}))
+	require.NoError(t, f.db.Exec(`CREATE TABLE user_circle_relationships (
+		uc_relationship_id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		circle_id INTEGER NOT NULL,
+		relationship_type TEXT NOT NULL,
+		token_balance TEXT DEFAULT 0,
+		balance_block INTEGER DEFAULT 0,
+		held_since DATETIME,
+		join_price TEXT,
+		contribution_score REAL DEFAULT 0,
+		can_post NUMERIC DEFAULT true,
+		can_moderate NUMERIC DEFAULT false,
+		can_invite NUMERIC DEFAULT false,
+		gate_demoted NUMERIC DEFAULT false,
+		joined_at DATETIME,
+		last_interaction_at DATETIME
+	)`).Error)
+
+	policy := middleware.NewPolicy(repository.NewUserRepository(f.db), repository.NewCircleRepository(f.db), repository.NewCircleMemberRepository(f.db))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	circles := f.router.Group("/api/v1/circles/:id", f.auth.Authenticate())
+	circles.POST("/posts", policy.RequireCirclePermission("id", middleware.PermissionPost), ok)
+	circles.POST("/posts/pin", policy.RequireCirclePermission("id", middleware.PermissionModerate), ok)
+	circles.PUT("/settings", policy.RequireCirclePermission("id", middleware.PermissionManage), ok)
+	f.router.GET("/api/v1/admin/stats", f.auth.Authenticate(), middleware.RequirePlatformAdmin(), ok)
+
+	// Everyone signs in once so they have a user row
+	owner, moderator, member, muted, banned, stranger, admin :=
+		newWallet(t), newWallet(t), newWallet(t), newWallet(t), newWallet(t), newWallet(t), newWallet(t)
+	tokens := map[*wallet]string{}
+	users := map[*wallet]*models.User{}
+	var adminRefreshToken string
+	for _, w := range []*wallet{owner, moderator, member, muted, banned, stranger, admin} {
+		resp := f.login(t, w, "browser")
+		tokens[w], users[w] = resp.AccessToken, resp.User
+		if w == admin {
+			adminRefreshToken = resp.RefreshToken
+		}
+	}
+
+	circle := &models.Circle{
+		ChainCircleID: 1,
+		OwnerAddress:  crypto.PubkeyToAddress(owner.key.PublicKey).Hex(),
+		TokenAddress:  "0x0000000000000000000000000000000000000001",
+		Name:          "Builders",
+		Symbol:        "BLD",
+	}
+	require.NoError(t, f.db.Create(circle).Error)
+	members := repository.NewCircleMemberRepository(f.db)
+	for w, rel := range map[*wallet]*models.UserCircleRelationship{
+		moderator: {RelationshipType: "MODERATOR", CanPost: true, CanModerate: true},
+		member:    {RelationshipType: "MEMBER", CanPost: true},
+		muted:     {RelationshipType: "MEMBER"},
+		banned:    {RelationshipType: "MODERATOR", CanPost: true, CanModerate: true},
+	} {
+		rel.UserID, rel.CircleID = users[w].UserID, circle.ID
+		require.NoError(t, members.Create(context.Background(), rel))
+	}
+	// GORM skips the false CanPost and the column defaults to true
+	require.NoError(t, f.db.Model(&models.UserCircleRelationship{}).
+		Where("user_id = ?", users[muted].UserID).Update("can_post", false).Error)
+	require.NoError(t, f.db.Model(users[banned]).Update("is_banned", true).Error)
+
+	// A promotion applies once the admin's token is refreshed
+	require.NoError(t, f.db.Model(users[admin]).Update("platform_role", middleware.RoleAdmin).Error)
+	assert.Equal(t, http.StatusForbidden, f.do(t, http.MethodGet, "/api/v1/admin/stats", nil, tokens[admin]).Code)
+	refreshed, code := f.refresh(t, adminRefreshToken)
+	require.Equal(t, http.StatusOK, code)
+	tokens[admin] = refreshed.AccessToken
+
+	tests := []struct {
+		name   string
+		caller *wallet
+		method string
+		path   string
+		want   int
+	}{
+		{"owner changes settings", owner, http.MethodPut, "/api/v1/circles/1/settings", http.StatusNoContent},
+		{"owner pins", owner, http.MethodPost, "/api/v1/circles/1/posts/pin", http.StatusNoContent},
+		{"moderator pins", moderator, http.MethodPost, "/api/v1/circles/1/posts/pin", http.StatusNoContent},
+		{"moderator cannot change settings", moderator, http.MethodPut, "/api/v1/circles/1/settings", http.StatusForbidden},
+		{"member posts", member, http.MethodPost, "/api/v1/circles/1/posts", http.StatusNoContent},
+		{"member cannot pin", member, http.MethodPost, "/api/v1/circles/1/posts/pin", http.StatusForbidden},
+		{"muted member cannot post", muted, http.MethodPost, "/api/v1/circles/1/posts", http.StatusForbidden},
+		{"banned moderator cannot pin", banned, http.MethodPost, "/api/v1/circles/1/posts/pin", http.StatusForbidden},
+		{"stranger cannot post", stranger, http.MethodPost, "/api/v1/circles/1/posts", http.StatusForbidden},
+		{"admin changes settings", admin, http.MethodPut, "/api/v1/circles/1/settings", http.StatusNoContent},
+		{"admin reaches admin routes", admin, http.MethodGet, "/api/v1/admin/stats", http.StatusNoContent},
+		{"owner is not a platform admin", owner, http.MethodGet, "/api/v1/admin/stats", http.StatusForbidden},
+		{"unknown circle", admin, http.MethodPost, "/api/v1/circles/99/posts", http.StatusNotFound},
+		{"malformed circle ID", member, http.MethodPost, "/api/v1/circles/abc/posts", http.StatusBadRequest},
+		{"anonymous", nil, http.MethodPost, "/api/v1/circles/1/posts", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, f.do(t, tt.method, tt.path, nil, tokens[tt.caller]).Code)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/fast-socialfi/backend/internal/database"
+	"github.com/fast-socialfi/backend/internal/middleware"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// login signs the wallet in from the given device
+func (f *fixture) login(t *testing.T, w *wallet, userAgent string) *service.LoginResponse {
+	resp, err := f.svc.Login(context.Background(), w.sign(t, w.message(f.nonce(t))), service.ClientInfo{
+		UserAgent: userAgent,
+		IPAddress: "203.0.113.7",
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+func (f *fixture) refresh(t *testing.T, refreshToken string) (*service.LoginResponse, int) {
+	w := f.do(t, http.MethodPost, "/api/v1/auth/refresh", &service.RefreshRequest{RefreshToken: refreshToken}, "")
+	if w.Code != http.StatusOK {
+		return nil, w.Code
+	}
+	var resp service.LoginResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return &resp, w.Code
+}
+
+func (f *fixture) authorized(t *testing.T, accessToken string) bool {
+	return f.do(t, http.MethodGet, "/api/v1/me", nil, accessToken).Code == http.StatusOK
+}
+
+func TestRefreshRotatesTokens(t *testing.T) {
+	f := setup(t)
+	first := f.login(t, newWallet(t), "phone")
+	assert.NotEmpty(t, first.SessionID)
+	assert.NotEmpty(t, first.RefreshToken)
+
+	second, code := f.refresh(t, first.RefreshToken)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, first.SessionID, second.SessionID)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.True(t, f.authorized(t, second.AccessToken))
+
+	// Replaying the rotated token looks like theft: the whole session ends,
+	// including the token the legitimate device holds now
+	_, code = f.refresh(t, first.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	_, code = f.refresh(t, second.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.False(t, f.authorized(t, second.AccessToken))
+
+	_, code = f.refresh(t, "not-a-token")
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestSessionsCanBeListedAndRevoked(t *testing.T) {
+	f := setup(t)
+	w := newWallet(t)
+	phone := f.login(t, w, "phone")
+	laptop := f.login(t, w, "laptop")
+	other := f.login(t, newWallet(t), "other")
+
+	resp := f.do(t, http.MethodGet, "/api/v1/auth/sessions", nil, laptop.AccessToken)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var sessions []service.SessionResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &sessions))
+	require.Len(t, sessions, 2)
+	for _, s := range sessions {
+		assert.Equal(t, s.SessionID == laptop.SessionID, s.Current)
+		assert.Equal(t, "203.0.113.7", s.IPAddress)
+		assert.False(t, s.LastSeenAt.IsZero())
+	}
+
+	// Another wallet's session cannot be touched
+	resp = f.do(t, http.MethodDelete, "/api/v1/auth/sessions/"+other.SessionID, nil, laptop.AccessToken)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.True(t, f.authorized(t, other.AccessToken))
+
+	// Killing the phone's session from the laptop locks the phone out
+	resp = f.do(t, http.MethodDelete, "/api/v1/auth/sessions/"+phone.SessionID, nil, laptop.AccessToken)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.False(t, f.authorized(t, phone.AccessToken))
+	_, code := f.refresh(t, phone.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.True(t, f.authorized(t, laptop.AccessToken))
+
+	tablet := f.login(t, w, "tablet")
+	resp = f.do(t, http.MethodPost, "/api/v1/auth/logout-all", nil, laptop.AccessToken)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var out service.LogoutAllResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+	assert.Equal(t, 2, out.RevokedSessions)
+	assert.False(t, f.authorized(t, laptop.AccessToken))
+	assert.False(t, f.authorized(t, tablet.AccessToken))
+	assert.True(t, f.authorized(t, other.AccessToken))
+
+	// Logging out only ends the calling session
+	resp = f.do(t, http.MethodPost, "/api/v1/auth/logout", nil, other.AccessToken)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.False(t, f.authorized(t, other.AccessToken))
+}
+
+func TestRedisRevocationListSharesLogouts(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := &database.RedisClient{Client: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	t.Cleanup(func() { client.Close() })
+
+	// Both replicas use the same secret, database and revocation list
+	a, b := setup(t), setup(t)
+	a.auth.SetRevocationList(middleware.NewRedisRevocationList(client))
+	b.auth.SetRevocationList(middleware.NewRedisRevocationList(client))
+
+	session := a.login(t, newWallet(t), "phone")
+	require.True(t, b.authorized(t, session.AccessToken))
+
+	resp := a.do(t, http.MethodPost, "/api/v1/auth/logout", nil, session.AccessToken)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.False(t, b.authorized(t, session.AccessToken))
+}
@@ -0,0 +1,238 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package comments_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/tests/testutil"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	db := testutil.OpenDB(t)
+	testutil.MigrateIndexer(t, db)
+	testutil.Migrate(t, db, &models.Post{}, &models.Comment{})
+	return db
+}
+
+func commentCount(t *testing.T, db *gorm.DB, postID uint64) uint {
+	var post models.Post
+	require.NoError(t, db.First(&post, "post_id = ?", postID).Error)
+	return post.CommentCount
+}
+
+func commentIDs(nodes []*service.CommentNode) []uint64 {
+	ids := make([]uint64, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.CommentID)
+	}
+	return ids
+}
+
+// TestComments builds a comment tree, reads it back in both orders with
+// depth limits and branch cursors, and soft-deletes within it
+func TestComments(t *testing.T) {
+	c := testutil.NewChain(t)
+	db := setupDB(t)
+	ctx := context.Background()
+	ix := indexer.NewIndexer(c.Svc, db, config.IndexerConfig{BatchSize: 100})
+
+	c.Mine(t, c.CreateCircle(t, "Alpha", "ALPHA"))
+	testutil.SyncToHead(t, ix)
+	circle := testutil.CircleByChainID(t, db, 1)
+
+	membershipSvc := service.NewMembershipService(
+		repository.NewCircleRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewCircleMemberRepository(db),
+		repository.NewMembershipRuleRepository(db),
+		c.Svc,
+	)
+	svc := service.NewCommentService(
+		repository.NewCommentRepository(db),
+		repository.NewPostRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewRelationshipRepository(db),
+		membershipSvc,
+	)
+
+	alice := models.User{WalletAddress: common.HexToAddress("0x00000000000000000000000000000000000a11ce").Hex()}
+	bob := common.HexToAddress("0x0000000000000000000000000000000000000b0b").Hex()
+	require.NoError(t, db.Create(&alice).Error)
+	post := testutil.CreatePost(t, db, alice.UserID, nil)
+
+	add := func(author string, parent *uint64, content string) *service.CommentNode {
+		node, err := svc.AddComment(ctx, post.PostID, author, &service.CreateCommentRequest{Content: content, ParentCommentID: parent})
+		require.NoError(t, err)
+		return node
+	}
+	// c1
+	// ├── c2 (bob)
+	// │   └── c4
+	// │       └── c5
+	// └── c3
+	// c6
+	// c7
+	c1 := add(alice.WalletAddress, nil, "first")
+	c2 := add(bob, &c1.CommentID, "reply from bob")
+	c3 := add(alice.WalletAddress, &c1.CommentID, "another reply")
+	c4 := add(alice.WalletAddress, &c2.CommentID, "deeper")
+	add(alice.WalletAddress, &c4.CommentID, "deepest")
+	c6 := add(alice.WalletAddress, nil, "second")
+	c7 := add(bob, nil, "  third  ")
+	assert.Equal(t, "third", c7.Content)
+	assert.Equal(t, bob, c7.Author)
+	assert.Equal(t, uint(7), commentCount(t, db, post.PostID))
+
+	for id, upvotes := range map[uint64]int{c1.CommentID: 2, c6.CommentID: 5, c7.CommentID: 5} {
+		require.NoError(t, db.Model(&models.Comment{}).Where("comment_id = ?", id).Update("upvotes", upvotes).Error)
+	}
+
+	t.Run("rejects bad comments", func(t *testing.T) {
+		_, err := svc.AddComment(ctx, post.PostID, bob, &service.CreateCommentRequest{Content: "   "})
+		assert.ErrorIs(t, err, service.ErrInvalidComment)
+		_, err = svc.AddComment(ctx, 999, bob, &service.CreateCommentRequest{Content: "hello?"})
+		assert.ErrorIs(t, err, service.ErrPostNotFound)
+
+		other := testutil.CreatePost(t, db, alice.UserID, nil)
+		_, err = svc.AddComment(ctx, other.PostID, bob, &service.CreateCommentRequest{Content: "wrong thread", ParentCommentID: &c1.CommentID})
+		assert.ErrorIs(t, err, service.ErrCommentNotFound)
+		assert.Equal(t, uint(0), commentCount(t, db, other.PostID))
+	})
+
+	t.Run("loads the top of the tree", func(t *testing.T) {
+		resp, err := svc.GetComments(ctx, post.PostID, &service.CommentQuery{Limit: 2, Replies: 1, Depth: 2})
+		require.NoError(t, err)
+		// Ties on upvotes go to the newer comment
+		assert.Equal(t, []uint64{c7.CommentID, c6.CommentID}, commentIDs(resp.Comments))
+		require.NotEmpty(t, resp.NextCursor)
+
+		resp, err = svc.GetComments(ctx, post.PostID, &service.CommentQuery{Cursor: resp.NextCursor, Limit: 2, Replies: 1, Depth: 2})
+		require.NoError(t, err)
+		require.Equal(t, []uint64{c1.CommentID}, commentIDs(resp.Comments))
+		assert.Empty(t, resp.NextCursor)
+
+		first := resp.Comments[0]
+		assert.Equal(t, 2, first.ReplyCount)
+		require.Equal(t, []uint64{c3.CommentID}, commentIDs(first.Replies))
+		assert.Equal(t, 0, first.Replies[0].ReplyCount)
+		assert.Empty(t, first.Replies[0].MoreReplies)
+		require.NotEmpty(t, first.MoreReplies)
+
+		// Loading more of the branch picks up after c3, and the depth limit
+		// leaves c4's reply behind a cursor of its own
+		more, err := svc.GetComments(ctx, post.PostID, &service.CommentQuery{Cursor: first.MoreReplies, Depth: 2})
+		require.NoError(t, err)
+		require.Equal(t, []uint64{c2.CommentID}, commentIDs(more.Comments))
+		require.Equal(t, []uint64{c4.CommentID}, commentIDs(more.Comments[0].Replies))
+		deeper := more.Comments[0].Replies[0]
+		assert.Equal(t, 1, deeper.ReplyCount)
+		assert.Empty(t, deeper.Replies)
+		require.NotEmpty(t, deeper.MoreReplies)
+
+		deepest, err := svc.GetComments(ctx, post.PostID, &service.CommentQuery{Cursor: deeper.MoreReplies})
+		require.NoError(t, err)
+		require.Len(t, deepest.Comments, 1)
+		assert.Equal(t, "deepest", deepest.Comments[0].Content)
+	})
+
+	t.Run("sorts newest first", func(t *testing.T) {
+		resp, err := svc.GetComments(ctx, post.PostID, &service.CommentQuery{Sort: "NEW", Depth: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{c7.CommentID, c6.CommentID, c1.CommentID}, commentIDs(resp.Comments))
+		assert.Equal(t, []uint64{c3.CommentID, c2.CommentID}, commentIDs(resp.Comments[2].Replies))
+		assert.Len(t, resp.Comments[2].Replies[1].Replies[0].Replies, 1)
+
+		_, err = svc.GetComments(ctx, post.PostID, &service.CommentQuery{Sort: "hot"})
+		assert.ErrorIs(t, err, service.ErrInvalidComment)
+		_, err = svc.GetComments(ctx, post.PostID, &service.CommentQuery{Cursor: "not-a-cursor"})
+		assert.ErrorIs(t, err, service.ErrInvalidComment)
+	})
+
+	t.Run("soft deletes keep the thread shape", func(t *testing.T) {
+		assert.ErrorIs(t, svc.DeleteComment(ctx, c2.CommentID, alice.WalletAddress), service.ErrNotCommentAuthor)
+		require.NoError(t, svc.DeleteComment(ctx, c2.CommentID, bob))
+		assert.Equal(t, uint(6), commentCount(t, db, post.PostID))
+		assert.ErrorIs(t, svc.DeleteComment(ctx, c2.CommentID, bob), service.ErrCommentNotFound)
+		assert.Equal(t, uint(6), commentCount(t, db, post.PostID))
+
+		_, err := svc.AddComment(ctx, post.PostID, alice.WalletAddress, &service.CreateCommentRequest{Content: "too late", ParentCommentID: &c2.CommentID})
+		assert.ErrorIs(t, err, service.ErrCommentNotFound)
+
+		resp, err := svc.GetComments(ctx, post.PostID, &service.CommentQuery{Sort: "new", Depth: 4})
+		require.NoError(t, err)
+		deleted := resp.Comments[2].Replies[1]
+		assert.Equal(t, c2.CommentID, deleted.CommentID)
+		assert.True(t, deleted.IsDeleted)
+		assert.Empty(t, deleted.Content)
+		assert.Empty(t, deleted.Author)
+		assert.Equal(t, []uint64{c4.CommentID}, commentIDs(deleted.Replies))
+		assert.Equal(t, "deeper", deleted.Replies[0].Content)
+	})
+
+	t.Run("gates comments on circle posts", func(t *testing.T) {
+		owner := c.Auth.From.Hex()
+		_, err := membershipSvc.JoinCircle(ctx, circle.ID, owner)
+		require.NoError(t, err)
+		ownerUser, err := repository.NewUserRepository(db).GetByAddress(ctx, owner)
+		require.NoError(t, err)
+		circlePost := testutil.CreatePost(t, db, ownerUser.UserID, &circle.ID)
+
+		_, err = svc.AddComment(ctx, circlePost.PostID, bob, &service.CreateCommentRequest{Content: "let me in"})
+		assert.ErrorIs(t, err, service.ErrNotCircleMember)
+		_, err = svc.AddComment(ctx, circlePost.PostID, owner, &service.CreateCommentRequest{Content: "members only"})
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), commentCount(t, db, circlePost.PostID))
+	})
+
+	t.Run("serves comments over HTTP", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		// Stands in for the auth middleware
+		router.Use(func(c *gin.Context) {
+			if address := c.GetHeader("X-Test-Address"); address != "" {
+				c.Set("user_address", address)
+			}
+		})
+		handler.NewCommentHandler(svc).RegisterRoutes(router.Group("/api/v1"))
+		do := func(method, path, body, address string) int {
+			req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			if address != "" {
+				req.Header.Set("X-Test-Address", address)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec.Code
+		}
+		path := fmt.Sprintf("/api/v1/posts/%d/comments", post.PostID)
+
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, path, `{"content":"hi"}`, ""))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, path, `{}`, bob))
+		assert.Equal(t, http.StatusCreated, do(http.MethodPost, path, `{"content":"hi"}`, bob))
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, path+"?sort=new&depth=2", "", ""))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, path+"?depth=0", "", ""))
+		assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/posts/999/comments", "", ""))
+		assert.Equal(t, http.StatusForbidden, do(http.MethodDelete, fmt.Sprintf("/api/v1/comments/%d", c7.CommentID), "", alice.WalletAddress))
+		assert.Equal(t, http.StatusOK, do(http.MethodDelete, fmt.Sprintf("/api/v1/comments/%d", c7.CommentID), "", bob))
+		assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, fmt.Sprintf("/api/v1/comments/%d", c7.CommentID), "", bob))
+	})
+}
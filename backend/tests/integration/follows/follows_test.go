@@ -0,0 +1,299 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package follows_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/ipfs"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/tests/testutil"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	db := testutil.OpenDB(t)
+	testutil.MigrateIndexer(t, db)
+	testutil.Migrate(t, db, &models.Post{}, &models.Comment{}, &models.UserRelationship{})
+	return db
+}
+
+func followCounts(t *testing.T, db *gorm.DB, userID uint64) (uint, uint) {
+	var user models.User
+	require.NoError(t, db.First(&user, "user_id = ?", userID).Error)
+	return user.FollowerCount, user.FollowingCount
+}
+
+func followAddresses(resp *service.FollowListResponse) []string {
+	addresses := make([]string, 0, len(resp.Users))
+	for _, user := range resp.Users {
+		addresses = append(addresses, user.WalletAddress)
+	}
+	return addresses
+}
+
+// TestFollows follows and unfollows users with counters kept in step, pages
+// follower, following and mutual lists, and blocks users, which severs their
+// follows and hides their posts and comments
+func TestFollows(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	node := testutil.NewFakeIPFS(t)
+
+	relRepo := repository.NewRelationshipRepository(db)
+	svc := service.NewFollowService(relRepo, repository.NewUserRepository(db))
+
+	users := make([]models.User, 4)
+	for i, address := range []string{
+		"0x00000000000000000000000000000000000a11ce",
+		"0x0000000000000000000000000000000000000b0b",
+		"0x00000000000000000000000000000000000ca401",
+		"0x00000000000000000000000000000000000da7e0",
+	} {
+		users[i] = models.User{WalletAddress: common.HexToAddress(address).Hex()}
+		require.NoError(t, db.Create(&users[i]).Error)
+	}
+	alice, bob, carol, dave := users[0], users[1], users[2], users[3]
+
+	t.Run("follows once and keeps both counters", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			resp, err := svc.Follow(ctx, bob.WalletAddress, alice.WalletAddress)
+			require.NoError(t, err)
+			assert.True(t, resp.Following)
+			assert.False(t, resp.FollowedBy)
+			assert.Equal(t, uint(1), resp.FollowerCount)
+		}
+		followers, _ := followCounts(t, db, alice.UserID)
+		_, following := followCounts(t, db, bob.UserID)
+		assert.Equal(t, uint(1), followers)
+		assert.Equal(t, uint(1), following)
+
+		var notifications []models.Notification
+		require.NoError(t, db.Where("user_id = ? AND notification_type = ?", alice.UserID, "NEW_FOLLOWER").Find(&notifications).Error)
+		require.Len(t, notifications, 1, "only the first follow notifies")
+		require.NotNil(t, notifications[0].RelatedUserID)
+		assert.Equal(t, bob.UserID, *notifications[0].RelatedUserID)
+
+		for i := 0; i < 2; i++ {
+			resp, err := svc.Unfollow(ctx, bob.WalletAddress, alice.WalletAddress)
+			require.NoError(t, err)
+			assert.False(t, resp.Following)
+			assert.Equal(t, uint(0), resp.FollowerCount)
+		}
+		_, following = followCounts(t, db, bob.UserID)
+		assert.Equal(t, uint(0), following)
+	})
+
+	t.Run("counts concurrent follows once", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := svc.Follow(ctx, dave.WalletAddress, carol.WalletAddress)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		followers, _ := followCounts(t, db, carol.UserID)
+		assert.Equal(t, uint(1), followers)
+		_, err := svc.Unfollow(ctx, dave.WalletAddress, carol.WalletAddress)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects bad follows", func(t *testing.T) {
+		_, err := svc.Follow(ctx, alice.WalletAddress, alice.WalletAddress)
+		assert.ErrorIs(t, err, service.ErrInvalidFollow)
+		_, err = svc.Block(ctx, alice.WalletAddress, alice.WalletAddress)
+		assert.ErrorIs(t, err, service.ErrInvalidFollow)
+		_, err = svc.Follow(ctx, alice.WalletAddress, "0x1234")
+		assert.ErrorIs(t, err, service.ErrInvalidFollow)
+		_, err = svc.Follow(ctx, alice.WalletAddress, "0x0000000000000000000000000000000000000404")
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+		_, err = svc.ListFollowers(ctx, alice.WalletAddress, &service.FollowQuery{Cursor: "abc"})
+		assert.ErrorIs(t, err, service.ErrInvalidFollow)
+	})
+
+	t.Run("pages followers, following and mutuals", func(t *testing.T) {
+		for _, follow := range [][2]models.User{
+			{bob, alice}, {carol, alice}, {dave, alice},
+			{alice, bob}, {alice, carol},
+		} {
+			_, err := svc.Follow(ctx, follow[0].WalletAddress, follow[1].WalletAddress)
+			require.NoError(t, err)
+		}
+
+		page, err := svc.ListFollowers(ctx, alice.WalletAddress, &service.FollowQuery{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{dave.WalletAddress, carol.WalletAddress}, followAddresses(page))
+		require.NotEmpty(t, page.NextCursor)
+		assert.False(t, page.Users[0].FollowedAt.IsZero())
+
+		page, err = svc.ListFollowers(ctx, alice.WalletAddress, &service.FollowQuery{Limit: 2, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		assert.Equal(t, []string{bob.WalletAddress}, followAddresses(page))
+		assert.Empty(t, page.NextCursor)
+
+		page, err = svc.ListFollowing(ctx, alice.WalletAddress, &service.FollowQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{carol.WalletAddress, bob.WalletAddress}, followAddresses(page))
+
+		page, err = svc.ListMutuals(ctx, alice.WalletAddress, &service.FollowQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{carol.WalletAddress, bob.WalletAddress}, followAddresses(page))
+		page, err = svc.ListMutuals(ctx, dave.WalletAddress, &service.FollowQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Users)
+
+		page, err = svc.ListFollowers(ctx, "0x0000000000000000000000000000000000000404", &service.FollowQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Users, "wallets without a profile have no followers")
+
+		followers, following := followCounts(t, db, alice.UserID)
+		assert.Equal(t, uint(3), followers)
+		assert.Equal(t, uint(2), following)
+	})
+
+	t.Run("blocking severs follows both ways and hides content", func(t *testing.T) {
+		resp, err := svc.Block(ctx, alice.WalletAddress, bob.WalletAddress)
+		require.NoError(t, err)
+		assert.True(t, resp.Blocking)
+		assert.False(t, resp.Following)
+		assert.False(t, resp.FollowedBy)
+
+		followers, following := followCounts(t, db, alice.UserID)
+		assert.Equal(t, uint(2), followers)
+		assert.Equal(t, uint(1), following)
+		followers, following = followCounts(t, db, bob.UserID)
+		assert.Equal(t, uint(0), followers)
+		assert.Equal(t, uint(0), following)
+
+		_, err = svc.Follow(ctx, bob.WalletAddress, alice.WalletAddress)
+		assert.ErrorIs(t, err, service.ErrUserBlocked)
+		_, err = svc.Follow(ctx, alice.WalletAddress, bob.WalletAddress)
+		assert.ErrorIs(t, err, service.ErrUserBlocked)
+		followed, err := relRepo.Follow(ctx, bob.UserID, alice.UserID, &models.Notification{})
+		require.NoError(t, err)
+		assert.False(t, followed, "the repository refuses a follow across a block")
+
+		// Posts by either side are left out of the other's feed
+		alicePost := testutil.CreatePost(t, db, alice.UserID, nil)
+		bobPost := testutil.CreatePost(t, db, bob.UserID, nil)
+		carolPost := testutil.CreatePost(t, db, carol.UserID, nil)
+		postSvc := service.NewPostService(
+			repository.NewPostRepository(db),
+			repository.NewUserRepository(db),
+			repository.NewCircleRepository(db),
+			nil,
+			ipfs.NewClient(node.Config()),
+		)
+		feedIDs := func(viewer string) []uint64 {
+			feed, err := postSvc.ListPosts(ctx, &service.PostQuery{Viewer: viewer})
+			require.NoError(t, err)
+			ids := make([]uint64, 0, len(feed.Posts))
+			for _, post := range feed.Posts {
+				ids = append(ids, post.PostID)
+			}
+			return ids
+		}
+		assert.Equal(t, []uint64{carolPost.PostID, alicePost.PostID}, feedIDs(alice.WalletAddress))
+		assert.Equal(t, []uint64{carolPost.PostID, bobPost.PostID}, feedIDs(bob.WalletAddress))
+		assert.Equal(t, []uint64{carolPost.PostID, bobPost.PostID, alicePost.PostID}, feedIDs(carol.WalletAddress))
+		assert.Equal(t, []uint64{carolPost.PostID, bobPost.PostID, alicePost.PostID}, feedIDs(""))
+
+		// Comments by either side keep their place in the thread but lose
+		// their author and content
+		commentSvc := service.NewCommentService(
+			repository.NewCommentRepository(db),
+			repository.NewPostRepository(db),
+			repository.NewUserRepository(db),
+			relRepo,
+			nil,
+		)
+		bobComment, err := commentSvc.AddComment(ctx, carolPost.PostID, bob.WalletAddress, &service.CreateCommentRequest{Content: "hi from bob"})
+		require.NoError(t, err)
+		_, err = commentSvc.AddComment(ctx, carolPost.PostID, alice.WalletAddress, &service.CreateCommentRequest{Content: "reply from alice", ParentCommentID: &bobComment.CommentID})
+		require.NoError(t, err)
+
+		thread, err := commentSvc.GetComments(ctx, carolPost.PostID, &service.CommentQuery{Viewer: alice.WalletAddress})
+		require.NoError(t, err)
+		require.Len(t, thread.Comments, 1)
+		assert.True(t, thread.Comments[0].IsHidden)
+		assert.Empty(t, thread.Comments[0].Author)
+		assert.Empty(t, thread.Comments[0].Content)
+		require.Len(t, thread.Comments[0].Replies, 1)
+		assert.False(t, thread.Comments[0].Replies[0].IsHidden)
+		assert.Equal(t, "reply from alice", thread.Comments[0].Replies[0].Content)
+
+		thread, err = commentSvc.GetComments(ctx, carolPost.PostID, &service.CommentQuery{Viewer: carol.WalletAddress})
+		require.NoError(t, err)
+		assert.False(t, thread.Comments[0].IsHidden)
+		assert.Equal(t, "hi from bob", thread.Comments[0].Content)
+		assert.Equal(t, "reply from alice", thread.Comments[0].Replies[0].Content)
+
+		// Unblocking allows follows again without restoring severed ones
+		resp, err = svc.Unblock(ctx, alice.WalletAddress, bob.WalletAddress)
+		require.NoError(t, err)
+		assert.False(t, resp.Blocking)
+		assert.False(t, resp.Following)
+		_, err = svc.Follow(ctx, bob.WalletAddress, alice.WalletAddress)
+		require.NoError(t, err)
+		followers, _ = followCounts(t, db, alice.UserID)
+		assert.Equal(t, uint(3), followers)
+		assert.Equal(t, []uint64{carolPost.PostID, bobPost.PostID, alicePost.PostID}, feedIDs(alice.WalletAddress))
+	})
+
+	t.Run("serves the follow graph over HTTP", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		// Stands in for the auth middleware
+		router.Use(func(c *gin.Context) {
+			if address := c.GetHeader("X-Test-Address"); address != "" {
+				c.Set("user_address", address)
+			}
+		})
+		handler.NewFollowHandler(svc).RegisterRoutes(router.Group("/api/v1"))
+		do := func(method, path, address string) int {
+			req := httptest.NewRequest(method, path, nil)
+			if address != "" {
+				req.Header.Set("X-Test-Address", address)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec.Code
+		}
+		carolPath := "/api/v1/users/" + carol.WalletAddress
+
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, carolPath+"/follow", ""))
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, carolPath+"/follow", dave.WalletAddress))
+		assert.Equal(t, http.StatusOK, do(http.MethodDelete, carolPath+"/follow", dave.WalletAddress))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, carolPath+"/follow", carol.WalletAddress))
+		assert.Equal(t, http.StatusNotFound, do(http.MethodPost, "/api/v1/users/0x0000000000000000000000000000000000000404/follow", dave.WalletAddress))
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, carolPath+"/block", dave.WalletAddress))
+		assert.Equal(t, http.StatusForbidden, do(http.MethodPost, carolPath+"/follow", dave.WalletAddress))
+		assert.Equal(t, http.StatusOK, do(http.MethodDelete, carolPath+"/block", dave.WalletAddress))
+
+		var page service.FollowListResponse
+		assert.Equal(t, http.StatusOK, testutil.GetJSON(t, router, "/api/v1/users/"+alice.WalletAddress+"/followers?limit=1", &page))
+		assert.Len(t, page.Users, 1)
+		assert.NotEmpty(t, page.NextCursor)
+		assert.Equal(t, http.StatusOK, testutil.GetJSON(t, router, "/api/v1/users/"+alice.WalletAddress+"/mutuals", &page))
+		assert.Equal(t, http.StatusOK, testutil.GetJSON(t, router, "/api/v1/users/"+alice.WalletAddress+"/following", &page))
+		assert.Equal(t, http.StatusBadRequest, testutil.GetJSON(t, router, "/api/v1/users/"+alice.WalletAddress+"/followers?limit=0", nil))
+		assert.Equal(t, http.StatusBadRequest, testutil.GetJSON(t, router, "/api/v1/users/"+alice.WalletAddress+"/following?cursor=x", nil))
+	})
+}
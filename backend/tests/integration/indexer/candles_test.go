@@ -0,0 +1,160 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// insertTrade stands in the row the indexer writes for a bonding curve trade,
+// since buys revert against the deployed CircleToken
+func insertTrade(t *testing.T, db *gorm.DB, circleID, traderID, block uint64, at time.Time, price, tokenAmount, ethAmount string) {
+	var count int64
+	require.NoError(t, db.Model(&models.Trade{}).Count(&count).Error)
+	require.NoError(t, db.Create(&models.Trade{
+		TxHash:      common.BigToHash(big.NewInt(count + 1)).Hex(),
+		TraderID:    traderID,
+		CircleID:    circleID,
+		TradeType:   "BUY",
+		TokenAmount: tokenAmount,
+		ETHAmount:   ethAmount,
+		Price:       price,
+		BlockNumber: block,
+		Timestamp:   at,
+	}).Error)
+}
+
+// assertCandle compares a candle with "open high low close tokens eth count"
+func assertCandle(t *testing.T, want string, candle *models.Candle) {
+	var open, high, low, closing, tokenVolume, ethVolume string
+	var count int
+	_, err := fmt.Sscan(want, &open, &high, &low, &closing, &tokenVolume, &ethVolume, &count)
+	require.NoError(t, err)
+
+	for _, field := range []struct{ want, got string }{
+		{open, candle.Open}, {high, candle.High}, {low, candle.Low}, {closing, candle.Close},
+		{tokenVolume, candle.TokenVolume}, {ethVolume, candle.ETHVolume},
+	} {
+		wantUnits, err := web3.ParseUnits(field.want, 18)
+		require.NoError(t, err)
+		gotUnits, err := web3.ParseUnits(field.got, 18)
+		require.NoError(t, err)
+		assert.Equal(t, wantUnits, gotUnits, "%s candle at %s: want %s, got %+v", candle.Interval, candle.OpenTime, want, candle)
+	}
+	assert.Equal(t, count, candle.TradeCount)
+}
+
+// TestCandles backfills candles from existing trades, extends them as new
+// blocks are indexed and rolls them back when those blocks are orphaned
+func TestCandles(t *testing.T) {
+	c := setupChain(t)
+	db := setupDB(t)
+	ctx := context.Background()
+	ix := indexer.NewIndexer(c.svc, db, config.IndexerConfig{
+		BatchSize:         100,
+		ConfirmationDepth: 2,
+	})
+
+	c.mine(t, c.createCircle(t, "Alpha", "ALPHA"))
+	syncToHead(t, ix)
+	circle := circleByChainID(t, db, 1)
+	trader := models.User{WalletAddress: common.HexToAddress("0x00000000000000000000000000000000000a11ce").Hex()}
+	require.NoError(t, db.Create(&trader).Error)
+
+	head, err := c.backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	block := head.Number.Uint64()
+
+	base := time.Date(2025, 2, 11, 17, 0, 0, 0, time.UTC)
+	insertTrade(t, db, circle.ID, trader.UserID, block, base.Add(10*time.Second), "1", "10", "10")
+	insertTrade(t, db, circle.ID, trader.UserID, block, base.Add(50*time.Second), "1.5", "5", "6")
+	insertTrade(t, db, circle.ID, trader.UserID, block, base.Add(3*time.Minute), "0.5", "4", "3")
+	insertTrade(t, db, circle.ID, trader.UserID, block, base.Add(7*time.Minute), "2", "1", "2")
+
+	require.NoError(t, ix.BackfillCandles(ctx))
+
+	svc := service.NewCandleService(repository.NewCircleRepository(db), repository.NewCandleRepository(db))
+	get := func(interval string) []*models.Candle {
+		resp, err := svc.GetCandles(ctx, circle.ID, &service.CandleQuery{
+			Interval: interval,
+			From:     base.Unix(),
+			To:       base.Add(2 * time.Hour).Unix(),
+		})
+		require.NoError(t, err)
+		return resp.Candles
+	}
+
+	minutes := get("1m")
+	require.Len(t, minutes, 3)
+	assert.Equal(t, base, minutes[0].OpenTime.UTC())
+	assertCandle(t, "1 1.5 1 1.5 15 16 2", minutes[0])
+	assertCandle(t, "0.5 0.5 0.5 0.5 4 3 1", minutes[1])
+	assertCandle(t, "2 2 2 2 1 2 1", minutes[2])
+
+	fives := get("5m")
+	require.Len(t, fives, 2)
+	assertCandle(t, "1 1.5 0.5 0.5 19 19 3", fives[0])
+	assert.Equal(t, base.Add(5*time.Minute), fives[1].OpenTime.UTC())
+	assertCandle(t, "2 2 2 2 1 2 1", fives[1])
+
+	hours := get("1h")
+	require.Len(t, hours, 1)
+	assertCandle(t, "1 2 0.5 2 20 21 4", hours[0])
+	days := get("1d")
+	require.Len(t, days, 1)
+	assert.Equal(t, time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC), days[0].OpenTime.UTC())
+	assertCandle(t, "1 2 0.5 2 20 21 4", days[0])
+
+	// Backfilling again leaves the candles unchanged
+	require.NoError(t, ix.BackfillCandles(ctx))
+	assert.Len(t, get("1m"), 3)
+	assertCandle(t, "1 2 0.5 2 20 21 4", get("1d")[0])
+
+	// A trade in the next indexed block extends the candles it falls in
+	forkPoint := head.Hash()
+	insertTrade(t, db, circle.ID, trader.UserID, block+1, base.Add(8*time.Minute), "3", "2", "6")
+	c.fund(t, common.HexToAddress(trader.WalletAddress))
+	syncToHead(t, ix)
+
+	minutes = get("1m")
+	require.Len(t, minutes, 4)
+	assertCandle(t, "3 3 3 3 2 6 1", minutes[3])
+	assertCandle(t, "2 3 2 3 3 8 2", get("5m")[1])
+	assertCandle(t, "1 3 0.5 3 22 27 5", get("1h")[0])
+
+	// Orphaning that block removes the trade from every interval
+	require.NoError(t, c.backend.Fork(ctx, forkPoint))
+	for i := 0; i < 3; i++ {
+		c.mine(t)
+	}
+	syncToHead(t, ix)
+
+	assert.Len(t, get("1m"), 3)
+	assertCandle(t, "2 2 2 2 1 2 1", get("5m")[1])
+	assertCandle(t, "1 2 0.5 2 20 21 4", get("1h")[0])
+	assertCandle(t, "1 2 0.5 2 20 21 4", get("1d")[0])
+
+	_, err = svc.GetCandles(ctx, circle.ID, &service.CandleQuery{Interval: "2m"})
+	assert.ErrorIs(t, err, service.ErrInvalidCandleQuery)
+	_, err = svc.GetCandles(ctx, circle.ID, &service.CandleQuery{Interval: "1m", From: base.Unix(), To: base.Add(24 * time.Hour).Unix()})
+	assert.ErrorIs(t, err, service.ErrInvalidCandleQuery, "a day of minutes exceeds the candle limit")
+	_, err = svc.GetCandles(ctx, circle.ID+100, &service.CandleQuery{Interval: "1h"})
+	assert.ErrorIs(t, err, service.ErrCircleNotFound)
+}
@@ -0,0 +1,114 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/realtime"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type publishedEvent struct {
+	channel string
+	event   string
+	data    interface{}
+}
+
+// recordingPublisher collects the events the indexer publishes
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []publishedEvent
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, channel, event string, data interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, publishedEvent{channel: channel, event: event, data: data})
+	return nil
+}
+
+func (p *recordingPublisher) take() []publishedEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	events := p.events
+	p.events = nil
+	return events
+}
+
+// TestIndexerPublishesTrades checks each indexed batch sends its trades and
+// the latest price of every traded circle to the real-time feed
+func TestIndexerPublishesTrades(t *testing.T) {
+	c := setupChain(t)
+	db := setupDB(t)
+	ctx := context.Background()
+	ix := indexer.NewIndexer(c.svc, db, config.IndexerConfig{BatchSize: 100})
+	publisher := &recordingPublisher{}
+	ix.SetPublisher(publisher)
+
+	c.mine(t, c.createCircle(t, "Alpha", "ALPHA"))
+	syncToHead(t, ix)
+	assert.Empty(t, publisher.take())
+
+	circle := circleByChainID(t, db, 1)
+	trader := models.User{WalletAddress: common.HexToAddress("0x00000000000000000000000000000000000a11ce").Hex()}
+	require.NoError(t, db.Create(&trader).Error)
+
+	head, err := c.backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	block := head.Number.Uint64()
+	at := time.Date(2025, 2, 11, 17, 0, 0, 0, time.UTC)
+	insertTrade(t, db, circle.ID, trader.UserID, block+1, at, "1", "10", "10")
+	insertTrade(t, db, circle.ID, trader.UserID, block+2, at.Add(time.Minute), "1.25", "4", "5")
+	c.fund(t, common.HexToAddress(trader.WalletAddress))
+	c.fund(t, common.HexToAddress(trader.WalletAddress))
+	syncToHead(t, ix)
+
+	events := publisher.take()
+	require.Len(t, events, 3)
+
+	for i, want := range []struct {
+		block uint64
+		price string
+	}{{block + 1, "1"}, {block + 2, "1.25"}} {
+		assert.Equal(t, realtime.CircleTradesChannel(circle.ID), events[i].channel)
+		assert.Equal(t, realtime.EventTrade, events[i].event)
+		trade, ok := events[i].data.(*realtime.TradeEvent)
+		require.True(t, ok)
+		assert.Equal(t, circle.ID, trade.CircleID)
+		assert.Equal(t, trader.WalletAddress, trade.Trader)
+		assert.Equal(t, want.block, trade.BlockNumber)
+		assertUnits(t, want.price, trade.Price)
+	}
+
+	assert.Equal(t, realtime.CirclePriceChannel(circle.ID), events[2].channel)
+	assert.Equal(t, realtime.EventPrice, events[2].event)
+	price, ok := events[2].data.(*realtime.PriceEvent)
+	require.True(t, ok)
+	assert.Equal(t, block+2, price.BlockNumber)
+	assertUnits(t, "1.25", price.Price)
+
+	// Batches without trades publish nothing
+	c.fund(t, common.HexToAddress(trader.WalletAddress))
+	syncToHead(t, ix)
+	assert.Empty(t, publisher.take())
+}
+
+func assertUnits(t *testing.T, want, got string) {
+	wantUnits, err := web3.ParseUnits(want, 18)
+	require.NoError(t, err)
+	gotUnits, err := web3.ParseUnits(got, 18)
+	require.NoError(t, err)
+	assert.Equal(t, wantUnits, gotUnits, "want %s, got %s", want, got)
+}
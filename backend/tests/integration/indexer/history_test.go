@@ -0,0 +1,186 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// historyStart is when the first seeded trade happened
+var historyStart = time.Date(2025, 2, 11, 17, 0, 0, 0, time.UTC)
+
+// seedTrade records a trade at the given offset from historyStart
+func seedTrade(t *testing.T, db *gorm.DB, tradeType string, circleID, traderID uint64, ethAmount string, offset time.Duration) {
+	var count int64
+	require.NoError(t, db.Model(&models.Trade{}).Count(&count).Error)
+	require.NoError(t, db.Create(&models.Trade{
+		TxHash:      common.BigToHash(big.NewInt(count + 1)).Hex(),
+		TraderID:    traderID,
+		CircleID:    circleID,
+		TradeType:   tradeType,
+		TokenAmount: "100",
+		ETHAmount:   ethAmount,
+		Price:       "0.001",
+		Fee:         "0",
+		BlockNumber: uint64(count + 1),
+		Timestamp:   historyStart.Add(offset),
+	}).Error)
+}
+
+// getJSON requests a path and decodes the JSON response
+func getJSON(t *testing.T, router http.Handler, path string, out interface{}) int {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if out != nil && rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out), rec.Body.String())
+	}
+	return rec.Code
+}
+
+// TestTradeHistory pages through a wallet's and a circle's trades with
+// keyset cursors and filters, and exports them
+func TestTradeHistory(t *testing.T) {
+	db := setupDB(t)
+	gin.SetMode(gin.TestMode)
+
+	alice := models.User{WalletAddress: common.HexToAddress("0x00000000000000000000000000000000000a11ce").Hex()}
+	bob := models.User{WalletAddress: common.HexToAddress("0x0000000000000000000000000000000000000b0b").Hex()}
+	require.NoError(t, db.Create(&alice).Error)
+	require.NoError(t, db.Create(&bob).Error)
+	alpha := models.Circle{Name: "Alpha", Symbol: "ALPHA", ChainCircleID: 1, TokenAddress: "0x0000000000000000000000000000000000000001", Status: "active"}
+	beta := models.Circle{Name: "Beta", Symbol: "BETA", ChainCircleID: 2, TokenAddress: "0x0000000000000000000000000000000000000002", Status: "active"}
+	require.NoError(t, db.Create(&alpha).Error)
+	require.NoError(t, db.Create(&beta).Error)
+
+	// Alice traded seven times, three of them in the same second so the
+	// cursor has to break ties on the trade ID
+	seedTrade(t, db, "BUY", alpha.ID, alice.UserID, "0.5", 0)
+	seedTrade(t, db, "BUY", alpha.ID, alice.UserID, "10", time.Minute)
+	seedTrade(t, db, "SELL", alpha.ID, alice.UserID, "2", time.Minute)
+	seedTrade(t, db, "BUY", beta.ID, alice.UserID, "5", time.Minute)
+	seedTrade(t, db, "SELL", beta.ID, alice.UserID, "1", 2*time.Minute)
+	seedTrade(t, db, "BUY", alpha.ID, alice.UserID, "20", 3*time.Minute)
+	seedTrade(t, db, "SELL", alpha.ID, alice.UserID, "3", 4*time.Minute)
+	seedTrade(t, db, "BUY", alpha.ID, bob.UserID, "7", 5*time.Minute)
+
+	router := gin.New()
+	svc := service.NewTradeHistoryService(
+		repository.NewCircleRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewTradeRepository(db),
+	)
+	handler.NewTradeHistoryHandler(svc).RegisterRoutes(router.Group("/api/v1"))
+	userPath := "/api/v1/users/" + strings.ToLower(alice.WalletAddress) + "/trades"
+
+	t.Run("pages newest first without gaps", func(t *testing.T) {
+		var ids []uint64
+		cursor := ""
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 10, "cursor never ran out")
+			var resp service.TradeHistoryResponse
+			require.Equal(t, http.StatusOK, getJSON(t, router, userPath+"?limit=2&cursor="+cursor, &resp))
+			assert.LessOrEqual(t, len(resp.Trades), 2)
+			for _, trade := range resp.Trades {
+				assert.Equal(t, alice.WalletAddress, trade.Trader)
+				ids = append(ids, trade.TradeID)
+			}
+			if resp.NextCursor == "" {
+				break
+			}
+			cursor = resp.NextCursor
+		}
+		assert.Equal(t, []uint64{7, 6, 5, 4, 3, 2, 1}, ids)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		var resp service.TradeHistoryResponse
+		require.Equal(t, http.StatusOK, getJSON(t, router, userPath+"?type=sell", &resp))
+		assert.Equal(t, []uint64{7, 5, 3}, tradeIDs(resp.Trades))
+
+		from := historyStart.Add(time.Minute).Unix()
+		to := historyStart.Add(3 * time.Minute).Unix()
+		require.Equal(t, http.StatusOK, getJSON(t, router, fmt.Sprintf("%s?from=%d&to=%d", userPath, from, to), &resp))
+		assert.Equal(t, []uint64{5, 4, 3, 2}, tradeIDs(resp.Trades))
+
+		// Amounts compare as numbers, so 10 and 20 pass and 5 does not
+		require.Equal(t, http.StatusOK, getJSON(t, router, userPath+"?min_size=5.5", &resp))
+		assert.Equal(t, []uint64{6, 2}, tradeIDs(resp.Trades))
+
+		require.Equal(t, http.StatusOK, getJSON(t, router, fmt.Sprintf("/api/v1/circles/%d/trades?type=BUY", alpha.ID), &resp))
+		assert.Equal(t, []uint64{8, 6, 2, 1}, tradeIDs(resp.Trades))
+		assert.Equal(t, "ALPHA", resp.Trades[0].CircleSymbol)
+		assert.Equal(t, bob.WalletAddress, resp.Trades[0].Trader)
+	})
+
+	t.Run("rejects bad queries", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, getJSON(t, router, userPath+"?cursor=bogus", nil))
+		assert.Equal(t, http.StatusBadRequest, getJSON(t, router, userPath+"?type=HOLD", nil))
+		assert.Equal(t, http.StatusBadRequest, getJSON(t, router, userPath+"?from=200&to=100", nil))
+		assert.Equal(t, http.StatusBadRequest, getJSON(t, router, userPath+"?min_size=lots", nil))
+		assert.Equal(t, http.StatusBadRequest, getJSON(t, router, userPath+"/export?format=xml", nil))
+		assert.Equal(t, http.StatusNotFound, getJSON(t, router, "/api/v1/circles/999/trades", nil))
+
+		var resp service.TradeHistoryResponse
+		require.Equal(t, http.StatusOK, getJSON(t, router, "/api/v1/users/0x000000000000000000000000000000000000dead/trades", &resp))
+		assert.Empty(t, resp.Trades)
+		assert.Empty(t, resp.NextCursor)
+	})
+
+	t.Run("exports CSV oldest first", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, userPath+"/export?type=BUY", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "trades-"+strings.ToLower(alice.WalletAddress)+".csv")
+
+		rows, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 5)
+		assert.Equal(t, "timestamp", rows[0][0])
+		var ids []string
+		for _, row := range rows[1:] {
+			ids = append(ids, row[1])
+			assert.Equal(t, "BUY", row[7])
+		}
+		assert.Equal(t, []string{"1", "2", "4", "6"}, ids)
+	})
+
+	t.Run("exports JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/circles/%d/trades/export?format=json", beta.ID), nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), fmt.Sprintf("trades-circle-%d.json", beta.ID))
+
+		var trades []*service.TradeEntry
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &trades))
+		assert.Equal(t, []uint64{4, 5}, tradeIDs(trades))
+	})
+}
+
+func tradeIDs(trades []*service.TradeEntry) []uint64 {
+	ids := make([]uint64, 0, len(trades))
+	for _, trade := range trades {
+		ids = append(ids, trade.TradeID)
+	}
+	return ids
+}
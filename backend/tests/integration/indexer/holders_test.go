@@ -0,0 +1,158 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// TestHolderRegistry indexes Transfer events into the circle's holder table
+// and checks a reorg rebuilds it from the transfers that survive
+func TestHolderRegistry(t *testing.T) {
+	c := setupChain(t)
+	db := setupDB(t)
+	ctx := context.Background()
+	ix := indexer.NewIndexer(c.svc, db, config.IndexerConfig{
+		BatchSize:         100,
+		ConfirmationDepth: 2,
+	})
+
+	c.mine(t, c.createCircle(t, "Alpha", "ALPHA"))
+	syncToHead(t, ix)
+	circle := circleByChainID(t, db, 1)
+	token, err := contracts.NewCircleToken(common.HexToAddress(circle.TokenAddress), c.backend)
+	require.NoError(t, err)
+	supply, err := token.TotalSupply(nil)
+	require.NoError(t, err)
+
+	svc := service.NewHolderService(repository.NewCircleRepository(db), repository.NewHolderRepository(db))
+	owner := c.auth.From.Hex()
+
+	// The founder allocation is minted to the owner at no cost
+	resp, err := svc.GetTopHolders(ctx, circle.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.HolderCount)
+	require.Len(t, resp.Holders, 1)
+	assert.Equal(t, owner, resp.Holders[0].HolderAddress)
+	assert.InDelta(t, 1.0, resp.Holders[0].Share, 1e-9)
+	assert.InDelta(t, 1.0, resp.Top10Share, 1e-9)
+	assert.InDelta(t, 0.0, resp.GiniCoefficient, 1e-9)
+	cost, err := web3.ParseUnits(resp.Holders[0].CostBasis, 18)
+	require.NoError(t, err)
+	assert.Zero(t, cost.Sign())
+
+	alice := common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	bob := common.HexToAddress("0x0000000000000000000000000000000000000b0b")
+	forkPoint := c.mine(t, mustTransfer(t, c, token, alice, 300))
+	c.mine(t, mustTransfer(t, c, token, bob, 100))
+	syncToHead(t, ix)
+
+	resp, err = svc.GetTopHolders(ctx, circle.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.HolderCount)
+	require.Len(t, resp.Holders, 3)
+	assert.Equal(t, []string{owner, alice.Hex(), bob.Hex()}, holderAddresses(resp.Holders))
+	total, err := web3.ParseUnits(resp.TotalSupply, 18)
+	require.NoError(t, err)
+	assert.Equal(t, supply, total, "transfers must not change the supply")
+	assert.Greater(t, resp.GiniCoefficient, 0.0)
+	assert.InDelta(t, 1.0, resp.Top10Share, 1e-9)
+
+	page, err := svc.GetTopHolders(ctx, circle.ID, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page.Holders, 1)
+	assert.Equal(t, alice.Hex(), page.Holders[0].HolderAddress)
+
+	// Replaying the same blocks must not double count
+	var cursor models.IndexerCursor
+	require.NoError(t, db.First(&cursor, "name = ?", indexer.IndexerCursorName).Error)
+	require.NoError(t, db.Model(&cursor).Update("block_number", 0).Error)
+	syncToHead(t, ix)
+	assert.Equal(t, tokens(300), holderBalance(t, db, circle.ID, alice))
+
+	// Orphan bob's transfer and replace it with one to carol
+	require.NoError(t, c.backend.Fork(ctx, forkPoint))
+	carol := common.HexToAddress("0x00000000000000000000000000000000000ca201")
+	carolTx := mustTransfer(t, c, token, carol, 50)
+	for i := 0; i < 3; i++ {
+		c.mine(t)
+	}
+	c.requireSuccess(t, carolTx)
+	syncToHead(t, ix)
+
+	resp, err = svc.GetTopHolders(ctx, circle.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{owner, alice.Hex(), carol.Hex()}, holderAddresses(resp.Holders))
+	assert.Equal(t, 3, resp.HolderCount)
+	assert.Equal(t, tokens(50), holderBalance(t, db, circle.ID, carol))
+	ownerBalance, err := token.BalanceOf(nil, c.auth.From)
+	require.NoError(t, err)
+	assert.Equal(t, ownerBalance, holderBalance(t, db, circle.ID, c.auth.From))
+
+	var orphaned int64
+	require.NoError(t, db.Model(&models.CircleHolder{}).Where("holder_address = ?", bob.Hex()).Count(&orphaned).Error)
+	assert.Zero(t, orphaned)
+
+	// A holder who sends back their whole balance drops out of the table
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	dave := crypto.PubkeyToAddress(key.PublicKey)
+	daveAuth, err := bind.NewKeyedTransactorWithChainID(key, c.backend.Blockchain().Config().ChainID)
+	require.NoError(t, err)
+	c.fund(t, dave)
+	c.mine(t, mustTransfer(t, c, token, dave, 20))
+	syncToHead(t, ix)
+	assert.Equal(t, tokens(20), holderBalance(t, db, circle.ID, dave))
+
+	returned, err := token.Transfer(daveAuth, c.auth.From, tokens(20))
+	require.NoError(t, err)
+	c.mine(t, returned)
+	syncToHead(t, ix)
+	_, err = repository.NewHolderRepository(db).Get(ctx, circle.ID, dave.Hex())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Equal(t, 3, circleByChainID(t, db, 1).HolderCount)
+
+	_, err = svc.GetTopHolders(ctx, circle.ID+100, 10, 0)
+	assert.ErrorIs(t, err, service.ErrCircleNotFound)
+}
+
+func mustTransfer(t *testing.T, c *chain, token *contracts.CircleToken, to common.Address, amount int64) *types.Transaction {
+	tx, err := token.Transfer(c.auth, to, tokens(amount))
+	require.NoError(t, err)
+	return tx
+}
+
+func holderAddresses(holders []*service.HolderEntry) []string {
+	addresses := make([]string, 0, len(holders))
+	for _, holder := range holders {
+		addresses = append(addresses, holder.HolderAddress)
+	}
+	return addresses
+}
+
+func holderBalance(t *testing.T, db *gorm.DB, circleID uint64, holder common.Address) *big.Int {
+	row, err := repository.NewHolderRepository(db).Get(context.Background(), circleID, holder.Hex())
+	require.NoError(t, err)
+	balance, err := web3.ParseUnits(row.Balance, 18)
+	require.NoError(t, err)
+	return balance
+}
@@ -0,0 +1,143 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sign signs a transaction prepared for a wallet with the chain's account
+func (c *chain) sign(t *testing.T, utx *web3.UnsignedTx) string {
+	tip, _ := new(big.Int).SetString(utx.MaxPriorityFeePerGas, 10)
+	feeCap, _ := new(big.Int).SetString(utx.MaxFeePerGas, 10)
+	value, _ := new(big.Int).SetString(utx.Value, 10)
+	to := common.HexToAddress(utx.To)
+
+	tx, err := c.auth.Signer(c.auth.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   c.backend.Blockchain().Config().ChainID,
+		Nonce:     utx.Nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       utx.Gas,
+		To:        &to,
+		Value:     value,
+		Data:      hexutil.MustDecode(utx.Data),
+	}))
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return hexutil.Encode(raw)
+}
+
+// TestCircleLifecycle takes a circle through owner-signed deactivation and
+// ownership transfer and checks the audit trail records who made each change.
+// The reconciler only settles the transaction rows; the circle changes when
+// the indexer reaches the block.
+func TestCircleLifecycle(t *testing.T) {
+	c := setupChain(t)
+	db := setupReconcilerDB(t)
+	ctx := context.Background()
+	rc := indexer.NewReconciler(c.svc, db, config.ReconcilerConfig{
+		DropTimeout:       time.Minute,
+		ConfirmationDepth: 2,
+	})
+	ix := indexer.NewIndexer(c.svc, db, config.IndexerConfig{BatchSize: 100})
+
+	c.mine(t, c.createCircle(t, "Alpha", "ALPHA"))
+	c.mine(t, c.createCircle(t, "Beta", "BETA"))
+	syncToHead(t, ix)
+
+	owner := c.auth.From.Hex()
+	circle := circleByChainID(t, db, 1)
+	require.True(t, circle.Active)
+
+	svc := service.NewCircleService(
+		repository.NewCircleRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewTransactionRepository(db),
+		repository.NewCircleEventRepository(db),
+		c.svc,
+	)
+
+	stranger := "0x00000000000000000000000000000000000c0de1"
+	_, err := svc.PrepareDeactivateCircle(ctx, circle.ID, &service.CircleLifecycleRequest{FromAddress: stranger})
+	assert.ErrorIs(t, err, service.ErrNotCircleOwner)
+	_, err = svc.PrepareReactivateCircle(ctx, circle.ID, &service.CircleLifecycleRequest{FromAddress: owner})
+	assert.ErrorIs(t, err, service.ErrCircleStateConflict)
+	_, err = svc.PrepareDeactivateCircle(ctx, circle.ID+100, &service.CircleLifecycleRequest{FromAddress: owner})
+	assert.ErrorIs(t, err, service.ErrCircleNotFound)
+
+	// A transaction for another circle the owner holds is refused
+	other, err := c.svc.PrepareDeactivateCircle(ctx, c.auth.From, big.NewInt(2))
+	require.NoError(t, err)
+	_, err = svc.SubmitCircleLifecycle(ctx, circle.ID, &service.SubmitCircleLifecycleRequest{
+		SignedTx:    c.sign(t, other),
+		FromAddress: owner,
+	})
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+
+	prepared, err := svc.PrepareDeactivateCircle(ctx, circle.ID, &service.CircleLifecycleRequest{FromAddress: owner})
+	require.NoError(t, err)
+	submitted, err := svc.SubmitCircleLifecycle(ctx, circle.ID, &service.SubmitCircleLifecycleRequest{
+		SignedTx:    c.sign(t, prepared.Transaction),
+		FromAddress: owner,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "deactivate_circle", submitted.Action)
+
+	c.mine(t)
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	assert.Equal(t, "mined", transactionByHash(t, db, common.HexToHash(submitted.TxHash)).Status)
+	assert.True(t, circleByChainID(t, db, 1).Active, "the reconciler leaves the circle to the indexer")
+
+	syncToHead(t, ix)
+	assert.False(t, circleByChainID(t, db, 1).Active)
+
+	newOwner := common.HexToAddress("0x00000000000000000000000000000000000b0b01")
+	prepared, err = svc.PrepareTransferCircleOwnership(ctx, circle.ID, &service.TransferCircleOwnershipRequest{
+		NewOwner:    newOwner.Hex(),
+		FromAddress: owner,
+	})
+	require.NoError(t, err)
+	submitted, err = svc.SubmitCircleLifecycle(ctx, circle.ID, &service.SubmitCircleLifecycleRequest{
+		SignedTx:    c.sign(t, prepared.Transaction),
+		FromAddress: owner,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "transfer_circle_ownership", submitted.Action)
+
+	c.mine(t)
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	syncToHead(t, ix)
+	assert.Equal(t, newOwner.Hex(), circleByChainID(t, db, 1).OwnerAddress)
+
+	events, err := svc.GetCircleEvents(ctx, circle.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, "OWNERSHIP_TRANSFERRED", events[0].EventType)
+	assert.Equal(t, owner, events[0].ActorAddress)
+	assert.Equal(t, newOwner.Hex(), events[0].OwnerAddress)
+	assert.Equal(t, "DEACTIVATED", events[1].EventType)
+	assert.Equal(t, owner, events[1].ActorAddress)
+	assert.Equal(t, "CREATED", events[2].EventType)
+
+	// The previous owner has lost control of the circle
+	_, err = svc.PrepareReactivateCircle(ctx, circle.ID, &service.CircleLifecycleRequest{FromAddress: owner})
+	assert.ErrorIs(t, err, service.ErrNotCircleOwner)
+}
@@ -0,0 +1,169 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// tokens converts whole circle tokens to base units
+func tokens(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+// fund sends a wallet enough ETH from the chain's account to pay for gas
+func (c *chain) fund(t *testing.T, to common.Address) {
+	ctx := context.Background()
+	nonce, err := c.backend.PendingNonceAt(ctx, c.auth.From)
+	require.NoError(t, err)
+	head, err := c.backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	tx, err := c.auth.Signer(c.auth.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   c.backend.Blockchain().Config().ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: new(big.Int).Mul(head.BaseFee, big.NewInt(2)),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+	}))
+	require.NoError(t, err)
+	require.NoError(t, c.backend.SendTransaction(ctx, tx))
+	c.mine(t, tx)
+}
+
+func memberOf(t *testing.T, db *gorm.DB, userID, circleID uint64) *models.UserCircleRelationship {
+	member, err := repository.NewCircleMemberRepository(db).GetByUserAndCircle(context.Background(), userID, circleID)
+	require.NoError(t, err)
+	return member
+}
+
+// TestTokenGatedMembership gates joining and posting on the circle token
+// balance and checks the indexer demotes a member who sells below the minimum
+func TestTokenGatedMembership(t *testing.T) {
+	c := setupChain(t)
+	db := setupDB(t)
+	ctx := context.Background()
+	ix := indexer.NewIndexer(c.svc, db, config.IndexerConfig{
+		BatchSize:         100,
+		ConfirmationDepth: 2,
+	})
+
+	c.mine(t, c.createCircle(t, "Alpha", "ALPHA"))
+	syncToHead(t, ix)
+	circle := circleByChainID(t, db, 1)
+	token, err := contracts.NewCircleToken(common.HexToAddress(circle.TokenAddress), c.backend)
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	holder := crypto.PubkeyToAddress(key.PublicKey)
+	holderAuth, err := bind.NewKeyedTransactorWithChainID(key, c.backend.Blockchain().Config().ChainID)
+	require.NoError(t, err)
+	c.fund(t, holder)
+
+	svc := service.NewMembershipService(
+		repository.NewCircleRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewCircleMemberRepository(db),
+		repository.NewMembershipRuleRepository(db),
+		c.svc,
+	)
+	owner := c.auth.From.Hex()
+
+	_, err = svc.SetMembershipRule(ctx, circle.ID, &service.SetMembershipRuleRequest{MinBalance: "10", FromAddress: holder.Hex()})
+	assert.ErrorIs(t, err, service.ErrNotCircleOwner)
+	_, err = svc.SetMembershipRule(ctx, circle.ID, &service.SetMembershipRuleRequest{MinBalance: "1.5e3", FromAddress: owner})
+	assert.ErrorIs(t, err, service.ErrInvalidMembershipRule)
+	_, err = svc.SetMembershipRule(ctx, circle.ID, &service.SetMembershipRuleRequest{MinBalance: "10", FromAddress: owner})
+	require.NoError(t, err)
+
+	_, err = svc.JoinCircle(ctx, circle.ID, holder.Hex())
+	assert.ErrorIs(t, err, service.ErrMembershipRequirement)
+
+	transfer, err := token.Transfer(c.auth, holder, tokens(50))
+	require.NoError(t, err)
+	c.mine(t, transfer)
+
+	joined, err := svc.JoinCircle(ctx, circle.ID, holder.Hex())
+	require.NoError(t, err)
+	assert.True(t, joined.CanPost)
+	_, err = svc.JoinCircle(ctx, circle.ID, holder.Hex())
+	assert.ErrorIs(t, err, service.ErrAlreadyCircleMember)
+	_, err = svc.AuthorizePost(ctx, circle.ID, holder.Hex())
+	require.NoError(t, err)
+
+	// Selling below the minimum makes the member read-only once indexed
+	transfer, err = token.Transfer(holderAuth, c.auth.From, tokens(45))
+	require.NoError(t, err)
+	c.mine(t, transfer)
+	syncToHead(t, ix)
+
+	demoted := memberOf(t, db, joined.UserID, circle.ID)
+	assert.False(t, demoted.CanPost)
+	assert.True(t, demoted.GateDemoted)
+	assert.Nil(t, demoted.HeldSince)
+	balance, err := web3.ParseUnits(demoted.TokenBalance, 18)
+	require.NoError(t, err)
+	assert.Equal(t, tokens(5), balance)
+	_, err = svc.AuthorizePost(ctx, circle.ID, holder.Hex())
+	assert.ErrorIs(t, err, service.ErrMembershipRequirement)
+
+	// Topping the balance back up restores posting
+	transfer, err = token.Transfer(c.auth, holder, tokens(10))
+	require.NoError(t, err)
+	c.mine(t, transfer)
+	syncToHead(t, ix)
+
+	restored := memberOf(t, db, joined.UserID, circle.ID)
+	assert.True(t, restored.CanPost)
+	assert.False(t, restored.GateDemoted)
+
+	// A hold period applies to the time since the balance last met the minimum
+	_, err = svc.SetMembershipRule(ctx, circle.ID, &service.SetMembershipRuleRequest{MinBalance: "10", MinHoldSeconds: 3600, FromAddress: owner})
+	require.NoError(t, err)
+	assert.False(t, memberOf(t, db, joined.UserID, circle.ID).CanPost)
+	_, err = svc.AuthorizePost(ctx, circle.ID, holder.Hex())
+	assert.ErrorIs(t, err, service.ErrMembershipRequirement)
+
+	require.NoError(t, db.Model(&models.UserCircleRelationship{}).
+		Where("uc_relationship_id = ?", joined.UCRelationshipID).
+		Update("held_since", time.Now().Add(-2*time.Hour)).Error)
+	_, err = svc.AuthorizePost(ctx, circle.ID, holder.Hex())
+	require.NoError(t, err)
+
+	// A moderator's mute is not lifted by the balance
+	require.NoError(t, db.Model(&models.UserCircleRelationship{}).
+		Where("uc_relationship_id = ?", joined.UCRelationshipID).
+		Update("can_post", false).Error)
+	_, err = svc.AuthorizePost(ctx, circle.ID, holder.Hex())
+	assert.ErrorIs(t, err, service.ErrPostingRestricted)
+
+	// The owner is never gated
+	ownerMember, err := svc.JoinCircle(ctx, circle.ID, owner)
+	require.NoError(t, err)
+	assert.Equal(t, "OWNS", ownerMember.RelationshipType)
+	_, err = svc.AuthorizePost(ctx, circle.ID, owner)
+	require.NoError(t, err)
+}
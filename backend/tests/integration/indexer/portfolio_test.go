@@ -0,0 +1,161 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/pnl"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// recordTrade stands in a trade the indexer would have written, like
+// insertTrade but for either side
+func recordTrade(t *testing.T, db *gorm.DB, tradeType string, circleID, traderID uint64, tokenAmount, ethAmount string) {
+	var count int64
+	require.NoError(t, db.Model(&models.Trade{}).Count(&count).Error)
+	require.NoError(t, db.Create(&models.Trade{
+		TxHash:      common.BigToHash(big.NewInt(count + 1)).Hex(),
+		TraderID:    traderID,
+		CircleID:    circleID,
+		TradeType:   tradeType,
+		TokenAmount: tokenAmount,
+		ETHAmount:   ethAmount,
+		Price:       "0.001",
+		BlockNumber: uint64(count + 1),
+		Timestamp:   time.Date(2025, 2, 11, 17, 0, int(count), 0, time.UTC),
+	}).Error)
+}
+
+func eth(t *testing.T, value string) *big.Int {
+	amount, err := web3.ParseUnits(value, 18)
+	require.NoError(t, err)
+	return amount
+}
+
+// assertETH compares a decimal amount with an expected amount in wei
+func assertETH(t *testing.T, want *big.Int, got string, field string) {
+	t.Helper()
+	assert.Equal(t, want.String(), eth(t, got).String(), field)
+}
+
+// TestPortfolio values a wallet's positions at the curve price with cost
+// basis from its trades, and snapshots every holder's portfolio
+func TestPortfolio(t *testing.T) {
+	c := setupChain(t)
+	db := setupDB(t)
+	ctx := context.Background()
+	ix := indexer.NewIndexer(c.svc, db, config.IndexerConfig{BatchSize: 100})
+
+	c.mine(t, c.createCircle(t, "Alpha", "ALPHA"))
+	syncToHead(t, ix)
+	circle := circleByChainID(t, db, 1)
+	tokenAddress := common.HexToAddress(circle.TokenAddress)
+	token, err := contracts.NewCircleToken(tokenAddress, c.backend)
+	require.NoError(t, err)
+
+	alice := models.User{WalletAddress: common.HexToAddress("0x00000000000000000000000000000000000a11ce").Hex()}
+	bob := models.User{WalletAddress: common.HexToAddress("0x0000000000000000000000000000000000000b0b").Hex()}
+	require.NoError(t, db.Create(&alice).Error)
+	require.NoError(t, db.Create(&bob).Error)
+
+	// Alice bought 400 tokens in two lots and sold 100, leaving the 300 the
+	// chain shows her holding
+	c.mine(t, mustTransfer(t, c, token, common.HexToAddress(alice.WalletAddress), 300))
+	syncToHead(t, ix)
+	recordTrade(t, db, "BUY", circle.ID, alice.UserID, "200", "0.1")
+	recordTrade(t, db, "BUY", circle.ID, alice.UserID, "200", "0.3")
+	recordTrade(t, db, "SELL", circle.ID, alice.UserID, "100", "0.2")
+	// Bob sold out, so the holder table no longer lists him
+	recordTrade(t, db, "BUY", circle.ID, bob.UserID, "10", "1")
+	recordTrade(t, db, "SELL", circle.ID, bob.UserID, "10", "3")
+
+	price, err := c.svc.GetCurrentPrice(ctx, tokenAddress)
+	require.NoError(t, err)
+	value := pnl.TokenValue(tokens(300), price)
+
+	svc := service.NewPortfolioService(
+		repository.NewCircleRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewHolderRepository(db),
+		repository.NewTradeRepository(db),
+		repository.NewPortfolioSnapshotRepository(db),
+		c.svc,
+	)
+
+	resp, err := svc.GetPortfolio(ctx, strings.ToLower(alice.WalletAddress))
+	require.NoError(t, err)
+	assert.Equal(t, alice.WalletAddress, resp.Address)
+	require.Len(t, resp.Positions, 1)
+	position := resp.Positions[0]
+	assert.Equal(t, circle.ID, position.CircleID)
+	assert.Equal(t, "ALPHA", position.Symbol)
+	assertETH(t, tokens(300), position.Balance, "balance")
+	assertETH(t, price, position.Price, "price")
+	assertETH(t, value, position.Value, "value")
+	assertETH(t, value, resp.TotalValue, "total value")
+
+	// FIFO sells from the 0.0005 ETH lot; average cost at 0.001 ETH a token
+	assertETH(t, eth(t, "0.35"), position.FIFO.CostBasis, "FIFO cost basis")
+	assertETH(t, eth(t, "0.15"), position.FIFO.RealizedPnL, "FIFO realized")
+	assertETH(t, new(big.Int).Sub(value, eth(t, "0.35")), position.FIFO.UnrealizedPnL, "FIFO unrealized")
+	assertETH(t, new(big.Int).Sub(value, eth(t, "0.2")), position.FIFO.TotalPnL, "FIFO total")
+	assertETH(t, eth(t, "0.3"), position.AverageCost.CostBasis, "average cost basis")
+	assertETH(t, eth(t, "0.1"), position.AverageCost.RealizedPnL, "average realized")
+	assertETH(t, new(big.Int).Sub(value, eth(t, "0.3")), position.AverageCost.UnrealizedPnL, "average unrealized")
+	assert.Equal(t, position.FIFO, resp.FIFO)
+	assert.Equal(t, position.AverageCost, resp.AverageCost)
+
+	// A sold out position has no value but keeps its realized gain
+	resp, err = svc.GetPortfolio(ctx, bob.WalletAddress)
+	require.NoError(t, err)
+	require.Len(t, resp.Positions, 1)
+	assertETH(t, big.NewInt(0), resp.Positions[0].Balance, "balance")
+	assertETH(t, big.NewInt(0), resp.TotalValue, "total value")
+	assertETH(t, eth(t, "2"), resp.FIFO.RealizedPnL, "FIFO realized")
+	assertETH(t, eth(t, "2"), resp.AverageCost.TotalPnL, "average total")
+
+	_, err = svc.GetPortfolio(ctx, "not-an-address")
+	assert.ErrorIs(t, err, service.ErrInvalidPortfolioQuery)
+
+	// Snapshots cover every holder and replace earlier ones for the same time
+	at := time.Date(2025, 2, 11, 18, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		taken, err := svc.SnapshotAll(ctx, at)
+		require.NoError(t, err)
+		assert.Equal(t, 2, taken)
+	}
+
+	history, err := svc.GetHistory(ctx, alice.WalletAddress, at.Add(-time.Hour).Unix(), at.Add(time.Hour).Unix())
+	require.NoError(t, err)
+	require.Len(t, history.Snapshots, 1)
+	snapshot := history.Snapshots[0]
+	assert.Equal(t, at, snapshot.SnapshotAt.UTC())
+	assertETH(t, value, snapshot.TotalValue, "snapshot value")
+	assertETH(t, eth(t, "0.3"), snapshot.CostBasis, "snapshot cost basis")
+	assertETH(t, eth(t, "0.1"), snapshot.RealizedPnL, "snapshot realized")
+	assert.Equal(t, 1, snapshot.PositionCount)
+
+	history, err = svc.GetHistory(ctx, bob.WalletAddress, at.Add(-time.Hour).Unix(), at.Add(time.Hour).Unix())
+	require.NoError(t, err)
+	assert.Empty(t, history.Snapshots)
+
+	_, err = svc.GetHistory(ctx, alice.WalletAddress, at.Unix(), at.Unix())
+	assert.ErrorIs(t, err, service.ErrInvalidPortfolioQuery)
+}
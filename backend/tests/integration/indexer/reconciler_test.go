@@ -0,0 +1,308 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupReconcilerDB adds the replacement history the reconciler reads to
+// the indexer tables
+func setupReconcilerDB(t *testing.T) *gorm.DB {
+	db := setupDB(t)
+	require.NoError(t, db.AutoMigrate(&models.TransactionReplacement{}))
+	return db
+}
+
+// transfer sends a plain value transfer; the reconciler only looks at
+// receipts, so it can stand in for a trade submitted through the API
+func (c *chain) transfer(t *testing.T, to common.Address) *types.Transaction {
+	ctx := context.Background()
+	nonce, err := c.backend.PendingNonceAt(ctx, c.auth.From)
+	require.NoError(t, err)
+	head, err := c.backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	tx, err := c.auth.Signer(c.auth.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   c.backend.Blockchain().Config().ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: new(big.Int).Add(big.NewInt(1e9), new(big.Int).Mul(head.BaseFee, big.NewInt(2))),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1e15),
+	}))
+	require.NoError(t, err)
+	require.NoError(t, c.backend.SendTransaction(ctx, tx))
+	return tx
+}
+
+// TestReconcilerSettlesSubmittedTransactions follows rows recorded at
+// submission time through mined, confirmed and dropped
+func TestReconcilerSettlesSubmittedTransactions(t *testing.T) {
+	c := setupChain(t)
+	db := setupReconcilerDB(t)
+	rc := indexer.NewReconciler(c.svc, db, config.ReconcilerConfig{
+		DropTimeout:       time.Minute,
+		ConfirmationDepth: 2,
+	})
+	ctx := context.Background()
+
+	trader := models.User{WalletAddress: c.auth.From.Hex()}
+	require.NoError(t, db.Create(&trader).Error)
+
+	buyTx := c.transfer(t, common.HexToAddress("0x00000000000000000000000000000000000c0de1"))
+	require.NoError(t, db.Create(&models.Transaction{
+		TxHash:      buyTx.Hash().Hex(),
+		TxType:      "buy",
+		FromAddress: c.auth.From.Hex(),
+		Status:      "pending",
+		Timestamp:   time.Now(),
+	}).Error)
+
+	// Never broadcast and submitted long enough ago to count as dropped
+	lostHash := common.HexToHash("0x1057").Hex()
+	require.NoError(t, db.Create(&models.Transaction{
+		TxHash:      lostHash,
+		TxType:      "create_circle",
+		FromAddress: c.auth.From.Hex(),
+		Status:      "pending",
+		Timestamp:   time.Now().Add(-time.Hour),
+	}).Error)
+	require.NoError(t, db.Create(&models.Circle{
+		Name:          "Lost",
+		Symbol:        "LOST",
+		ChainCircleID: 99,
+		TokenAddress:  "0x000000000000000000000000000000000000dead",
+		Status:        "pending",
+		TxHash:        lostHash,
+	}).Error)
+
+	// Still in the mempool, so the buy stays pending
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	assert.Equal(t, "pending", transactionByHash(t, db, buyTx.Hash()).Status)
+
+	c.mine(t, buyTx)
+	require.NoError(t, rc.ReconcileOnce(ctx))
+
+	row := transactionByHash(t, db, buyTx.Hash())
+	receipt, err := c.backend.TransactionReceipt(ctx, buyTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, "mined", row.Status)
+	assert.Equal(t, receipt.BlockNumber.Uint64(), row.BlockNumber)
+	assert.Equal(t, receipt.BlockHash.Hex(), row.BlockHash)
+	assert.Equal(t, uint64(21000), row.GasUsed)
+	assert.Equal(t, receipt.EffectiveGasPrice.String(), row.GasPrice)
+	assert.True(t, row.Applied)
+
+	dropped := transactionByHash(t, db, common.HexToHash(lostHash))
+	assert.Equal(t, "dropped", dropped.Status)
+	var lost models.Circle
+	require.NoError(t, db.Where("tx_hash = ?", lostHash).First(&lost).Error)
+	assert.Equal(t, "failed", lost.Status)
+
+	var notifications []models.Notification
+	require.NoError(t, db.Where("user_id = ?", trader.UserID).Find(&notifications).Error)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "TRADE_EXECUTED", notifications[0].NotificationType)
+
+	c.mine(t)
+	c.mine(t)
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	assert.Equal(t, "confirmed", transactionByHash(t, db, buyTx.Hash()).Status)
+
+	// Settling again must not notify twice
+	require.NoError(t, db.Where("user_id = ?", trader.UserID).Find(&notifications).Error)
+	assert.Len(t, notifications, 1)
+}
+
+// TestReconcilerWaitsToFailRevertedTransactions keeps a reverted circle
+// creation mined until it is confirmed, since a reorg could still replace it
+// with a successful one, and only then fails the circle
+func TestReconcilerWaitsToFailRevertedTransactions(t *testing.T) {
+	c := setupChain(t)
+	db := setupReconcilerDB(t)
+	rc := indexer.NewReconciler(c.svc, db, config.ReconcilerConfig{
+		DropTimeout:       time.Minute,
+		ConfirmationDepth: 2,
+	})
+	ctx := context.Background()
+
+	// No such circle, so the call reverts; a fixed gas limit skips estimation
+	opts := *c.auth
+	opts.GasLimit = 100000
+	reverted, err := c.factory.Transact(&opts, "deactivateCircle", big.NewInt(999))
+	require.NoError(t, err)
+	c.mine(t)
+	receipt, err := c.backend.TransactionReceipt(ctx, reverted.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+
+	require.NoError(t, db.Create(&models.Transaction{
+		TxHash:      reverted.Hash().Hex(),
+		TxType:      "create_circle",
+		FromAddress: c.auth.From.Hex(),
+		Status:      "pending",
+		Timestamp:   time.Now(),
+	}).Error)
+	require.NoError(t, db.Create(&models.Circle{
+		Name:          "Reverted",
+		Symbol:        "RVT",
+		ChainCircleID: 97,
+		TokenAddress:  "0x000000000000000000000000000000000000f00d",
+		Status:        "pending",
+		TxHash:        reverted.Hash().Hex(),
+	}).Error)
+
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	assert.Equal(t, "mined", transactionByHash(t, db, reverted.Hash()).Status)
+	assert.Equal(t, "pending", circleByChainID(t, db, 97).Status)
+
+	c.mine(t)
+	c.mine(t)
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	assert.Equal(t, "failed", transactionByHash(t, db, reverted.Hash()).Status)
+	assert.Equal(t, "failed", circleByChainID(t, db, 97).Status)
+}
+
+// TestReconcilerPagesPastStuckTransactions checks one batch at a time so an
+// older row that stays pending cannot keep a newer one from settling, and
+// that a row whose gas is already known is still announced when it mines
+func TestReconcilerPagesPastStuckTransactions(t *testing.T) {
+	c := setupChain(t)
+	db := setupReconcilerDB(t)
+	rc := indexer.NewReconciler(c.svc, db, config.ReconcilerConfig{
+		DropTimeout:       time.Hour,
+		ConfirmationDepth: 2,
+		BatchSize:         1,
+	})
+	ctx := context.Background()
+
+	trader := models.User{WalletAddress: c.auth.From.Hex()}
+	require.NoError(t, db.Create(&trader).Error)
+
+	// Unknown to the node but too recent to drop, so it stays pending
+	stuckHash := common.HexToHash("0x57c4").Hex()
+	require.NoError(t, db.Create(&models.Transaction{
+		TxHash:      stuckHash,
+		TxType:      "buy",
+		FromAddress: c.auth.From.Hex(),
+		Status:      "pending",
+		Timestamp:   time.Now().Add(-time.Minute),
+	}).Error)
+
+	buyTx := c.transfer(t, common.HexToAddress("0x00000000000000000000000000000000000c0de1"))
+	c.mine(t, buyTx)
+	require.NoError(t, db.Create(&models.Transaction{
+		TxHash:      buyTx.Hash().Hex(),
+		TxType:      "buy",
+		FromAddress: c.auth.From.Hex(),
+		Status:      "pending",
+		GasUsed:     21000,
+		Timestamp:   time.Now(),
+	}).Error)
+
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	assert.Equal(t, "pending", transactionByHash(t, db, buyTx.Hash()).Status)
+	require.NotNil(t, transactionByHash(t, db, common.HexToHash(stuckHash)).CheckedAt)
+
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	assert.Equal(t, "mined", transactionByHash(t, db, buyTx.Hash()).Status)
+	assert.Equal(t, "pending", transactionByHash(t, db, common.HexToHash(stuckHash)).Status)
+
+	var notifications []models.Notification
+	require.NoError(t, db.Where("user_id = ?", trader.UserID).Find(&notifications).Error)
+	assert.Len(t, notifications, 1)
+}
+
+// TestReconcilerSettlesOnReplacedBroadcast cancels a circle creation with a
+// replacement that never mines and checks the row neither drops while the
+// original is still in the mempool nor fails the circle once it mines
+func TestReconcilerSettlesOnReplacedBroadcast(t *testing.T) {
+	c := setupChain(t)
+	db := setupReconcilerDB(t)
+	rc := indexer.NewReconciler(c.svc, db, config.ReconcilerConfig{
+		DropTimeout:       time.Minute,
+		ConfirmationDepth: 2,
+	})
+	ctx := context.Background()
+	txRepo := repository.NewTransactionRepository(db)
+
+	original := c.transfer(t, common.HexToAddress("0x00000000000000000000000000000000000c0de1"))
+	row := &models.Transaction{
+		TxHash:      original.Hash().Hex(),
+		TxType:      "create_circle",
+		FromAddress: c.auth.From.Hex(),
+		ToAddress:   common.HexToAddress("0x00000000000000000000000000000000000c0de1").Hex(),
+		Amount:      "0.001",
+		Status:      "pending",
+		Timestamp:   time.Now().Add(-time.Hour),
+	}
+	require.NoError(t, txRepo.Create(ctx, row))
+	require.NoError(t, db.Create(&models.Circle{
+		Name:          "Replaced",
+		Symbol:        "RPL",
+		ChainCircleID: 98,
+		TokenAddress:  "0x000000000000000000000000000000000000beef",
+		Status:        "pending",
+		TxHash:        original.Hash().Hex(),
+	}).Error)
+
+	// A cancel the node never saw, as if it lost the fee race
+	cancelHash := common.HexToHash("0xca7ce1").Hex()
+	superseded := &models.TransactionReplacement{
+		TxHash:    row.TxHash,
+		TxType:    row.TxType,
+		ToAddress: row.ToAddress,
+		Amount:    row.Amount,
+	}
+	row.TxHash = cancelHash
+	row.ReplacedHash = superseded.TxHash
+	row.TxType = "cancel"
+	row.ToAddress = row.FromAddress
+	row.Amount = "0"
+	require.NoError(t, txRepo.Replace(ctx, row, superseded))
+
+	var circle models.Circle
+	require.NoError(t, db.Where("chain_circle_id = ?", 98).First(&circle).Error)
+	assert.Equal(t, cancelHash, circle.TxHash, "the circle follows the replacement")
+
+	// The original is still pending, so the row is not dropped
+	require.NoError(t, rc.ReconcileOnce(ctx))
+	assert.Equal(t, "pending", transactionByHash(t, db, common.HexToHash(cancelHash)).Status)
+
+	c.mine(t, original)
+	require.NoError(t, rc.ReconcileOnce(ctx))
+
+	settled := transactionByHash(t, db, original.Hash())
+	assert.Equal(t, row.ID, settled.ID)
+	assert.Equal(t, "mined", settled.Status)
+	assert.Equal(t, "create_circle", settled.TxType)
+	assert.Equal(t, superseded.ToAddress, settled.ToAddress)
+	assertUnits(t, "0.001", settled.Amount)
+
+	require.NoError(t, db.Where("chain_circle_id = ?", 98).First(&circle).Error)
+	assert.Equal(t, original.Hash().Hex(), circle.TxHash)
+	assert.Equal(t, "pending", circle.Status, "the cancel did not mine, so the circle is not failed")
+
+	replacements, err := txRepo.GetSuperseded(ctx, row.ID)
+	require.NoError(t, err)
+	require.Len(t, replacements, 1)
+	assert.Equal(t, cancelHash, replacements[0].TxHash)
+	assert.Equal(t, "cancel", replacements[0].TxType)
+}
@@ -0,0 +1,369 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package indexer_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// artifactDir holds the Foundry build output of the contracts
+var artifactDir = filepath.Join("..", "..", "..", "..", "out")
+
+type chain struct {
+	backend *backends.SimulatedBackend
+	auth    *bind.TransactOpts
+	factory *bind.BoundContract
+	svc     *web3.Web3Service
+}
+
+func loadArtifact(t *testing.T, name string) (abi.ABI, []byte) {
+	raw, err := os.ReadFile(filepath.Join(artifactDir, name+".sol", name+".json"))
+	require.NoError(t, err)
+
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &artifact))
+
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	require.NoError(t, err)
+	return parsed, common.FromHex(artifact.Bytecode.Object)
+}
+
+func setupChain(t *testing.T) *chain {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		owner: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	}, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+
+	chainID := backend.Blockchain().Config().ChainID
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+
+	factoryABI, factoryCode := loadArtifact(t, "CircleFactory")
+
+	treasury := common.HexToAddress("0x00000000000000000000000000000000000fee01")
+	factoryAddr, _, factory, err := bind.DeployContract(auth, factoryABI, factoryCode, backend, treasury)
+	require.NoError(t, err)
+	backend.Commit()
+
+	var out []interface{}
+	require.NoError(t, factory.Call(&bind.CallOpts{}, &out, "bondingCurveImpl"))
+	curveAddr := out[0].(common.Address)
+
+	svc, err := web3.NewWeb3ServiceWithBackend(backend, chainID, factoryAddr.Hex(), curveAddr.Hex())
+	require.NoError(t, err)
+
+	return &chain{
+		backend: backend,
+		auth:    auth,
+		factory: factory,
+		svc:     svc,
+	}
+}
+
+func setupDB(t *testing.T) *gorm.DB {
+	// A named shared-cache database keeps every pooled connection on the
+	// same data while isolating tests from each other
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	// The MySQL enum columns are not understood by SQLite, so the tables
+	// that use them are declared by hand
+	require.NoError(t, db.Exec(`CREATE TABLE trades (
+		trade_id INTEGER PRIMARY KEY AUTOINCREMENT,
+		tx_hash TEXT NOT NULL,
+		log_index INTEGER NOT NULL DEFAULT 0,
+		trader_id INTEGER NOT NULL,
+		circle_id INTEGER NOT NULL,
+		trade_type TEXT NOT NULL,
+		token_amount TEXT NOT NULL,
+		eth_amount TEXT NOT NULL,
+		price TEXT NOT NULL,
+		fee TEXT DEFAULT 0,
+		block_number INTEGER NOT NULL,
+		block_hash TEXT,
+		timestamp DATETIME NOT NULL,
+		UNIQUE (tx_hash, log_index)
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE circle_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		circle_id INTEGER NOT NULL,
+		event_type TEXT NOT NULL,
+		actor_address TEXT,
+		owner_address TEXT,
+		active BOOLEAN,
+		tx_hash TEXT NOT NULL,
+		log_index INTEGER NOT NULL,
+		block_number INTEGER NOT NULL,
+		block_hash TEXT,
+		created_at DATETIME,
+		UNIQUE (tx_hash, log_index)
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE notifications (
+		notification_id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		notification_type TEXT NOT NULL,
+		title TEXT NOT NULL,
+		content TEXT,
+		related_user_id INTEGER,
+		related_post_id INTEGER,
+		related_circle_id INTEGER,
+		is_read BOOLEAN DEFAULT FALSE,
+		created_at DATETIME
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE user_circle_relationships (
+		uc_relationship_id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		circle_id INTEGER NOT NULL,
+		relationship_type TEXT NOT NULL,
+		token_balance TEXT DEFAULT 0,
+		balance_block INTEGER DEFAULT 0,
+		held_since DATETIME,
+		join_price TEXT,
+		contribution_score REAL DEFAULT 0,
+		can_post NUMERIC DEFAULT true,
+		can_moderate NUMERIC DEFAULT false,
+		can_invite NUMERIC DEFAULT false,
+		gate_demoted NUMERIC DEFAULT false,
+		joined_at DATETIME,
+		last_interaction_at DATETIME,
+		UNIQUE (user_id, circle_id)
+	)`).Error)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Circle{},
+		&models.Transaction{},
+		&models.IndexerCursor{},
+		&models.IndexedBlock{},
+		&models.CircleMembershipRule{},
+		&models.TokenTransfer{},
+		&models.CircleHolder{},
+		&models.Candle{},
+		&models.PortfolioSnapshot{},
+	))
+
+	return db
+}
+
+func (c *chain) createCircle(t *testing.T, name, symbol string) *types.Transaction {
+	opts := *c.auth
+	opts.Value = big.NewInt(1e16) // circle creation fee
+	tx, err := c.factory.Transact(&opts, "createCircle",
+		name, symbol, "reorg test circle", uint8(0),
+		big.NewInt(1e12), big.NewInt(1e6), big.NewInt(0), big.NewInt(0),
+	)
+	require.NoError(t, err)
+	return tx
+}
+
+func (c *chain) deactivateCircle(t *testing.T, circleID int64) *types.Transaction {
+	tx, err := c.factory.Transact(c.auth, "deactivateCircle", big.NewInt(circleID))
+	require.NoError(t, err)
+	return tx
+}
+
+func (c *chain) transferCircle(t *testing.T, circleID int64, newOwner common.Address) *types.Transaction {
+	tx, err := c.factory.Transact(c.auth, "transferCircleOwnership", big.NewInt(circleID), newOwner)
+	require.NoError(t, err)
+	return tx
+}
+
+// mine seals the pending block and checks the given transactions succeeded.
+// Blocks on a fork only become canonical once the fork is the longest chain,
+// so their transactions are checked after the fork has been extended.
+func (c *chain) mine(t *testing.T, txs ...*types.Transaction) common.Hash {
+	hash := c.backend.Commit()
+	c.requireSuccess(t, txs...)
+	return hash
+}
+
+func (c *chain) requireSuccess(t *testing.T, txs ...*types.Transaction) {
+	for _, tx := range txs {
+		receipt, err := c.backend.TransactionReceipt(context.Background(), tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "transaction %s reverted", tx.Hash().Hex())
+	}
+}
+
+func syncToHead(t *testing.T, ix *indexer.Indexer) {
+	for i := 0; i < 10; i++ {
+		caughtUp, err := ix.SyncOnce(context.Background())
+		require.NoError(t, err)
+		if caughtUp {
+			return
+		}
+	}
+	t.Fatal("indexer did not reach the chain head")
+}
+
+func circleByChainID(t *testing.T, db *gorm.DB, chainCircleID uint64) models.Circle {
+	var circle models.Circle
+	require.NoError(t, db.Where("chain_circle_id = ?", chainCircleID).First(&circle).Error)
+	return circle
+}
+
+func transactionByHash(t *testing.T, db *gorm.DB, hash common.Hash) models.Transaction {
+	var tx models.Transaction
+	require.NoError(t, db.Where("tx_hash = ?", hash.Hex()).First(&tx).Error)
+	return tx
+}
+
+// TestIndexerRollsBackOrphanedBlocks indexes a chain, replaces its tip with a
+// longer fork and checks the database ends up mirroring only the new chain
+func TestIndexerRollsBackOrphanedBlocks(t *testing.T) {
+	c := setupChain(t)
+	db := setupDB(t)
+	ix := indexer.NewIndexer(c.svc, db, config.IndexerConfig{
+		BatchSize:         100,
+		ConfirmationDepth: 4,
+	})
+
+	// Block 2 is shared by both chains
+	alphaTx := c.createCircle(t, "Alpha", "ALPHA")
+	forkPoint := c.mine(t, alphaTx)
+
+	// Blocks 3-5 are orphaned later
+	deactivateTx := c.deactivateCircle(t, 1)
+	c.mine(t, deactivateTx)
+	betaTx := c.createCircle(t, "Beta", "BETA")
+	c.mine(t, betaTx)
+	newOwner := common.HexToAddress("0x00000000000000000000000000000000000b0b01")
+	transferTx := c.transferCircle(t, 2, newOwner)
+	tip := c.mine(t, transferTx)
+
+	syncToHead(t, ix)
+
+	alpha := circleByChainID(t, db, 1)
+	assert.False(t, alpha.Active)
+	assert.Equal(t, "mined", alpha.Status)
+	beta := circleByChainID(t, db, 2)
+	assert.Equal(t, "Beta", beta.Name)
+	assert.Equal(t, newOwner.Hex(), beta.OwnerAddress)
+
+	// The deployed CircleToken only lets the factory mint, so bonding curve
+	// buys revert on this chain; stand in the row the indexer writes for one
+	trader := models.User{WalletAddress: newOwner.Hex()}
+	require.NoError(t, db.Create(&trader).Error)
+	require.NoError(t, db.Create(&models.Trade{
+		TxHash:      common.HexToHash("0xb0b").Hex(),
+		TraderID:    trader.UserID,
+		CircleID:    beta.ID,
+		TradeType:   "BUY",
+		TokenAmount: "1",
+		ETHAmount:   "0.1",
+		Price:       "0.1",
+		BlockNumber: 5,
+		BlockHash:   tip.Hex(),
+		Timestamp:   time.Now(),
+	}).Error)
+
+	// Replace blocks 3-5 with a longer chain that creates a different circle
+	require.NoError(t, c.backend.Fork(context.Background(), forkPoint))
+	gammaTx := c.createCircle(t, "Gamma", "GAMMA")
+	for i := 0; i < 4; i++ {
+		c.mine(t)
+	}
+	c.requireSuccess(t, gammaTx)
+
+	syncToHead(t, ix)
+
+	alpha = circleByChainID(t, db, 1)
+	assert.True(t, alpha.Active, "orphaned deactivation must be reverted")
+	assert.Equal(t, "confirmed", alpha.Status)
+
+	gamma := circleByChainID(t, db, 2)
+	assert.Equal(t, "Gamma", gamma.Name)
+	assert.Equal(t, c.auth.From.Hex(), gamma.OwnerAddress)
+	assert.Equal(t, gammaTx.Hash().Hex(), gamma.TxHash)
+	assert.Equal(t, "mined", gamma.Status, "block 3 is not yet buried four blocks deep")
+
+	var circles int64
+	require.NoError(t, db.Model(&models.Circle{}).Count(&circles).Error)
+	assert.Equal(t, int64(2), circles)
+
+	var trades int64
+	require.NoError(t, db.Model(&models.Trade{}).Count(&trades).Error)
+	assert.Equal(t, int64(0), trades, "trades from orphaned blocks must be removed")
+
+	for _, hash := range []common.Hash{deactivateTx.Hash(), betaTx.Hash(), transferTx.Hash()} {
+		orphaned := transactionByHash(t, db, hash)
+		assert.Equal(t, "pending", orphaned.Status)
+		assert.Zero(t, orphaned.BlockNumber)
+	}
+
+	head, err := c.backend.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	gammaRow := transactionByHash(t, db, gammaTx.Hash())
+	gammaReceipt, err := c.backend.TransactionReceipt(context.Background(), gammaTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, gammaReceipt.BlockHash.Hex(), gammaRow.BlockHash)
+	assert.Equal(t, "mined", gammaRow.Status)
+
+	var cursor models.IndexerCursor
+	require.NoError(t, db.First(&cursor, "name = ?", indexer.IndexerCursorName).Error)
+	assert.Equal(t, head.Number.Uint64(), cursor.BlockNumber)
+	assert.Equal(t, head.Hash().Hex(), cursor.BlockHash)
+}
+
+// TestIndexerWaitsForConfirmations checks rows stay mined until they are
+// buried under the configured number of blocks
+func TestIndexerWaitsForConfirmations(t *testing.T) {
+	c := setupChain(t)
+	db := setupDB(t)
+	ix := indexer.NewIndexer(c.svc, db, config.IndexerConfig{
+		BatchSize:         100,
+		ConfirmationDepth: 2,
+	})
+
+	createTx := c.createCircle(t, "Alpha", "ALPHA")
+	c.mine(t, createTx)
+	syncToHead(t, ix)
+
+	assert.Equal(t, "mined", circleByChainID(t, db, 1).Status)
+	assert.Equal(t, "mined", transactionByHash(t, db, createTx.Hash()).Status)
+
+	c.mine(t)
+	c.mine(t)
+	syncToHead(t, ix)
+
+	assert.Equal(t, "confirmed", circleByChainID(t, db, 1).Status)
+	assert.Equal(t, "confirmed", transactionByHash(t, db, createTx.Hash()).Status)
+}
@@ -0,0 +1,291 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package posts_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/ipfs"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+	"github.com/fast-socialfi/backend/tests/testutil"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	db := testutil.OpenDB(t)
+	testutil.MigrateIndexer(t, db)
+	testutil.Migrate(t, db, &models.Post{})
+	return db
+}
+
+// TestPosts creates, reads, edits and deletes posts stored on IPFS, with
+// circle posts gated on the author's right to post there
+func TestPosts(t *testing.T) {
+	c := testutil.NewChain(t)
+	db := setupDB(t)
+	ctx := context.Background()
+	ix := indexer.NewIndexer(c.Svc, db, config.IndexerConfig{BatchSize: 100})
+	node := testutil.NewFakeIPFS(t)
+
+	c.Mine(t, c.CreateCircle(t, "Alpha", "ALPHA"))
+	testutil.SyncToHead(t, ix)
+	circle := testutil.CircleByChainID(t, db, 1)
+	token, err := contracts.NewCircleToken(common.HexToAddress(circle.TokenAddress), c.Backend)
+	require.NoError(t, err)
+
+	membershipSvc := service.NewMembershipService(
+		repository.NewCircleRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewCircleMemberRepository(db),
+		repository.NewMembershipRuleRepository(db),
+		c.Svc,
+	)
+	svc := service.NewPostService(
+		repository.NewPostRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewCircleRepository(db),
+		membershipSvc,
+		ipfs.NewClient(node.Config()),
+	)
+	owner := c.Auth.From.Hex()
+	outsider := common.HexToAddress("0x0000000000000000000000000000000000000b0b").Hex()
+
+	// The holder keeps 50 tokens against a minimum of 10
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	holder := crypto.PubkeyToAddress(key.PublicKey)
+	holderAuth, err := bind.NewKeyedTransactorWithChainID(key, c.Backend.Blockchain().Config().ChainID)
+	require.NoError(t, err)
+	c.Fund(t, holder)
+	c.Mine(t, c.TransferTokens(t, token, holder, 50))
+	_, err = membershipSvc.SetMembershipRule(ctx, circle.ID, &service.SetMembershipRuleRequest{MinBalance: "10", FromAddress: owner})
+	require.NoError(t, err)
+	_, err = membershipSvc.JoinCircle(ctx, circle.ID, holder.Hex())
+	require.NoError(t, err)
+
+	t.Run("stores the body on IPFS with a plain preview", func(t *testing.T) {
+		body := "# Launch day\n\nRead the **whitepaper** at [our site](https://example.com) <br/> and `join`."
+		created, err := svc.CreatePost(ctx, strings.ToLower(outsider), &service.CreatePostRequest{Title: " Hello ", Body: body})
+		require.NoError(t, err)
+		assert.Equal(t, "TEXT", created.ContentType)
+		assert.Equal(t, outsider, created.Author.WalletAddress)
+		assert.Nil(t, created.CircleID)
+		require.NotNil(t, created.Title)
+		assert.Equal(t, "Hello", *created.Title)
+		require.NotNil(t, created.PreviewText)
+		assert.Equal(t, "Launch day Read the whitepaper at our site and join.", *created.PreviewText)
+		assert.Equal(t, node.URL()+"/ipfs/"+created.ContentIPFSHash, created.ContentURL)
+
+		stored, ok := node.Get(created.ContentIPFSHash)
+		require.True(t, ok)
+		var content service.PostContent
+		require.NoError(t, json.Unmarshal(stored, &content))
+		assert.Equal(t, body, content.Body)
+		assert.Equal(t, outsider, content.Author)
+
+		read, err := svc.GetPost(ctx, created.PostID)
+		require.NoError(t, err)
+		require.NotNil(t, read.Content)
+		assert.Equal(t, body, read.Content.Body)
+		assert.Equal(t, "Hello", read.Content.Title)
+	})
+
+	t.Run("cuts long previews at a word", func(t *testing.T) {
+		created, err := svc.CreatePost(ctx, outsider, &service.CreatePostRequest{Body: strings.Repeat("lorem ipsum ", 100)})
+		require.NoError(t, err)
+		require.NotNil(t, created.PreviewText)
+		preview := *created.PreviewText
+		assert.True(t, strings.HasSuffix(preview, "ipsum…") || strings.HasSuffix(preview, "lorem…"), preview)
+		assert.LessOrEqual(t, utf8.RuneCountInString(preview), 281)
+	})
+
+	t.Run("rejects malformed posts", func(t *testing.T) {
+		for _, req := range []*service.CreatePostRequest{
+			{Body: "   "},
+			{ContentType: "IMAGE", Body: "no picture"},
+			{ContentType: "LINK", MediaURL: "javascript:alert(1)"},
+			{ContentType: "NFT", Body: "minted elsewhere"},
+			{Title: strings.Repeat("t", 256), Body: "long title"},
+		} {
+			_, err := svc.CreatePost(ctx, outsider, req)
+			assert.ErrorIs(t, err, service.ErrInvalidPost)
+		}
+
+		created, err := svc.CreatePost(ctx, outsider, &service.CreatePostRequest{ContentType: "image", MediaURL: "ipfs://bafyimage"})
+		require.NoError(t, err)
+		assert.Equal(t, "IMAGE", created.ContentType)
+		assert.Nil(t, created.PreviewText)
+	})
+
+	var circlePostID uint64
+	t.Run("gates circle posts on membership", func(t *testing.T) {
+		_, err := svc.CreatePost(ctx, outsider, &service.CreatePostRequest{CircleID: &circle.ID, Body: "let me in"})
+		assert.ErrorIs(t, err, service.ErrNotCircleMember)
+		missing := uint64(999)
+		_, err = svc.CreatePost(ctx, holder.Hex(), &service.CreatePostRequest{CircleID: &missing, Body: "where"})
+		assert.ErrorIs(t, err, service.ErrCircleNotFound)
+
+		created, err := svc.CreatePost(ctx, holder.Hex(), &service.CreatePostRequest{CircleID: &circle.ID, Body: "gm holders"})
+		require.NoError(t, err)
+		require.NotNil(t, created.CircleID)
+		assert.Equal(t, circle.ID, *created.CircleID)
+		circlePostID = created.PostID
+	})
+
+	t.Run("edits upload a new version", func(t *testing.T) {
+		before, err := svc.GetPost(ctx, circlePostID)
+		require.NoError(t, err)
+
+		body := "gm holders, edited"
+		_, err = svc.UpdatePost(ctx, circlePostID, outsider, &service.UpdatePostRequest{Body: &body})
+		assert.ErrorIs(t, err, service.ErrNotPostAuthor)
+
+		title := "Morning"
+		updated, err := svc.UpdatePost(ctx, circlePostID, holder.Hex(), &service.UpdatePostRequest{Title: &title})
+		require.NoError(t, err)
+		updated, err = svc.UpdatePost(ctx, circlePostID, holder.Hex(), &service.UpdatePostRequest{Body: &body})
+		require.NoError(t, err)
+		assert.NotEqual(t, before.ContentIPFSHash, updated.ContentIPFSHash)
+		assert.Equal(t, "Morning", *updated.Title)
+		assert.Equal(t, body, *updated.PreviewText)
+
+		// The earlier version stays on IPFS
+		_, ok := node.Get(before.ContentIPFSHash)
+		assert.True(t, ok)
+		read, err := svc.GetPost(ctx, circlePostID)
+		require.NoError(t, err)
+		assert.Equal(t, body, read.Content.Body)
+		assert.Equal(t, "Morning", read.Content.Title)
+	})
+
+	t.Run("lists feeds newest first", func(t *testing.T) {
+		resp, err := svc.ListPosts(ctx, &service.PostQuery{CircleID: circle.ID})
+		require.NoError(t, err)
+		require.Len(t, resp.Posts, 1)
+		assert.Equal(t, circlePostID, resp.Posts[0].PostID)
+		assert.Nil(t, resp.Posts[0].Content)
+
+		var ids []uint64
+		cursor := ""
+		for {
+			resp, err := svc.ListPosts(ctx, &service.PostQuery{Author: outsider, Cursor: cursor, Limit: 2})
+			require.NoError(t, err)
+			for _, post := range resp.Posts {
+				ids = append(ids, post.PostID)
+			}
+			if resp.NextCursor == "" {
+				break
+			}
+			cursor = resp.NextCursor
+		}
+		assert.Equal(t, []uint64{3, 2, 1}, ids)
+
+		resp, err = svc.ListPosts(ctx, &service.PostQuery{Author: "0x000000000000000000000000000000000000dead"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Posts)
+		_, err = svc.ListPosts(ctx, &service.PostQuery{Cursor: "soon"})
+		assert.ErrorIs(t, err, service.ErrInvalidPost)
+	})
+
+	t.Run("refuses authors who fell below the minimum", func(t *testing.T) {
+		transfer, err := token.Transfer(holderAuth, c.Auth.From, testutil.Tokens(45))
+		require.NoError(t, err)
+		c.Mine(t, transfer)
+
+		_, err = svc.CreatePost(ctx, holder.Hex(), &service.CreatePostRequest{CircleID: &circle.ID, Body: "still here"})
+		assert.ErrorIs(t, err, service.ErrMembershipRequirement)
+		body := "one more edit"
+		_, err = svc.UpdatePost(ctx, circlePostID, holder.Hex(), &service.UpdatePostRequest{Body: &body})
+		assert.ErrorIs(t, err, service.ErrMembershipRequirement)
+	})
+
+	t.Run("moderators soft-delete circle posts", func(t *testing.T) {
+		assert.ErrorIs(t, svc.DeletePost(ctx, circlePostID, outsider), service.ErrNotPostAuthor)
+
+		_, err := membershipSvc.JoinCircle(ctx, circle.ID, owner)
+		require.NoError(t, err)
+		require.NoError(t, svc.DeletePost(ctx, circlePostID, owner))
+
+		_, err = svc.GetPost(ctx, circlePostID)
+		assert.ErrorIs(t, err, service.ErrPostNotFound)
+		assert.ErrorIs(t, svc.DeletePost(ctx, circlePostID, owner), service.ErrPostNotFound)
+		resp, err := svc.ListPosts(ctx, &service.PostQuery{CircleID: circle.ID})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Posts)
+		row, err := repository.NewPostRepository(db).GetByID(ctx, circlePostID)
+		require.NoError(t, err)
+		assert.True(t, row.IsDeleted)
+	})
+
+	t.Run("serves posts over HTTP", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		// Stands in for the auth middleware
+		router.Use(func(c *gin.Context) {
+			if address := c.GetHeader("X-Test-Address"); address != "" {
+				c.Set("user_address", address)
+			}
+		})
+		handler.NewPostHandler(svc).RegisterRoutes(router.Group("/api/v1"))
+		do := func(method, path, body, address string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			if address != "" {
+				req.Header.Set("X-Test-Address", address)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec
+		}
+
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/api/v1/posts", `{"body":"hi"}`, "").Code)
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/posts", `{"content_type":"VIDEO"}`, outsider).Code)
+		assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/v1/posts", fmt.Sprintf(`{"circle_id":%d,"body":"hi"}`, circle.ID), outsider).Code)
+
+		rec := do(http.MethodPost, "/api/v1/posts", `{"body":"over http"}`, outsider)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		var created service.PostResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		path := fmt.Sprintf("/api/v1/posts/%d", created.PostID)
+
+		assert.Equal(t, http.StatusForbidden, do(http.MethodPut, path, `{"body":"mine now"}`, owner).Code)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodGet, fmt.Sprintf("/api/v1/posts/%d", circlePostID), "", "").Code)
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/posts/first", "", "").Code)
+
+		node.SetDown(true)
+		assert.Equal(t, http.StatusBadGateway, do(http.MethodGet, path, "", "").Code)
+		assert.Equal(t, http.StatusBadGateway, do(http.MethodPost, "/api/v1/posts", `{"body":"lost"}`, outsider).Code)
+		node.SetDown(false)
+
+		rec = do(http.MethodGet, path, "", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var read service.PostResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &read))
+		assert.Equal(t, "over http", read.Content.Body)
+
+		assert.Equal(t, http.StatusOK, do(http.MethodDelete, path, "", outsider).Code)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodGet, path, "", "").Code)
+	})
+}
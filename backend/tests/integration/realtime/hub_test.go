@@ -0,0 +1,254 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package realtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/database"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/middleware"
+	"github.com/fast-socialfi/backend/internal/realtime"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	alice = "0x00000000000000000000000000000000000A11cE"
+	bob   = "0x0000000000000000000000000000000000000b0b"
+)
+
+// replica is one API server with its own hub, sharing Redis with the others
+type replica struct {
+	hub  *realtime.Hub
+	auth *middleware.AuthMiddleware
+	url  string
+}
+
+func startReplica(t *testing.T, mr *miniredis.Miniredis, cfg config.WebSocketConfig) *replica {
+	client := &database.RedisClient{Client: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	t.Cleanup(func() { client.Close() })
+
+	subscribed := mr.PubSubNumPat()
+	hub := realtime.NewHub(realtime.NewRedisBroker(client), cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hub.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	// Events published before the hub subscribes would be lost
+	require.Eventually(t, func() bool { return mr.PubSubNumPat() > subscribed }, 2*time.Second, 10*time.Millisecond)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	auth := middleware.NewAuthMiddleware("test-secret")
+	handler.NewWebSocketHandler(hub, auth, []string{"*"}).RegisterRoutes(router.Group("/api/v1"))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return &replica{hub: hub, auth: auth, url: "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/ws"}
+}
+
+func (r *replica) dial(t *testing.T, address string, ttl time.Duration) *websocket.Conn {
+	token, err := r.auth.GenerateToken(address, ttl)
+	require.NoError(t, err)
+	conn, _, err := websocket.DefaultDialer.Dial(r.url+"?token="+token, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, action, channel string) {
+	require.NoError(t, conn.WriteJSON(&realtime.Command{Action: action, Channel: channel}))
+}
+
+func receive(t *testing.T, conn *websocket.Conn) *realtime.Message {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var msg realtime.Message
+	require.NoError(t, conn.ReadJSON(&msg))
+	return &msg
+}
+
+func subscribe(t *testing.T, conn *websocket.Conn, channel string) {
+	send(t, conn, "subscribe", channel)
+	msg := receive(t, conn)
+	require.Equal(t, "subscribed", msg.Type, msg.Error)
+}
+
+// closeCode reads until the server hangs up and returns its close code
+func closeCode(t *testing.T, conn *websocket.Conn) int {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+		var closeErr *websocket.CloseError
+		require.True(t, errors.As(err, &closeErr), "expected a close frame, got %v", err)
+		return closeErr.Code
+	}
+}
+
+func TestConnectRequiresToken(t *testing.T) {
+	r := startReplica(t, miniredis.RunT(t), config.WebSocketConfig{})
+
+	_, resp, err := websocket.DefaultDialer.Dial(r.url, nil)
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	_, resp, err = websocket.DefaultDialer.Dial(r.url+"?token=not-a-token", nil)
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	other, err := middleware.NewAuthMiddleware("other-secret").GenerateToken(alice, time.Hour)
+	require.NoError(t, err)
+	_, resp, err = websocket.DefaultDialer.Dial(r.url, http.Header{"Authorization": {"Bearer " + other}})
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestSubscriptions(t *testing.T) {
+	r := startReplica(t, miniredis.RunT(t), config.WebSocketConfig{MaxSubscriptions: 3})
+	ctx := context.Background()
+	conn := r.dial(t, alice, time.Hour)
+
+	subscribe(t, conn, "circle:7:trades")
+	require.NoError(t, r.hub.Publish(ctx, realtime.CircleTradesChannel(7), realtime.EventTrade, &realtime.TradeEvent{
+		TradeID: 1, CircleID: 7, TradeType: "BUY", Price: "1.5",
+	}))
+	msg := receive(t, conn)
+	assert.Equal(t, "event", msg.Type)
+	assert.Equal(t, "circle:7:trades", msg.Channel)
+	assert.Equal(t, realtime.EventTrade, msg.Event)
+	var trade realtime.TradeEvent
+	require.NoError(t, json.Unmarshal(msg.Data, &trade))
+	assert.Equal(t, uint64(7), trade.CircleID)
+	assert.Equal(t, "1.5", trade.Price)
+
+	// A user's own channel may be named in any case; the reply gives the
+	// canonical name events are published on
+	send(t, conn, "subscribe", "user:"+strings.ToLower(alice)+":notifications")
+	msg = receive(t, conn)
+	require.Equal(t, "subscribed", msg.Type, msg.Error)
+	assert.Equal(t, realtime.UserNotificationsChannel(alice), msg.Channel)
+
+	send(t, conn, "subscribe", realtime.UserNotificationsChannel(bob))
+	msg = receive(t, conn)
+	assert.Equal(t, "error", msg.Type)
+	assert.Equal(t, realtime.ErrChannelForbidden.Error(), msg.Error)
+
+	for _, channel := range []string{"circle:0:trades", "circle:7:posts", "user:nobody:notifications", "trades"} {
+		send(t, conn, "subscribe", channel)
+		msg = receive(t, conn)
+		assert.Equal(t, "error", msg.Type, channel)
+		assert.Contains(t, msg.Error, realtime.ErrInvalidChannel.Error(), channel)
+	}
+
+	subscribe(t, conn, "circle:7:price")
+	send(t, conn, "subscribe", "circle:8:price")
+	msg = receive(t, conn)
+	assert.Equal(t, "error", msg.Type)
+	assert.Contains(t, msg.Error, "subscription limit")
+
+	// Events on channels the client left or never joined are not delivered
+	send(t, conn, "unsubscribe", "circle:7:trades")
+	assert.Equal(t, "unsubscribed", receive(t, conn).Type)
+	require.NoError(t, r.hub.Publish(ctx, realtime.CircleTradesChannel(7), realtime.EventTrade, &realtime.TradeEvent{TradeID: 2}))
+	require.NoError(t, r.hub.Publish(ctx, realtime.CirclePriceChannel(8), realtime.EventPrice, &realtime.PriceEvent{CircleID: 8}))
+	require.NoError(t, r.hub.Publish(ctx, realtime.CirclePriceChannel(7), realtime.EventPrice, &realtime.PriceEvent{CircleID: 7}))
+	msg = receive(t, conn)
+	assert.Equal(t, "circle:7:price", msg.Channel)
+	assert.Equal(t, realtime.EventPrice, msg.Event)
+}
+
+func TestEventsReachEveryReplica(t *testing.T) {
+	mr := miniredis.RunT(t)
+	producer := startReplica(t, mr, config.WebSocketConfig{})
+	consumer := startReplica(t, mr, config.WebSocketConfig{})
+
+	conn := consumer.dial(t, alice, time.Hour)
+	channel := realtime.UserNotificationsChannel(alice)
+	subscribe(t, conn, channel)
+
+	require.NoError(t, producer.hub.Publish(context.Background(), channel, realtime.EventNotification, map[string]string{"title": "Trade executed"}))
+	msg := receive(t, conn)
+	assert.Equal(t, channel, msg.Channel)
+	assert.Equal(t, realtime.EventNotification, msg.Event)
+	assert.JSONEq(t, `{"title":"Trade executed"}`, string(msg.Data))
+}
+
+func TestSlowConsumerIsEvicted(t *testing.T) {
+	r := startReplica(t, miniredis.RunT(t), config.WebSocketConfig{SendQueueSize: 1, WriteTimeout: time.Minute})
+	conn := r.dial(t, alice, time.Hour)
+	subscribe(t, conn, "circle:1:trades")
+	require.Equal(t, 1, r.hub.ClientCount())
+
+	// The client stops reading. Once the socket buffers fill, the write pump
+	// stalls, the queue fills and the hub drops the client instead of waiting.
+	payload := strings.Repeat("x", 256<<10)
+	require.Eventually(t, func() bool {
+		require.NoError(t, r.hub.Publish(context.Background(), "circle:1:trades", realtime.EventTrade, payload))
+		return r.hub.ClientCount() == 0
+	}, 10*time.Second, time.Millisecond)
+
+	assert.Equal(t, 1013, closeCode(t, conn))
+}
+
+func TestHeartbeat(t *testing.T) {
+	r := startReplica(t, miniredis.RunT(t), config.WebSocketConfig{
+		PingInterval: 50 * time.Millisecond,
+		PongTimeout:  200 * time.Millisecond,
+	})
+
+	// A reading client answers pings and stays connected
+	live := r.dial(t, alice, time.Hour)
+	var pings atomic.Int32
+	live.SetPingHandler(func(data string) error {
+		pings.Add(1)
+		return live.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	go func() {
+		for {
+			if _, _, err := live.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// A client that never answers is dropped once the pong timeout passes
+	r.dial(t, bob, time.Hour)
+	require.Eventually(t, func() bool { return r.hub.ClientCount() == 2 }, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return r.hub.ClientCount() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	assert.GreaterOrEqual(t, pings.Load(), int32(3))
+	assert.Equal(t, 1, r.hub.ClientCount())
+}
+
+func TestExpiredTokenDisconnects(t *testing.T) {
+	r := startReplica(t, miniredis.RunT(t), config.WebSocketConfig{})
+	conn := r.dial(t, common.HexToAddress(alice).Hex(), time.Second)
+
+	assert.Equal(t, websocket.ClosePolicyViolation, closeCode(t, conn))
+	require.Eventually(t, func() bool { return r.hub.ClientCount() == 0 }, time.Second, 10*time.Millisecond)
+}
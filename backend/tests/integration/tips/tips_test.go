@@ -0,0 +1,310 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package tips_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/tests/testutil"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	db := testutil.OpenDB(t)
+	testutil.MigrateIndexer(t, db)
+	testutil.Migrate(t, db, &models.Post{}, &models.PostTip{}, &models.PostTipIntent{})
+	return db
+}
+
+// send signs a prepared transaction with the chain's account, broadcasts it
+// and mines it
+func send(t *testing.T, c *testutil.Chain, utx *web3.UnsignedTx) common.Hash {
+	tx := new(types.Transaction)
+	require.NoError(t, tx.UnmarshalBinary(hexutil.MustDecode(c.Sign(t, utx))))
+	require.NoError(t, c.Backend.SendTransaction(context.Background(), tx))
+	c.Mine(t, tx)
+	return tx.Hash()
+}
+
+func tipIDs(entries []*service.TipLeaderboardEntry) []uint64 {
+	ids := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.PostID)
+	}
+	return ids
+}
+
+// TestTips tips posts in ETH and in their circle's token through prepared
+// transactions, verifies them from receipts once confirmed, and ranks the
+// circle's posts by what they received
+func TestTips(t *testing.T) {
+	c := testutil.NewChain(t)
+	db := setupDB(t)
+	ctx := context.Background()
+	ix := indexer.NewIndexer(c.Svc, db, config.IndexerConfig{BatchSize: 100})
+
+	c.Mine(t, c.CreateCircle(t, "Alpha", "ALPHA"))
+	testutil.SyncToHead(t, ix)
+	circle := testutil.CircleByChainID(t, db, 1)
+
+	svc := service.NewTipService(
+		repository.NewTipRepository(db),
+		repository.NewPostRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewCircleRepository(db),
+		c.Svc,
+		1,
+	)
+
+	alice := models.User{WalletAddress: common.HexToAddress("0x00000000000000000000000000000000000a11ce").Hex()}
+	require.NoError(t, db.Create(&alice).Error)
+	tipper := c.Auth.From.Hex()
+	post := testutil.CreatePost(t, db, alice.UserID, &circle.ID)
+	second := testutil.CreatePost(t, db, alice.UserID, &circle.ID)
+	outside := testutil.CreatePost(t, db, alice.UserID, nil)
+
+	tip := func(postID uint64, asset, amount string) common.Hash {
+		prepared, err := svc.PrepareTip(ctx, postID, tipper, &service.PrepareTipRequest{Asset: asset, Amount: amount})
+		require.NoError(t, err)
+		return send(t, c, prepared.Transaction)
+	}
+	submit := func(postID uint64, asset string, hash common.Hash) (*service.TipResponse, error) {
+		return svc.SubmitTip(ctx, postID, tipper, &service.SubmitTipRequest{Asset: asset, TxHash: hash.Hex()})
+	}
+
+	var ethTip common.Hash
+	t.Run("records an ETH tip once confirmed", func(t *testing.T) {
+		prepared, err := svc.PrepareTip(ctx, post.PostID, tipper, &service.PrepareTipRequest{Amount: "0.5"})
+		require.NoError(t, err)
+		assert.Equal(t, alice.WalletAddress, prepared.Transaction.To)
+		assert.Equal(t, "500000000000000000", prepared.Transaction.Value)
+
+		ethTip = send(t, c, prepared.Transaction)
+		_, err = submit(post.PostID, "eth", ethTip)
+		assert.ErrorIs(t, err, web3.ErrTipNotSettled)
+
+		c.Backend.Commit()
+		resp, err := submit(post.PostID, "eth", ethTip)
+		require.NoError(t, err)
+		assert.True(t, resp.Recorded)
+		assert.Empty(t, resp.Tip.TokenAddress)
+		testutil.AssertUnits(t, "0.5", resp.Tip.Amount)
+		testutil.AssertUnits(t, "0.5", resp.RewardAmount)
+		testutil.AssertUnits(t, "0", resp.TokenRewardAmount)
+
+		var notifications []models.Notification
+		require.NoError(t, db.Where("user_id = ? AND notification_type = ?", alice.UserID, "POST_REWARD").Find(&notifications).Error)
+		require.Len(t, notifications, 1)
+		require.NotNil(t, notifications[0].RelatedPostID)
+		assert.Equal(t, post.PostID, *notifications[0].RelatedPostID)
+		assert.Contains(t, *notifications[0].Content, "tipped 0.500000000000000000 ETH")
+	})
+
+	t.Run("counts a resubmitted transaction once", func(t *testing.T) {
+		resp, err := submit(post.PostID, "eth", ethTip)
+		require.NoError(t, err)
+		assert.False(t, resp.Recorded)
+		testutil.AssertUnits(t, "0.5", resp.RewardAmount)
+
+		_, err = submit(second.PostID, "eth", ethTip)
+		assert.ErrorIs(t, err, service.ErrTipClaimed)
+
+		var count int64
+		require.NoError(t, db.Model(&models.Notification{}).Where("user_id = ?", alice.UserID).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("records a circle token tip", func(t *testing.T) {
+		prepared, err := svc.PrepareTip(ctx, post.PostID, tipper, &service.PrepareTipRequest{Asset: "token", Amount: "3"})
+		require.NoError(t, err)
+		assert.Equal(t, circle.TokenAddress, prepared.Transaction.To)
+		assert.Equal(t, "0", prepared.Transaction.Value)
+
+		hash := send(t, c, prepared.Transaction)
+		c.Backend.Commit()
+
+		// The transfer moved tokens, not ETH
+		_, err = submit(post.PostID, "eth", hash)
+		assert.ErrorIs(t, err, web3.ErrTipMismatch)
+
+		resp, err := submit(post.PostID, "token", hash)
+		require.NoError(t, err)
+		assert.True(t, resp.Recorded)
+		assert.Equal(t, circle.TokenAddress, resp.Tip.TokenAddress)
+		testutil.AssertUnits(t, "3", resp.TokenRewardAmount)
+		testutil.AssertUnits(t, "0.5", resp.RewardAmount)
+	})
+
+	t.Run("rejects bad tips", func(t *testing.T) {
+		_, err := svc.PrepareTip(ctx, outside.PostID, tipper, &service.PrepareTipRequest{Asset: "token", Amount: "1"})
+		assert.ErrorIs(t, err, service.ErrInvalidTip)
+		_, err = svc.PrepareTip(ctx, post.PostID, tipper, &service.PrepareTipRequest{Amount: "0"})
+		assert.ErrorIs(t, err, service.ErrInvalidTip)
+		_, err = svc.PrepareTip(ctx, post.PostID, tipper, &service.PrepareTipRequest{Asset: "nft", Amount: "1"})
+		assert.ErrorIs(t, err, service.ErrInvalidTip)
+		_, err = svc.PrepareTip(ctx, post.PostID, alice.WalletAddress, &service.PrepareTipRequest{Amount: "1"})
+		assert.ErrorIs(t, err, service.ErrInvalidTip)
+		_, err = svc.PrepareTip(ctx, 999, tipper, &service.PrepareTipRequest{Amount: "1"})
+		assert.ErrorIs(t, err, service.ErrPostNotFound)
+
+		_, err = svc.SubmitTip(ctx, post.PostID, tipper, &service.SubmitTipRequest{TxHash: "0x1234"})
+		assert.ErrorIs(t, err, service.ErrInvalidTip)
+		_, err = submit(post.PostID, "eth", common.HexToHash("0xdead"))
+		assert.ErrorIs(t, err, web3.ErrTipNotSettled)
+
+		// A transfer to someone else is not a tip to the author
+		other := testutil.CreatePost(t, db, alice.UserID, &circle.ID)
+		require.NoError(t, db.Model(&models.User{}).Where("user_id = ?", alice.UserID).
+			Update("wallet_address", common.HexToAddress("0x0000000000000000000000000000000000000b0b").Hex()).Error)
+		misdirected := tip(other.PostID, "eth", "1")
+		require.NoError(t, db.Model(&models.User{}).Where("user_id = ?", alice.UserID).Update("wallet_address", alice.WalletAddress).Error)
+		c.Backend.Commit()
+		_, err = submit(other.PostID, "eth", misdirected)
+		assert.ErrorIs(t, err, web3.ErrTipMismatch)
+	})
+
+	t.Run("only credits transfers sent for a prepared tip", func(t *testing.T) {
+		// A transfer straight to the author, not prepared through the service
+		transfer := func(amount string) common.Hash {
+			wei, err := web3.ParseUnits(amount, 18)
+			require.NoError(t, err)
+			utx, err := c.Svc.PrepareTip(ctx, c.Auth.From, common.HexToAddress(alice.WalletAddress), common.Address{}, wei)
+			require.NoError(t, err)
+			return send(t, c, utx)
+		}
+
+		unprepared := transfer("0.7")
+		c.Backend.Commit()
+		_, err := submit(second.PostID, "eth", unprepared)
+		assert.ErrorIs(t, err, service.ErrTipNotPrepared)
+
+		// Preparing a tip afterwards does not make the earlier transfer one
+		_, err = svc.PrepareTip(ctx, second.PostID, tipper, &service.PrepareTipRequest{Amount: "0.7"})
+		require.NoError(t, err)
+		_, err = submit(second.PostID, "eth", unprepared)
+		assert.ErrorIs(t, err, service.ErrTipNotPrepared)
+
+		// Nor does it cover a transfer of another amount or to another post
+		otherAmount := transfer("0.2")
+		otherPost := transfer("0.7")
+		c.Backend.Commit()
+		_, err = submit(second.PostID, "eth", otherAmount)
+		assert.ErrorIs(t, err, service.ErrTipNotPrepared)
+		_, err = submit(post.PostID, "eth", otherPost)
+		assert.ErrorIs(t, err, service.ErrTipNotPrepared)
+
+		// A prepared tip is credited once
+		first := transfer("0.7")
+		again := transfer("0.7")
+		c.Backend.Commit()
+		_, err = submit(second.PostID, "eth", first)
+		require.NoError(t, err)
+		_, err = submit(second.PostID, "eth", again)
+		assert.ErrorIs(t, err, service.ErrTipNotPrepared)
+		testutil.AssertUnits(t, "0.7", web3.FormatUnits(rewardOf(t, svc, circle.ID, second.PostID), 18))
+	})
+
+	t.Run("ranks a circle's most tipped posts", func(t *testing.T) {
+		hash := tip(second.PostID, "eth", "1.25")
+		c.Backend.Commit()
+		_, err := submit(second.PostID, "eth", hash)
+		require.NoError(t, err)
+
+		resp, err := svc.GetLeaderboard(ctx, circle.ID, "", 10)
+		require.NoError(t, err)
+		assert.Equal(t, service.TipAssetETH, resp.Asset)
+		assert.Equal(t, []uint64{second.PostID, post.PostID}, tipIDs(resp.Posts))
+		assert.Equal(t, 1, resp.Posts[0].Rank)
+		assert.Equal(t, alice.WalletAddress, resp.Posts[0].Author.WalletAddress)
+
+		resp, err = svc.GetLeaderboard(ctx, circle.ID, "token", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{post.PostID}, tipIDs(resp.Posts))
+
+		require.NoError(t, db.Model(&models.Post{}).Where("post_id = ?", second.PostID).Update("is_deleted", true).Error)
+		resp, err = svc.GetLeaderboard(ctx, circle.ID, "eth", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{post.PostID}, tipIDs(resp.Posts))
+		require.NoError(t, db.Model(&models.Post{}).Where("post_id = ?", second.PostID).Update("is_deleted", false).Error)
+
+		_, err = svc.GetLeaderboard(ctx, circle.ID+100, "eth", 10)
+		assert.ErrorIs(t, err, service.ErrCircleNotFound)
+	})
+
+	t.Run("serves tips over HTTP", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		// Stands in for the auth middleware
+		router.Use(func(c *gin.Context) {
+			if address := c.GetHeader("X-Test-Address"); address != "" {
+				c.Set("user_address", address)
+			}
+		})
+		handler.NewTipHandler(svc).RegisterRoutes(router.Group("/api/v1"))
+		do := func(method, path, body, address string) int {
+			req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			if address != "" {
+				req.Header.Set("X-Test-Address", address)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec.Code
+		}
+		prepare := fmt.Sprintf("/api/v1/posts/%d/tips/prepare", post.PostID)
+		tips := fmt.Sprintf("/api/v1/posts/%d/tips", post.PostID)
+
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, prepare, `{"amount":"1"}`, ""))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, prepare, `{}`, tipper))
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, prepare, `{"amount":"1"}`, tipper))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, prepare, `{"amount":"1"}`, alice.WalletAddress))
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, tips, fmt.Sprintf(`{"tx_hash":"%s"}`, ethTip.Hex()), tipper))
+		assert.Equal(t, http.StatusConflict, do(http.MethodPost, tips, fmt.Sprintf(`{"tx_hash":"%s"}`, common.HexToHash("0xbeef").Hex()), tipper))
+
+		hash := tip(post.PostID, "eth", "0.1")
+		c.Backend.Commit()
+		assert.Equal(t, http.StatusCreated, do(http.MethodPost, tips, fmt.Sprintf(`{"tx_hash":"%s"}`, hash.Hex()), tipper))
+		testutil.AssertUnits(t, "0.6", web3.FormatUnits(rewardOf(t, svc, circle.ID, post.PostID), 18))
+
+		leaderboard := fmt.Sprintf("/api/v1/circles/%d/tips/leaderboard", circle.ID)
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, leaderboard+"?asset=token&limit=5", "", ""))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, leaderboard+"?asset=nft", "", ""))
+		assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/circles/999/tips/leaderboard", "", ""))
+	})
+}
+
+// rewardOf reads a post's ETH tip total off its circle's leaderboard
+func rewardOf(t *testing.T, svc *service.TipService, circleID, postID uint64) *big.Int {
+	resp, err := svc.GetLeaderboard(context.Background(), circleID, "eth", 100)
+	require.NoError(t, err)
+	for _, entry := range resp.Posts {
+		if entry.PostID == postID {
+			amount, err := web3.ParseUnits(entry.RewardAmount, 18)
+			require.NoError(t, err)
+			return amount
+		}
+	}
+	t.Fatalf("post %d is not on the leaderboard", postID)
+	return nil
+}
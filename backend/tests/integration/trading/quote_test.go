@@ -0,0 +1,275 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package trading_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/bondingcurve"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// artifactDir holds the Foundry build output of the contracts
+var artifactDir = filepath.Join("..", "..", "..", "..", "out")
+
+var (
+	basePrice = big.NewInt(1e12)
+	slope     = big.NewInt(1e6)
+	// Every circle token starts with 1000 founder tokens
+	founderSupply = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+)
+
+func loadArtifact(t *testing.T, name string) (abi.ABI, []byte) {
+	raw, err := os.ReadFile(filepath.Join(artifactDir, name+".sol", name+".json"))
+	require.NoError(t, err)
+
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &artifact))
+
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	require.NoError(t, err)
+	return parsed, common.FromHex(artifact.Bytecode.Object)
+}
+
+type fixture struct {
+	svc     *service.TradingService
+	curve   *bind.BoundContract
+	token   common.Address
+	circle  *models.Circle
+	circles *repository.CircleRepository
+}
+
+// setup creates a linear circle through the real factory and records it as
+// confirmed so it can be traded
+func setup(t *testing.T) *fixture {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		owner: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	}, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+
+	chainID := backend.Blockchain().Config().ChainID
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+
+	factoryABI, factoryCode := loadArtifact(t, "CircleFactory")
+	curveABI, _ := loadArtifact(t, "BondingCurve")
+
+	treasury := common.HexToAddress("0x00000000000000000000000000000000000fee01")
+	factoryAddr, _, factory, err := bind.DeployContract(auth, factoryABI, factoryCode, backend, treasury)
+	require.NoError(t, err)
+	backend.Commit()
+
+	opts := *auth
+	opts.Value = big.NewInt(1e16) // circle creation fee
+	_, err = factory.Transact(&opts, "createCircle",
+		"Quote", "QUO", "quote test circle", uint8(0), basePrice, slope, big.NewInt(0), big.NewInt(0),
+	)
+	require.NoError(t, err)
+	backend.Commit()
+
+	var out []interface{}
+	require.NoError(t, factory.Call(&bind.CallOpts{}, &out, "bondingCurveImpl"))
+	curveAddr := out[0].(common.Address)
+	out = nil
+	require.NoError(t, factory.Call(&bind.CallOpts{}, &out, "circles", big.NewInt(1)))
+	token := out[2].(common.Address)
+
+	web3Svc, err := web3.NewWeb3ServiceWithBackend(backend, chainID, factoryAddr.Hex(), curveAddr.Hex())
+	require.NoError(t, err)
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Circle{}, &models.Transaction{}))
+
+	circles := repository.NewCircleRepository(db)
+	circle := &models.Circle{
+		Name:          "Quote",
+		Symbol:        "QUO",
+		ChainCircleID: 1,
+		TokenAddress:  token.Hex(),
+		Active:        true,
+		Status:        "confirmed",
+	}
+	require.NoError(t, circles.Create(context.Background(), circle))
+
+	svc := service.NewTradingService(circles, repository.NewUserRepository(db), repository.NewTransactionRepository(db), web3Svc)
+	return &fixture{
+		svc:     svc,
+		curve:   bind.NewBoundContract(curveAddr, curveABI, backend, backend, backend),
+		token:   token,
+		circle:  circle,
+		circles: circles,
+	}
+}
+
+func (f *fixture) callUint(t *testing.T, method string, args ...interface{}) *big.Int {
+	var out []interface{}
+	require.NoError(t, f.curve.Call(&bind.CallOpts{}, &out, method, args...))
+	return out[0].(*big.Int)
+}
+
+func TestQuoteBuy(t *testing.T) {
+	f := setup(t)
+	amount := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+
+	quote, err := f.svc.Quote(context.Background(), &service.QuoteRequest{
+		CircleID:    f.circle.ID,
+		Side:        "buy",
+		Amount:      amount,
+		SlippageBps: 100,
+	})
+	require.NoError(t, err)
+
+	cost := f.callUint(t, "calculateBuyCost", f.token, amount, founderSupply)
+	params := bondingcurve.NewParams(bondingcurve.Linear, basePrice, slope, nil, nil)
+	newPrice, err := params.Price(new(big.Int).Add(founderSupply, amount))
+	require.NoError(t, err)
+	avg, impact, err := params.BuyPriceImpact(founderSupply, amount)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, quote.QuoteID)
+	assert.Equal(t, founderSupply, quote.Supply)
+	assert.Equal(t, f.callUint(t, "getCurrentPrice", f.token), quote.Price)
+	assert.Equal(t, cost, quote.Cost)
+	assert.Equal(t, newPrice, quote.NewPrice)
+	assert.Equal(t, avg, quote.AveragePrice)
+	assert.Equal(t, impact, quote.PriceImpactBps)
+	// 2.5% transaction fee taken out of the payment
+	assert.Equal(t, new(big.Int).Div(new(big.Int).Mul(cost, big.NewInt(250)), big.NewInt(10000)), quote.Fee)
+
+	maxCost := new(big.Int).Mul(cost, big.NewInt(10100))
+	maxCost.Add(maxCost, big.NewInt(9999)).Div(maxCost, big.NewInt(10000))
+	assert.Equal(t, maxCost, quote.MaxCost)
+	assert.Nil(t, quote.MinRefund)
+	assert.True(t, quote.ExpiresAt.After(time.Now()))
+
+	// No tolerance means the exact cost
+	quote, err = f.svc.Quote(context.Background(), &service.QuoteRequest{CircleID: f.circle.ID, Side: "buy", Amount: amount})
+	require.NoError(t, err)
+	assert.Equal(t, cost, quote.MaxCost)
+}
+
+func TestQuoteSell(t *testing.T) {
+	f := setup(t)
+	amount := new(big.Int).Mul(big.NewInt(25), big.NewInt(1e18))
+
+	quote, err := f.svc.Quote(context.Background(), &service.QuoteRequest{
+		CircleID:    f.circle.ID,
+		Side:        "sell",
+		Amount:      amount,
+		SlippageBps: 50,
+	})
+	require.NoError(t, err)
+
+	refund := f.callUint(t, "calculateSellRefund", f.token, amount, founderSupply)
+	fee := new(big.Int).Div(new(big.Int).Mul(refund, big.NewInt(250)), big.NewInt(10000))
+	params := bondingcurve.NewParams(bondingcurve.Linear, basePrice, slope, nil, nil)
+	newPrice, err := params.Price(new(big.Int).Sub(founderSupply, amount))
+	require.NoError(t, err)
+
+	assert.Equal(t, refund, quote.Refund)
+	assert.Equal(t, fee, quote.Fee)
+	assert.Equal(t, new(big.Int).Sub(refund, fee), quote.NetRefund)
+	assert.Equal(t, newPrice, quote.NewPrice)
+	assert.Equal(t, new(big.Int).Div(new(big.Int).Mul(refund, big.NewInt(9950)), big.NewInt(10000)), quote.MinRefund)
+	assert.Nil(t, quote.MaxCost)
+
+	// Selling more than exists reverts on-chain
+	_, err = f.svc.Quote(context.Background(), &service.QuoteRequest{
+		CircleID: f.circle.ID,
+		Side:     "sell",
+		Amount:   new(big.Int).Add(founderSupply, big.NewInt(1)),
+	})
+	assert.Error(t, err)
+}
+
+func TestQuoteRejectsBadRequests(t *testing.T) {
+	f := setup(t)
+	ctx := context.Background()
+
+	_, err := f.svc.Quote(ctx, &service.QuoteRequest{CircleID: f.circle.ID, Side: "buy", Amount: big.NewInt(0)})
+	assert.ErrorIs(t, err, service.ErrInvalidQuote)
+
+	_, err = f.svc.Quote(ctx, &service.QuoteRequest{CircleID: 999, Side: "buy", Amount: big.NewInt(1e18)})
+	assert.ErrorIs(t, err, service.ErrCircleNotFound)
+
+	// An inactive circle can still be sold out of, but not bought into
+	f.circle.Active = false
+	require.NoError(t, f.circles.Update(ctx, f.circle))
+	_, err = f.svc.Quote(ctx, &service.QuoteRequest{CircleID: f.circle.ID, Side: "buy", Amount: big.NewInt(1e18)})
+	assert.ErrorIs(t, err, service.ErrCircleStateConflict)
+	_, err = f.svc.Quote(ctx, &service.QuoteRequest{CircleID: f.circle.ID, Side: "sell", Amount: big.NewInt(1e18)})
+	assert.NoError(t, err)
+}
+
+func TestQuoteRedemption(t *testing.T) {
+	f := setup(t)
+	ctx := context.Background()
+	from := "0x00000000000000000000000000000000000c0de1"
+	amount := big.NewInt(1e18)
+
+	buyQuote, err := f.svc.Quote(ctx, &service.QuoteRequest{CircleID: f.circle.ID, Side: "buy", Amount: amount})
+	require.NoError(t, err)
+
+	// A buy quote cannot bound a sell
+	_, err = f.svc.PrepareSellTokens(ctx, &service.SellTokensRequest{CircleID: f.circle.ID, QuoteID: buyQuote.QuoteID, FromAddress: from})
+	assert.ErrorIs(t, err, service.ErrQuoteMismatch)
+
+	// Nor a different amount
+	_, err = f.svc.PrepareBuyTokens(ctx, &service.BuyTokensRequest{
+		CircleID: f.circle.ID, QuoteID: buyQuote.QuoteID, Amount: big.NewInt(2e18), FromAddress: from,
+	})
+	assert.ErrorIs(t, err, service.ErrQuoteMismatch)
+
+	_, err = f.svc.PrepareBuyTokens(ctx, &service.BuyTokensRequest{CircleID: f.circle.ID, QuoteID: "missing", FromAddress: from})
+	assert.ErrorIs(t, err, service.ErrQuoteNotFound)
+
+	store := service.NewMemoryQuoteStore()
+	require.NoError(t, store.Save(ctx, &service.TradeQuote{
+		QuoteID:   "stale",
+		CircleID:  f.circle.ID,
+		Side:      "buy",
+		Amount:    amount,
+		MaxCost:   big.NewInt(1),
+		ExpiresAt: time.Now().Add(-time.Second),
+	}))
+	f.svc.SetQuoteStore(store)
+	_, err = f.svc.PrepareBuyTokens(ctx, &service.BuyTokensRequest{CircleID: f.circle.ID, QuoteID: "stale", FromAddress: from})
+	assert.ErrorIs(t, err, service.ErrQuoteExpired)
+}
@@ -0,0 +1,231 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package votes_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/handler"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
+	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/tests/testutil"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	db := testutil.OpenDB(t)
+	testutil.MigrateIndexer(t, db)
+	testutil.Migrate(t, db, &models.Post{}, &models.Comment{}, &models.Vote{})
+	return db
+}
+
+func postVotes(t *testing.T, db *gorm.DB, postID uint64) (uint, uint) {
+	var post models.Post
+	require.NoError(t, db.First(&post, "post_id = ?", postID).Error)
+	return post.Upvotes, post.Downvotes
+}
+
+func newVoteService(db *gorm.DB, cfg config.VoteConfig) *service.VoteService {
+	return service.NewVoteService(
+		repository.NewVoteRepository(db),
+		repository.NewPostRepository(db),
+		repository.NewCommentRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewHolderRepository(db),
+		cfg,
+	)
+}
+
+// TestVotes moves votes through up, down and retract, checks that retries
+// and concurrent repeats count once, weighs votes in circles by token
+// balance and reconciles drifted counters from the ledger
+func TestVotes(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	svc := newVoteService(db, config.VoteConfig{})
+
+	alice := models.User{WalletAddress: common.HexToAddress("0x00000000000000000000000000000000000a11ce").Hex()}
+	bob := common.HexToAddress("0x0000000000000000000000000000000000000b0b").Hex()
+	carol := common.HexToAddress("0x0000000000000000000000000000000000000ca7").Hex()
+	require.NoError(t, db.Create(&alice).Error)
+	post := testutil.CreatePost(t, db, alice.UserID, nil)
+	comment := &models.Comment{PostID: post.PostID, AuthorID: alice.UserID, Content: "nice"}
+	require.NoError(t, db.Omit("Author", "Post").Create(comment).Error)
+
+	t.Run("moves through up, down and retract", func(t *testing.T) {
+		resp, err := svc.VotePost(ctx, post.PostID, bob, "up")
+		require.NoError(t, err)
+		assert.True(t, resp.Changed)
+		assert.Equal(t, service.VoteUp, resp.Direction)
+		assert.Equal(t, uint(1), resp.Upvotes)
+
+		// A retry counts once
+		resp, err = svc.VotePost(ctx, post.PostID, bob, "UP")
+		require.NoError(t, err)
+		assert.False(t, resp.Changed)
+		assert.Equal(t, uint(1), resp.Upvotes)
+
+		_, err = svc.VotePost(ctx, post.PostID, carol, "up")
+		require.NoError(t, err)
+		resp, err = svc.VotePost(ctx, post.PostID, bob, "down")
+		require.NoError(t, err)
+		assert.True(t, resp.Changed)
+		assert.Equal(t, uint(1), resp.Upvotes)
+		assert.Equal(t, uint(1), resp.Downvotes)
+		up, down := postVotes(t, db, post.PostID)
+		assert.Equal(t, uint(1), up)
+		assert.Equal(t, uint(1), down)
+
+		resp, err = svc.VotePost(ctx, post.PostID, bob, "none")
+		require.NoError(t, err)
+		assert.True(t, resp.Changed)
+		assert.Equal(t, uint(0), resp.Downvotes)
+		resp, err = svc.VotePost(ctx, post.PostID, bob, "none")
+		require.NoError(t, err)
+		assert.False(t, resp.Changed)
+
+		var ledger int64
+		require.NoError(t, db.Model(&models.Vote{}).Where("target_type = ? AND target_id = ?", models.VoteTargetPost, post.PostID).Count(&ledger).Error)
+		assert.Equal(t, int64(1), ledger, "only carol's vote stands")
+	})
+
+	t.Run("counts concurrent repeats once", func(t *testing.T) {
+		dave := common.HexToAddress("0x000000000000000000000000000000000000da7e").Hex()
+		_, err := svc.VoteComment(ctx, comment.CommentID, dave, "none")
+		require.NoError(t, err, "creates the voter up front")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := svc.VoteComment(ctx, comment.CommentID, dave, "up")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		var stored models.Comment
+		require.NoError(t, db.First(&stored, "comment_id = ?", comment.CommentID).Error)
+		assert.Equal(t, uint(1), stored.Upvotes)
+	})
+
+	t.Run("rejects bad votes", func(t *testing.T) {
+		_, err := svc.VotePost(ctx, post.PostID, bob, "sideways")
+		assert.ErrorIs(t, err, service.ErrInvalidVote)
+		_, err = svc.VoteComment(ctx, comment.CommentID, bob, "down")
+		assert.ErrorIs(t, err, service.ErrInvalidVote)
+		_, err = svc.VotePost(ctx, 999, bob, "up")
+		assert.ErrorIs(t, err, service.ErrPostNotFound)
+		_, err = svc.VoteComment(ctx, 999, bob, "up")
+		assert.ErrorIs(t, err, service.ErrCommentNotFound)
+
+		deleted := testutil.CreatePost(t, db, alice.UserID, nil)
+		require.NoError(t, db.Model(deleted).Update("is_deleted", true).Error)
+		_, err = svc.VotePost(ctx, deleted.PostID, bob, "up")
+		assert.ErrorIs(t, err, service.ErrPostNotFound)
+	})
+
+	t.Run("weighs circle votes by token balance", func(t *testing.T) {
+		weighted := newVoteService(db, config.VoteConfig{WeightByBalance: true, MaxWeight: 10})
+		circleID := uint64(7)
+		circlePost := testutil.CreatePost(t, db, alice.UserID, &circleID)
+		require.NoError(t, db.Create(&models.CircleHolder{CircleID: circleID, HolderAddress: bob, Balance: "49", CostBasis: "0"}).Error)
+		require.NoError(t, db.Create(&models.CircleHolder{CircleID: circleID, HolderAddress: carol, Balance: "1000000", CostBasis: "0"}).Error)
+
+		resp, err := weighted.VotePost(ctx, circlePost.PostID, bob, "up")
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), resp.Upvotes)
+		resp, err = weighted.VotePost(ctx, circlePost.PostID, carol, "down")
+		require.NoError(t, err)
+		assert.Equal(t, uint(10), resp.Downvotes, "capped")
+		dave := common.HexToAddress("0x000000000000000000000000000000000000da7e").Hex()
+		resp, err = weighted.VotePost(ctx, circlePost.PostID, dave, "up")
+		require.NoError(t, err)
+		assert.Equal(t, uint(8), resp.Upvotes, "holding nothing still counts once")
+
+		// A vote keeps the weight it was cast with until it changes
+		require.NoError(t, db.Model(&models.CircleHolder{}).Where("holder_address = ?", bob).Update("balance", "0").Error)
+		resp, err = weighted.VotePost(ctx, circlePost.PostID, bob, "up")
+		require.NoError(t, err)
+		assert.False(t, resp.Changed)
+		assert.Equal(t, uint(8), resp.Upvotes)
+		resp, err = weighted.VotePost(ctx, circlePost.PostID, bob, "none")
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), resp.Upvotes)
+
+		// Votes outside circles are never weighted
+		resp, err = weighted.VotePost(ctx, post.PostID, carol, "down")
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), resp.Downvotes)
+	})
+
+	t.Run("reconciles counters from the ledger", func(t *testing.T) {
+		require.NoError(t, db.Model(&models.Post{}).Where("post_id = ?", post.PostID).
+			Updates(map[string]interface{}{"upvotes": 40, "downvotes": 3}).Error)
+		require.NoError(t, db.Model(&models.Comment{}).Where("comment_id = ?", comment.CommentID).Update("upvotes", 0).Error)
+
+		fixed, err := svc.Reconcile(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), fixed)
+		up, down := postVotes(t, db, post.PostID)
+		assert.Equal(t, uint(0), up)
+		assert.Equal(t, uint(1), down)
+		var stored models.Comment
+		require.NoError(t, db.First(&stored, "comment_id = ?", comment.CommentID).Error)
+		assert.Equal(t, uint(1), stored.Upvotes)
+
+		fixed, err = svc.Reconcile(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), fixed)
+	})
+
+	t.Run("serves votes over HTTP", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		// Stands in for the auth middleware
+		router.Use(func(c *gin.Context) {
+			if address := c.GetHeader("X-Test-Address"); address != "" {
+				c.Set("user_address", address)
+			}
+		})
+		handler.NewVoteHandler(svc).RegisterRoutes(router.Group("/api/v1"))
+		do := func(method, path, body, address string) int {
+			req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			if address != "" {
+				req.Header.Set("X-Test-Address", address)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec.Code
+		}
+		postPath := fmt.Sprintf("/api/v1/posts/%d/vote", post.PostID)
+		commentPath := fmt.Sprintf("/api/v1/comments/%d/vote", comment.CommentID)
+
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodPut, postPath, `{"direction":"up"}`, ""))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, postPath, `{}`, bob))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, postPath, `{"direction":"sideways"}`, bob))
+		assert.Equal(t, http.StatusOK, do(http.MethodPut, postPath, `{"direction":"up"}`, bob))
+		assert.Equal(t, http.StatusOK, do(http.MethodDelete, postPath, "", bob))
+		assert.Equal(t, http.StatusNotFound, do(http.MethodPut, "/api/v1/posts/999/vote", `{"direction":"up"}`, bob))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, commentPath, `{"direction":"down"}`, bob))
+		assert.Equal(t, http.StatusOK, do(http.MethodPut, commentPath, `{"direction":"up"}`, bob))
+		assert.Equal(t, http.StatusOK, do(http.MethodDelete, commentPath, "", bob))
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodDelete, "/api/v1/comments/abc/vote", "", bob))
+	})
+}
@@ -0,0 +1,219 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/indexer"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// artifactDir holds the Foundry build output of the contracts
+var artifactDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "out")
+}()
+
+// Chain is a simulated chain with the contracts deployed and a funded
+// account that owns them
+type Chain struct {
+	Backend *backends.SimulatedBackend
+	Auth    *bind.TransactOpts
+	Factory *bind.BoundContract
+	Svc     *web3.Web3Service
+}
+
+// LoadArtifact reads a contract's ABI and creation code from the Foundry
+// build output
+func LoadArtifact(t *testing.T, name string) (abi.ABI, []byte) {
+	raw, err := os.ReadFile(filepath.Join(artifactDir, name+".sol", name+".json"))
+	require.NoError(t, err)
+
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &artifact))
+
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	require.NoError(t, err)
+	return parsed, common.FromHex(artifact.Bytecode.Object)
+}
+
+// NewChain deploys the circle factory, and with it the bonding curve, on a
+// fresh simulated chain
+func NewChain(t *testing.T) *Chain {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		owner: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	}, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+
+	chainID := backend.Blockchain().Config().ChainID
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+
+	factoryABI, factoryCode := LoadArtifact(t, "CircleFactory")
+
+	treasury := common.HexToAddress("0x00000000000000000000000000000000000fee01")
+	factoryAddr, _, factory, err := bind.DeployContract(auth, factoryABI, factoryCode, backend, treasury)
+	require.NoError(t, err)
+	backend.Commit()
+
+	var out []interface{}
+	require.NoError(t, factory.Call(&bind.CallOpts{}, &out, "bondingCurveImpl"))
+	curveAddr := out[0].(common.Address)
+
+	svc, err := web3.NewWeb3ServiceWithBackend(backend, chainID, factoryAddr.Hex(), curveAddr.Hex())
+	require.NoError(t, err)
+
+	return &Chain{
+		Backend: backend,
+		Auth:    auth,
+		Factory: factory,
+		Svc:     svc,
+	}
+}
+
+// Tokens converts whole circle tokens to base units
+func Tokens(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+// CreateCircle sends a createCircle call with a linear curve
+func (c *Chain) CreateCircle(t *testing.T, name, symbol string) *types.Transaction {
+	opts := *c.Auth
+	opts.Value = big.NewInt(1e16) // circle creation fee
+	tx, err := c.Factory.Transact(&opts, "createCircle",
+		name, symbol, "test circle", uint8(0),
+		big.NewInt(1e12), big.NewInt(1e6), big.NewInt(0), big.NewInt(0),
+	)
+	require.NoError(t, err)
+	return tx
+}
+
+// Mine seals the pending block and checks the given transactions succeeded.
+// Blocks on a fork only become canonical once the fork is the longest chain,
+// so their transactions are checked after the fork has been extended.
+func (c *Chain) Mine(t *testing.T, txs ...*types.Transaction) common.Hash {
+	hash := c.Backend.Commit()
+	c.RequireSuccess(t, txs...)
+	return hash
+}
+
+// RequireSuccess checks the given transactions were mined without reverting
+func (c *Chain) RequireSuccess(t *testing.T, txs ...*types.Transaction) {
+	for _, tx := range txs {
+		receipt, err := c.Backend.TransactionReceipt(context.Background(), tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "transaction %s reverted", tx.Hash().Hex())
+	}
+}
+
+// Sign signs a transaction prepared for a wallet with the chain's account
+func (c *Chain) Sign(t *testing.T, utx *web3.UnsignedTx) string {
+	tip, _ := new(big.Int).SetString(utx.MaxPriorityFeePerGas, 10)
+	feeCap, _ := new(big.Int).SetString(utx.MaxFeePerGas, 10)
+	value, _ := new(big.Int).SetString(utx.Value, 10)
+	to := common.HexToAddress(utx.To)
+
+	tx, err := c.Auth.Signer(c.Auth.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   c.Backend.Blockchain().Config().ChainID,
+		Nonce:     utx.Nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       utx.Gas,
+		To:        &to,
+		Value:     value,
+		Data:      hexutil.MustDecode(utx.Data),
+	}))
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return hexutil.Encode(raw)
+}
+
+// Fund sends a wallet enough ETH from the chain's account to pay for gas
+func (c *Chain) Fund(t *testing.T, to common.Address) {
+	ctx := context.Background()
+	nonce, err := c.Backend.PendingNonceAt(ctx, c.Auth.From)
+	require.NoError(t, err)
+	head, err := c.Backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	tx, err := c.Auth.Signer(c.Auth.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   c.Backend.Blockchain().Config().ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: new(big.Int).Mul(head.BaseFee, big.NewInt(2)),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+	}))
+	require.NoError(t, err)
+	require.NoError(t, c.Backend.SendTransaction(ctx, tx))
+	c.Mine(t, tx)
+}
+
+// SyncToHead runs the indexer until it has caught up with the chain
+func SyncToHead(t *testing.T, ix *indexer.Indexer) {
+	for i := 0; i < 10; i++ {
+		caughtUp, err := ix.SyncOnce(context.Background())
+		require.NoError(t, err)
+		if caughtUp {
+			return
+		}
+	}
+	t.Fatal("indexer did not reach the chain head")
+}
+
+// CircleByChainID loads the indexed circle with the given on-chain ID
+func CircleByChainID(t *testing.T, db *gorm.DB, chainCircleID uint64) models.Circle {
+	var circle models.Circle
+	require.NoError(t, db.Where("chain_circle_id = ?", chainCircleID).First(&circle).Error)
+	return circle
+}
+
+// TransferTokens sends whole circle tokens from the chain's account
+func (c *Chain) TransferTokens(t *testing.T, token *contracts.CircleToken, to common.Address, amount int64) *types.Transaction {
+	tx, err := token.Transfer(c.Auth, to, Tokens(amount))
+	require.NoError(t, err)
+	return tx
+}
+
+// AssertUnits compares two decimal token amounts by value
+func AssertUnits(t *testing.T, want, got string) {
+	wantUnits, err := web3.ParseUnits(want, 18)
+	require.NoError(t, err)
+	gotUnits, err := web3.ParseUnits(got, 18)
+	require.NoError(t, err)
+	assert.Equal(t, wantUnits, gotUnits, "want %s, got %s", want, got)
+}
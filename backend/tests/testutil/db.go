@@ -0,0 +1,96 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package testutil
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// dialector is SQLite with the MySQL enum columns of the models declared as
+// text, so the tables can be created from the models themselves
+type dialector struct {
+	*sqlite.Dialector
+}
+
+func (d dialector) DataTypeOf(field *schema.Field) string {
+	if strings.HasPrefix(strings.ToLower(string(field.DataType)), "enum(") {
+		return "text"
+	}
+	return d.Dialector.DataTypeOf(field)
+}
+
+func (d dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	m := d.Dialector.Migrator(db).(sqlite.Migrator)
+	m.Dialector = d
+	return m
+}
+
+// OpenDB opens an empty SQLite database private to the test. It lives in a
+// file rather than in shared-cache memory so that concurrent writers wait
+// for each other's locks, as they would on MySQL, instead of failing.
+func OpenDB(t *testing.T) *gorm.DB {
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate", filepath.Join(t.TempDir(), "test.db"))
+	db, err := gorm.Open(dialector{sqlite.Open(dsn).(*sqlite.Dialector)}, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// Migrate creates the tables of the given models
+func Migrate(t *testing.T, db *gorm.DB, tables ...interface{}) {
+	require.NoError(t, db.AutoMigrate(tables...))
+}
+
+// MigrateIndexer creates the tables the contract event indexer and the
+// transaction reconciler write to
+func MigrateIndexer(t *testing.T, db *gorm.DB) {
+	Migrate(t, db,
+		&models.User{},
+		&models.Circle{},
+		&models.UserCircleRelationship{},
+		&models.Trade{},
+		&models.Notification{},
+		&models.Transaction{},
+		&models.IndexerCursor{},
+		&models.IndexedBlock{},
+		&models.CircleEvent{},
+		&models.CircleMembershipRule{},
+		&models.TokenTransfer{},
+		&models.CircleHolder{},
+		&models.Candle{},
+		&models.PortfolioSnapshot{},
+	)
+}
+
+// CreatePost inserts a post directly, standing in for one created through
+// IPFS
+func CreatePost(t *testing.T, db *gorm.DB, authorID uint64, circleID *uint64) *models.Post {
+	post := &models.Post{
+		AuthorID:          authorID,
+		CircleID:          circleID,
+		ContentIPFSHash:   "bafypost",
+		ContentType:       "TEXT",
+		RewardAmount:      "0",
+		TokenRewardAmount: "0",
+		ModerationStatus:  "APPROVED",
+	}
+	require.NoError(t, db.Omit("Author", "Circle").Create(post).Error)
+	return post
+}
@@ -0,0 +1,105 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package testutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fast-socialfi/backend/internal/config"
+)
+
+// FakeIPFS is an in-process IPFS node and gateway. Content is addressed by
+// its SHA-256, which is enough for tests even if it is not a real CID.
+type FakeIPFS struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	down    bool
+}
+
+// NewFakeIPFS starts a fake IPFS node that is stopped when the test ends
+func NewFakeIPFS(t *testing.T) *FakeIPFS {
+	f := &FakeIPFS{objects: make(map[string][]byte)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v0/add", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || f.isDown() {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		content, err := io.ReadAll(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sum := sha256.Sum256(content)
+		cid := "bafy" + hex.EncodeToString(sum[:20])
+
+		f.mu.Lock()
+		f.objects[cid] = content
+		f.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]string{"Name": "post.json", "Hash": cid, "Size": fmt.Sprint(len(content))})
+	})
+	mux.HandleFunc("/ipfs/", func(w http.ResponseWriter, r *http.Request) {
+		if f.isDown() {
+			http.Error(w, "unavailable", http.StatusBadGateway)
+			return
+		}
+		content, ok := f.Get(strings.TrimPrefix(r.URL.Path, "/ipfs/"))
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(content)
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// Config points an IPFS client at the fake node
+func (f *FakeIPFS) Config() config.IPFSConfig {
+	return config.IPFSConfig{NodeURL: f.server.URL, Gateway: f.server.URL + "/ipfs"}
+}
+
+// URL returns the base URL of the fake node
+func (f *FakeIPFS) URL() string {
+	return f.server.URL
+}
+
+// Get returns the content stored under a CID
+func (f *FakeIPFS) Get(cid string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	content, ok := f.objects[cid]
+	return content, ok
+}
+
+func (f *FakeIPFS) isDown() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.down
+}
+
+// SetDown makes the node fail every request until it is brought back up
+func (f *FakeIPFS) SetDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
@@ -0,0 +1,220 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package bondingcurve_test
+
+import (
+	"encoding/json"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fast-socialfi/backend/internal/bondingcurve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Regenerate the vectors from the compiled contracts with
+//
+//	go test ./tests/unit/bondingcurve -run TestGoldenVectors -update
+var update = flag.Bool("update", false, "regenerate golden vectors from the Solidity contracts")
+
+var goldenPath = filepath.Join("testdata", "golden_vectors.json")
+
+// revert marks a value the contract call reverted for
+const revert = "revert"
+
+// curveCase is a set of curve arguments as passed to createCircle
+type curveCase struct {
+	Name      string `json:"name"`
+	CurveType uint8  `json:"curve_type"`
+	BasePrice string `json:"base_price"`
+	Param1    string `json:"param1"`
+	Param2    string `json:"param2"`
+	Param3    string `json:"param3"`
+}
+
+// vector holds the contract's answers for one curve, supply and amount
+type vector struct {
+	Curve      string `json:"curve"`
+	Supply     string `json:"supply"`
+	Amount     string `json:"amount"`
+	Price      string `json:"price"`
+	BuyCost    string `json:"buy_cost"`
+	SellRefund string `json:"sell_refund"`
+	BuyAvg     string `json:"buy_avg"`
+	BuyImpact  string `json:"buy_impact"`
+	SellAvg    string `json:"sell_avg"`
+	SellImpact string `json:"sell_impact"`
+}
+
+type goldenFile struct {
+	Curves  []curveCase `json:"curves"`
+	Vectors []vector    `json:"vectors"`
+}
+
+var (
+	linearSupplies      = []string{"0", "1", "1000", "1000000000000000000", "1000000000000000000000", "12345678901234567890123"}
+	linearAmounts       = []string{"1", "7", "1000000000000000000", "250000000000000000000"}
+	exponentialSupplies = []string{"0", "1", "2", "50", "1000"}
+	exponentialAmounts  = []string{"1", "2", "7", "60"}
+)
+
+func goldenCurves() []curveCase {
+	return []curveCase{
+		{Name: "linear", CurveType: 0, BasePrice: "1000000000000000", Param1: "1000000000000", Param2: "0", Param3: "0"},
+		{Name: "linear-flat", CurveType: 0, BasePrice: "1000000000000000", Param1: "0", Param2: "0", Param3: "0"},
+		{Name: "linear-steep", CurveType: 0, BasePrice: "1", Param1: "500000000000000000", Param2: "0", Param3: "0"},
+		{Name: "linear-overflow", CurveType: 0, BasePrice: "100000000000000000000000000000000000000000000000000000000000", Param1: "1", Param2: "0", Param3: "0"},
+		{Name: "exponential", CurveType: 1, BasePrice: "1000000000000000", Param1: "0", Param2: "10000000000000000000", Param3: "0"},
+		{Name: "exponential-steep", CurveType: 1, BasePrice: "3", Param1: "0", Param2: "123456789", Param3: "0"},
+		{Name: "sigmoid", CurveType: 2, BasePrice: "1000000000000000", Param1: "0", Param2: "0", Param3: "1000000000000000000000"},
+		{Name: "sigmoid-underflow", CurveType: 2, BasePrice: "1000000000000000", Param1: "0", Param2: "0", Param3: "1000"},
+	}
+}
+
+func curveSupplies(c curveCase) ([]string, []string) {
+	if c.CurveType == 1 {
+		return exponentialSupplies, exponentialAmounts
+	}
+	return linearSupplies, linearAmounts
+}
+
+func num(t *testing.T, s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	require.True(t, ok, "bad number %q", s)
+	return v
+}
+
+func (c curveCase) params(t *testing.T) bondingcurve.Params {
+	return bondingcurve.NewParams(
+		bondingcurve.CurveType(c.CurveType),
+		num(t, c.BasePrice), num(t, c.Param1), num(t, c.Param2), num(t, c.Param3),
+	)
+}
+
+// format renders a calculator result the way the generator records a call
+func format(v *big.Int, err error) string {
+	if err != nil {
+		return revert
+	}
+	return v.String()
+}
+
+func TestGoldenVectors(t *testing.T) {
+	if *update {
+		golden := generateGoldenVectors(t)
+		raw, err := json.MarshalIndent(golden, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenPath), 0o755))
+		require.NoError(t, os.WriteFile(goldenPath, append(raw, '\n'), 0o644))
+	}
+
+	raw, err := os.ReadFile(goldenPath)
+	require.NoError(t, err)
+	var golden goldenFile
+	require.NoError(t, json.Unmarshal(raw, &golden))
+	require.NotEmpty(t, golden.Vectors)
+
+	curves := make(map[string]bondingcurve.Params)
+	for _, c := range golden.Curves {
+		curves[c.Name] = c.params(t)
+	}
+
+	for _, v := range golden.Vectors {
+		p, ok := curves[v.Curve]
+		require.True(t, ok, "unknown curve %s", v.Curve)
+		supply, amount := num(t, v.Supply), num(t, v.Amount)
+		label := v.Curve + "/supply=" + v.Supply + "/amount=" + v.Amount
+
+		assert.Equal(t, v.Price, format(p.Price(supply)), "%s price", label)
+		assert.Equal(t, v.BuyCost, format(p.BuyCost(supply, amount)), "%s buy cost", label)
+		assert.Equal(t, v.SellRefund, format(p.SellRefund(supply, amount)), "%s sell refund", label)
+
+		avg, impact, err := p.BuyPriceImpact(supply, amount)
+		assert.Equal(t, v.BuyAvg, format(avg, err), "%s buy average", label)
+		assert.Equal(t, v.BuyImpact, format(impact, err), "%s buy impact", label)
+
+		avg, impact, err = p.SellPriceImpact(supply, amount)
+		assert.Equal(t, v.SellAvg, format(avg, err), "%s sell average", label)
+		assert.Equal(t, v.SellImpact, format(impact, err), "%s sell impact", label)
+	}
+}
+
+// sqrt and power are internal to the library and unused by BondingCurve, so
+// no deployed bytecode exposes them; these vectors are worked by hand from
+// the Solidity source
+func TestSqrt(t *testing.T) {
+	cases := map[string]string{
+		"0":                   "0",
+		"1":                   "1",
+		"2":                   "1",
+		"3":                   "1",
+		"4":                   "2",
+		"15":                  "3",
+		"16":                  "4",
+		"1000000000000000000": "1000000000",
+	}
+	for in, want := range cases {
+		got, err := bondingcurve.Sqrt(num(t, in))
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got.String(), "sqrt(%s)", in)
+	}
+
+	// Babylonian iteration converges on the floor of the square root
+	for _, in := range []string{"99999999999999999999", "123456789012345678901234567890"} {
+		x := num(t, in)
+		y, err := bondingcurve.Sqrt(x)
+		require.NoError(t, err)
+		next := new(big.Int).Add(y, big.NewInt(1))
+		assert.True(t, new(big.Int).Mul(y, y).Cmp(x) <= 0, "sqrt(%s) too large", in)
+		assert.True(t, new(big.Int).Mul(next, next).Cmp(x) > 0, "sqrt(%s) too small", in)
+	}
+
+	// The first step computes x + 1, which overflows for the largest uint256
+	_, err := bondingcurve.Sqrt(num(t, "115792089237316195423570985008687907853269984665640564039457584007913129639935"))
+	assert.ErrorIs(t, err, bondingcurve.ErrOverflow)
+}
+
+func TestPower(t *testing.T) {
+	cases := []struct {
+		base, exponent, want string
+	}{
+		{"2000000000000000000", "0", "1000000000000000000"},
+		{"2000000000000000000", "10", "1024000000000000000000"},
+		{"1500000000000000000", "3", "3375000000000000000"},
+		{"1010000000000000000", "5", "1051010050100000000"},
+		// Every multiplication rounds down, so 0.333...^2 loses its last digits
+		{"333333333333333333", "2", "111111111111111110"},
+		{"0", "5", "0"},
+	}
+	for _, c := range cases {
+		got, err := bondingcurve.Power(num(t, c.base), num(t, c.exponent))
+		require.NoError(t, err)
+		assert.Equal(t, c.want, got.String(), "power(%s, %s)", c.base, c.exponent)
+	}
+
+	// Squaring the base overflows uint256 and reverts on-chain
+	_, err := bondingcurve.Power(new(big.Int).Lsh(big.NewInt(1), 200), big.NewInt(2))
+	assert.ErrorIs(t, err, bondingcurve.ErrOverflow)
+}
+
+func TestCalculatorErrors(t *testing.T) {
+	linear := bondingcurve.NewParams(bondingcurve.Linear, big.NewInt(1e15), big.NewInt(1e12), nil, nil)
+	_, err := linear.SellRefund(big.NewInt(5), big.NewInt(6))
+	assert.ErrorIs(t, err, bondingcurve.ErrInsufficientSupply)
+
+	_, _, err = linear.BuyPriceImpact(big.NewInt(5), big.NewInt(0))
+	assert.ErrorIs(t, err, bondingcurve.ErrDivisionByZero)
+
+	exponential := bondingcurve.NewParams(bondingcurve.Exponential, big.NewInt(1e15), nil, big.NewInt(1e4), nil)
+	_, err = exponential.BuyCost(big.NewInt(0), new(big.Int).Add(bondingcurve.MaxExponentialSteps, big.NewInt(1)))
+	assert.ErrorIs(t, err, bondingcurve.ErrTooManySteps)
+
+	sigmoid := bondingcurve.NewParams(bondingcurve.Sigmoid, big.NewInt(1e15), nil, nil, big.NewInt(1000))
+	_, err = sigmoid.Price(big.NewInt(1))
+	assert.ErrorIs(t, err, bondingcurve.ErrUnderflow)
+}
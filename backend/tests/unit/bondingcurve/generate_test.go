@@ -0,0 +1,180 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package bondingcurve_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// artifactDir holds the Foundry build output of the contracts
+var artifactDir = filepath.Join("..", "..", "..", "..", "out")
+
+func loadArtifact(t *testing.T, name string) (abi.ABI, []byte) {
+	raw, err := os.ReadFile(filepath.Join(artifactDir, name+".sol", name+".json"))
+	require.NoError(t, err)
+
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &artifact))
+
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	require.NoError(t, err)
+	return parsed, common.FromHex(artifact.Bytecode.Object)
+}
+
+// contracts runs the compiled BondingCurve with the test account standing in
+// for the factory, so it can initialize curves and mint or burn supply
+type contracts struct {
+	backend  *backends.SimulatedBackend
+	auth     *bind.TransactOpts
+	curve    *bind.BoundContract
+	curveABI abi.ABI
+	tokenABI abi.ABI
+	tokenBin []byte
+	curveAt  common.Address
+}
+
+func deployContracts(t *testing.T) *contracts {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		from: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	}, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+
+	auth, err := bind.NewKeyedTransactorWithChainID(key, backend.Blockchain().Config().ChainID)
+	require.NoError(t, err)
+
+	curveABI, curveBin := loadArtifact(t, "BondingCurve")
+	tokenABI, tokenBin := loadArtifact(t, "CircleToken")
+
+	curveAt, _, curve, err := bind.DeployContract(auth, curveABI, curveBin, backend, from)
+	require.NoError(t, err)
+	backend.Commit()
+
+	return &contracts{
+		backend:  backend,
+		auth:     auth,
+		curve:    curve,
+		curveABI: curveABI,
+		tokenABI: tokenABI,
+		tokenBin: tokenBin,
+		curveAt:  curveAt,
+	}
+}
+
+// transact sends a call and requires it to succeed
+func (c *contracts) transact(t *testing.T, contract *bind.BoundContract, method string, args ...interface{}) {
+	tx, err := contract.Transact(c.auth, method, args...)
+	require.NoError(t, err, method)
+	c.backend.Commit()
+
+	receipt, err := c.backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.Status, "%s reverted", method)
+}
+
+// call returns the contract's outputs as decimal strings, or revert for each
+// output if the call reverted
+func (c *contracts) call(contract *bind.BoundContract, outputs int, method string, args ...interface{}) []string {
+	var out []interface{}
+	err := contract.Call(&bind.CallOpts{}, &out, method, args...)
+
+	values := make([]string, outputs)
+	for i := range values {
+		if err != nil {
+			values[i] = revert
+		} else {
+			values[i] = out[i].(*big.Int).String()
+		}
+	}
+	return values
+}
+
+// newToken deploys a circle token whose curve uses the given arguments
+func (c *contracts) newToken(t *testing.T, cc curveCase) (common.Address, *bind.BoundContract) {
+	from := c.auth.From
+	at, _, token, err := bind.DeployContract(c.auth, c.tokenABI, c.tokenBin, c.backend,
+		cc.Name, strings.ToUpper(cc.Name[:3]), from, from, c.curveAt, from, big.NewInt(1))
+	require.NoError(t, err)
+	c.backend.Commit()
+
+	c.transact(t, c.curve, "initializeCurve", at, cc.CurveType,
+		num(t, cc.BasePrice), num(t, cc.Param1), num(t, cc.Param2), num(t, cc.Param3))
+	return at, token
+}
+
+// setSupply mints or burns the token until its total supply matches
+func (c *contracts) setSupply(t *testing.T, token *bind.BoundContract, supply *big.Int) {
+	var out []interface{}
+	require.NoError(t, token.Call(&bind.CallOpts{}, &out, "totalSupply"))
+	current := out[0].(*big.Int)
+
+	switch current.Cmp(supply) {
+	case -1:
+		c.transact(t, token, "mint", c.auth.From, new(big.Int).Sub(supply, current))
+	case 1:
+		c.transact(t, token, "burn", c.auth.From, new(big.Int).Sub(current, supply))
+	}
+}
+
+// generateGoldenVectors asks the compiled contracts for every quote the
+// calculator offers
+func generateGoldenVectors(t *testing.T) goldenFile {
+	c := deployContracts(t)
+	golden := goldenFile{Curves: goldenCurves()}
+
+	for _, cc := range golden.Curves {
+		at, token := c.newToken(t, cc)
+		supplies, amounts := curveSupplies(cc)
+
+		for _, s := range supplies {
+			supply := num(t, s)
+			c.setSupply(t, token, supply)
+			price := c.call(c.curve, 1, "getCurrentPrice", at)[0]
+
+			for _, a := range amounts {
+				amount := num(t, a)
+				buy := c.call(c.curve, 2, "getBuyPriceImpact", at, amount)
+				sell := c.call(c.curve, 2, "getSellPriceImpact", at, amount)
+
+				golden.Vectors = append(golden.Vectors, vector{
+					Curve:      cc.Name,
+					Supply:     s,
+					Amount:     a,
+					Price:      price,
+					BuyCost:    c.call(c.curve, 1, "calculateBuyCost", at, amount, supply)[0],
+					SellRefund: c.call(c.curve, 1, "calculateSellRefund", at, amount, supply)[0],
+					BuyAvg:     buy[0],
+					BuyImpact:  buy[1],
+					SellAvg:    sell[0],
+					SellImpact: sell[1],
+				})
+			}
+		}
+	}
+
+	return golden
+}
@@ -0,0 +1,82 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package candles_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/candles"
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func units(t *testing.T, value string) *big.Int {
+	amount, err := web3.ParseUnits(value, 18)
+	require.NoError(t, err)
+	return amount
+}
+
+func TestOpenTimeUsesUTCBuckets(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*60*60)
+	at := time.Date(2025, 2, 12, 1, 30, 45, 0, shanghai)
+
+	assert.Equal(t, time.Date(2025, 2, 11, 17, 30, 0, 0, time.UTC), candles.OpenTime(at, time.Minute))
+	assert.Equal(t, time.Date(2025, 2, 11, 17, 0, 0, 0, time.UTC), candles.OpenTime(at, time.Hour))
+	assert.Equal(t, time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC), candles.OpenTime(at, 24*time.Hour))
+}
+
+func TestAggregate(t *testing.T) {
+	base := time.Date(2025, 2, 11, 17, 0, 0, 0, time.UTC)
+	trade := func(offset time.Duration, price, tokens, eth string) *models.Candle {
+		return candles.FromTrade(&models.Trade{
+			CircleID:    7,
+			Price:       price,
+			TokenAmount: tokens,
+			ETHAmount:   eth,
+			Timestamp:   base.Add(offset),
+		})
+	}
+
+	fiveMinutes, ok := candles.Lookup("5m")
+	require.True(t, ok)
+	_, ok = candles.Lookup("15m")
+	assert.False(t, ok)
+
+	merged, err := candles.Aggregate(fiveMinutes, []*models.Candle{
+		trade(0, "2", "1", "2"),
+		trade(time.Minute, "5", "1", "5"),
+		trade(2*time.Minute, "1", "3", "3"),
+		trade(4*time.Minute, "3", "1", "3"),
+		trade(6*time.Minute, "4", "2", "8"),
+	})
+	require.NoError(t, err)
+	require.Len(t, merged, 2)
+
+	first := merged[0]
+	assert.Equal(t, uint64(7), first.CircleID)
+	assert.Equal(t, "5m", first.Interval)
+	assert.Equal(t, base, first.OpenTime)
+	assert.Equal(t, "2", first.Open)
+	assert.Equal(t, units(t, "5"), units(t, first.High))
+	assert.Equal(t, units(t, "1"), units(t, first.Low))
+	assert.Equal(t, "3", first.Close)
+	assert.Equal(t, units(t, "6"), units(t, first.TokenVolume))
+	assert.Equal(t, units(t, "13"), units(t, first.ETHVolume))
+	assert.Equal(t, 4, first.TradeCount)
+
+	assert.Equal(t, base.Add(5*time.Minute), merged[1].OpenTime)
+	assert.Equal(t, 1, merged[1].TradeCount)
+
+	empty, err := candles.Aggregate(fiveMinutes, nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	_, err = candles.Aggregate(fiveMinutes, []*models.Candle{trade(0, "not a price", "1", "1")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package pnl_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/pnl"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func units(t *testing.T, value string) *big.Int {
+	amount, err := web3.ParseUnits(value, 18)
+	require.NoError(t, err)
+	return amount
+}
+
+func trade(tradeType, tokens, eth string) *models.Trade {
+	return &models.Trade{TradeType: tradeType, TokenAmount: tokens, ETHAmount: eth}
+}
+
+// assertPnL compares a position with cost basis, realized and unrealized
+func assertPnL(t *testing.T, p *pnl.Position, costBasis, realized, unrealized string) {
+	t.Helper()
+	assert.Equal(t, units(t, costBasis).String(), p.CostBasis.String(), "cost basis")
+	assert.Equal(t, units(t, realized).String(), p.Realized.String(), "realized")
+	assert.Equal(t, units(t, unrealized).String(), p.Unrealized.String(), "unrealized")
+}
+
+func TestFromTradesFIFOAndAverageCost(t *testing.T) {
+	trades := []*models.Trade{
+		trade("BUY", "10", "10"),
+		trade("BUY", "10", "20"),
+		trade("SELL", "15", "45"),
+	}
+
+	// FIFO sells the whole first lot and half of the second; average cost
+	// sells at 1.5 ETH a token
+	fifo, average, err := pnl.FromTrades(trades, units(t, "5"), units(t, "4"))
+	require.NoError(t, err)
+	assertPnL(t, fifo, "10", "25", "10")
+	assertPnL(t, average, "7.5", "22.5", "12.5")
+
+	// Both methods agree on the total
+	assert.Equal(t, units(t, "35").String(), fifo.Total().String())
+	assert.Equal(t, units(t, "35").String(), average.Total().String())
+}
+
+func TestFromTradesFollowsTransfers(t *testing.T) {
+	trades := []*models.Trade{
+		trade("BUY", "10", "10"),
+		trade("BUY", "10", "20"),
+	}
+
+	// Tokens sent away leave the position without realizing anything
+	fifo, average, err := pnl.FromTrades(trades, units(t, "5"), units(t, "2"))
+	require.NoError(t, err)
+	assertPnL(t, fifo, "10", "0", "0")
+	assertPnL(t, average, "7.5", "0", "2.5")
+
+	// Tokens received carry no cost, and selling them is all gain
+	trades = append(trades, trade("SELL", "25", "50"))
+	fifo, average, err = pnl.FromTrades(trades, units(t, "5"), units(t, "2"))
+	require.NoError(t, err)
+	assertPnL(t, fifo, "0", "20", "10")
+	assertPnL(t, average, "0", "20", "10")
+}
+
+func TestFromTradesWithoutTrades(t *testing.T) {
+	fifo, average, err := pnl.FromTrades(nil, units(t, "3"), units(t, "0.5"))
+	require.NoError(t, err)
+	assertPnL(t, fifo, "0", "0", "1.5")
+	assertPnL(t, average, "0", "0", "1.5")
+
+	_, _, err = pnl.FromTrades([]*models.Trade{trade("BUY", "x", "1")}, units(t, "0"), units(t, "1"))
+	assert.Error(t, err)
+}
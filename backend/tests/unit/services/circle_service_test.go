@@ -6,334 +6,320 @@ package services_test
 
 import (
 	"context"
-	"errors"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/repository"
 	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
-// MockCircleRepository is a mock implementation of CircleRepository
-type MockCircleRepository struct {
-	mock.Mock
-}
-
-func (m *MockCircleRepository) Create(ctx context.Context, circle *models.Circle) error {
-	args := m.Called(ctx, circle)
-	return args.Error(0)
-}
-
-func (m *MockCircleRepository) GetByID(ctx context.Context, id uint64) (*models.Circle, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*models.Circle), args.Error(1)
-}
+// artifactDir holds the Foundry build output of the contracts
+var artifactDir = filepath.Join("..", "..", "..", "..", "out")
 
-func (m *MockCircleRepository) Update(ctx context.Context, circle *models.Circle) error {
-	args := m.Called(ctx, circle)
-	return args.Error(0)
-}
+func loadArtifact(t *testing.T, name string) (abi.ABI, []byte) {
+	raw, err := os.ReadFile(filepath.Join(artifactDir, name+".sol", name+".json"))
+	require.NoError(t, err)
 
-func (m *MockCircleRepository) List(ctx context.Context, limit, offset int) ([]*models.Circle, error) {
-	args := m.Called(ctx, limit, offset)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
 	}
-	return args.Get(0).([]*models.Circle), args.Error(1)
-}
+	require.NoError(t, json.Unmarshal(raw, &artifact))
 
-func (m *MockCircleRepository) Count(ctx context.Context) (int64, error) {
-	args := m.Called(ctx)
-	return args.Get(0).(int64), args.Error(1)
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	require.NoError(t, err)
+	return parsed, common.FromHex(artifact.Bytecode.Object)
 }
 
-func (m *MockCircleRepository) Search(ctx context.Context, query string, limit, offset int) ([]*models.Circle, error) {
-	args := m.Called(ctx, query, limit, offset)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*models.Circle), args.Error(1)
+type fixture struct {
+	backend *backends.SimulatedBackend
+	chainID *big.Int
+	key     *ecdsa.PrivateKey
+	owner   common.Address
+	factory common.Address
+	curve   common.Address
+	circles *repository.CircleRepository
+	txs     *repository.TransactionRepository
+	circle  *service.CircleService
+	trading *service.TradingService
 }
 
-func (m *MockCircleRepository) GetTrending(ctx context.Context, limit int) ([]*models.Circle, error) {
-	args := m.Called(ctx, limit)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
+// setup deploys the real factory on a simulated chain and wires the services
+// to it and to an in-memory database. Nothing is signed with a server key:
+// the test key plays the user's wallet.
+func setup(t *testing.T) *fixture {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		owner: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	}, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+
+	chainID := backend.Blockchain().Config().ChainID
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+
+	factoryABI, factoryCode := loadArtifact(t, "CircleFactory")
+	treasury := common.HexToAddress("0x00000000000000000000000000000000000fee01")
+	factoryAddr, _, factory, err := bind.DeployContract(auth, factoryABI, factoryCode, backend, treasury)
+	require.NoError(t, err)
+	backend.Commit()
+
+	var out []interface{}
+	require.NoError(t, factory.Call(&bind.CallOpts{}, &out, "bondingCurveImpl"))
+	curveAddr := out[0].(common.Address)
+
+	web3Svc, err := web3.NewWeb3ServiceWithBackend(backend, chainID, factoryAddr.Hex(), curveAddr.Hex())
+	require.NoError(t, err)
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Circle{}, &models.Transaction{}))
+
+	circles := repository.NewCircleRepository(db)
+	users := repository.NewUserRepository(db)
+	txs := repository.NewTransactionRepository(db)
+
+	return &fixture{
+		backend: backend,
+		chainID: chainID,
+		key:     key,
+		owner:   owner,
+		factory: factoryAddr,
+		curve:   curveAddr,
+		circles: circles,
+		txs:     txs,
+		circle:  service.NewCircleService(circles, users, txs, repository.NewCircleEventRepository(db), web3Svc),
+		trading: service.NewTradingService(circles, users, txs, web3Svc),
 	}
-	return args.Get(0).([]*models.Circle), args.Error(1)
 }
 
-// MockUserRepository mock
-type MockUserRepository struct {
-	mock.Mock
+// sign signs a prepared transaction the way the user's wallet would
+func (f *fixture) sign(t *testing.T, key *ecdsa.PrivateKey, utx *web3.UnsignedTx) string {
+	chainID, _ := new(big.Int).SetString(utx.ChainID, 10)
+	tip, _ := new(big.Int).SetString(utx.MaxPriorityFeePerGas, 10)
+	feeCap, _ := new(big.Int).SetString(utx.MaxFeePerGas, 10)
+	value, _ := new(big.Int).SetString(utx.Value, 10)
+	to := common.HexToAddress(utx.To)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     utx.Nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       utx.Gas,
+		To:        &to,
+		Value:     value,
+		Data:      hexutil.MustDecode(utx.Data),
+	})
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return hexutil.Encode(raw)
 }
 
-// MockTransactionRepository mock
-type MockTransactionRepository struct {
-	mock.Mock
-}
-
-// MockWeb3Service mock
-type MockWeb3Service struct {
-	mock.Mock
-}
-
-func (m *MockWeb3Service) CreateCircle(ctx context.Context, privateKey string, params interface{}) (string, error) {
-	args := m.Called(ctx, privateKey, params)
-	return args.String(0), args.Error(1)
-}
-
-func (m *MockWeb3Service) GetCircle(ctx context.Context, circleID *big.Int) (interface{}, error) {
-	args := m.Called(ctx, circleID)
-	return args.Get(0), args.Error(1)
-}
-
-func (m *MockWeb3Service) GetCurrentPrice(ctx context.Context, tokenAddress interface{}) (*big.Int, error) {
-	args := m.Called(ctx, tokenAddress)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*big.Int), args.Error(1)
-}
-
-// TestCreateCircle_Success tests successful circle creation
-func TestCreateCircle_Success(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewCircleService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
-	ctx := context.Background()
-	req := &service.CreateCircleRequest{
+func (f *fixture) createRequest() *service.CreateCircleRequest {
+	return &service.CreateCircleRequest{
 		Name:        "Test Circle",
 		Symbol:      "TST",
 		Description: "Test Description",
 		CurveType:   0, // Linear
-		BasePrice:   big.NewInt(1000000000000000), // 0.001 ETH
-		K:           big.NewInt(1000000000000000),
-		PrivateKey:  "0xtest",
+		BasePrice:   big.NewInt(1e12),
+		K:           big.NewInt(1e6),
+		M:           big.NewInt(0),
+		N:           big.NewInt(0),
+		FromAddress: f.owner.Hex(),
 	}
+}
 
-	expectedTxHash := "0x1234567890abcdef"
-
-	// Expectations
-	mockWeb3Svc.On("CreateCircle", ctx, req.PrivateKey, mock.Anything).Return(expectedTxHash, nil)
-	mockCircleRepo.On("Create", ctx, mock.AnythingOfType("*models.Circle")).Return(nil)
+// createCircle creates a circle through the wallet flow, mines it and syncs
+// the confirmed circle from the chain
+func (f *fixture) createCircle(t *testing.T) *models.Circle {
+	ctx := context.Background()
+	prepared, err := f.circle.PrepareCreateCircle(ctx, f.createRequest())
+	require.NoError(t, err)
+
+	resp, err := f.circle.SubmitCreateCircle(ctx, &service.SubmitCreateCircleRequest{
+		SignedTx:    f.sign(t, f.key, prepared.Transaction),
+		FromAddress: f.owner.Hex(),
+	})
+	require.NoError(t, err)
+	f.backend.Commit()
+
+	circle, err := f.circles.GetByID(ctx, resp.CircleID)
+	require.NoError(t, err)
+	circle.ChainCircleID = 1
+	require.NoError(t, f.circles.Update(ctx, circle))
+	require.NoError(t, f.circle.UpdateCircleFromBlockchain(ctx, circle.ID))
+
+	circle, err = f.circles.GetByID(ctx, resp.CircleID)
+	require.NoError(t, err)
+	return circle
+}
 
-	// Execute
-	resp, err := svc.CreateCircle(ctx, req)
+func TestCreateCircle_Success(t *testing.T) {
+	f := setup(t)
+	ctx := context.Background()
 
-	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, resp)
-	assert.Equal(t, expectedTxHash, resp.TxHash)
+	prepared, err := f.circle.PrepareCreateCircle(ctx, f.createRequest())
+	require.NoError(t, err)
+	assert.Equal(t, f.owner.Hex(), prepared.Transaction.From)
+	assert.Equal(t, f.factory.Hex(), prepared.Transaction.To)
+	assert.Equal(t, "10000000000000000", prepared.Transaction.Value, "the creation fee is sent as value")
+
+	resp, err := f.circle.SubmitCreateCircle(ctx, &service.SubmitCreateCircleRequest{
+		SignedTx:    f.sign(t, f.key, prepared.Transaction),
+		FromAddress: f.owner.Hex(),
+	})
+	require.NoError(t, err)
 	assert.Contains(t, resp.Message, "submitted")
 
-	mockWeb3Svc.AssertExpectations(t)
-	mockCircleRepo.AssertExpectations(t)
+	circle, err := f.circles.GetByID(ctx, resp.CircleID)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", circle.Status)
+	assert.Equal(t, "Test Circle", circle.Name)
+	assert.Equal(t, f.owner.Hex(), circle.OwnerAddress)
+	assert.Equal(t, resp.TxHash, circle.TxHash)
+
+	tx, err := f.txs.GetByHash(ctx, resp.TxHash)
+	require.NoError(t, err)
+	assert.Equal(t, "create_circle", tx.TxType)
+
+	f.backend.Commit()
+	receipt, err := f.backend.TransactionReceipt(ctx, common.HexToHash(resp.TxHash))
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
 }
 
-// TestCreateCircle_InvalidCurveParams tests validation failure
 func TestCreateCircle_InvalidCurveParams(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewCircleService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
-	ctx := context.Background()
-	req := &service.CreateCircleRequest{
-		Name:        "Test Circle",
-		Symbol:      "TST",
-		Description: "Test Description",
-		CurveType:   0, // Linear
-		BasePrice:   big.NewInt(1000000000000000),
-		K:           big.NewInt(0), // Invalid: K must be > 0
-		PrivateKey:  "0xtest",
-	}
-
-	// Execute
-	resp, err := svc.CreateCircle(ctx, req)
+	f := setup(t)
+	req := f.createRequest()
+	req.K = big.NewInt(0) // Invalid: K must be > 0
 
-	// Assert
-	assert.Error(t, err)
+	resp, err := f.circle.PrepareCreateCircle(context.Background(), req)
 	assert.Nil(t, resp)
-	assert.Contains(t, err.Error(), "invalid curve parameters")
+	assert.ErrorContains(t, err, "invalid curve parameters")
 }
 
-// TestCreateCircle_BlockchainFailure tests blockchain failure
-func TestCreateCircle_BlockchainFailure(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewCircleService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+func TestSubmitCreateCircle_RejectsInvalidTransactions(t *testing.T) {
+	f := setup(t)
 	ctx := context.Background()
-	req := &service.CreateCircleRequest{
-		Name:        "Test Circle",
-		Symbol:      "TST",
-		Description: "Test Description",
-		CurveType:   0,
-		BasePrice:   big.NewInt(1000000000000000),
-		K:           big.NewInt(1000000000000000),
-		PrivateKey:  "0xtest",
-	}
-
-	// Expectations
-	mockWeb3Svc.On("CreateCircle", ctx, req.PrivateKey, mock.Anything).
-		Return("", errors.New("blockchain error"))
-
-	// Execute
-	resp, err := svc.CreateCircle(ctx, req)
-
-	// Assert
-	assert.Error(t, err)
-	assert.Nil(t, resp)
-	assert.Contains(t, err.Error(), "failed to create circle on blockchain")
-
-	mockWeb3Svc.AssertExpectations(t)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	prepared, err := f.circle.PrepareCreateCircle(ctx, f.createRequest())
+	require.NoError(t, err)
+
+	// Signed by another wallet than the one submitting it
+	_, err = f.circle.SubmitCreateCircle(ctx, &service.SubmitCreateCircleRequest{
+		SignedTx:    f.sign(t, other, prepared.Transaction),
+		FromAddress: f.owner.Hex(),
+	})
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+
+	// Curve parameters are checked again on the signed calldata
+	factoryABI, _ := loadArtifact(t, "CircleFactory")
+	data, err := factoryABI.Pack("createCircle", "Test Circle", "TST", "Test Description", uint8(0),
+		big.NewInt(1e12), big.NewInt(0), big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	tampered := *prepared.Transaction
+	tampered.Data = hexutil.Encode(data)
+	_, err = f.circle.SubmitCreateCircle(ctx, &service.SubmitCreateCircleRequest{
+		SignedTx:    f.sign(t, f.key, &tampered),
+		FromAddress: f.owner.Hex(),
+	})
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+	assert.ErrorContains(t, err, "invalid curve parameters")
+
+	// Other factory methods are not circle creations
+	data, err = factoryABI.Pack("deactivateCircle", big.NewInt(1))
+	require.NoError(t, err)
+	tampered.Data = hexutil.Encode(data)
+	_, err = f.circle.SubmitCreateCircle(ctx, &service.SubmitCreateCircleRequest{
+		SignedTx:    f.sign(t, f.key, &tampered),
+		FromAddress: f.owner.Hex(),
+	})
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+
+	circles, total, err := f.circle.ListCircles(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, circles, "nothing is stored for a rejected transaction")
+	assert.Zero(t, total)
 }
 
-// TestGetCircle_Success tests successful circle retrieval
 func TestGetCircle_Success(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewCircleService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
-	ctx := context.Background()
-	circleID := uint64(1)
-
-	expectedCircle := &models.Circle{
-		ID:          circleID,
-		Name:        "Test Circle",
-		Symbol:      "TST",
-		Description: "Test Description",
-		Status:      "pending",
-	}
-
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, circleID).Return(expectedCircle, nil)
-
-	// Execute
-	result, err := svc.GetCircle(ctx, circleID)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, expectedCircle, result.Circle)
-
-	mockCircleRepo.AssertExpectations(t)
+	f := setup(t)
+	created := f.createCircle(t)
+
+	result, err := f.circle.GetCircle(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, result.Circle.ID)
+	assert.Equal(t, "confirmed", result.Circle.Status)
+	assert.NotEqual(t, common.Address{}.Hex(), result.Circle.TokenAddress)
+	price, ok := result.CurrentPrice.(*big.Int)
+	require.True(t, ok)
+	assert.Equal(t, 1, price.Sign(), "confirmed circles carry their on-chain price")
 }
 
-// TestGetCircle_NotFound tests circle not found scenario
 func TestGetCircle_NotFound(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewCircleService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
-	ctx := context.Background()
-	circleID := uint64(999)
-
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, circleID).Return(nil, errors.New("not found"))
-
-	// Execute
-	result, err := svc.GetCircle(ctx, circleID)
+	f := setup(t)
 
-	// Assert
-	assert.Error(t, err)
+	result, err := f.circle.GetCircle(context.Background(), 999)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "circle not found")
-
-	mockCircleRepo.AssertExpectations(t)
+	assert.ErrorContains(t, err, "circle not found")
 }
 
-// TestListCircles_Success tests successful listing
 func TestListCircles_Success(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewCircleService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+	f := setup(t)
 	ctx := context.Background()
-	limit, offset := 10, 0
-
-	expectedCircles := []*models.Circle{
-		{ID: 1, Name: "Circle 1"},
-		{ID: 2, Name: "Circle 2"},
-	}
-
-	// Expectations
-	mockCircleRepo.On("List", ctx, limit, offset).Return(expectedCircles, nil)
-	mockCircleRepo.On("Count", ctx).Return(int64(2), nil)
+	require.NoError(t, f.circles.Create(ctx, &models.Circle{Name: "Circle 1", Symbol: "ONE", ChainCircleID: 1, TokenAddress: "0x0000000000000000000000000000000000000001", Status: "pending", TxHash: "0x01"}))
+	require.NoError(t, f.circles.Create(ctx, &models.Circle{Name: "Circle 2", Symbol: "TWO", ChainCircleID: 2, TokenAddress: "0x0000000000000000000000000000000000000002", Status: "pending", TxHash: "0x02"}))
 
-	// Execute
-	circles, total, err := svc.ListCircles(ctx, limit, offset)
-
-	// Assert
-	assert.NoError(t, err)
+	circles, total, err := f.circle.ListCircles(ctx, 10, 0)
+	require.NoError(t, err)
 	assert.Len(t, circles, 2)
 	assert.Equal(t, int64(2), total)
-
-	mockCircleRepo.AssertExpectations(t)
 }
 
-// TestSearchCircles_Success tests successful search
 func TestSearchCircles_Success(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewCircleService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+	f := setup(t)
 	ctx := context.Background()
-	query := "test"
-	limit, offset := 10, 0
-
-	expectedCircles := []*models.Circle{
-		{ID: 1, Name: "Test Circle 1"},
-	}
-
-	// Expectations
-	mockCircleRepo.On("Search", ctx, query, limit, offset).Return(expectedCircles, nil)
-
-	// Execute
-	circles, err := svc.SearchCircles(ctx, query, limit, offset)
+	require.NoError(t, f.circles.Create(ctx, &models.Circle{Name: "Test Circle 1", Symbol: "ONE", ChainCircleID: 1, TokenAddress: "0x0000000000000000000000000000000000000001", Status: "pending", TxHash: "0x01"}))
+	require.NoError(t, f.circles.Create(ctx, &models.Circle{Name: "Other", Symbol: "TWO", ChainCircleID: 2, TokenAddress: "0x0000000000000000000000000000000000000002", Status: "pending", TxHash: "0x02"}))
 
-	// Assert
-	assert.NoError(t, err)
+	circles, err := f.circle.SearchCircles(ctx, "test", 10, 0)
+	require.NoError(t, err)
 	assert.Len(t, circles, 1)
-
-	mockCircleRepo.AssertExpectations(t)
 }
 
-// TestValidateCurveParams tests curve parameter validation
 func TestValidateCurveParams(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -347,7 +333,8 @@ func TestValidateCurveParams(t *testing.T) {
 			name:      "Valid Linear",
 			curveType: 0,
 			k:         big.NewInt(1),
-			wantError: false,
+			m:         big.NewInt(0),
+			n:         big.NewInt(0),
 		},
 		{
 			name:      "Invalid Linear - K is 0",
@@ -359,15 +346,8 @@ func TestValidateCurveParams(t *testing.T) {
 			name:      "Valid Exponential",
 			curveType: 1,
 			k:         big.NewInt(1),
-			wantError: false,
-		},
-		{
-			name:      "Valid Sigmoid",
-			curveType: 2,
-			k:         big.NewInt(1),
-			m:         big.NewInt(1),
-			n:         big.NewInt(1),
-			wantError: false,
+			m:         big.NewInt(0),
+			n:         big.NewInt(0),
 		},
 		{
 			name:      "Invalid Sigmoid - Missing M",
@@ -384,41 +364,20 @@ func TestValidateCurveParams(t *testing.T) {
 		},
 	}
 
+	f := setup(t)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockCircleRepo := new(MockCircleRepository)
-			mockUserRepo := new(MockUserRepository)
-			mockTxRepo := new(MockTransactionRepository)
-			mockWeb3Svc := new(MockWeb3Service)
-
-			svc := service.NewCircleService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
-			// We need to access the private method indirectly via CreateCircle
-			ctx := context.Background()
-			req := &service.CreateCircleRequest{
-				Name:        "Test",
-				Symbol:      "TST",
-				Description: "Test",
-				CurveType:   tt.curveType,
-				BasePrice:   big.NewInt(1000),
-				K:           tt.k,
-				M:           tt.m,
-				N:           tt.n,
-				PrivateKey:  "0xtest",
-			}
-
-			_, err := svc.CreateCircle(ctx, req)
+			// The private check runs when the transaction is prepared
+			req := f.createRequest()
+			req.CurveType = tt.curveType
+			req.K, req.M, req.N = tt.k, tt.m, tt.n
 
+			_, err := f.circle.PrepareCreateCircle(context.Background(), req)
 			if tt.wantError {
-				assert.Error(t, err)
-			} else {
-				// We expect blockchain call to happen, so mock it
-				if !tt.wantError {
-					mockWeb3Svc.On("CreateCircle", ctx, req.PrivateKey, mock.Anything).
-						Return("0xtxhash", nil).Maybe()
-					mockCircleRepo.On("Create", ctx, mock.Anything).Return(nil).Maybe()
-				}
+				assert.ErrorContains(t, err, "invalid curve parameters")
+				return
 			}
+			assert.NoError(t, err)
 		})
 	}
 }
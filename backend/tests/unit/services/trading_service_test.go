@@ -6,346 +6,227 @@ package services_test
 
 import (
 	"context"
-	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/fast-socialfi/backend/internal/models"
 	"github.com/fast-socialfi/backend/internal/service"
+	"github.com/fast-socialfi/backend/internal/web3"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func (m *MockWeb3Service) BuyTokens(ctx context.Context, privateKey string, tokenAddr common.Address, amount, maxCost *big.Int) (string, error) {
-	args := m.Called(ctx, privateKey, tokenAddr, amount, maxCost)
-	return args.String(0), args.Error(1)
-}
-
-func (m *MockWeb3Service) SellTokens(ctx context.Context, privateKey string, tokenAddr common.Address, amount, minRefund *big.Int) (string, error) {
-	args := m.Called(ctx, privateKey, tokenAddr, amount, minRefund)
-	return args.String(0), args.Error(1)
-}
-
-func (m *MockWeb3Service) GetTokenBalance(ctx context.Context, tokenAddr, userAddr common.Address) (*big.Int, error) {
-	args := m.Called(ctx, tokenAddr, userAddr)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*big.Int), args.Error(1)
-}
+var tokens = new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
 
-func (m *MockTransactionRepository) Create(ctx context.Context, tx *models.Transaction) error {
-	args := m.Called(ctx, tx)
-	return args.Error(0)
+// signTrade signs a bonding curve call the way the user's wallet would. The
+// gas is fixed rather than estimated: the token only lets the factory mint
+// and burn, so the curve's trades revert when executed.
+func (f *fixture) signTrade(t *testing.T, method string, value *big.Int, args ...interface{}) string {
+	ctx := context.Background()
+	curveABI, _ := loadArtifact(t, "BondingCurve")
+	data, err := curveABI.Pack(method, args...)
+	require.NoError(t, err)
+
+	nonce, err := f.backend.PendingNonceAt(ctx, f.owner)
+	require.NoError(t, err)
+	head, err := f.backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	return f.sign(t, f.key, &web3.UnsignedTx{
+		ChainID:              f.chainID.String(),
+		To:                   f.curve.Hex(),
+		Nonce:                nonce,
+		Gas:                  300_000,
+		MaxPriorityFeePerGas: "1",
+		MaxFeePerGas:         new(big.Int).Mul(head.BaseFee, big.NewInt(2)).String(),
+		Value:                value.String(),
+		Data:                 hexutil.Encode(data),
+	})
 }
 
-// TestBuyTokens_Success tests successful token purchase
 func TestBuyTokens_Success(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewTradingService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+	f := setup(t)
 	ctx := context.Background()
-	req := &service.BuyTokensRequest{
-		CircleID:   1,
-		Amount:     big.NewInt(100),
-		MaxCost:    big.NewInt(1000000000000000000), // 1 ETH
-		PrivateKey: "0xtest",
-	}
-
-	circle := &models.Circle{
-		ID:           1,
-		Status:       "confirmed",
-		Active:       true,
-		TokenAddress: "0x1234567890123456789012345678901234567890",
-	}
-
-	expectedTxHash := "0xabcdef"
-
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, req.CircleID).Return(circle, nil)
-	mockWeb3Svc.On("BuyTokens", ctx, req.PrivateKey, mock.Anything, req.Amount, req.MaxCost).
-		Return(expectedTxHash, nil)
-	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
-
-	// Execute
-	resp, err := svc.BuyTokens(ctx, req)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, resp)
-	assert.Equal(t, expectedTxHash, resp.TxHash)
+	circle := f.createCircle(t)
+	token := common.HexToAddress(circle.TokenAddress)
+
+	resp, err := f.trading.SubmitTrade(ctx, &service.SubmitTradeRequest{
+		CircleID:    circle.ID,
+		SignedTx:    f.signTrade(t, "buyTokens", big.NewInt(1e16), token, tokens),
+		FromAddress: f.owner.Hex(),
+	})
+	require.NoError(t, err)
 	assert.Contains(t, resp.Message, "Buy transaction submitted")
 
-	mockCircleRepo.AssertExpectations(t)
-	mockWeb3Svc.AssertExpectations(t)
-	mockTxRepo.AssertExpectations(t)
+	tx, err := f.txs.GetByHash(ctx, resp.TxHash)
+	require.NoError(t, err)
+	assert.Equal(t, "buy", tx.TxType)
+	assert.Equal(t, "pending", tx.Status)
+	assert.Equal(t, f.curve.Hex(), tx.ToAddress)
+	assert.Equal(t, circle.TokenAddress, tx.TokenAddress)
+	// Only the minimum is known until the purchase is indexed
+	amount, ok := new(big.Float).SetString(tx.Amount)
+	require.True(t, ok)
+	assert.Zero(t, amount.Sign())
+
+	_, pending, err := f.backend.TransactionByHash(ctx, common.HexToHash(resp.TxHash))
+	require.NoError(t, err)
+	assert.True(t, pending, "the signed transaction is broadcast as is")
 }
 
-// TestBuyTokens_CircleNotFound tests buy with non-existent circle
 func TestBuyTokens_CircleNotFound(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewTradingService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
-	ctx := context.Background()
-	req := &service.BuyTokensRequest{
-		CircleID:   999,
-		Amount:     big.NewInt(100),
-		MaxCost:    big.NewInt(1000000000000000000),
-		PrivateKey: "0xtest",
-	}
-
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, req.CircleID).Return(nil, errors.New("not found"))
-
-	// Execute
-	resp, err := svc.BuyTokens(ctx, req)
-
-	// Assert
-	assert.Error(t, err)
+	f := setup(t)
+
+	resp, err := f.trading.PrepareBuyTokens(context.Background(), &service.BuyTokensRequest{
+		CircleID:    999,
+		Amount:      tokens,
+		MaxCost:     big.NewInt(1e18),
+		FromAddress: f.owner.Hex(),
+	})
 	assert.Nil(t, resp)
-	assert.Contains(t, err.Error(), "circle not found")
-
-	mockCircleRepo.AssertExpectations(t)
+	assert.ErrorContains(t, err, "circle not found")
 }
 
-// TestBuyTokens_CircleNotConfirmed tests buy with unconfirmed circle
 func TestBuyTokens_CircleNotConfirmed(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewTradingService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+	f := setup(t)
 	ctx := context.Background()
-	req := &service.BuyTokensRequest{
-		CircleID:   1,
-		Amount:     big.NewInt(100),
-		MaxCost:    big.NewInt(1000000000000000000),
-		PrivateKey: "0xtest",
-	}
-
-	circle := &models.Circle{
-		ID:     1,
-		Status: "pending",
-	}
-
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, req.CircleID).Return(circle, nil)
-
-	// Execute
-	resp, err := svc.BuyTokens(ctx, req)
-
-	// Assert
-	assert.Error(t, err)
+	circle := &models.Circle{Name: "Pending", Symbol: "PND", Status: "pending", TxHash: "0x01"}
+	require.NoError(t, f.circles.Create(ctx, circle))
+
+	resp, err := f.trading.PrepareBuyTokens(ctx, &service.BuyTokensRequest{
+		CircleID:    circle.ID,
+		Amount:      tokens,
+		MaxCost:     big.NewInt(1e18),
+		FromAddress: f.owner.Hex(),
+	})
 	assert.Nil(t, resp)
-	assert.Contains(t, err.Error(), "not confirmed")
-
-	mockCircleRepo.AssertExpectations(t)
+	assert.ErrorContains(t, err, "not confirmed")
 }
 
-// TestBuyTokens_CircleNotActive tests buy with inactive circle
 func TestBuyTokens_CircleNotActive(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewTradingService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+	f := setup(t)
 	ctx := context.Background()
-	req := &service.BuyTokensRequest{
-		CircleID:   1,
-		Amount:     big.NewInt(100),
-		MaxCost:    big.NewInt(1000000000000000000),
-		PrivateKey: "0xtest",
-	}
-
-	circle := &models.Circle{
-		ID:           1,
-		Status:       "confirmed",
-		Active:       false,
-		TokenAddress: "0x1234567890123456789012345678901234567890",
-	}
-
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, req.CircleID).Return(circle, nil)
-
-	// Execute
-	resp, err := svc.BuyTokens(ctx, req)
-
-	// Assert
-	assert.Error(t, err)
+	circle := f.createCircle(t)
+	token := common.HexToAddress(circle.TokenAddress)
+
+	circle.Active = false
+	require.NoError(t, f.circles.Update(ctx, circle))
+
+	resp, err := f.trading.PrepareBuyTokens(ctx, &service.BuyTokensRequest{
+		CircleID:    circle.ID,
+		Amount:      tokens,
+		MaxCost:     big.NewInt(1e18), // 1 ETH
+		FromAddress: f.owner.Hex(),
+	})
 	assert.Nil(t, resp)
-	assert.Contains(t, err.Error(), "not active")
-
-	mockCircleRepo.AssertExpectations(t)
+	assert.ErrorContains(t, err, "not active")
+
+	// A buy signed while the circle was active is not broadcast either
+	_, err = f.trading.SubmitTrade(ctx, &service.SubmitTradeRequest{
+		CircleID:    circle.ID,
+		SignedTx:    f.signTrade(t, "buyTokens", big.NewInt(1e16), token, tokens),
+		FromAddress: f.owner.Hex(),
+	})
+	assert.ErrorContains(t, err, "not active")
 }
 
-// TestSellTokens_Success tests successful token sale
-func TestSellTokens_Success(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewTradingService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+func TestSubmitTrade_RejectsInvalidTransactions(t *testing.T) {
+	f := setup(t)
 	ctx := context.Background()
-	req := &service.SellTokensRequest{
-		CircleID:   1,
-		Amount:     big.NewInt(50),
-		MinRefund:  big.NewInt(500000000000000000), // 0.5 ETH
-		PrivateKey: "0xtest",
+	circle := f.createCircle(t)
+
+	other := &models.Circle{
+		Name:          "Other",
+		Symbol:        "OTH",
+		ChainCircleID: 2,
+		Status:        "confirmed",
+		Active:        true,
+		TokenAddress:  "0x1234567890123456789012345678901234567890",
+		TxHash:        "0x02",
 	}
-
-	circle := &models.Circle{
-		ID:           1,
-		Status:       "confirmed",
-		Active:       true,
-		TokenAddress: "0x1234567890123456789012345678901234567890",
-	}
-
-	expectedTxHash := "0xdef456"
-
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, req.CircleID).Return(circle, nil)
-	mockWeb3Svc.On("SellTokens", ctx, req.PrivateKey, mock.Anything, req.Amount, req.MinRefund).
-		Return(expectedTxHash, nil)
-	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
-
-	// Execute
-	resp, err := svc.SellTokens(ctx, req)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, resp)
-	assert.Equal(t, expectedTxHash, resp.TxHash)
-	assert.Contains(t, resp.Message, "Sell transaction submitted")
-
-	mockCircleRepo.AssertExpectations(t)
-	mockWeb3Svc.AssertExpectations(t)
-	mockTxRepo.AssertExpectations(t)
+	require.NoError(t, f.circles.Create(ctx, other))
+
+	// A trade of one circle's token cannot be recorded against another circle
+	_, err := f.trading.SubmitTrade(ctx, &service.SubmitTradeRequest{
+		CircleID:    other.ID,
+		SignedTx:    f.signTrade(t, "buyTokens", big.NewInt(1e16), common.HexToAddress(circle.TokenAddress), tokens),
+		FromAddress: f.owner.Hex(),
+	})
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+
+	// Curve methods other than buy and sell are not trades
+	_, err = f.trading.SubmitTrade(ctx, &service.SubmitTradeRequest{
+		CircleID:    circle.ID,
+		SignedTx:    f.signTrade(t, "getCurrentPrice", big.NewInt(0), common.HexToAddress(circle.TokenAddress)),
+		FromAddress: f.owner.Hex(),
+	})
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+
+	recorded, err := f.txs.GetByCircle(ctx, other.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, recorded, "nothing is recorded for a rejected transaction")
 }
 
-// TestGetTokenBalance_Success tests successful balance retrieval
-func TestGetTokenBalance_Success(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewTradingService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+func TestSellTokens_Success(t *testing.T) {
+	f := setup(t)
 	ctx := context.Background()
-	circleID := uint64(1)
-	userAddress := "0xabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+	circle := f.createCircle(t)
+	token := common.HexToAddress(circle.TokenAddress)
+
+	resp, err := f.trading.SubmitTrade(ctx, &service.SubmitTradeRequest{
+		CircleID:    circle.ID,
+		SignedTx:    f.signTrade(t, "sellTokens", big.NewInt(0), token, big.NewInt(1e18), big.NewInt(0)),
+		FromAddress: f.owner.Hex(),
+	})
+	require.NoError(t, err)
+	assert.Contains(t, resp.Message, "Sell transaction submitted")
 
-	circle := &models.Circle{
-		ID:           1,
-		Status:       "confirmed",
-		TokenAddress: "0x1234567890123456789012345678901234567890",
-	}
+	tx, err := f.txs.GetByHash(ctx, resp.TxHash)
+	require.NoError(t, err)
+	assert.Equal(t, "sell", tx.TxType)
+	assert.Equal(t, "1000000000000000000", tx.Amount)
+}
 
-	expectedBalance := big.NewInt(1000)
+func TestSellTokens_CircleNotFound(t *testing.T) {
+	f := setup(t)
 
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, circleID).Return(circle, nil)
-	mockWeb3Svc.On("GetTokenBalance", ctx, mock.Anything, mock.Anything).Return(expectedBalance, nil)
+	resp, err := f.trading.PrepareSellTokens(context.Background(), &service.SellTokensRequest{
+		CircleID:    999,
+		Amount:      big.NewInt(1e18),
+		MinRefund:   big.NewInt(0),
+		FromAddress: f.owner.Hex(),
+	})
+	assert.Nil(t, resp)
+	assert.ErrorContains(t, err, "circle not found")
+}
 
-	// Execute
-	balance, err := svc.GetTokenBalance(ctx, circleID, userAddress)
+func TestGetTokenBalance_Success(t *testing.T) {
+	f := setup(t)
+	circle := f.createCircle(t)
 
-	// Assert
-	assert.NoError(t, err)
+	balance, err := f.trading.GetTokenBalance(context.Background(), circle.ID, f.owner.Hex())
+	require.NoError(t, err)
 	assert.NotNil(t, balance)
-	assert.Equal(t, expectedBalance, balance)
-
-	mockCircleRepo.AssertExpectations(t)
-	mockWeb3Svc.AssertExpectations(t)
 }
 
-// TestGetCurrentPrice_Success tests successful price retrieval
 func TestGetCurrentPrice_Success(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewTradingService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
-	ctx := context.Background()
-	circleID := uint64(1)
-
-	circle := &models.Circle{
-		ID:           1,
-		Status:       "confirmed",
-		TokenAddress: "0x1234567890123456789012345678901234567890",
-	}
-
-	expectedPrice := big.NewInt(5000000000000000) // 0.005 ETH
+	f := setup(t)
+	circle := f.createCircle(t)
 
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, circleID).Return(circle, nil)
-	mockWeb3Svc.On("GetCurrentPrice", ctx, mock.Anything).Return(expectedPrice, nil)
-
-	// Execute
-	price, err := svc.GetCurrentPrice(ctx, circleID)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, price)
-	assert.Equal(t, expectedPrice, price)
-
-	mockCircleRepo.AssertExpectations(t)
-	mockWeb3Svc.AssertExpectations(t)
+	price, err := f.trading.GetCurrentPrice(context.Background(), circle.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, price.Sign())
 }
 
-// TestGetTokenBalance_CircleNotConfirmed tests balance retrieval with unconfirmed circle
 func TestGetTokenBalance_CircleNotConfirmed(t *testing.T) {
-	// Setup
-	mockCircleRepo := new(MockCircleRepository)
-	mockUserRepo := new(MockUserRepository)
-	mockTxRepo := new(MockTransactionRepository)
-	mockWeb3Svc := new(MockWeb3Service)
-
-	svc := service.NewTradingService(mockCircleRepo, mockUserRepo, mockTxRepo, mockWeb3Svc)
-
+	f := setup(t)
 	ctx := context.Background()
-	circleID := uint64(1)
-	userAddress := "0xabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+	circle := &models.Circle{Name: "Pending", Symbol: "PND", Status: "pending", TxHash: "0x01"}
+	require.NoError(t, f.circles.Create(ctx, circle))
 
-	circle := &models.Circle{
-		ID:     1,
-		Status: "pending",
-	}
-
-	// Expectations
-	mockCircleRepo.On("GetByID", ctx, circleID).Return(circle, nil)
-
-	// Execute
-	balance, err := svc.GetTokenBalance(ctx, circleID, userAddress)
-
-	// Assert
-	assert.Error(t, err)
+	balance, err := f.trading.GetTokenBalance(ctx, circle.ID, f.owner.Hex())
 	assert.Nil(t, balance)
-	assert.Contains(t, err.Error(), "not confirmed")
-
-	mockCircleRepo.AssertExpectations(t)
+	assert.ErrorContains(t, err, "not confirmed")
 }
@@ -0,0 +1,117 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package siwe_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/siwe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exampleMessage is the example from the EIP-4361 specification
+const exampleMessage = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParseSpecExample(t *testing.T) {
+	msg, err := siwe.Parse(exampleMessage)
+	require.NoError(t, err)
+
+	assert.Equal(t, "service.invalid", msg.Domain)
+	assert.Equal(t, common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), msg.Address)
+	assert.Equal(t, "I accept the ServiceOrg Terms of Service: https://service.invalid/tos", msg.Statement)
+	assert.Equal(t, "https://service.invalid/login", msg.URI)
+	assert.Equal(t, int64(1), msg.ChainID)
+	assert.Equal(t, "32891756", msg.Nonce)
+	assert.Equal(t, time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC), msg.IssuedAt)
+	assert.Nil(t, msg.ExpirationTime)
+	assert.Len(t, msg.Resources, 2)
+
+	// Rendering gives back the exact text the wallet signed
+	assert.Equal(t, exampleMessage, msg.String())
+}
+
+func TestParseOptionalFields(t *testing.T) {
+	expires := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	notBefore := time.Date(2021, 9, 30, 17, 0, 0, 0, time.UTC)
+	msg := &siwe.Message{
+		Domain:         "localhost:8080",
+		Address:        common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		URI:            "http://localhost:8080",
+		Version:        siwe.Version,
+		ChainID:        11155111,
+		Nonce:          "abcdef0123456789",
+		IssuedAt:       time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC),
+		ExpirationTime: &expires,
+		NotBefore:      &notBefore,
+		RequestID:      "login-1",
+	}
+
+	parsed, err := siwe.Parse(msg.String())
+	require.NoError(t, err)
+	assert.Equal(t, msg, parsed)
+
+	// Wallets that drop the blank line of a missing statement are accepted
+	parsed, err = siwe.Parse(strings.Replace(msg.String(), "\n\n\n", "\n\n", 1))
+	require.NoError(t, err)
+	assert.Equal(t, msg, parsed)
+}
+
+func TestParseRejectsMalformedMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+	}{
+		{"missing header", [2]string{" wants you to sign in with your Ethereum account:", ""}},
+		{"lowercase address", [2]string{"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"}},
+		{"unknown version", [2]string{"Version: 1", "Version: 2"}},
+		{"bad chain ID", [2]string{"Chain ID: 1", "Chain ID: one"}},
+		{"short nonce", [2]string{"Nonce: 32891756", "Nonce: 123"}},
+		{"bad timestamp", [2]string{"2021-09-30T16:25:24Z", "yesterday"}},
+		{"missing nonce", [2]string{"Nonce: 32891756\n", ""}},
+		{"trailing garbage", [2]string{"my-web2-claim.json", "my-web2-claim.json\nExtra: field"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := siwe.Parse(strings.Replace(exampleMessage, tt.replace[0], tt.replace[1], 1))
+			assert.ErrorIs(t, err, siwe.ErrInvalidMessage)
+		})
+	}
+}
+
+func TestRecoverSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(exampleMessage)), key)
+	require.NoError(t, err)
+	// Wallets report V as 27 or 28
+	sig[crypto.RecoveryIDOffset] += 27
+
+	signer, err := siwe.RecoverSigner(exampleMessage, sig)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	_, err = siwe.RecoverSigner(exampleMessage, sig[:64])
+	assert.ErrorIs(t, err, siwe.ErrInvalidSignature)
+}
@@ -0,0 +1,33 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package votes_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/fast-socialfi/backend/internal/votes"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func units(t *testing.T, value string) *big.Int {
+	amount, err := web3.ParseUnits(value, 18)
+	require.NoError(t, err)
+	return amount
+}
+
+func TestWeightGrowsWithSquareRootOfStake(t *testing.T) {
+	assert.Equal(t, uint(1), votes.Weight(nil, 10))
+	assert.Equal(t, uint(1), votes.Weight(units(t, "0"), 10))
+	assert.Equal(t, uint(1), votes.Weight(units(t, "0.5"), 10), "dust still counts once")
+	assert.Equal(t, uint(1), votes.Weight(units(t, "3.99"), 10))
+	assert.Equal(t, uint(2), votes.Weight(units(t, "4"), 10))
+	assert.Equal(t, uint(7), votes.Weight(units(t, "50"), 10))
+	assert.Equal(t, uint(10), votes.Weight(units(t, "100"), 10))
+	assert.Equal(t, uint(10), votes.Weight(units(t, "1000000"), 10), "capped")
+	assert.Equal(t, uint(1000), votes.Weight(units(t, "1000000"), 0), "uncapped")
+}
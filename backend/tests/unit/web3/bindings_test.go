@@ -0,0 +1,81 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBindingsMatchArtifacts fails when a contract changed without the
+// bindings being regenerated with go generate ./internal/web3/contracts
+func TestBindingsMatchArtifacts(t *testing.T) {
+	bindings := map[string]string{
+		"CircleFactory": "circle_factory.go",
+		"BondingCurve":  "bonding_curve.go",
+		"CircleToken":   "circle_token.go",
+	}
+
+	for name, file := range bindings {
+		t.Run(name, func(t *testing.T) {
+			raw, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "out", name+".sol", name+".json"))
+			require.NoError(t, err)
+
+			var artifact struct {
+				ABI      json.RawMessage `json:"abi"`
+				Bytecode struct {
+					Object string `json:"object"`
+				} `json:"bytecode"`
+			}
+			require.NoError(t, json.Unmarshal(raw, &artifact))
+
+			want, err := bind.Bind([]string{name}, []string{string(artifact.ABI)}, []string{artifact.Bytecode.Object},
+				nil, "contracts", bind.LangGo, nil, nil)
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join("..", "..", "..", "internal", "web3", "contracts", file))
+			require.NoError(t, err)
+
+			assert.True(t, strings.ReplaceAll(string(got), "\r\n", "\n") == want,
+				"%s is out of date, run go generate ./internal/web3/contracts", file)
+		})
+	}
+}
+
+func TestBuyCalldataUsesContractSignature(t *testing.T) {
+	f := setupFees(t)
+	tx := f.buy(t)
+
+	curveABI, err := contracts.BondingCurveMetaData.GetAbi()
+	require.NoError(t, err)
+	method, err := curveABI.MethodById(tx.Data()[:4])
+	require.NoError(t, err)
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	require.NoError(t, err)
+
+	// buyTokens(token, minTokens) is payable: the max cost travels as value
+	assert.Equal(t, "buyTokens", method.Name)
+	assert.Equal(t, []interface{}{tokenAddress, big.NewInt(1e18)}, args)
+	assert.Equal(t, big.NewInt(1e15), tx.Value())
+
+	// Wallet-signed calls decode through the same ABI
+	utx, err := f.svc.PrepareSellTokens(context.Background(), f.from, tokenAddress, big.NewInt(5e17), big.NewInt(1))
+	require.NoError(t, err)
+	_, call, err := f.svc.VerifyBondingCurveTx(f.signUnsigned(t, utx), f.from, "sellTokens")
+	require.NoError(t, err)
+	assert.Equal(t, "sellTokens", call.Method)
+	assert.Equal(t, tokenAddress, call.Args[0].(common.Address))
+}
@@ -0,0 +1,277 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/config"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gwei = 1_000_000_000
+
+// mempoolBackend keeps sent transactions in a fake mempool instead of mining
+// them, so a nonce can be replaced the way a real node allows, and serves a
+// canned eth_feeHistory response
+type mempoolBackend struct {
+	*backends.SimulatedBackend
+	history *ethereum.FeeHistory
+
+	mu      sync.Mutex
+	pending map[common.Hash]*types.Transaction
+}
+
+func (b *mempoolBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending[tx.Hash()] = tx
+	return nil
+}
+
+func (b *mempoolBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	b.mu.Lock()
+	tx, ok := b.pending[hash]
+	b.mu.Unlock()
+	if ok {
+		return tx, true, nil
+	}
+	return b.SimulatedBackend.TransactionByHash(ctx, hash)
+}
+
+func (b *mempoolBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return b.history, nil
+}
+
+type feeFixture struct {
+	backend *mempoolBackend
+	svc     *web3.Web3Service
+	key     *ecdsa.PrivateKey
+	from    common.Address
+}
+
+func setupFees(t *testing.T) *feeFixture {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{
+		from: {Balance: big.NewInt(1e18)},
+	}, 30_000_000)
+	t.Cleanup(func() { sim.Close() })
+
+	backend := &mempoolBackend{
+		SimulatedBackend: sim,
+		pending:          make(map[common.Hash]*types.Transaction),
+		// Rewards of 1, 3 and 2 gwei with one empty block; next base fee 10 gwei
+		history: &ethereum.FeeHistory{
+			Reward: [][]*big.Int{
+				{big.NewInt(1 * gwei)}, {big.NewInt(3 * gwei)}, {big.NewInt(0)}, {big.NewInt(2 * gwei)},
+			},
+			BaseFee: []*big.Int{
+				big.NewInt(8 * gwei), big.NewInt(9 * gwei), big.NewInt(9 * gwei), big.NewInt(9 * gwei), big.NewInt(10 * gwei),
+			},
+		},
+	}
+
+	chainID := sim.Blockchain().Config().ChainID
+	svc, err := web3.NewWeb3ServiceWithBackend(backend, chainID, "", curveAddress.Hex())
+	require.NoError(t, err)
+
+	return &feeFixture{
+		backend: backend,
+		svc:     svc,
+		key:     key,
+		from:    from,
+	}
+}
+
+func (f *feeFixture) buy(t *testing.T) *types.Transaction {
+	return f.buyWith(t, f.svc)
+}
+
+// buyWith prepares a buy through svc, signs it as the wallet and broadcasts it
+func (f *feeFixture) buyWith(t *testing.T, svc *web3.Web3Service) *types.Transaction {
+	ctx := context.Background()
+	utx, err := svc.PrepareBuyTokens(ctx, f.from, tokenAddress, big.NewInt(1e18), big.NewInt(1e15))
+	require.NoError(t, err)
+	signed, _, err := svc.VerifyBondingCurveTx(f.signUnsigned(t, utx), f.from, "buyTokens")
+	require.NoError(t, err)
+	require.NoError(t, svc.SendSignedTx(ctx, signed))
+
+	tx, pending, err := f.backend.TransactionByHash(ctx, signed.Hash())
+	require.NoError(t, err)
+	require.True(t, pending)
+	return tx
+}
+
+// signUnsigned signs a transaction prepared for a wallet
+func (f *feeFixture) signUnsigned(t *testing.T, utx *web3.UnsignedTx) string {
+	chainID, _ := new(big.Int).SetString(utx.ChainID, 10)
+	tip, _ := new(big.Int).SetString(utx.MaxPriorityFeePerGas, 10)
+	feeCap, _ := new(big.Int).SetString(utx.MaxFeePerGas, 10)
+	value, _ := new(big.Int).SetString(utx.Value, 10)
+	to := common.HexToAddress(utx.To)
+
+	tx, err := types.SignNewTx(f.key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     utx.Nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       utx.Gas,
+		To:        &to,
+		Value:     value,
+		Data:      hexutil.MustDecode(utx.Data),
+	})
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return hexutil.Encode(raw)
+}
+
+// replace prepares a speed-up or cancel of original, signs it as the wallet
+// and broadcasts it
+func (f *feeFixture) replace(t *testing.T, original *types.Transaction, cancel bool) *types.Transaction {
+	ctx := context.Background()
+	utx, err := f.svc.PrepareReplacement(ctx, original.Hash().Hex(), f.from, cancel)
+	require.NoError(t, err)
+	signed, _, err := f.svc.VerifyReplacementTx(f.signUnsigned(t, utx), f.from, original)
+	require.NoError(t, err)
+	require.NoError(t, f.svc.SendSignedTx(ctx, signed))
+	return signed
+}
+
+func TestSendUsesFeeHistory(t *testing.T) {
+	f := setupFees(t)
+
+	tx := f.buy(t)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	// Median of the non-empty rewards; cap leaves room for the base fee to double
+	assert.Equal(t, big.NewInt(2*gwei), tx.GasTipCap())
+	assert.Equal(t, big.NewInt(22*gwei), tx.GasFeeCap())
+	assert.Equal(t, curveAddress, *tx.To())
+}
+
+func TestSendFallsBackWithoutFeeHistory(t *testing.T) {
+	f := setupFees(t)
+	svc, err := web3.NewWeb3ServiceWithBackend(f.backend.SimulatedBackend, f.backend.Blockchain().Config().ChainID, "", curveAddress.Hex())
+	require.NoError(t, err)
+
+	tx := f.buyWith(t, svc)
+	hash := tx.Hash().Hex()
+
+	head, err := f.backend.SimulatedBackend.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assert.Equal(t, big.NewInt(1), tx.GasTipCap())
+	assert.Equal(t, new(big.Int).Add(big.NewInt(1), new(big.Int).Mul(head.BaseFee, big.NewInt(2))), tx.GasFeeCap())
+
+	// Once mined it can no longer be replaced
+	f.backend.Commit()
+	_, err = svc.PrepareReplacement(context.Background(), hash, f.from, false)
+	assert.ErrorIs(t, err, web3.ErrNotReplaceable)
+}
+
+func TestSendHonorsConfiguredCaps(t *testing.T) {
+	f := setupFees(t)
+	f.svc.SetFeeLimits(config.BlockchainConfig{
+		GasLimit:       60_000,
+		GasPrice:       15 * gwei,
+		MaxPriorityFee: 1_500_000_000,
+	})
+
+	tx := f.buy(t)
+	assert.Equal(t, big.NewInt(1_500_000_000), tx.GasTipCap())
+	assert.Equal(t, big.NewInt(15*gwei), tx.GasFeeCap())
+	assert.LessOrEqual(t, tx.Gas(), uint64(60_000))
+
+	// A call that needs more than the limit is refused rather than truncated
+	f.svc.SetFeeLimits(config.BlockchainConfig{GasLimit: 21_000})
+	_, err := f.svc.PrepareBuyTokens(context.Background(), f.from, tokenAddress, big.NewInt(1e18), big.NewInt(1e15))
+	assert.ErrorContains(t, err, "exceeds the configured limit")
+
+	// A base fee above the cap cannot be paid at all
+	f.svc.SetFeeLimits(config.BlockchainConfig{GasPrice: 5 * gwei})
+	_, err = f.svc.PrepareBuyTokens(context.Background(), f.from, tokenAddress, big.NewInt(1e18), big.NewInt(1e15))
+	assert.ErrorIs(t, err, web3.ErrFeeCapExceeded)
+}
+
+func TestReplacementSpeedUpAndCancel(t *testing.T) {
+	f := setupFees(t)
+	ctx := context.Background()
+	original := f.buy(t)
+
+	speedUp := f.replace(t, original, false)
+	assert.Equal(t, original.Nonce(), speedUp.Nonce())
+	assert.Equal(t, original.To(), speedUp.To())
+	assert.Equal(t, original.Value(), speedUp.Value())
+	assert.Equal(t, original.Data(), speedUp.Data())
+	// 12% bump, rounded up, beats the current suggestion
+	assert.Equal(t, big.NewInt(2_240_000_000), speedUp.GasTipCap())
+	assert.Equal(t, big.NewInt(24_640_000_000), speedUp.GasFeeCap())
+
+	cancel := f.replace(t, speedUp, true)
+	assert.Equal(t, original.Nonce(), cancel.Nonce())
+	assert.Equal(t, f.from, *cancel.To())
+	assert.Zero(t, cancel.Value().Sign())
+	assert.Empty(t, cancel.Data())
+	assert.Equal(t, uint64(21000), cancel.Gas())
+	assert.Equal(t, 1, cancel.GasFeeCap().Cmp(speedUp.GasFeeCap()))
+
+	// A bump above the fee ceiling is refused
+	f.svc.SetFeeLimits(config.BlockchainConfig{GasPrice: cancel.GasFeeCap().Int64()})
+	_, err := f.svc.PrepareReplacement(ctx, cancel.Hash().Hex(), f.from, false)
+	assert.ErrorIs(t, err, web3.ErrFeeCapExceeded)
+}
+
+func TestWalletReplacementIsVerified(t *testing.T) {
+	f := setupFees(t)
+	ctx := context.Background()
+	original := f.buy(t)
+
+	utx, err := f.svc.PrepareReplacement(ctx, original.Hash().Hex(), f.from, true)
+	require.NoError(t, err)
+	assert.Equal(t, original.Nonce(), utx.Nonce)
+	assert.Equal(t, f.from.Hex(), utx.To)
+
+	signed, cancel, err := f.svc.VerifyReplacementTx(f.signUnsigned(t, utx), f.from, original)
+	require.NoError(t, err)
+	assert.True(t, cancel)
+	assert.Equal(t, original.Nonce(), signed.Nonce())
+
+	// Only the sender can replace its own transaction
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = f.svc.PrepareReplacement(ctx, original.Hash().Hex(), crypto.PubkeyToAddress(other.PublicKey), false)
+	assert.ErrorIs(t, err, web3.ErrNotReplaceable)
+
+	// Resigning the original fees is not a valid replacement
+	utx, err = f.svc.PrepareReplacement(ctx, original.Hash().Hex(), f.from, false)
+	require.NoError(t, err)
+	utx.MaxPriorityFeePerGas = original.GasTipCap().String()
+	utx.MaxFeePerGas = original.GasFeeCap().String()
+	_, _, err = f.svc.VerifyReplacementTx(f.signUnsigned(t, utx), f.from, original)
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+
+	// Neither the original call nor a cancel
+	utx, err = f.svc.PrepareReplacement(ctx, original.Hash().Hex(), f.from, false)
+	require.NoError(t, err)
+	utx.Value = "1"
+	_, _, err = f.svc.VerifyReplacementTx(f.signUnsigned(t, utx), f.from, original)
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+}
@@ -0,0 +1,77 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fast-socialfi/backend/internal/models"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func units(t *testing.T, value string) *big.Int {
+	amount, err := web3.ParseUnits(value, 18)
+	require.NoError(t, err)
+	return amount
+}
+
+func TestHolderPositionKeepsAverageCost(t *testing.T) {
+	first := time.Date(2025, 2, 11, 17, 0, 0, 0, time.UTC)
+	holder := &models.CircleHolder{Balance: "0", CostBasis: "0"}
+
+	require.NoError(t, web3.CreditHolder(holder, units(t, "100"), units(t, "1"), first))
+	require.NoError(t, web3.CreditHolder(holder, units(t, "100"), units(t, "3"), first.Add(time.Hour)))
+	assert.Equal(t, first, holder.FirstAcquiredAt, "topping up keeps the first acquisition time")
+	assert.Equal(t, units(t, "200"), units(t, holder.Balance))
+	assert.Equal(t, units(t, "4"), units(t, holder.CostBasis))
+
+	// Selling a quarter of the position removes a quarter of the cost
+	require.NoError(t, web3.DebitHolder(holder, units(t, "50")))
+	assert.Equal(t, units(t, "150"), units(t, holder.Balance))
+	assert.Equal(t, units(t, "3"), units(t, holder.CostBasis))
+
+	assert.Error(t, web3.DebitHolder(holder, units(t, "151")))
+
+	require.NoError(t, web3.DebitHolder(holder, units(t, "150")))
+	assert.Zero(t, units(t, holder.Balance).Sign())
+	assert.Zero(t, units(t, holder.CostBasis).Sign())
+
+	// A holder who sold out and buys again starts a new position
+	require.NoError(t, web3.CreditHolder(holder, units(t, "1"), new(big.Int), first.Add(2*time.Hour)))
+	assert.Equal(t, first.Add(2*time.Hour), holder.FirstAcquiredAt)
+}
+
+func TestHolderConcentration(t *testing.T) {
+	top, gini := web3.HolderConcentration(nil)
+	assert.Zero(t, top)
+	assert.Zero(t, gini)
+
+	// Equal balances are perfectly spread
+	equal := []*big.Int{units(t, "5"), units(t, "5"), units(t, "5"), units(t, "5")}
+	top, gini = web3.HolderConcentration(equal)
+	assert.InDelta(t, 1.0, top, 1e-9)
+	assert.InDelta(t, 0.0, gini, 1e-9)
+
+	// Zero balances are not holders
+	_, gini = web3.HolderConcentration([]*big.Int{units(t, "0"), units(t, "0"), units(t, "40")})
+	assert.InDelta(t, 0.0, gini, 1e-9)
+
+	// One of n holding nearly everything approaches (n-1)/n
+	skewed := []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1), units(t, "40")}
+	_, gini = web3.HolderConcentration(skewed)
+	assert.InDelta(t, 0.75, gini, 1e-6)
+
+	// Only the ten largest of twenty equal holders count towards the share
+	twenty := make([]*big.Int, 20)
+	for i := range twenty {
+		twenty[i] = units(t, "1")
+	}
+	top, _ = web3.HolderConcentration(twenty)
+	assert.InDelta(t, 0.5, top, 1e-9)
+}
@@ -0,0 +1,215 @@
+// Author: Aitachi
+// Email: 44158892@qq.com
+// Date: 11-02-2025 17
+
+package web3_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fast-socialfi/backend/internal/web3"
+	"github.com/fast-socialfi/backend/internal/web3/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	factoryAddress = common.HexToAddress("0x00000000000000000000000000000000000c0de1")
+	curveAddress   = common.HexToAddress("0x00000000000000000000000000000000000c0de2")
+	tokenAddress   = common.HexToAddress("0x00000000000000000000000000000000000c0de3")
+)
+
+type signedTxFixture struct {
+	backend *backends.SimulatedBackend
+	svc     *web3.Web3Service
+	chainID *big.Int
+	key     *ecdsa.PrivateKey
+	from    common.Address
+}
+
+func setupSignedTx(t *testing.T) *signedTxFixture {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		from: {Balance: big.NewInt(1e18)},
+	}, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+
+	chainID := backend.Blockchain().Config().ChainID
+	svc, err := web3.NewWeb3ServiceWithBackend(backend, chainID, factoryAddress.Hex(), curveAddress.Hex())
+	require.NoError(t, err)
+
+	return &signedTxFixture{
+		backend: backend,
+		svc:     svc,
+		chainID: chainID,
+		key:     key,
+		from:    from,
+	}
+}
+
+// sign signs a call the way a wallet would and returns the raw transaction
+func (f *signedTxFixture) sign(t *testing.T, key *ecdsa.PrivateKey, chainID *big.Int, to common.Address, data []byte) string {
+	head, err := f.backend.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     0,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: new(big.Int).Mul(head.BaseFee, big.NewInt(2)),
+		Gas:       100_000,
+		To:        &to,
+		Value:     big.NewInt(1e15),
+		Data:      data,
+	})
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return hexutil.Encode(raw)
+}
+
+func curveCall(t *testing.T, method string, args ...interface{}) []byte {
+	curveABI, err := contracts.BondingCurveMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := curveABI.Pack(method, args...)
+	require.NoError(t, err)
+	return data
+}
+
+// buyData packs a buyTokens call for the test token
+func buyData(t *testing.T) []byte {
+	return curveCall(t, "buyTokens", tokenAddress, big.NewInt(1e18))
+}
+
+func TestVerifyBondingCurveTx(t *testing.T) {
+	f := setupSignedTx(t)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	buy := buyData(t)
+	sell := curveCall(t, "sellTokens", tokenAddress, big.NewInt(1e18), big.NewInt(0))
+
+	tests := []struct {
+		name    string
+		rawTx   func() string
+		wantErr string
+	}{
+		{
+			name:  "allowed call",
+			rawTx: func() string { return f.sign(t, f.key, f.chainID, curveAddress, buy) },
+		},
+		{
+			name:    "malformed hex",
+			rawTx:   func() string { return "0xzz" },
+			wantErr: "malformed hex",
+		},
+		{
+			name:    "wrong chain ID",
+			rawTx:   func() string { return f.sign(t, f.key, new(big.Int).Add(f.chainID, big.NewInt(1)), curveAddress, buy) },
+			wantErr: "chain ID",
+		},
+		{
+			name:    "wrong sender",
+			rawTx:   func() string { return f.sign(t, other, f.chainID, curveAddress, buy) },
+			wantErr: "signed by " + crypto.PubkeyToAddress(other.PublicKey).Hex(),
+		},
+		{
+			name:    "wrong target",
+			rawTx:   func() string { return f.sign(t, f.key, f.chainID, factoryAddress, buy) },
+			wantErr: "does not target " + curveAddress.Hex(),
+		},
+		{
+			name:    "missing selector",
+			rawTx:   func() string { return f.sign(t, f.key, f.chainID, curveAddress, nil) },
+			wantErr: "missing method selector",
+		},
+		{
+			name:    "unknown selector",
+			rawTx:   func() string { return f.sign(t, f.key, f.chainID, curveAddress, []byte{0xde, 0xad, 0xbe, 0xef}) },
+			wantErr: "unknown method selector",
+		},
+		{
+			name:    "selector outside allowlist",
+			rawTx:   func() string { return f.sign(t, f.key, f.chainID, curveAddress, sell) },
+			wantErr: "method sellTokens is not allowed",
+		},
+		{
+			name:    "undecodable args",
+			rawTx:   func() string { return f.sign(t, f.key, f.chainID, curveAddress, buy[:20]) },
+			wantErr: "failed to decode arguments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, call, err := f.svc.VerifyBondingCurveTx(tt.rawTx(), f.from, web3.MethodBuyTokens)
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, tx)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, curveAddress, *tx.To())
+			assert.Equal(t, "buyTokens", call.Method)
+			require.Len(t, call.Args, 2)
+			assert.Equal(t, tokenAddress, call.Args[0])
+			assert.Equal(t, big.NewInt(1e18), call.Args[1])
+		})
+	}
+}
+
+func TestVerifyFactoryTxChecksFactoryTarget(t *testing.T) {
+	f := setupSignedTx(t)
+
+	// A bonding curve call is not a factory call, even with a valid signature
+	_, _, err := f.svc.VerifyFactoryTx(f.sign(t, f.key, f.chainID, curveAddress, buyData(t)), f.from, web3.MethodCreateCircle)
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+	assert.ErrorContains(t, err, "does not target "+factoryAddress.Hex())
+
+	// Calldata of another contract is not decoded against the factory ABI
+	_, _, err = f.svc.VerifyFactoryTx(f.sign(t, f.key, f.chainID, factoryAddress, buyData(t)), f.from, web3.MethodCreateCircle)
+	assert.ErrorIs(t, err, web3.ErrInvalidTransaction)
+	assert.ErrorContains(t, err, "unknown method selector")
+}
+
+func TestVerifyRejectsUnknownAllowedMethod(t *testing.T) {
+	f := setupSignedTx(t)
+
+	// A misspelt allowlist entry is a programming error, not a bad transaction
+	_, _, err := f.svc.VerifyBondingCurveTx(f.sign(t, f.key, f.chainID, curveAddress, buyData(t)), f.from, "buyToken")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, web3.ErrInvalidTransaction)
+	assert.ErrorContains(t, err, "not defined by the contract ABI")
+}
+
+func TestSendSignedTx(t *testing.T) {
+	f := setupSignedTx(t)
+	ctx := context.Background()
+
+	tx, _, err := f.svc.VerifyBondingCurveTx(f.sign(t, f.key, f.chainID, curveAddress, buyData(t)), f.from, web3.MethodBuyTokens)
+	require.NoError(t, err)
+	require.NoError(t, f.svc.SendSignedTx(ctx, tx))
+	f.backend.Commit()
+
+	receipt, err := f.backend.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), receipt.TxHash)
+
+	// Its nonce is spent, so the node refuses the same transaction again
+	err = f.svc.SendSignedTx(ctx, tx)
+	assert.ErrorContains(t, err, "failed to send transaction")
+}
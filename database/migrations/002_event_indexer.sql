@@ -0,0 +1,19 @@
+-- ============================================
+-- SocialFi Database Schema - Event Indexer
+-- MySQL 8.0+
+-- ============================================
+
+-- Trades are keyed by the log that produced them so replays are idempotent
+ALTER TABLE `trades` DROP INDEX `tx_hash`;
+ALTER TABLE `trades` ADD COLUMN `log_index` INT UNSIGNED NOT NULL DEFAULT 0 AFTER `tx_hash`;
+ALTER TABLE `trades` ADD CONSTRAINT `uk_trade_tx_log` UNIQUE (`tx_hash`, `log_index`);
+
+-- ============================================
+-- Indexer Cursors Table
+-- ============================================
+CREATE TABLE `indexer_cursors` (
+    `name` VARCHAR(50) PRIMARY KEY,
+    `block_number` BIGINT UNSIGNED NOT NULL,
+    `block_hash` VARCHAR(66) DEFAULT NULL,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
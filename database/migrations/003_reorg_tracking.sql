@@ -0,0 +1,41 @@
+-- ============================================
+-- SocialFi Database Schema - Reorg Tracking
+-- MySQL 8.0+
+-- ============================================
+
+-- Trades remember the block they were mined in so orphaned rows can be found
+ALTER TABLE `trades` ADD COLUMN `block_hash` VARCHAR(66) DEFAULT NULL AFTER `block_number`;
+CREATE INDEX `idx_trades_block` ON `trades`(`block_number`);
+
+-- ============================================
+-- Indexed Blocks Table
+-- ============================================
+CREATE TABLE `indexed_blocks` (
+    `block_number` BIGINT UNSIGNED PRIMARY KEY,
+    `block_hash` VARCHAR(66) NOT NULL,
+    `parent_hash` VARCHAR(66) NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================
+-- Circle Events Table
+-- ============================================
+CREATE TABLE `circle_events` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `circle_id` BIGINT UNSIGNED NOT NULL,
+    `event_type` ENUM('CREATED', 'DEACTIVATED', 'REACTIVATED', 'OWNERSHIP_TRANSFERRED') NOT NULL,
+    `owner_address` VARCHAR(42) DEFAULT NULL,
+    `active` BOOLEAN DEFAULT TRUE,
+
+    `tx_hash` VARCHAR(66) NOT NULL,
+    `log_index` INT UNSIGNED NOT NULL,
+    `block_number` BIGINT UNSIGNED NOT NULL,
+    `block_hash` VARCHAR(66) DEFAULT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT `uk_circle_event_log` UNIQUE (`tx_hash`, `log_index`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Indexes for circle_events
+CREATE INDEX `idx_circle_events_circle` ON `circle_events`(`circle_id`, `block_number` DESC);
+CREATE INDEX `idx_circle_events_block` ON `circle_events`(`block_number`);
@@ -0,0 +1,7 @@
+-- ============================================
+-- SocialFi Database Schema - Circle Lifecycle
+-- MySQL 8.0+
+-- ============================================
+
+-- The audit trail records which wallet made each lifecycle change
+ALTER TABLE `circle_events` ADD COLUMN `actor_address` VARCHAR(42) DEFAULT NULL AFTER `event_type`;
@@ -0,0 +1,27 @@
+-- ============================================
+-- SocialFi Database Schema - User Sessions
+-- MySQL 8.0+
+-- ============================================
+
+-- ============================================
+-- User Sessions Table
+-- ============================================
+-- One row per signed-in device. Refresh tokens rotate on every use and only
+-- the SHA-256 of the latest one is kept.
+CREATE TABLE `user_sessions` (
+    `session_id` CHAR(36) PRIMARY KEY,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `wallet_address` VARCHAR(42) NOT NULL,
+    `refresh_token_hash` CHAR(64) NOT NULL,
+    `user_agent` VARCHAR(255) DEFAULT NULL,
+    `ip_address` VARCHAR(45) DEFAULT NULL,
+    `last_seen_at` TIMESTAMP NULL DEFAULT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `revoked_at` TIMESTAMP NULL DEFAULT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT `fk_session_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Indexes for user_sessions
+CREATE INDEX `idx_user_sessions_user` ON `user_sessions`(`user_id`, `revoked_at`);
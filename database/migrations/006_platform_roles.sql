@@ -0,0 +1,9 @@
+-- ============================================
+-- SocialFi Database Schema - Platform Roles
+-- MySQL 8.0+
+-- ============================================
+
+-- Platform-wide role carried in access tokens. ADMIN may moderate and
+-- configure every circle; a promotion applies from the user's next login or
+-- token refresh.
+ALTER TABLE `users` ADD COLUMN `platform_role` VARCHAR(20) NOT NULL DEFAULT 'USER' AFTER `is_banned`;
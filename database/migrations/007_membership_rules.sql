@@ -0,0 +1,25 @@
+-- ============================================
+-- SocialFi Database Schema - Token-Gated Membership
+-- MySQL 8.0+
+-- ============================================
+
+-- token_balance becomes a cache of the member's on-chain balance, read at
+-- balance_block. held_since is when the balance last reached the circle's
+-- minimum, and gate_demoted marks members made read-only by the rule rather
+-- than by a moderator.
+ALTER TABLE `user_circle_relationships` ADD COLUMN `balance_block` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `token_balance`;
+ALTER TABLE `user_circle_relationships` ADD COLUMN `held_since` TIMESTAMP NULL DEFAULT NULL AFTER `balance_block`;
+ALTER TABLE `user_circle_relationships` ADD COLUMN `gate_demoted` BOOLEAN DEFAULT FALSE AFTER `can_invite`;
+CREATE INDEX `idx_ucr_balance_block` ON `user_circle_relationships`(`balance_block`);
+
+-- ============================================
+-- Circle Membership Rules Table
+-- ============================================
+-- One row per gated circle. Circles without a row let anyone join and post.
+CREATE TABLE `circle_membership_rules` (
+    `circle_id` BIGINT UNSIGNED PRIMARY KEY,
+    `min_balance` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `min_hold_seconds` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,51 @@
+-- ============================================
+-- SocialFi Database Schema - Circle Holder Registry
+-- MySQL 8.0+
+-- ============================================
+
+-- Holder count and concentration of the circle token supply, recomputed by
+-- the indexer from circle_holders whenever a Transfer touches the circle
+ALTER TABLE `circles` ADD COLUMN `holder_count` INT UNSIGNED NOT NULL DEFAULT 0 AFTER `member_count`;
+ALTER TABLE `circles` ADD COLUMN `top10_share` DOUBLE NOT NULL DEFAULT 0 AFTER `holder_count`;
+ALTER TABLE `circles` ADD COLUMN `gini_coefficient` DOUBLE NOT NULL DEFAULT 0 AFTER `top10_share`;
+
+-- ============================================
+-- Token Transfers Table
+-- ============================================
+-- Every CircleToken Transfer log, including mints from and burns to the zero
+-- address. Keyed by the log so replays are idempotent; the holder table of a
+-- circle is rebuilt from these rows when blocks are orphaned.
+CREATE TABLE `token_transfers` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `circle_id` BIGINT UNSIGNED NOT NULL,
+    `tx_hash` VARCHAR(66) NOT NULL,
+    `log_index` INT UNSIGNED NOT NULL,
+    `from_address` VARCHAR(42) NOT NULL,
+    `to_address` VARCHAR(42) NOT NULL,
+    `amount` DECIMAL(30,18) NOT NULL,
+    `block_number` BIGINT UNSIGNED NOT NULL,
+    `block_hash` VARCHAR(66) DEFAULT NULL,
+    `timestamp` TIMESTAMP NOT NULL,
+    CONSTRAINT `uk_token_transfer_log` UNIQUE (`tx_hash`, `log_index`),
+    FOREIGN KEY (`circle_id`) REFERENCES `circles`(`circle_id`) ON DELETE CASCADE,
+    INDEX `idx_token_transfer_circle` (`circle_id`, `block_number`, `log_index`),
+    INDEX `idx_token_transfer_block` (`block_number`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================
+-- Circle Holders Table
+-- ============================================
+-- One row per wallet with a non-zero balance. cost_basis is the ETH paid for
+-- the tokens still held, at average cost.
+CREATE TABLE `circle_holders` (
+    `circle_id` BIGINT UNSIGNED NOT NULL,
+    `holder_address` VARCHAR(42) NOT NULL,
+    `balance` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `cost_basis` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `first_acquired_at` TIMESTAMP NULL DEFAULT NULL,
+    `last_block` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`circle_id`, `holder_address`),
+    FOREIGN KEY (`circle_id`) REFERENCES `circles`(`circle_id`) ON DELETE CASCADE,
+    INDEX `idx_circle_holders_balance` (`circle_id`, `balance` DESC)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
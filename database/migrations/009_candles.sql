@@ -0,0 +1,28 @@
+-- ============================================
+-- SocialFi Database Schema - Price Candles
+-- MySQL 8.0+
+-- ============================================
+
+-- ============================================
+-- Candles Table
+-- ============================================
+-- OHLCV bars per circle token. 1m candles are built from trades and 5m, 1h
+-- and 1d candles are rolled up from the interval below; the indexer rebuilds
+-- the buckets touched by each batch and by reorgs. Buckets without trades
+-- have no row.
+CREATE TABLE `candles` (
+    `circle_id` BIGINT UNSIGNED NOT NULL,
+    `candle_interval` VARCHAR(3) NOT NULL,
+    `open_time` TIMESTAMP NOT NULL,
+    `open` DECIMAL(30,18) NOT NULL,
+    `high` DECIMAL(30,18) NOT NULL,
+    `low` DECIMAL(30,18) NOT NULL,
+    `close` DECIMAL(30,18) NOT NULL,
+    `token_volume` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `eth_volume` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `trade_count` INT UNSIGNED NOT NULL DEFAULT 0,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`circle_id`, `candle_interval`, `open_time`),
+    FOREIGN KEY (`circle_id`) REFERENCES `circles`(`circle_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
@@ -0,0 +1,22 @@
+-- ============================================
+-- SocialFi Database Schema - Portfolio Snapshots
+-- MySQL 8.0+
+-- ============================================
+
+-- ============================================
+-- Portfolio Snapshots Table
+-- ============================================
+-- Periodic valuation of every wallet holding a circle token, for portfolio
+-- charts. Positions are valued at the bonding curve price at snapshot time;
+-- cost basis and profit and loss use the average cost method. Re-running a
+-- snapshot for the same time replaces it.
+CREATE TABLE `portfolio_snapshots` (
+    `wallet_address` VARCHAR(42) NOT NULL,
+    `snapshot_at` TIMESTAMP NOT NULL,
+    `total_value` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `cost_basis` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `realized_pnl` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `unrealized_pnl` DECIMAL(30,18) NOT NULL DEFAULT 0,
+    `position_count` INT UNSIGNED NOT NULL DEFAULT 0,
+    PRIMARY KEY (`wallet_address`, `snapshot_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,16 @@
+-- ============================================
+-- SocialFi Database Schema - Trade History
+-- MySQL 8.0+
+-- ============================================
+
+-- Trade history pages by (timestamp, trade_id) so trades sharing a second
+-- keep a stable order; the indexes carry the tie-breaker so each page is a
+-- single range scan. Each index is swapped within one ALTER so the foreign
+-- keys on trader_id and circle_id are never left without one.
+ALTER TABLE `trades`
+    DROP INDEX `idx_trades_trader`,
+    ADD INDEX `idx_trades_trader` (`trader_id`, `timestamp` DESC, `trade_id` DESC),
+    DROP INDEX `idx_trades_circle`,
+    ADD INDEX `idx_trades_circle` (`circle_id`, `timestamp` DESC, `trade_id` DESC),
+    DROP INDEX `idx_trades_timestamp`,
+    ADD INDEX `idx_trades_timestamp` (`timestamp` DESC, `trade_id` DESC);
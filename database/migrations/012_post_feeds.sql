@@ -0,0 +1,14 @@
+-- ============================================
+-- SocialFi Database Schema - Post Feeds
+-- MySQL 8.0+
+-- ============================================
+
+-- Feeds list undeleted posts newest first by post ID, per author or per
+-- circle, and page by the last post ID seen, so each page is a single range
+-- scan of these indexes. They are swapped within one ALTER so the foreign
+-- keys on author_id and circle_id are never left without one.
+ALTER TABLE `posts`
+    DROP INDEX `idx_posts_author`,
+    ADD INDEX `idx_posts_author` (`author_id`, `is_deleted`, `post_id` DESC),
+    DROP INDEX `idx_posts_circle`,
+    ADD INDEX `idx_posts_circle` (`circle_id`, `is_deleted`, `post_id` DESC);
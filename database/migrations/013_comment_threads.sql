@@ -0,0 +1,12 @@
+-- ============================================
+-- SocialFi Database Schema - Comment Threads
+-- MySQL 8.0+
+-- ============================================
+
+-- A post's comment tree is read one level at a time: the first level pages
+-- through the comments under a parent in top (upvotes) or new (comment ID)
+-- order, and each deeper level ranks the replies of a set of parents with a
+-- window function. These indexes serve both orders for a parent directly.
+CREATE INDEX `idx_comments_thread_new` ON `comments`(`post_id`, `parent_comment_id`, `comment_id` DESC);
+CREATE INDEX `idx_comments_thread_top` ON `comments`(`post_id`, `parent_comment_id`, `upvotes` DESC, `comment_id` DESC);
+CREATE INDEX `idx_comments_replies_top` ON `comments`(`parent_comment_id`, `upvotes` DESC, `comment_id` DESC);
@@ -0,0 +1,28 @@
+-- ============================================
+-- SocialFi Database Schema - Votes
+-- MySQL 8.0+
+-- ============================================
+
+-- ============================================
+-- Votes Table
+-- ============================================
+-- The ledger behind the upvote and downvote counters of posts and comments:
+-- one row per user and target holding their standing vote. Retracting a vote
+-- deletes its row. The counters are kept in step in the same transaction and
+-- periodically recomputed from this table, summing each vote's weight.
+CREATE TABLE `votes` (
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `target_type` ENUM('POST', 'COMMENT') NOT NULL,
+    `target_id` BIGINT UNSIGNED NOT NULL,
+    `value` TINYINT NOT NULL COMMENT '+1 for an upvote, -1 for a downvote',
+    `weight` INT UNSIGNED NOT NULL DEFAULT 1,
+
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`user_id`, `target_type`, `target_id`),
+    CONSTRAINT `fk_vote_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Reconciliation sums the votes of each target
+CREATE INDEX `idx_votes_target` ON `votes`(`target_type`, `target_id`, `value`);
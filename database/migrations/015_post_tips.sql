@@ -0,0 +1,38 @@
+-- ============================================
+-- SocialFi Database Schema - Post Tips
+-- MySQL 8.0+
+-- ============================================
+
+-- Posts total their tips in ETH in reward_amount and in their circle's token
+-- in token_reward_amount. A circle's leaderboard ranks its posts by either.
+ALTER TABLE `posts`
+    ADD COLUMN `token_reward_amount` DECIMAL(30,18) DEFAULT 0 AFTER `reward_amount`;
+
+CREATE INDEX `idx_posts_circle_reward` ON `posts`(`circle_id`, `reward_amount` DESC);
+CREATE INDEX `idx_posts_circle_token_reward` ON `posts`(`circle_id`, `token_reward_amount` DESC);
+
+-- ============================================
+-- Post Tips Table
+-- ============================================
+-- One row per verified tip transaction, so a transaction submitted twice is
+-- only counted once. token_address is empty for ETH tips.
+CREATE TABLE `post_tips` (
+    `tip_id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `post_id` BIGINT UNSIGNED NOT NULL,
+    `circle_id` BIGINT UNSIGNED DEFAULT NULL,
+    `tipper_id` BIGINT UNSIGNED NOT NULL,
+    `author_id` BIGINT UNSIGNED NOT NULL,
+    `tx_hash` VARCHAR(66) NOT NULL,
+    `token_address` VARCHAR(42) NOT NULL DEFAULT '',
+    `amount` DECIMAL(30,18) NOT NULL,
+    `block_number` BIGINT UNSIGNED NOT NULL,
+
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY `uk_post_tips_tx` (`tx_hash`),
+    INDEX `idx_post_tips_post` (`post_id`),
+    INDEX `idx_post_tips_tipper` (`tipper_id`),
+    CONSTRAINT `fk_tip_post` FOREIGN KEY (`post_id`) REFERENCES `posts`(`post_id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_tip_tipper` FOREIGN KEY (`tipper_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_tip_author` FOREIGN KEY (`author_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,28 @@
+-- ============================================
+-- SocialFi Database Schema - Post Tip Intents
+-- MySQL 8.0+
+-- ============================================
+
+-- ============================================
+-- Post Tip Intents Table
+-- ============================================
+-- One row per tip prepared for a tipper to sign. A submitted transfer is only
+-- counted against an unexpired intent for the same post, tipper, asset and
+-- amount whose nonce it does not precede, and using it deletes the intent.
+-- This keeps transfers sent before the tip was prepared from being claimed.
+-- token_address is empty for ETH tips.
+CREATE TABLE `post_tip_intents` (
+    `intent_id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `post_id` BIGINT UNSIGNED NOT NULL,
+    `tipper_id` BIGINT UNSIGNED NOT NULL,
+    `token_address` VARCHAR(42) NOT NULL DEFAULT '',
+    `amount` DECIMAL(30,18) NOT NULL,
+    `nonce` BIGINT UNSIGNED NOT NULL COMMENT 'tipper account nonce the transfer was prepared with',
+    `expires_at` TIMESTAMP NOT NULL,
+
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX `idx_tip_intent_post_tipper` (`post_id`, `tipper_id`),
+    CONSTRAINT `fk_tip_intent_post` FOREIGN KEY (`post_id`) REFERENCES `posts`(`post_id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_tip_intent_tipper` FOREIGN KEY (`tipper_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,26 @@
+-- ============================================
+-- SocialFi Database Schema - Transaction Replacements
+-- MySQL 8.0+
+-- ============================================
+
+-- ============================================
+-- Transaction Replacements Table
+-- ============================================
+-- One row per broadcast of a transaction's nonce that a speed-up or cancel
+-- superseded. The transaction row follows its newest hash, but any of the
+-- broadcasts may be the one mined, so the reconciler checks these too before
+-- it counts the transaction as dropped. tx_type, to_address and amount are
+-- what the transaction was under this hash, restored if this one is mined.
+CREATE TABLE `transaction_replacements` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `transaction_id` BIGINT UNSIGNED NOT NULL,
+    `tx_hash` VARCHAR(66) NOT NULL,
+    `tx_type` VARCHAR(20) NOT NULL,
+    `to_address` VARCHAR(42) DEFAULT NULL,
+    `amount` DECIMAL(30,18) DEFAULT NULL,
+
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY `uk_transaction_replacements_tx` (`tx_hash`),
+    INDEX `idx_transaction_replacements_transaction` (`transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,33 @@
+-- ============================================
+-- SocialFi Database Schema - Transactions
+-- MySQL 8.0+
+-- ============================================
+
+-- ============================================
+-- Transactions Table
+-- ============================================
+-- One row per transaction the API submitted or recorded for a wallet. The
+-- reconciler stamps the block a row was mined in so rows mined in orphaned
+-- blocks can be found and put back to pending.
+CREATE TABLE `transactions` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `circle_id` BIGINT UNSIGNED DEFAULT NULL,
+    `tx_hash` VARCHAR(66) NOT NULL,
+    `tx_type` VARCHAR(20) NOT NULL,
+    `from_address` VARCHAR(42) DEFAULT NULL,
+    `to_address` VARCHAR(42) DEFAULT NULL,
+    `amount` DECIMAL(30,18) DEFAULT NULL,
+    `status` VARCHAR(20) DEFAULT 'pending',
+    `token_address` VARCHAR(42) DEFAULT NULL,
+    `block_number` BIGINT UNSIGNED DEFAULT NULL,
+    `block_hash` VARCHAR(66) DEFAULT NULL,
+    `timestamp` TIMESTAMP NULL DEFAULT NULL,
+
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY `uk_transactions_tx` (`tx_hash`),
+    INDEX `idx_transactions_circle` (`circle_id`),
+    INDEX `idx_transactions_from` (`from_address`),
+    INDEX `idx_transactions_to` (`to_address`),
+    INDEX `idx_transactions_block` (`block_number`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,17 @@
+-- ============================================
+-- SocialFi Database Schema - Transaction Settlement
+-- MySQL 8.0+
+-- ============================================
+
+-- What a mined transaction cost, recorded from its receipt
+ALTER TABLE `transactions` ADD COLUMN `gas_used` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `block_hash`;
+ALTER TABLE `transactions` ADD COLUMN `gas_price` VARCHAR(78) DEFAULT NULL AFTER `gas_used`;
+ALTER TABLE `transactions` ADD COLUMN `fee` DECIMAL(30,18) DEFAULT 0 AFTER `gas_price`;
+
+-- applied is set once the reconciler has applied a receipt to the row, so a
+-- trade is announced only the first time it is seen mined. checked_at is
+-- when the reconciler last looked the row up; it pages through unsettled
+-- rows least recently checked first, so one stuck row cannot starve others.
+ALTER TABLE `transactions` ADD COLUMN `applied` BOOLEAN NOT NULL DEFAULT FALSE AFTER `fee`;
+ALTER TABLE `transactions` ADD COLUMN `checked_at` TIMESTAMP NULL DEFAULT NULL AFTER `applied`;
+CREATE INDEX `idx_transactions_unsettled` ON `transactions`(`status`, `checked_at`);
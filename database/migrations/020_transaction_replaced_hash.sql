@@ -0,0 +1,9 @@
+-- ============================================
+-- SocialFi Database Schema - Replaced Transaction Hash
+-- MySQL 8.0+
+-- ============================================
+
+-- The hash of the broadcast the row's latest speed-up or cancel superseded;
+-- every earlier broadcast is kept in transaction_replacements
+ALTER TABLE `transactions` ADD COLUMN `replaced_hash` VARCHAR(66) DEFAULT NULL AFTER `tx_hash`;
+CREATE INDEX `idx_transactions_replaced` ON `transactions`(`replaced_hash`);